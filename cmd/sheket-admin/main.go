@@ -0,0 +1,113 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	_ "github.com/lib/pq"
+	"log"
+	"os"
+	c "sheket/server/controller"
+	"sheket/server/models"
+	"time"
+)
+
+const usage = `usage: sheket-admin <command> [arguments]
+
+commands:
+	reconcile [-company id] [-repair]
+		compares the quantity of every item in every branch with its
+		transaction history, -repair fixes the ones that drifted.
+
+	snapshot [-company id] [-date unix-time]
+		snapshots the quantities at the date(defaults to now), this keeps
+		GetStockAsOf fast. Run it periodically, e.g: monthly from cron.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "reconcile":
+		reconcile(os.Args[2:])
+	case "snapshot":
+		snapshot(os.Args[2:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+func connectStore() {
+	db_store, err := models.ConnectDbStore()
+	if err != nil {
+		log.Fatalf("%s", err.Error())
+	}
+	c.Store = models.NewShStore(db_store)
+}
+
+// @args company_id	0 returns every company
+func companyIds(company_id int) []int {
+	if company_id != 0 {
+		return []int{company_id}
+	}
+
+	companies, err := c.Store.ListCompanies()
+	if err != nil {
+		log.Fatalf("listing companies: %v", err)
+	}
+	var company_ids []int
+	for _, company := range companies {
+		company_ids = append(company_ids, company.CompanyId)
+	}
+	return company_ids
+}
+
+func reconcile(args []string) {
+	flags := flag.NewFlagSet("reconcile", flag.ExitOnError)
+	company_id := flags.Int("company", 0, "only reconcile this company, 0 reconciles every company")
+	repair := flags.Bool("repair", false, "fix the drifted quantities")
+	flags.Parse(args)
+
+	connectStore()
+	company_ids := companyIds(*company_id)
+
+	total_drifts := 0
+	for _, id := range company_ids {
+		drifts, err := c.ReconcileCompanyStock(id, *repair)
+		if err != nil {
+			log.Fatalf("company:%d, %v", id, err)
+		}
+		for _, drift := range drifts {
+			fmt.Printf("company:%d branch:%d item:%d quantity:%v ledger:%v expected:%v\n",
+				id, drift.BranchId, drift.ItemId,
+				drift.Quantity, drift.LedgerQuantity, drift.ExpectedQuantity)
+		}
+		total_drifts += len(drifts)
+	}
+
+	if *repair {
+		fmt.Printf("repaired %d drifts in %d companies\n", total_drifts, len(company_ids))
+	} else {
+		fmt.Printf("found %d drifts in %d companies\n", total_drifts, len(company_ids))
+	}
+}
+
+func snapshot(args []string) {
+	flags := flag.NewFlagSet("snapshot", flag.ExitOnError)
+	company_id := flags.Int("company", 0, "only snapshot this company, 0 snapshots every company")
+	date := flags.Int64("date", time.Now().Unix(), "the date of the snapshot")
+	flags.Parse(args)
+
+	connectStore()
+	company_ids := companyIds(*company_id)
+
+	for _, id := range company_ids {
+		if err := c.TakeStockSnapshot(id, *date); err != nil {
+			log.Fatalf("company:%d, %v", id, err)
+		}
+	}
+	fmt.Printf("took snapshot at %d of %d companies\n", *date, len(company_ids))
+}
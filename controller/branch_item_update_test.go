@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"github.com/golang/mock/gomock"
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+	"testing"
+)
+
+const (
+	t_bi_branch_id = 2
+	t_bi_item_id   = 9
+)
+
+func setup_branch_item_store(t *testing.T) (*models.SimpleBranchItemStore, func()) {
+	ctrl := gomock.NewController(t)
+	save_store := Store
+
+	branch_item_store := models.NewSimpleBranchItemStore(nil)
+	branch_item_store.AddItemToBranchInTx(nil, &models.ShBranchItem{
+		CompanyId:       t_item_company_id,
+		BranchId:        t_bi_branch_id,
+		ItemId:          t_bi_item_id,
+		Quantity:        4,
+		ItemLocation:    "A1",
+		ReorderLevel:    6,
+		HasReorderLevel: true,
+	})
+
+	mock := models.NewComposableShStoreMock(ctrl)
+	mock.BranchItemStore = branch_item_store
+	mock.RevisionStore = models.NewSimpleRevisionStore(nil)
+	mock.ItemPriceStore = models.NewSimpleItemPriceStore(nil)
+	Store = mock
+
+	return branch_item_store, func() {
+		ctrl.Finish()
+		Store = save_store
+	}
+}
+
+func t_update_branch_item(t *testing.T, branch_item *sp.BranchItem, field_mask ...string) *models.ShBranchItem {
+	branch_item.BranchId, branch_item.ItemId = t_bi_branch_id, t_bi_item_id
+	err := applyBranchItemOperations(nil, []*sp.EntityRequest_RequestBranchItem{
+		{BranchItem: branch_item, Action: sp.EntityRequest_UPDATE, FieldMask: field_mask},
+	}, new_Old_2_New(), t_item_company_id)
+	if err != nil {
+		t.Fatalf("update branch item: %v", err)
+	}
+	updated, _ := Store.GetBranchItemInTx(nil, t_bi_branch_id, t_bi_item_id)
+	return updated
+}
+
+func TestOldClientKeepsReorderOverride(t *testing.T) {
+	_, teardown := setup_branch_item_store(t)
+	defer teardown()
+
+	// it doesn't know about the override, so it only sends the location
+	updated := t_update_branch_item(t, &sp.BranchItem{ShelfLocation: "B4"})
+	if updated.ItemLocation != "B4" {
+		t.Errorf("expected the location to change, got %q", updated.ItemLocation)
+	}
+	if !updated.HasReorderLevel || updated.ReorderLevel != 6 {
+		t.Errorf("the override was lost, got %v %v", updated.HasReorderLevel, updated.ReorderLevel)
+	}
+	if updated.Quantity != 4 {
+		t.Errorf("the quantity can't be changed, got %v", updated.Quantity)
+	}
+}
+
+func TestReorderOverrideIsSetAndRemoved(t *testing.T) {
+	_, teardown := setup_branch_item_store(t)
+	defer teardown()
+
+	updated := t_update_branch_item(t, &sp.BranchItem{ShelfLocation: "A1",
+		ReorderLevel: 10, HasReorderLevel: true})
+	if !updated.HasReorderLevel || updated.ReorderLevel != 10 {
+		t.Errorf("expected the override to be 10, got %v %v", updated.HasReorderLevel, updated.ReorderLevel)
+	}
+
+	// it has to be named to be removed
+	updated = t_update_branch_item(t, &sp.BranchItem{}, "has_reorder_level")
+	if updated.HasReorderLevel {
+		t.Errorf("expected the override to be removed")
+	}
+	if updated.ItemLocation != "A1" {
+		t.Errorf("the location isn't in the mask, but it changed to %q", updated.ItemLocation)
+	}
+}
+
+func TestSellingPriceOverrideNeedsFlagOrMask(t *testing.T) {
+	_, teardown := setup_branch_item_store(t)
+	defer teardown()
+	prices := Store.(*models.ComposableShStoreMock).ItemPriceStore.(*models.SimpleItemPriceStore)
+
+	updated := t_update_branch_item(t, &sp.BranchItem{ShelfLocation: "A1",
+		SellingPrice: 25, HasSellingPrice: true})
+	if !updated.HasSellingPrice || updated.SellingPrice != 25 {
+		t.Fatalf("expected the override to be 25, got %v %v", updated.HasSellingPrice, updated.SellingPrice)
+	}
+
+	// an old client doesn't send the override, it is kept
+	updated = t_update_branch_item(t, &sp.BranchItem{ShelfLocation: "C2"})
+	if !updated.HasSellingPrice || updated.SellingPrice != 25 {
+		t.Errorf("the override was lost, got %v %v", updated.HasSellingPrice, updated.SellingPrice)
+	}
+	if len(prices.Prices) != 1 {
+		t.Errorf("expected only the override in the price history, got %d prices", len(prices.Prices))
+	}
+
+	// it has to be named to be removed
+	updated = t_update_branch_item(t, &sp.BranchItem{}, "has_selling_price")
+	if updated.HasSellingPrice {
+		t.Errorf("expected the override to be removed")
+	}
+	if len(prices.Prices) != 2 || prices.Prices[1].HasSellingPrice {
+		t.Errorf("expected the removal in the price history, got %v", prices.Prices)
+	}
+}
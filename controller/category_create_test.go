@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+	"testing"
+)
+
+func setup_category_store(t *testing.T) (*models.SimpleCategoryStore, *models.SimpleItemStore, func()) {
+	ctrl := gomock.NewController(t)
+	save_store := Store
+
+	item_store := models.NewSimpleItemStore(nil)
+	category_store := models.NewSimpleCategoryStore(item_store)
+	mock := models.NewComposableShStoreMock(ctrl)
+	mock.ItemStore = item_store
+	mock.CategoryStore = category_store
+	mock.RevisionStore = models.NewSimpleRevisionStore(nil)
+	Store = mock
+
+	return category_store, item_store, func() {
+		ctrl.Finish()
+		Store = save_store
+	}
+}
+
+// a new category with the client's temporary id
+func t_new_category(category_id, parent_id int32) *sp.EntityRequest_RequestCategory {
+	return &sp.EntityRequest_RequestCategory{
+		Action: sp.EntityRequest_CREATE,
+		Category: &sp.Category{CategoryId: category_id, ParentId: parent_id,
+			Name: fmt.Sprintf("category %d", category_id),
+			UUID: fmt.Sprintf("uuid-%d", category_id)},
+	}
+}
+
+func TestCreateCategoryUnderNewParent(t *testing.T) {
+	category_store, _, teardown := setup_category_store(t)
+	defer teardown()
+
+	// the child comes before its parent
+	old_2_new := new_Old_2_New()
+	response := new(sp.EntityResponse)
+	err := applyCategoryOperations(nil, []*sp.EntityRequest_RequestCategory{
+		t_new_category(-2, -1), t_new_category(-1, CLIENT_ROOT_CATEGORY_ID),
+	}, response, old_2_new, t_item_company_id)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	parent_id := old_2_new.getEntityType(_TYPE_CATEGORY)[-1]
+	child, _ := category_store.GetCategoryByIdInTx(nil, old_2_new.getEntityType(_TYPE_CATEGORY)[-2])
+	if child == nil || child.ParentId != parent_id || parent_id == 0 {
+		t.Errorf("expected the child under its new parent:%d, got %v", parent_id, child)
+	}
+	if len(response.RejectedCategories) != 0 {
+		t.Errorf("nothing should be rejected, got %v", response.RejectedCategories)
+	}
+}
+
+func TestCreatedCategoryCycleIsRejected(t *testing.T) {
+	category_store, _, teardown := setup_category_store(t)
+	defer teardown()
+
+	old_2_new := new_Old_2_New()
+	response := new(sp.EntityResponse)
+	err := applyCategoryOperations(nil, []*sp.EntityRequest_RequestCategory{
+		// -1 and -2 are each other's parent, -3 is under them
+		t_new_category(-1, -2), t_new_category(-2, -1), t_new_category(-3, -1),
+		// a category can't be its own parent either
+		t_new_category(-4, -4),
+		t_new_category(-5, CLIENT_ROOT_CATEGORY_ID),
+	}, response, old_2_new, t_item_company_id)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	rejected := make(map[int32]bool)
+	for _, r := range response.RejectedCategories {
+		if r.Reason != sp.EntityResponse_CATEGORY_CYCLE {
+			t.Errorf("expected a cycle, got %v", r)
+		}
+		rejected[r.CategoryId] = true
+	}
+	if len(rejected) != 4 || !rejected[-1] || !rejected[-2] || !rejected[-3] || !rejected[-4] {
+		t.Errorf("expected -1 to -4 rejected, got %v", response.RejectedCategories)
+	}
+
+	created := old_2_new.getEntityType(_TYPE_CATEGORY)
+	if len(created) != 1 || created[-5] == 0 {
+		t.Errorf("expected only -5 created, got %v", created)
+	}
+	// the root and -5
+	if len(category_store.Categories) != 2 {
+		t.Errorf("expected a single new category, got %d", len(category_store.Categories)-1)
+	}
+}
+
+func TestCreateCategoryUnderUnknownParent(t *testing.T) {
+	_, _, teardown := setup_category_store(t)
+	defer teardown()
+
+	err := applyCategoryOperations(nil, []*sp.EntityRequest_RequestCategory{
+		t_new_category(-1, -7),
+	}, new(sp.EntityResponse), new_Old_2_New(), t_item_company_id)
+	if err == nil {
+		t.Errorf("expected an error for a parent that isn't created")
+	}
+}
+
+func TestMovingCategoryUnderItsChildIsRejected(t *testing.T) {
+	category_store, _, teardown := setup_category_store(t)
+	defer teardown()
+
+	parent, _ := category_store.CreateCategoryInTx(nil, &models.ShCategory{
+		CompanyId: t_item_company_id, Name: "parts", ParentId: models.SERVER_ROOT_CATEGORY_ID})
+	child, _ := category_store.CreateCategoryInTx(nil, &models.ShCategory{
+		CompanyId: t_item_company_id, Name: "brakes", ParentId: parent.CategoryId})
+
+	response := new(sp.EntityResponse)
+	err := applyCategoryOperations(nil, []*sp.EntityRequest_RequestCategory{
+		{Action: sp.EntityRequest_UPDATE, Category: &sp.Category{CategoryId: int32(parent.CategoryId),
+			ParentId: int32(child.CategoryId), Name: "parts", Version: 1}},
+	}, response, new_Old_2_New(), t_item_company_id)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(response.RejectedCategories) != 1 ||
+		response.RejectedCategories[0].Reason != sp.EntityResponse_CATEGORY_CYCLE {
+		t.Errorf("expected the move to be rejected, got %v", response.RejectedCategories)
+	}
+	if parent.ParentId != models.SERVER_ROOT_CATEGORY_ID {
+		t.Errorf("the category shouldn't have moved")
+	}
+}
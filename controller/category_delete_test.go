@@ -0,0 +1,188 @@
+package controller
+
+import (
+	"github.com/golang/mock/gomock"
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+	"testing"
+)
+
+const (
+	t_category_branch_id = 1
+
+	t_parts  = 2
+	t_brakes = 3
+	t_pads   = 4
+
+	// the items in parts and brakes
+	t_parts_item  = 1
+	t_brakes_item = 2
+)
+
+// parts > brakes > pads, with an item in parts and another in brakes
+func setup_category_tree(t *testing.T) (category_store *models.SimpleCategoryStore,
+	item_store *models.SimpleItemStore, branch_categories *models.MockBranchCategoryStore,
+	revisions *models.SimpleRevisionStore, teardown func()) {
+
+	category_store, item_store, teardown = setup_category_store(t)
+	mock := Store.(*models.ComposableShStoreMock)
+
+	parent_id := models.SERVER_ROOT_CATEGORY_ID
+	for _, name := range []string{"parts", "brakes", "pads"} {
+		category, _ := category_store.CreateCategoryInTx(nil, &models.ShCategory{
+			CompanyId: t_item_company_id, Name: name, ParentId: parent_id,
+			StatusFlag: models.STATUS_VISIBLE})
+		parent_id = category.CategoryId
+	}
+	for _, category_id := range []int{t_parts, t_brakes} {
+		item_store.CreateItem(&models.ShItem{CompanyId: t_item_company_id,
+			CategoryId: category_id, StatusFlag: models.STATUS_VISIBLE, Version: 1})
+	}
+
+	return category_store, item_store, mock.BranchCategoryStore.(*models.MockBranchCategoryStore),
+		mock.RevisionStore.(*models.SimpleRevisionStore), teardown
+}
+
+func t_delete_category(t *testing.T, category_id int, policy sp.EntityRequest_DeletePolicy) *sp.EntityResponse {
+	response := new(sp.EntityResponse)
+	err := applyCategoryOperations(nil, []*sp.EntityRequest_RequestCategory{
+		{Action: sp.EntityRequest_DELETE, DeletePolicy: policy,
+			Category: &sp.Category{CategoryId: int32(category_id)}},
+	}, response, new_Old_2_New(), t_item_company_id)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return response
+}
+
+func t_branch_category(category_id int) *models.ShBranchCategory {
+	return &models.ShBranchCategory{CompanyId: t_item_company_id,
+		BranchId: t_category_branch_id, CategoryId: category_id}
+}
+
+// the ids affected by the revisions of the entity type and action
+func t_revised(revisions *models.SimpleRevisionStore, entity_type, action_type int) map[int]bool {
+	result := make(map[int]bool)
+	for _, rev := range revisions.Revisions {
+		if rev.EntityType != entity_type || rev.ActionType != action_type {
+			continue
+		}
+		if entity_type == models.REV_ENTITY_BRANCH_CATEGORY {
+			result[rev.AdditionalInfo] = rev.EntityAffectedId == t_category_branch_id
+		} else {
+			result[rev.EntityAffectedId] = true
+		}
+	}
+	return result
+}
+
+func TestDeleteCategoryMovesChildrenToParent(t *testing.T) {
+	categories, items, branch_categories, revisions, teardown := setup_category_tree(t)
+	defer teardown()
+
+	branch_categories.EXPECT().DeleteCategoryFromBranchesInTx(gomock.Any(), t_brakes, false).
+		Return([]*models.ShBranchCategory{t_branch_category(t_brakes)}, nil)
+
+	t_delete_category(t, t_brakes, sp.EntityRequest_MOVE_TO_PARENT)
+
+	if _, ok := categories.Categories[t_brakes]; ok {
+		t.Errorf("brakes should be deleted")
+	}
+	if categories.Categories[t_pads].ParentId != t_parts || items.Items[t_brakes_item].CategoryId != t_parts {
+		t.Errorf("the children of brakes should be under parts")
+	}
+
+	if rev := t_revised(revisions, models.REV_ENTITY_CATEGORY, models.REV_ACTION_UPDATE); len(rev) != 1 || !rev[t_pads] {
+		t.Errorf("expected an update revision of pads, got %v", rev)
+	}
+	if rev := t_revised(revisions, models.REV_ENTITY_ITEM, models.REV_ACTION_UPDATE); len(rev) != 1 || !rev[t_brakes_item] {
+		t.Errorf("expected an update revision of the item, got %v", rev)
+	}
+	if rev := t_revised(revisions, models.REV_ENTITY_CATEGORY, models.REV_ACTION_DELETE); len(rev) != 1 || !rev[t_brakes] {
+		t.Errorf("expected a delete revision of brakes, got %v", rev)
+	}
+	if rev := t_revised(revisions, models.REV_ENTITY_BRANCH_CATEGORY, models.REV_ACTION_DELETE); len(rev) != 1 || !rev[t_brakes] {
+		t.Errorf("expected brakes removed from the branch, got %v", rev)
+	}
+}
+
+func TestDeleteCategorySubtree(t *testing.T) {
+	categories, items, branch_categories, revisions, teardown := setup_category_tree(t)
+	defer teardown()
+
+	branch_categories.EXPECT().DeleteCategoryFromBranchesInTx(gomock.Any(), t_parts, true).
+		Return([]*models.ShBranchCategory{t_branch_category(t_brakes), t_branch_category(t_pads)}, nil)
+
+	t_delete_category(t, t_parts, sp.EntityRequest_DELETE_SUBTREE)
+
+	if len(categories.Categories) != 1 {
+		t.Errorf("only the root should be left, got %v", categories.Categories)
+	}
+	for _, item := range items.Items {
+		if item.CategoryId != models.SERVER_ROOT_CATEGORY_ID || item.StatusFlag != models.STATUS_IN_VISIBLE {
+			t.Errorf("expected the item hidden under the root, got %v", item)
+		}
+	}
+
+	if rev := t_revised(revisions, models.REV_ENTITY_CATEGORY, models.REV_ACTION_DELETE); len(rev) != 3 {
+		t.Errorf("expected delete revisions of the 3 categories, got %v", rev)
+	}
+	if rev := t_revised(revisions, models.REV_ENTITY_ITEM, models.REV_ACTION_UPDATE); len(rev) != 2 {
+		t.Errorf("expected update revisions of both items, got %v", rev)
+	}
+	if rev := t_revised(revisions, models.REV_ENTITY_BRANCH_CATEGORY, models.REV_ACTION_DELETE); len(rev) != 2 ||
+		!rev[t_brakes] || !rev[t_pads] {
+		t.Errorf("expected brakes and pads removed from the branch, got %v", rev)
+	}
+}
+
+func TestDeleteCategoryRefusedIfNotEmpty(t *testing.T) {
+	categories, _, branch_categories, revisions, teardown := setup_category_tree(t)
+	defer teardown()
+
+	response := t_delete_category(t, t_brakes, sp.EntityRequest_REFUSE_IF_NOT_EMPTY)
+	if len(response.RejectedCategories) != 1 ||
+		response.RejectedCategories[0].Reason != sp.EntityResponse_CATEGORY_NOT_EMPTY {
+		t.Errorf("expected brakes to be rejected, got %v", response.RejectedCategories)
+	}
+	if _, ok := categories.Categories[t_brakes]; !ok || len(revisions.Revisions) != 0 {
+		t.Errorf("nothing should change on a refused delete")
+	}
+
+	// pads is empty
+	branch_categories.EXPECT().DeleteCategoryFromBranchesInTx(gomock.Any(), t_pads, false).
+		Return([]*models.ShBranchCategory{t_branch_category(t_pads)}, nil)
+
+	response = t_delete_category(t, t_pads, sp.EntityRequest_REFUSE_IF_NOT_EMPTY)
+	if len(response.RejectedCategories) != 0 {
+		t.Errorf("an empty category should be deleted, got %v", response.RejectedCategories)
+	}
+	if _, ok := categories.Categories[t_pads]; ok {
+		t.Errorf("pads should be deleted")
+	}
+	if rev := t_revised(revisions, models.REV_ENTITY_BRANCH_CATEGORY, models.REV_ACTION_DELETE); len(rev) != 1 || !rev[t_pads] {
+		t.Errorf("expected pads removed from the branch, got %v", rev)
+	}
+}
+
+func TestCategoryOfAnotherCompanyIsntChanged(t *testing.T) {
+	categories, items, _, revisions, teardown := setup_category_tree(t)
+	defer teardown()
+
+	const other_company_id = t_item_company_id + 1
+	for _, action := range []sp.EntityRequest_Action{sp.EntityRequest_DELETE, sp.EntityRequest_UPDATE} {
+		err := applyCategoryOperations(nil, []*sp.EntityRequest_RequestCategory{
+			{Action: action, DeletePolicy: sp.EntityRequest_DELETE_SUBTREE,
+				Category: &sp.Category{CategoryId: t_parts, Name: "mine",
+					ParentId: CLIENT_ROOT_CATEGORY_ID}},
+		}, new(sp.EntityResponse), new_Old_2_New(), other_company_id)
+		if err == nil {
+			t.Errorf("action %v: expected the category of another company to be rejected", action)
+		}
+	}
+
+	if len(categories.Categories) != 4 || categories.Categories[t_parts].Name != "parts" ||
+		items.Items[t_brakes_item].CategoryId != t_brakes || len(revisions.Revisions) != 0 {
+		t.Errorf("nothing should change in the other company's tree")
+	}
+}
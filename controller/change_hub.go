@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	sp "sheket/server/sheketproto"
+	"sync"
+)
+
+/**
+ * A single device listening for changes on a company. Changes published
+ * while the device is busy sending a previous notification are merged into
+ * {@field pending}, so a slow device gets one notification instead of a
+ * backlog of them. That is fine because a notification only tells the
+ * device to sync, the actual changes are fetched through the sync calls.
+ */
+type changeSubscriber struct {
+	company_id int
+
+	pending *sp.ChangeNotification
+
+	// has a value if pending is non-nil
+	signal chan struct{}
+}
+
+/**
+ * Keeps track of the devices subscribed to each company so they can be
+ * told when other devices commit changes. It lives in-process, so devices
+ * connected to other server instances won't be notified.
+ */
+type changeHub struct {
+	mu sync.Mutex
+
+	// company_id -> the subscribers of that company
+	subscribers map[int]map[*changeSubscriber]bool
+}
+
+func newChangeHub() *changeHub {
+	return &changeHub{
+		subscribers: make(map[int]map[*changeSubscriber]bool),
+	}
+}
+
+// the hub used by the SheketController
+var ChangeHub = newChangeHub()
+
+func (h *changeHub) Subscribe(company_id int) *changeSubscriber {
+	sub := &changeSubscriber{
+		company_id: company_id,
+		signal:     make(chan struct{}, 1),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.subscribers[company_id]; !ok {
+		h.subscribers[company_id] = make(map[*changeSubscriber]bool)
+	}
+	h.subscribers[company_id][sub] = true
+	return sub
+}
+
+func (h *changeHub) Unsubscribe(sub *changeSubscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	company_subs := h.subscribers[sub.company_id]
+	delete(company_subs, sub)
+	if len(company_subs) == 0 {
+		delete(h.subscribers, sub.company_id)
+	}
+}
+
+/**
+ * Notifies every subscriber of the company, this never blocks on a subscriber.
+ */
+func (h *changeHub) Publish(company_id int, change *sp.ChangeNotification) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscribers[company_id] {
+		if sub.pending == nil {
+			sub.pending = new(sp.ChangeNotification)
+		}
+		sub.pending.EntitiesChanged = sub.pending.EntitiesChanged || change.EntitiesChanged
+		sub.pending.TransactionsChanged = sub.pending.TransactionsChanged || change.TransactionsChanged
+
+		select {
+		case sub.signal <- struct{}{}:
+		default:
+			// there is already a signal waiting, it will pick up the merged change
+		}
+	}
+}
+
+/**
+ * Blocks until there is a change for the subscriber or the context is done.
+ * Returns nil if the context is done.
+ */
+func (h *changeHub) next(ctx context.Context, sub *changeSubscriber) *sp.ChangeNotification {
+	select {
+	case <-ctx.Done():
+		return nil
+	case <-sub.signal:
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	change := sub.pending
+	sub.pending = nil
+	return change
+}
+
+func (s *SheketController) SubscribeChanges(request *sp.SubscribeRequest, stream sp.SheketService_SubscribeChangesServer) error {
+	defer trace("SubscribeChanges")()
+
+	user_info, err := GetUserWithCompanyPermission(request.CompanyAuth)
+	if err != nil {
+		return grpc.Errorf(codes.Unauthenticated, "%v", err)
+	}
+
+	sub := ChangeHub.Subscribe(user_info.CompanyId)
+	defer ChangeHub.Unsubscribe(sub)
+
+	for {
+		change := ChangeHub.next(stream.Context(), sub)
+		if change == nil {
+			// the client went away
+			return nil
+		}
+		if err := stream.Send(change); err != nil {
+			return err
+		}
+	}
+}
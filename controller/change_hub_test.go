@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"golang.org/x/net/context"
+	sp "sheket/server/sheketproto"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestChangeHubMergesPendingChanges(t *testing.T) {
+	hub := newChangeHub()
+	sub := hub.Subscribe(1)
+	other := hub.Subscribe(2)
+	defer hub.Unsubscribe(sub)
+	defer hub.Unsubscribe(other)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			hub.Publish(1, &sp.ChangeNotification{
+				EntitiesChanged:     i%2 == 0,
+				TransactionsChanged: i%2 == 1,
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	change := hub.next(context.Background(), sub)
+	if change == nil || !change.EntitiesChanged || !change.TransactionsChanged {
+		t.Errorf("expected a single merged change, got %v", change)
+	}
+
+	// everything was merged into the one above
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if change := hub.next(ctx, sub); change != nil {
+		t.Errorf("expected nothing else pending, got %v", change)
+	}
+	if change := hub.next(ctx, other); change != nil {
+		t.Errorf("the other company shouldn't be notified, got %v", change)
+	}
+}
+
+func TestChangeHubConcurrentSubscribers(t *testing.T) {
+	hub := newChangeHub()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sub := hub.Subscribe(1)
+			defer hub.Unsubscribe(sub)
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			if change := hub.next(ctx, sub); change == nil || !change.TransactionsChanged {
+				t.Errorf("expected the published change, got %v", change)
+			}
+		}()
+	}
+
+	// keep publishing until every subscriber got it and left
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	for {
+		select {
+		case <-done:
+			hub.mu.Lock()
+			defer hub.mu.Unlock()
+			if len(hub.subscribers) != 0 {
+				t.Errorf("expected every subscriber to be removed, got %v", hub.subscribers)
+			}
+			return
+		default:
+			hub.Publish(1, &sp.ChangeNotification{TransactionsChanged: true})
+			time.Sleep(time.Millisecond)
+		}
+	}
+}
+
+func TestChangeHubUnsubscribe(t *testing.T) {
+	hub := newChangeHub()
+	sub := hub.Subscribe(1)
+	hub.Unsubscribe(sub)
+
+	hub.Publish(1, &sp.ChangeNotification{EntitiesChanged: true})
+	if sub.pending != nil {
+		t.Errorf("an unsubscribed device shouldn't get changes")
+	}
+	if _, ok := hub.subscribers[1]; ok {
+		t.Errorf("the company should be removed with its last subscriber")
+	}
+}
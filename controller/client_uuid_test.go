@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"github.com/golang/mock/gomock"
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+	"testing"
+)
+
+const t_other_company_id = t_item_company_id + 1
+
+func t_post_item(t *testing.T, company_id int) int {
+	posted := []*sp.EntityRequest_RequestItem{
+		{Item: t_full_sp_item(), Action: sp.EntityRequest_CREATE},
+	}
+	old_2_new := new_Old_2_New()
+	if err := applyItemOperations(nil, posted, new(sp.EntityResponse), old_2_new, company_id, true); err != nil {
+		t.Fatalf("post item: %v", err)
+	}
+	item_id, ok := old_2_new.getEntityType(_TYPE_ITEM)[-1]
+	if !ok {
+		t.Fatalf("the item's id wasn't returned")
+	}
+	return item_id
+}
+
+func TestRetriedItemUploadIsNotDuplicated(t *testing.T) {
+	item_store, teardown := setup_item_store(t)
+	defer teardown()
+
+	first_id := t_post_item(t, t_item_company_id)
+	// the client didn't get the response, so it posts the same item again
+	retried_id := t_post_item(t, t_item_company_id)
+
+	if first_id != retried_id {
+		t.Errorf("expected the retry to return id %d, got %d", first_id, retried_id)
+	}
+	if len(item_store.Items) != 1 {
+		t.Errorf("expected 1 item, got %d", len(item_store.Items))
+	}
+}
+
+func TestItemUUIDIsScopedByCompany(t *testing.T) {
+	item_store, teardown := setup_item_store(t)
+	defer teardown()
+
+	first_id := t_post_item(t, t_item_company_id)
+	// another company's device happens to use the same uuid
+	other_id := t_post_item(t, t_other_company_id)
+
+	if first_id == other_id {
+		t.Fatalf("the other company got item %d of company %d", first_id, t_item_company_id)
+	}
+	if item_store.Items[other_id].CompanyId != t_other_company_id {
+		t.Errorf("expected the item to belong to company %d, got %d",
+			t_other_company_id, item_store.Items[other_id].CompanyId)
+	}
+}
+
+func t_post_branch(t *testing.T, company_id int) int {
+	posted := []*sp.EntityRequest_RequestBranch{
+		{
+			Branch: &sp.Branch{
+				BranchId: -1,
+				Name:     "main store",
+				UUID:     "8d3b1e52-6f0c-4a57-b1d4-3f2a9c7e0b61",
+			},
+			Action: sp.EntityRequest_CREATE,
+		},
+	}
+	old_2_new := new_Old_2_New()
+	if err := applyBranchOperations(nil, posted, new(sp.EntityResponse), old_2_new, company_id); err != nil {
+		t.Fatalf("post branch: %v", err)
+	}
+	branch_id, ok := old_2_new.getEntityType(_TYPE_BRANCH)[-1]
+	if !ok {
+		t.Fatalf("the branch's id wasn't returned")
+	}
+	return branch_id
+}
+
+func TestRetriedBranchUploadIsScopedByCompany(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	save_store := Store
+	defer func() {
+		ctrl.Finish()
+		Store = save_store
+	}()
+
+	branch_store := models.NewSimpleBranchStore()
+	mock := models.NewComposableShStoreMock(ctrl)
+	mock.BranchStore = branch_store
+	mock.RevisionStore = models.NewSimpleRevisionStore(nil)
+	Store = mock
+
+	first_id := t_post_branch(t, t_item_company_id)
+	if retried_id := t_post_branch(t, t_item_company_id); retried_id != first_id {
+		t.Errorf("expected the retry to return id %d, got %d", first_id, retried_id)
+	}
+	if other_id := t_post_branch(t, t_other_company_id); other_id == first_id {
+		t.Errorf("the other company got branch %d of company %d", first_id, t_item_company_id)
+	}
+	if len(branch_store.Branches) != 2 {
+		t.Errorf("expected 2 branches, got %d", len(branch_store.Branches))
+	}
+}
+
+func TestRetriedTransactionLooksUpUUIDInCompany(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	save_store := Store
+	defer func() {
+		ctrl.Finish()
+		Store = save_store
+	}()
+
+	trans_store := models.NewMockTransactionStore(ctrl)
+	mock := models.NewComposableShStoreMock(ctrl)
+	mock.TransactionStore = trans_store
+	Store = mock
+
+	const trans_uuid = "0f6a2c94-51d7-4e8b-a3c2-7b9d4e1f2a85"
+	trans_store.EXPECT().
+		GetShTransactionByUUIDInTx(nil, t_item_company_id, trans_uuid).
+		Return(&models.ShTransaction{TransactionId: 7, CompanyId: t_item_company_id}, nil)
+
+	request := &sp.TransactionRequest{
+		Transactions: []*sp.Transaction{
+			{TransId: -1, BranchId: 1, UUID: trans_uuid},
+		},
+	}
+	user_info := &UserCompanyPermission{
+		CompanyId: t_item_company_id,
+		User:      &models.User{UserId: 1},
+	}
+	_, old_2_new, err := addTransactions(nil, request, user_info)
+	if err != nil {
+		t.Fatalf("add transactions: %v", err)
+	}
+	if old_2_new[-1] != 7 {
+		t.Errorf("expected the previous transaction's id 7, got %d", old_2_new[-1])
+	}
+}
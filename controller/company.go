@@ -141,6 +141,7 @@ func (s *SheketController) CreateCompany(c context.Context, request *sp.NewCompa
 	response.Permission = permission.Encode()
 	response.SignedLicense = license
 	response.PaymentId = generatePaymentId(created_company)
+	response.CostingMethod = int32(models.CostingMethodOf(created_company))
 
 	return response, nil
 }
@@ -162,8 +163,25 @@ func (s *SheketController) EditCompany(c context.Context, request *sp.EditCompan
 		return nil, grpc.Errorf(codes.Internal, "%v", err)
 	}
 
-	company.CompanyName = request.NewName
+	if request.NewName != "" {
+		company.CompanyName = request.NewName
+	}
+	if request.CostingMethod != 0 {
+		if !user_info.Permission.HasManagerAccess() {
+			tnx.Rollback()
+			return nil, grpc.Errorf(codes.PermissionDenied, "%v",
+				fmt.Errorf("only managers can change the costing method"))
+		}
+		if request.CostingMethod != models.COSTING_METHOD_FIFO &&
+			request.CostingMethod != models.COSTING_METHOD_AVERAGE {
+			tnx.Rollback()
+			return nil, grpc.Errorf(codes.InvalidArgument, "%v",
+				fmt.Errorf("unknown costing method:%d", request.CostingMethod))
+		}
+		company.CostingMethod = int(request.CostingMethod)
+	}
 	if _, err = Store.UpdateCompanyInTx(tnx, company); err != nil {
+		tnx.Rollback()
 		return nil, grpc.Errorf(codes.Internal, "%v", err)
 	}
 	if err = tnx.Commit(); err != nil {
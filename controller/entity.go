@@ -29,6 +29,65 @@ func To_Client_Category_Id(category_id int) int{
 	}
 }
 
+// converts from *models.ShCategory ==>> *sheket_proto.Category
+func _to_sp_category(category *models.ShCategory) *sp.Category {
+	return &sp.Category{
+		CategoryId: int32(category.CategoryId),
+		Name:       category.Name,
+		ParentId:   int32(To_Client_Category_Id(category.ParentId)),
+		UUID:       category.ClientUUID,
+		StatusFlag: int32(category.StatusFlag),
+		Version:    int32(category.Version),
+	}
+}
+
+// converts from *models.ShItem ==>> *sheket_proto.Item
+// the cost price is only set if show_cost is
+func _to_sp_item(item *models.ShItem, show_cost bool) *sp.Item {
+	sp_item := &sp.Item{
+		ItemId:            int32(item.ItemId),
+		UUID:              item.ClientUUID,
+		Name:              item.Name,
+		Code:              item.ItemCode,
+		CategoryId:        int32(To_Client_Category_Id(item.CategoryId)),
+		UnitOfMeasurement: int32(item.UnitOfMeasurement),
+		HasDerivedUnit:    item.HasDerivedUnit,
+		DerivedName:       item.DerivedName,
+		DerivedFactor:     item.DerivedFactor,
+		ReorderLevel:      item.ReorderLevel,
+		ModelYear:         item.ModelYear,
+		PartNumber:        item.PartNumber,
+		BarCode:           item.BarCode,
+		HasBarCode:        item.HasBarCode,
+		StatusFlag:        int32(item.StatusFlag),
+		Version:           int32(item.Version),
+		SellingPrice:      item.SellingPrice,
+	}
+	if show_cost {
+		sp_item.CostPrice = item.CostPrice
+	}
+	sp_item.HasUnits = true
+	for _, unit := range item.Units {
+		sp_item.Units = append(sp_item.Units, &sp.Item_Unit{
+			UnitId: int32(unit.UnitId),
+			Name:   unit.Name,
+			Factor: unit.Factor,
+		})
+	}
+	return sp_item
+}
+
+// converts from *models.ShBranch ==>> *sheket_proto.Branch
+func _to_sp_branch(branch *models.ShBranch) *sp.Branch {
+	return &sp.Branch{
+		BranchId:   int32(branch.BranchId),
+		UUID:       branch.ClientUUID,
+		Name:       branch.Name,
+		StatusFlag: int32(branch.StatusFlag),
+		Version:    int32(branch.Version),
+	}
+}
+
 func (s *SheketController) SyncEntity(c context.Context, request *sp.EntityRequest) (response *sp.EntityResponse, err error) {
 	defer trace("SyncEntity")()
 
@@ -44,13 +103,21 @@ func (s *SheketController) SyncEntity(c context.Context, request *sp.EntityReque
 
 	var old_2_new OLD_ENTITY_ID_2_NEW
 
-	if old_2_new, err = applyEntityOperations(tnx, request, user_info); err != nil {
+	// conflicts found while applying the operations are written to the response
+	response = new(sp.EntityResponse)
+
+	if old_2_new, err = applyEntityOperations(tnx, request, response, user_info); err != nil {
 		tnx.Rollback()
 		return nil, grpc.Errorf(codes.Internal, "%v", err)
 	}
-	tnx.Commit()
+	if err = tnx.Commit(); err != nil {
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
 
-	response = new(sp.EntityResponse)
+	if hasEntityOperations(request) {
+		ChangeHub.Publish(user_info.CompanyId,
+			&sp.ChangeNotification{EntitiesChanged: true})
+	}
 
 	if err = fetchModifiedEntities(request, response, old_2_new, user_info); err != nil {
 		return nil, grpc.Errorf(codes.Internal, "%v", err)
@@ -78,6 +145,15 @@ func (s *SheketController) SyncEntity(c context.Context, request *sp.EntityReque
 	return response, nil
 }
 
+func hasEntityOperations(request *sp.EntityRequest) bool {
+	return len(request.Items) > 0 ||
+		len(request.Categories) > 0 ||
+		len(request.Branches) > 0 ||
+		len(request.Employees) > 0 ||
+		len(request.BranchItems) > 0 ||
+		len(request.BranchCategories) > 0
+}
+
 /**
  * Writes to the response any entities that have been (inserted/updated/deleted) since their
  * last respective revision. (e.g: it will sync any changes on branch_items that have occurred
@@ -92,7 +168,8 @@ func fetchModifiedEntities(request *sp.EntityRequest,
 		return err
 	}
 
-	if err := fetchItemsSinceLastRev(request, response, user_info.CompanyId); err != nil {
+	if err := fetchItemsSinceLastRev(request, response, user_info.CompanyId,
+		user_info.Permission.HasManagerAccess()); err != nil {
 		return err
 	}
 
@@ -116,17 +193,18 @@ func fetchCategoriesSinceLastRev(request *sp.EntityRequest,
 	response *sp.EntityResponse,
 	company_id int) error {
 
-	max_rev, category_revs, err := Store.GetRevisionsSince(
+	max_rev, category_revs, has_more, err := Store.GetRevisionPageSince(
 		&models.ShEntityRevision{
 			CompanyId:      company_id,
 			EntityType:     models.REV_ENTITY_CATEGORY,
 			RevisionNumber: int(request.OldCategoryRev),
-		})
+		}, int(request.PageSize))
 	if err != nil && err != models.ErrNoData {
 		return err
 	}
 
 	response.NewCategoryRev = int32(max_rev)
+	response.HasMore = response.HasMore || has_more
 
 	for _, rev := range category_revs {
 		category_id := rev.EntityAffectedId
@@ -143,19 +221,9 @@ func fetchCategoriesSinceLastRev(request *sp.EntityRequest,
 				}
 			}
 
-			category.ParentId = To_Client_Category_Id(category.ParentId)
-
 			response.Categories = append(response.Categories,
 				&sp.EntityResponse_SyncCategory{
-					Category: &sp.Category{
-						CategoryId: int32(category.CategoryId),
-						Name:       category.Name,
-						ParentId:   int32(category.ParentId),
-						UUID:       category.ClientUUID,
-
-						// TODO: check if we support "hiding" categories
-						StatusFlag: int32(models.STATUS_VISIBLE),
-					},
+					Category: _to_sp_category(category),
 				})
 
 		case models.REV_ACTION_DELETE:
@@ -174,19 +242,20 @@ func fetchCategoriesSinceLastRev(request *sp.EntityRequest,
 
 func fetchItemsSinceLastRev(request *sp.EntityRequest,
 	response *sp.EntityResponse,
-	company_id int) error {
+	company_id int, show_cost bool) error {
 
-	max_rev, changed_item_revs, err := Store.GetRevisionsSince(
+	max_rev, changed_item_revs, has_more, err := Store.GetRevisionPageSince(
 		&models.ShEntityRevision{
 			CompanyId:      company_id,
 			EntityType:     models.REV_ENTITY_ITEM,
 			RevisionNumber: int(request.OldItemRev),
-		})
+		}, int(request.PageSize))
 	if err != nil && err != models.ErrNoData {
 		return err
 	}
 
 	response.NewItemRev = int32(max_rev)
+	response.HasMore = response.HasMore || has_more
 
 	for _, item_rev := range changed_item_revs {
 		item_id := item_rev.EntityAffectedId
@@ -200,22 +269,9 @@ func fetchItemsSinceLastRev(request *sp.EntityRequest,
 			}
 		}
 
-		item.CategoryId = To_Client_Category_Id(item.CategoryId)
-
 		response.Items = append(response.Items,
 			&sp.EntityResponse_SyncItem{
-				Item: &sp.Item{
-					ItemId:            int32(item.ItemId),
-					UUID:              item.ClientUUID,
-					Name:              item.Name,
-					Code:              item.ItemCode,
-					CategoryId:        int32(item.CategoryId),
-					UnitOfMeasurement: int32(item.UnitOfMeasurement),
-					HasDerivedUnit:    item.HasDerivedUnit,
-					DerivedName:       item.DerivedName,
-					DerivedFactor:     item.DerivedFactor,
-					StatusFlag:        int32(item.StatusFlag),
-				},
+				Item: _to_sp_item(item, show_cost),
 			})
 	}
 	return nil
@@ -224,17 +280,18 @@ func fetchItemsSinceLastRev(request *sp.EntityRequest,
 func fetchBranchesSinceLastRev(request *sp.EntityRequest,
 	response *sp.EntityResponse, company_id int) error {
 
-	max_rev, new_branch_revs, err := Store.GetRevisionsSince(
+	max_rev, new_branch_revs, has_more, err := Store.GetRevisionPageSince(
 		&models.ShEntityRevision{
 			CompanyId:      company_id,
 			EntityType:     models.REV_ENTITY_BRANCH,
 			RevisionNumber: int(request.OldBranchRev),
-		})
+		}, int(request.PageSize))
 	if err != nil && err != models.ErrNoData {
 		return err
 	}
 
 	response.NewBranchRev = int32(max_rev)
+	response.HasMore = response.HasMore || has_more
 
 	for _, branch_rev := range new_branch_revs {
 		branch_id := branch_rev.EntityAffectedId
@@ -252,12 +309,7 @@ func fetchBranchesSinceLastRev(request *sp.EntityRequest,
 
 		response.Branches = append(response.Branches,
 			&sp.EntityResponse_SyncBranch{
-				Branch: &sp.Branch{
-					BranchId:   int32(branch_id),
-					UUID:       branch.ClientUUID,
-					Name:       branch.Name,
-					StatusFlag: int32(branch.StatusFlag),
-				},
+				Branch: _to_sp_branch(branch),
 			})
 	}
 	return nil
@@ -267,17 +319,18 @@ func fetchMembersSinceLastRev(request *sp.EntityRequest,
 	response *sp.EntityResponse,
 	company_id int) error {
 
-	max_rev, member_revs, err := Store.GetRevisionsSince(
+	max_rev, member_revs, has_more, err := Store.GetRevisionPageSince(
 		&models.ShEntityRevision{
 			CompanyId:      company_id,
 			EntityType:     models.REV_ENTITY_MEMBERS,
 			RevisionNumber: int(request.OldMemberRev),
-		})
+		}, int(request.PageSize))
 	if err != nil && err != models.ErrNoData {
 		return err
 	}
 
 	response.NewMemberRev = int32(max_rev)
+	response.HasMore = response.HasMore || has_more
 
 	for _, rev := range member_revs {
 		member_id := rev.EntityAffectedId
@@ -328,17 +381,18 @@ func fetchBranchCategoriesSinceLastRev(
 	response *sp.EntityResponse,
 	company_id int) error {
 
-	max_rev, branch_category_revs, err := Store.GetRevisionsSince(
+	max_rev, branch_category_revs, has_more, err := Store.GetRevisionPageSince(
 		&models.ShEntityRevision{
 			CompanyId:      company_id,
 			EntityType:     models.REV_ENTITY_BRANCH_CATEGORY,
 			RevisionNumber: int(request.OldBranchCategoryRev),
-		})
+		}, int(request.PageSize))
 	if err != nil && err != models.ErrNoData {
 		return err
 	}
 
 	response.NewBranchCategoryRev = int32(max_rev)
+	response.HasMore = response.HasMore || has_more
 
 	for _, rev := range branch_category_revs {
 		branch_id := rev.EntityAffectedId
@@ -0,0 +1,195 @@
+package controller
+
+import (
+	"github.com/golang/mock/gomock"
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+	"testing"
+)
+
+const t_conflict_item_id = 21
+
+func setup_conflict_store(t *testing.T, policy string) (*gomock.Controller, *models.ComposableShStoreMock, func()) {
+	ctrl := gomock.NewController(t)
+	save_store, save_policy := Store, conflict_policy
+
+	mock := models.NewComposableShStoreMock(ctrl)
+	mock.RevisionStore = models.NewSimpleRevisionStore(nil)
+	Store = mock
+	conflict_policy = policy
+
+	return ctrl, mock, func() {
+		ctrl.Finish()
+		Store, conflict_policy = save_store, save_policy
+	}
+}
+
+func t_server_item(version int, name string) *models.ShItem {
+	return &models.ShItem{
+		ItemId:     t_conflict_item_id,
+		CompanyId:  t_item_company_id,
+		Name:       name,
+		StatusFlag: models.STATUS_VISIBLE,
+		Version:    version,
+	}
+}
+
+func t_update_item_name(t *testing.T, client_version int32, name string) *sp.EntityResponse {
+	response := new(sp.EntityResponse)
+	err := applyItemOperations(nil, []*sp.EntityRequest_RequestItem{
+		{
+			Item: &sp.Item{ItemId: t_conflict_item_id, Name: name,
+				StatusFlag: int32(models.STATUS_VISIBLE), Version: client_version},
+			Action:    sp.EntityRequest_UPDATE,
+			FieldMask: []string{"name"},
+		},
+	}, response, new_Old_2_New(), t_item_company_id, true)
+	if err != nil {
+		t.Fatalf("update item: %v", err)
+	}
+	return response
+}
+
+func TestStaleUpdateIsRejected(t *testing.T) {
+	ctrl, mock, teardown := setup_conflict_store(t, CONFLICT_POLICY_REJECT)
+	defer teardown()
+
+	item_store := models.NewMockItemStore(ctrl)
+	mock.ItemStore = item_store
+	// it is never written
+	item_store.EXPECT().GetItemByIdInTx(gomock.Any(), t_conflict_item_id).
+		Return(t_server_item(3, "disc"), nil)
+
+	response := t_update_item_name(t, 2, "rotor")
+	if len(response.ItemConflicts) != 1 {
+		t.Fatalf("expected a conflict, got %v", response.ItemConflicts)
+	}
+	conflict := response.ItemConflicts[0]
+	if conflict.Resolution != sp.EntityResponse_REJECTED || conflict.Item.Name != "disc" {
+		t.Errorf("expected the current copy to be sent back, got %v", conflict)
+	}
+}
+
+func TestConcurrentUpdateIsReappliedOnNewCopy(t *testing.T) {
+	ctrl, mock, teardown := setup_conflict_store(t, CONFLICT_POLICY_LAST_WRITER_WINS)
+	defer teardown()
+
+	item_store := models.NewMockItemStore(ctrl)
+	mock.ItemStore = item_store
+
+	var written *models.ShItem
+	gomock.InOrder(
+		item_store.EXPECT().GetItemByIdInTx(gomock.Any(), t_conflict_item_id).
+			Return(t_server_item(3, "disc"), nil),
+		// another device updated it after it was read
+		item_store.EXPECT().UpdateItemInTx(gomock.Any(), gomock.Any()).
+			Return(nil, models.ErrVersionConflict),
+		item_store.EXPECT().GetItemByIdInTx(gomock.Any(), t_conflict_item_id).
+			Return(t_server_item(4, "brake disc"), nil),
+		item_store.EXPECT().UpdateItemInTx(gomock.Any(), gomock.Any()).
+			Do(func(_ interface{}, item *models.ShItem) { written = item }).
+			Return(nil, nil),
+	)
+
+	response := t_update_item_name(t, 3, "rotor")
+	if written == nil || written.Name != "rotor" || written.Version != 4 {
+		t.Fatalf("expected the edit to be written on version 4, got %v", written)
+	}
+	// the client's copy is now behind, it is told which edit it replaced, once
+	if len(response.ItemConflicts) != 1 {
+		t.Fatalf("expected a single conflict, got %v", response.ItemConflicts)
+	}
+	conflict := response.ItemConflicts[0]
+	if conflict.Resolution != sp.EntityResponse_OVERWRITTEN || conflict.Item.Name != "brake disc" {
+		t.Errorf("expected the overwritten copy, got %v", conflict)
+	}
+}
+
+func TestConcurrentUpdateIsRejectedOnNewCopy(t *testing.T) {
+	ctrl, mock, teardown := setup_conflict_store(t, CONFLICT_POLICY_REJECT)
+	defer teardown()
+
+	item_store := models.NewMockItemStore(ctrl)
+	mock.ItemStore = item_store
+	gomock.InOrder(
+		item_store.EXPECT().GetItemByIdInTx(gomock.Any(), t_conflict_item_id).
+			Return(t_server_item(3, "disc"), nil),
+		item_store.EXPECT().UpdateItemInTx(gomock.Any(), gomock.Any()).
+			Return(nil, models.ErrVersionConflict),
+		item_store.EXPECT().GetItemByIdInTx(gomock.Any(), t_conflict_item_id).
+			Return(t_server_item(4, "brake disc"), nil),
+	)
+
+	response := t_update_item_name(t, 3, "rotor")
+	if len(response.ItemConflicts) != 1 ||
+		response.ItemConflicts[0].Resolution != sp.EntityResponse_REJECTED ||
+		response.ItemConflicts[0].Item.Name != "brake disc" {
+		t.Errorf("expected the edit to be rejected with the new copy, got %v", response.ItemConflicts)
+	}
+}
+
+func TestUpdateGivesUpIfItKeepsChanging(t *testing.T) {
+	ctrl, mock, teardown := setup_conflict_store(t, CONFLICT_POLICY_LAST_WRITER_WINS)
+	defer teardown()
+
+	item_store := models.NewMockItemStore(ctrl)
+	mock.ItemStore = item_store
+	item_store.EXPECT().GetItemByIdInTx(gomock.Any(), t_conflict_item_id).
+		Return(t_server_item(3, "disc"), nil).Times(MAX_UPDATE_ATTEMPTS)
+	item_store.EXPECT().UpdateItemInTx(gomock.Any(), gomock.Any()).
+		Return(nil, models.ErrVersionConflict).Times(MAX_UPDATE_ATTEMPTS)
+
+	err := applyItemOperations(nil, []*sp.EntityRequest_RequestItem{
+		{Item: &sp.Item{ItemId: t_conflict_item_id, Name: "rotor"},
+			Action: sp.EntityRequest_UPDATE, FieldMask: []string{"name"}},
+	}, new(sp.EntityResponse), new_Old_2_New(), t_item_company_id, true)
+	if err == nil {
+		t.Errorf("expected an error after %d attempts", MAX_UPDATE_ATTEMPTS)
+	}
+}
+
+func TestStaleCategoryAndBranchUpdates(t *testing.T) {
+	ctrl, mock, teardown := setup_conflict_store(t, CONFLICT_POLICY_LAST_WRITER_WINS)
+	defer teardown()
+
+	category_store := models.NewMockCategoryStore(ctrl)
+	branch_store := models.NewMockBranchStore(ctrl)
+	mock.CategoryStore = category_store
+	mock.BranchStore = branch_store
+
+	category_store.EXPECT().GetCategoryByIdInTx(gomock.Any(), 7).
+		Return(&models.ShCategory{CategoryId: 7, CompanyId: t_item_company_id, Name: "brakes",
+			ParentId: models.SERVER_ROOT_CATEGORY_ID, Version: 5}, nil)
+	category_store.EXPECT().UpdateCategoryInTx(gomock.Any(), gomock.Any()).Return(nil, nil)
+	branch_store.EXPECT().GetBranchByIdInTx(gomock.Any(), 2).
+		Return(&models.ShBranch{BranchId: 2, Name: "piassa", Version: 2}, nil)
+	branch_store.EXPECT().UpdateBranchInTx(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	response := new(sp.EntityResponse)
+	err := applyCategoryOperations(nil, []*sp.EntityRequest_RequestCategory{
+		{Category: &sp.Category{CategoryId: 7, Name: "brake parts",
+			ParentId: CLIENT_ROOT_CATEGORY_ID, Version: 4},
+			Action: sp.EntityRequest_UPDATE},
+	}, response, new_Old_2_New(), t_item_company_id)
+	if err != nil {
+		t.Fatalf("update category: %v", err)
+	}
+	err = applyBranchOperations(nil, []*sp.EntityRequest_RequestBranch{
+		{Branch: &sp.Branch{BranchId: 2, Name: "merkato", Version: 1},
+			Action: sp.EntityRequest_UPDATE},
+	}, response, new_Old_2_New(), t_item_company_id)
+	if err != nil {
+		t.Fatalf("update branch: %v", err)
+	}
+
+	if len(response.CategoryConflicts) != 1 ||
+		response.CategoryConflicts[0].Resolution != sp.EntityResponse_OVERWRITTEN ||
+		response.CategoryConflicts[0].Category.Name != "brakes" {
+		t.Errorf("expected the category's overwritten copy, got %v", response.CategoryConflicts)
+	}
+	if len(response.BranchConflicts) != 1 ||
+		response.BranchConflicts[0].Resolution != sp.EntityResponse_OVERWRITTEN ||
+		response.BranchConflicts[0].Branch.Name != "piassa" {
+		t.Errorf("expected the branch's overwritten copy, got %v", response.BranchConflicts)
+	}
+}
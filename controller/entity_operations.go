@@ -4,10 +4,55 @@ import (
 	"container/list"
 	"database/sql"
 	"fmt"
+	"log"
+	"os"
 	"sheket/server/models"
 	sp "sheket/server/sheketproto"
+	"time"
 )
 
+const (
+	// The update made on a stale copy is applied anyway, the client is told
+	// which edit it replaced. This is the default.
+	CONFLICT_POLICY_LAST_WRITER_WINS = "last_writer_wins"
+
+	// The update made on a stale copy is dropped, the client gets the current copy.
+	CONFLICT_POLICY_REJECT = "reject"
+)
+
+var conflict_policy string
+
+// How many times an update is re-applied if another update changes the
+// entity between reading it and writing it back.
+const MAX_UPDATE_ATTEMPTS = 3
+
+func init() {
+	switch conflict_policy = os.Getenv("CONFLICT_POLICY"); conflict_policy {
+	case "":
+		conflict_policy = CONFLICT_POLICY_LAST_WRITER_WINS
+	case CONFLICT_POLICY_LAST_WRITER_WINS, CONFLICT_POLICY_REJECT:
+	default:
+		log.Fatalf("$CONFLICT_POLICY should be either %s or %s",
+			CONFLICT_POLICY_LAST_WRITER_WINS, CONFLICT_POLICY_REJECT)
+	}
+}
+
+/**
+ * An update is stale if the client edited a version different from what
+ * the server has. Clients that don't track versions send 0, those are
+ * never considered stale.
+ */
+func isStaleUpdate(client_version, server_version int) bool {
+	return client_version != 0 && client_version != server_version
+}
+
+func conflictResolution() sp.EntityResponse_ConflictResolution {
+	if conflict_policy == CONFLICT_POLICY_REJECT {
+		return sp.EntityResponse_REJECTED
+	}
+	return sp.EntityResponse_OVERWRITTEN
+}
+
 type _ENTITY_ID_TYPE int
 
 const (
@@ -32,21 +77,23 @@ func (old_2_new OLD_ENTITY_ID_2_NEW) getEntityType(id_type _ENTITY_ID_TYPE) map[
 
 func applyEntityOperations(tnx *sql.Tx,
 	request *sp.EntityRequest,
+	response *sp.EntityResponse,
 	user_info *UserCompanyPermission) (old_2_new OLD_ENTITY_ID_2_NEW, err error) {
 
 	old_2_new = new_Old_2_New()
 
 	company_id := user_info.CompanyId
 
-	if err = applyCategoryOperations(tnx, request.Categories, old_2_new, company_id); err != nil {
+	if err = applyCategoryOperations(tnx, request.Categories, response, old_2_new, company_id); err != nil {
 		return nil, err
 	}
 
-	if err = applyItemOperations(tnx, request.Items, old_2_new, company_id); err != nil {
+	if err = applyItemOperations(tnx, request.Items, response, old_2_new, company_id,
+		user_info.Permission.HasManagerAccess()); err != nil {
 		return nil, err
 	}
 
-	if err = applyBranchOperations(tnx, request.Branches, old_2_new, company_id); err != nil {
+	if err = applyBranchOperations(tnx, request.Branches, response, old_2_new, company_id); err != nil {
 		return nil, err
 	}
 
@@ -75,6 +122,13 @@ func _to_sh_category(sp_category *sp.Category) *models.ShCategory {
 	m_category.Name = sp_category.Name
 	m_category.CategoryId = int(sp_category.CategoryId)
 	m_category.ParentId = int(sp_category.ParentId)
+	m_category.StatusFlag = int(sp_category.StatusFlag)
+	m_category.Version = int(sp_category.Version)
+
+	// clients didn't use to send a status for categories
+	if m_category.StatusFlag == 0 {
+		m_category.StatusFlag = models.STATUS_VISIBLE
+	}
 
 	return m_category
 }
@@ -88,9 +142,14 @@ func _to_sh_category(sp_category *sp.Category) *models.ShCategory {
  * its dependency has been fulfilled. It it has, we go ahead and create it.
  * Otherwise we push back the category to the stack and add its
  * dependency(its parent) on top of it so its parent can be added first.
+ *
+ * The categories above one waiting for its parent are its ancestors, so if a
+ * category's parent is already waiting the new categories form a cycle. Those
+ * categories, and any new category under them, are rejected.
  */
 func insertCreatedCategories(tnx *sql.Tx,
 	posted_categories []*sp.EntityRequest_RequestCategory,
+	response *sp.EntityResponse,
 	old_2_new OLD_ENTITY_ID_2_NEW,
 	company_id int) error {
 
@@ -110,19 +169,31 @@ func insertCreatedCategories(tnx *sql.Tx,
 	}
 
 	created_categories := make(map[int]bool)
+	waiting_categories := make(map[int]bool)
+	rejected_categories := make(map[int]bool)
+
+	reject := func(category *models.ShCategory) {
+		rejected_categories[category.CategoryId] = true
+		response.RejectedCategories = append(response.RejectedCategories,
+			&sp.EntityResponse_RejectedCategory{
+				CategoryId: int32(category.CategoryId),
+				UUID:       category.ClientUUID,
+				Reason:     sp.EntityResponse_CATEGORY_CYCLE,
+			})
+	}
 
 	for category_stack.Len() > 0 {
 		category_id, _ := category_stack.Back().Value.(int)
 		category := categories[category_id]
 
-		if created_categories[category_id] {
+		if created_categories[category_id] || rejected_categories[category_id] {
 			// pop-off the stack
 			category_stack.Remove(category_stack.Back())
 			continue
 		}
 
 		// Check if the category already exists
-		if prev_category, err := Store.GetCategoryByUUIDInTx(tnx, category.ClientUUID); err == nil {
+		if prev_category, err := Store.GetCategoryByUUIDInTx(tnx, company_id, category.ClientUUID); err == nil {
 			old_2_new.getEntityType(_TYPE_CATEGORY)[category.CategoryId] = prev_category.CategoryId
 
 			// pop-off the stack
@@ -139,6 +210,16 @@ func insertCreatedCategories(tnx *sql.Tx,
 		} else if (category.ParentId < 0) &&
 			(category.ParentId != models.SERVER_ROOT_CATEGORY_ID) {
 
+			waiting_categories[category_id] = true
+			if waiting_categories[category.ParentId] || rejected_categories[category.ParentId] {
+				reject(category)
+				category_stack.Remove(category_stack.Back())
+				continue
+			}
+			if _, ok := categories[category.ParentId]; !ok {
+				return fmt.Errorf("parent:%d of category:%d isn't created", category.ParentId, category_id)
+			}
+
 			// if the parent hasn't been created and it is not ROOT,
 			// add the parent to the top of the stack so it is visited next
 			category_stack.PushBack(category.ParentId)
@@ -177,10 +258,11 @@ func insertCreatedCategories(tnx *sql.Tx,
 
 func applyCategoryOperations(tnx *sql.Tx,
 	posted_categories []*sp.EntityRequest_RequestCategory,
+	response *sp.EntityResponse,
 	old_2_new OLD_ENTITY_ID_2_NEW,
 	company_id int) error {
 
-	if err := insertCreatedCategories(tnx, posted_categories, old_2_new, company_id); err != nil {
+	if err := insertCreatedCategories(tnx, posted_categories, response, old_2_new, company_id); err != nil {
 		return err
 	}
 
@@ -192,60 +274,245 @@ func applyCategoryOperations(tnx *sql.Tx,
 
 		switch _p_category.Action {
 		case sp.EntityRequest_UPDATE:
-			category_to_update, err := Store.GetCategoryByIdInTx(tnx, category.CategoryId)
-			if err != nil {
-				return fmt.Errorf("error retriving category:%d '%s'", category.CategoryId, err.Error())
+			// the parent might have been created in this sync
+			if new_parent_id, ok := old_2_new.getEntityType(_TYPE_CATEGORY)[category.ParentId]; ok {
+				category.ParentId = new_parent_id
 			}
 
-			category_to_update.Name = category.Name
-			category_to_update.ParentId = category.ParentId
-
-			if _, err = Store.UpdateCategoryInTx(tnx, category_to_update); err != nil {
-				return fmt.Errorf("error updating category:%d '%s'", category.CategoryId, err.Error())
-			}
-			rev := &models.ShEntityRevision{
-				CompanyId:        company_id,
-				EntityType:       models.REV_ENTITY_CATEGORY,
-				ActionType:       models.REV_ACTION_UPDATE,
-				EntityAffectedId: category.CategoryId,
-				AdditionalInfo:   -1,
+			for attempt := 1; ; attempt++ {
+				err := applyCategoryUpdate(tnx, _p_category, category, response, company_id)
+				if err == models.ErrVersionConflict && attempt < MAX_UPDATE_ATTEMPTS {
+					// it changed after we read it, go over it again on the new copy
+					continue
+				} else if err != nil {
+					return fmt.Errorf("error updating category:%d '%s'", category.CategoryId, err.Error())
+				}
+				break
 			}
 
-			_, err = Store.AddEntityRevisionInTx(tnx, rev)
-			if err != nil {
-				return err
+		case sp.EntityRequest_DELETE:
+			if category.CategoryId == models.SERVER_ROOT_CATEGORY_ID {
+				// the root category is never deleted
+				continue
 			}
 
-		case sp.EntityRequest_DELETE:
-			if _, err := Store.GetCategoryByIdInTx(tnx, category.CategoryId); err != nil {
-				if err != models.ErrNoData {
+			category_to_delete, err := Store.GetCategoryByIdInTx(tnx, category.CategoryId)
+			if err != nil {
+				if err == models.ErrNoData {
+					// it has already been deleted
 					continue
 				} else {
 					return fmt.Errorf("error retriving category:%d '%s'", category.CategoryId, err.Error())
 				}
 			}
-
-			if err := Store.DeleteCategoryInTx(tnx, category.CategoryId); err != nil {
-				return fmt.Errorf("error deleting category: %d '%s'", category.CategoryId, err.Error())
+			if category_to_delete.CompanyId != company_id {
+				// the subtree and its items would go with it
+				return fmt.Errorf("category:%d isn't in the company", category.CategoryId)
 			}
 
-			rev := &models.ShEntityRevision{
-				CompanyId:        company_id,
-				EntityType:       models.REV_ENTITY_CATEGORY,
-				ActionType:       models.REV_ACTION_DELETE,
-				EntityAffectedId: category.CategoryId,
-				AdditionalInfo:   -1,
-			}
-
-			if _, err := Store.AddEntityRevisionInTx(tnx, rev); err != nil {
+			rejected, err := deleteCategory(tnx, category_to_delete, _p_category.DeletePolicy, company_id)
+			if err != nil {
 				return err
 			}
+			if rejected {
+				response.RejectedCategories = append(response.RejectedCategories,
+					&sp.EntityResponse_RejectedCategory{
+						CategoryId: _p_category.Category.CategoryId,
+						UUID:       _p_category.Category.UUID,
+						Reason:     sp.EntityResponse_CATEGORY_NOT_EMPTY,
+					})
+			}
 		}
 	}
 
 	return nil
 }
 
+/**
+ * Applies the client's edit of the category on the server's copy. Returns
+ * models.ErrVersionConflict if the copy changed before it was written back.
+ */
+func applyCategoryUpdate(tnx *sql.Tx,
+	_p_category *sp.EntityRequest_RequestCategory,
+	category *models.ShCategory,
+	response *sp.EntityResponse,
+	company_id int) error {
+
+	category_to_update, err := Store.GetCategoryByIdInTx(tnx, category.CategoryId)
+	if err != nil {
+		return err
+	}
+	if category_to_update.CompanyId != company_id {
+		return fmt.Errorf("category:%d isn't in the company", category.CategoryId)
+	}
+
+	// it is only reported once the update goes through, a retry might see another copy
+	var conflict *sp.EntityResponse_CategoryConflict
+	if isStaleUpdate(category.Version, category_to_update.Version) {
+		conflict = &sp.EntityResponse_CategoryConflict{
+			Category:   _to_sp_category(category_to_update),
+			Resolution: conflictResolution(),
+		}
+		if conflict.Resolution == sp.EntityResponse_REJECTED {
+			response.CategoryConflicts = append(response.CategoryConflicts, conflict)
+			return nil
+		}
+	}
+
+	if category.ParentId != category_to_update.ParentId {
+		// moving the category under itself or one of its children would create a cycle
+		is_cycle, err := Store.IsCategoryAncestorInTx(tnx, category.CategoryId, category.ParentId)
+		if err != nil {
+			return err
+		}
+		if is_cycle {
+			response.RejectedCategories = append(response.RejectedCategories,
+				&sp.EntityResponse_RejectedCategory{
+					CategoryId: _p_category.Category.CategoryId,
+					UUID:       _p_category.Category.UUID,
+					Reason:     sp.EntityResponse_CATEGORY_CYCLE,
+				})
+			return nil
+		}
+	}
+
+	category_to_update.Name = category.Name
+	category_to_update.ParentId = category.ParentId
+	category_to_update.StatusFlag = category.StatusFlag
+
+	if _, err = Store.UpdateCategoryInTx(tnx, category_to_update); err != nil {
+		return err
+	}
+	if conflict != nil {
+		response.CategoryConflicts = append(response.CategoryConflicts, conflict)
+	}
+
+	rev := &models.ShEntityRevision{
+		CompanyId:        company_id,
+		EntityType:       models.REV_ENTITY_CATEGORY,
+		ActionType:       models.REV_ACTION_UPDATE,
+		EntityAffectedId: category.CategoryId,
+		AdditionalInfo:   -1,
+	}
+
+	_, err = Store.AddEntityRevisionInTx(tnx, rev)
+	return err
+}
+
+/**
+ * Adds a revision of action_type for each of the entities.
+ */
+func addEntityRevisionsInTx(tnx *sql.Tx, company_id, entity_type, action_type int, entity_ids []int) error {
+	for _, entity_id := range entity_ids {
+		rev := &models.ShEntityRevision{
+			CompanyId:        company_id,
+			EntityType:       entity_type,
+			ActionType:       action_type,
+			EntityAffectedId: entity_id,
+			AdditionalInfo:   -1,
+		}
+
+		if _, err := Store.AddEntityRevisionInTx(tnx, rev); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/**
+ * Deletes the category, the policy decides what happens to its sub-categories
+ * and items. Everything that got moved gets an update revision so clients
+ * see the re-parenting. Returns true if the policy refused the delete.
+ */
+func deleteCategory(tnx *sql.Tx, category *models.ShCategory,
+	policy sp.EntityRequest_DeletePolicy, company_id int) (rejected bool, err error) {
+
+	category_id := category.CategoryId
+
+	var deleted_categories, moved_categories, moved_items []int
+
+	switch policy {
+	case sp.EntityRequest_REFUSE_IF_NOT_EMPTY:
+		is_empty, err := Store.IsCategoryEmptyInTx(tnx, category_id)
+		if err != nil {
+			return false, err
+		}
+		if !is_empty {
+			return true, nil
+		}
+		if err = removeCategoryFromBranchesInTx(tnx, category_id, false, company_id); err != nil {
+			return false, err
+		}
+		if err = Store.DeleteCategoryInTx(tnx, category_id); err != nil {
+			return false, fmt.Errorf("error deleting category: %d '%s'", category_id, err.Error())
+		}
+		deleted_categories = []int{category_id}
+
+	case sp.EntityRequest_DELETE_SUBTREE:
+		if err = removeCategoryFromBranchesInTx(tnx, category_id, true, company_id); err != nil {
+			return false, err
+		}
+		deleted_categories, moved_items, err = Store.DeleteCategorySubtreeInTx(tnx,
+			category_id, category.ParentId)
+		if err != nil {
+			return false, fmt.Errorf("error deleting category: %d '%s'", category_id, err.Error())
+		}
+
+	default: // sp.EntityRequest_MOVE_TO_PARENT
+		moved_categories, moved_items, err = Store.MoveCategoryChildrenInTx(tnx,
+			category_id, category.ParentId)
+		if err != nil {
+			return false, err
+		}
+		if err = removeCategoryFromBranchesInTx(tnx, category_id, false, company_id); err != nil {
+			return false, err
+		}
+		if err = Store.DeleteCategoryInTx(tnx, category_id); err != nil {
+			return false, fmt.Errorf("error deleting category: %d '%s'", category_id, err.Error())
+		}
+		deleted_categories = []int{category_id}
+	}
+
+	if err = addEntityRevisionsInTx(tnx, company_id, models.REV_ENTITY_CATEGORY,
+		models.REV_ACTION_UPDATE, moved_categories); err != nil {
+		return false, err
+	}
+	if err = addEntityRevisionsInTx(tnx, company_id, models.REV_ENTITY_ITEM,
+		models.REV_ACTION_UPDATE, moved_items); err != nil {
+		return false, err
+	}
+	if err = addEntityRevisionsInTx(tnx, company_id, models.REV_ENTITY_CATEGORY,
+		models.REV_ACTION_DELETE, deleted_categories); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+/**
+ * Deleting a category removes it from the branches with a cascade, so it is
+ * removed here first to give each of those branches a delete revision.
+ */
+func removeCategoryFromBranchesInTx(tnx *sql.Tx, category_id int, include_subtree bool, company_id int) error {
+	branch_categories, err := Store.DeleteCategoryFromBranchesInTx(tnx, category_id, include_subtree)
+	if err != nil {
+		return err
+	}
+
+	for _, branch_category := range branch_categories {
+		rev := &models.ShEntityRevision{
+			CompanyId:        company_id,
+			EntityType:       models.REV_ENTITY_BRANCH_CATEGORY,
+			ActionType:       models.REV_ACTION_DELETE,
+			EntityAffectedId: branch_category.BranchId,
+			AdditionalInfo:   branch_category.CategoryId,
+		}
+		if _, err := Store.AddEntityRevisionInTx(tnx, rev); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // converts from *sheket_proto.Item ==>> *models.ShItem
 func _to_sh_item(sp_item *sp.Item) *models.ShItem {
 	m_item := new(models.ShItem)
@@ -259,25 +526,175 @@ func _to_sh_item(sp_item *sp.Item) *models.ShItem {
 	m_item.HasDerivedUnit = sp_item.HasDerivedUnit
 	m_item.DerivedName = sp_item.DerivedName
 	m_item.DerivedFactor = sp_item.DerivedFactor
+	m_item.ReorderLevel = sp_item.ReorderLevel
+	m_item.ModelYear = sp_item.ModelYear
+	m_item.PartNumber = sp_item.PartNumber
+	m_item.BarCode = sp_item.BarCode
+	m_item.HasBarCode = sp_item.HasBarCode
 	m_item.StatusFlag = int(sp_item.StatusFlag)
+	m_item.Version = int(sp_item.Version)
+	m_item.CostPrice = sp_item.CostPrice
+	m_item.SellingPrice = sp_item.SellingPrice
+	if sp_item.HasUnits {
+		m_item.Units = make([]*models.ShItemUnit, 0, len(sp_item.Units))
+		for _, unit := range sp_item.Units {
+			m_item.Units = append(m_item.Units, &models.ShItemUnit{
+				UnitId: int(unit.UnitId),
+				Name:   unit.Name,
+				Factor: unit.Factor,
+			})
+		}
+	}
 
 	return m_item
 }
 
+/**
+ * Copies a field of the item the client sent onto the server's copy.
+ * The keys are the field names in sheket_proto.Item.
+ */
+var item_field_setters = map[string]func(dst, src *models.ShItem){
+	"name":                func(dst, src *models.ShItem) { dst.Name = src.Name },
+	"code":                func(dst, src *models.ShItem) { dst.ItemCode = src.ItemCode },
+	"status_flag":         func(dst, src *models.ShItem) { dst.StatusFlag = src.StatusFlag },
+	"category_id":         func(dst, src *models.ShItem) { dst.CategoryId = src.CategoryId },
+	"unit_of_measurement": func(dst, src *models.ShItem) { dst.UnitOfMeasurement = src.UnitOfMeasurement },
+	"has_derived_unit":    func(dst, src *models.ShItem) { dst.HasDerivedUnit = src.HasDerivedUnit },
+	"derived_name":        func(dst, src *models.ShItem) { dst.DerivedName = src.DerivedName },
+	"derived_factor":      func(dst, src *models.ShItem) { dst.DerivedFactor = src.DerivedFactor },
+	"reorder_level":       func(dst, src *models.ShItem) { dst.ReorderLevel = src.ReorderLevel },
+	"model_year":          func(dst, src *models.ShItem) { dst.ModelYear = src.ModelYear },
+	"part_number":         func(dst, src *models.ShItem) { dst.PartNumber = src.PartNumber },
+	"bar_code":            func(dst, src *models.ShItem) { dst.BarCode = src.BarCode },
+	"has_bar_code":        func(dst, src *models.ShItem) { dst.HasBarCode = src.HasBarCode },
+	"cost_price":          func(dst, src *models.ShItem) { dst.CostPrice = src.CostPrice },
+	"selling_price":       func(dst, src *models.ShItem) { dst.SellingPrice = src.SellingPrice },
+	"units": func(dst, src *models.ShItem) {
+		// nil if the client didn't send the units, e.g: it doesn't know about them
+		if src.Units != nil {
+			dst.Units = src.Units
+		}
+	},
+}
+
+/**
+ * Records the item's prices in the price history if they changed.
+ * @args prev	the item before the change, nil if it was just created
+ */
+func addItemPriceHistoryInTx(tnx *sql.Tx, prev, item *models.ShItem) error {
+	if prev == nil {
+		if item.CostPrice == 0 && item.SellingPrice == 0 {
+			// it doesn't have a price yet
+			return nil
+		}
+	} else if prev.CostPrice == item.CostPrice && prev.SellingPrice == item.SellingPrice {
+		return nil
+	}
+
+	return Store.AddItemPriceInTx(tnx, &models.ShItemPrice{
+		CompanyId:     item.CompanyId,
+		ItemId:        item.ItemId,
+		CostPrice:     item.CostPrice,
+		SellingPrice:  item.SellingPrice,
+		EffectiveDate: time.Now().Unix(),
+	})
+}
+
+/**
+ * Records the branch's selling price override in the price history if it changed.
+ * @args prev	the branch item before the change, nil if it was just added
+ */
+func addBranchPriceHistoryInTx(tnx *sql.Tx, prev, branch_item *models.ShBranchItem) error {
+	if prev == nil {
+		if !branch_item.HasSellingPrice {
+			return nil
+		}
+	} else if prev.HasSellingPrice == branch_item.HasSellingPrice &&
+		prev.SellingPrice == branch_item.SellingPrice {
+		return nil
+	}
+
+	return Store.AddItemPriceInTx(tnx, &models.ShItemPrice{
+		CompanyId:       branch_item.CompanyId,
+		ItemId:          branch_item.ItemId,
+		BranchId:        branch_item.BranchId,
+		SellingPrice:    branch_item.SellingPrice,
+		HasSellingPrice: branch_item.HasSellingPrice,
+		EffectiveDate:   time.Now().Unix(),
+	})
+}
+
+/**
+ * The fields every client sends, an update without a field mask only changes
+ * those. Clients that don't send a mask don't know about the fields added
+ * after them, so they send those as zero values. The units are changed only
+ * if the client sent them, see item_field_setters.
+ */
+var legacy_item_fields = []string{
+	"name", "code", "status_flag", "category_id",
+	"unit_of_measurement", "has_derived_unit", "derived_name", "derived_factor",
+	"units",
+}
+
+/**
+ * Updates the fields of dst named in the field_mask with the values in src.
+ * An empty mask only updates the legacy_item_fields, the other fields
+ * have to be named to be changed.
+ */
+func applyItemFieldMask(dst, src *models.ShItem, field_mask []string) {
+	if len(field_mask) == 0 {
+		field_mask = legacy_item_fields
+	}
+
+	for _, field := range field_mask {
+		if set_field, ok := item_field_setters[field]; ok {
+			set_field(dst, src)
+		}
+	}
+}
+
+/**
+ * Returns the rejection to report if the item couldn't be written because of
+ * a duplicate code, nil if err isn't such an error.
+ */
+func _to_rejected_item(sp_item *sp.Item, err error) *sp.EntityResponse_RejectedItem {
+	var reason sp.EntityResponse_RejectReason
+	switch err {
+	case models.ErrDuplicateBarCode:
+		reason = sp.EntityResponse_DUPLICATE_BAR_CODE
+	case models.ErrDuplicateItemCode:
+		reason = sp.EntityResponse_DUPLICATE_ITEM_CODE
+	default:
+		return nil
+	}
+	return &sp.EntityResponse_RejectedItem{
+		ItemId: sp_item.ItemId,
+		UUID:   sp_item.UUID,
+		Reason: reason,
+	}
+}
+
+/**
+ * @args is_manager	only managers can set the cost price
+ */
 func applyItemOperations(tnx *sql.Tx,
 	posted_items []*sp.EntityRequest_RequestItem,
+	response *sp.EntityResponse,
 	old_2_new OLD_ENTITY_ID_2_NEW,
-	company_id int) error {
+	company_id int, is_manager bool) error {
 
 	for _, _p_item := range posted_items {
 		item := _to_sh_item(_p_item.Item)
 		item.CompanyId = company_id
 		item.CategoryId = To_Server_Category_Id(item.CategoryId)
+		if !is_manager {
+			item.CostPrice = 0
+		}
 
 		switch _p_item.Action {
 		case sp.EntityRequest_CREATE:
 			// check if it already exists
-			if prev_item, err := Store.GetItemByUUIDInTx(tnx, _p_item.Item.UUID); err == nil {
+			if prev_item, err := Store.GetItemByUUIDInTx(tnx, company_id, _p_item.Item.UUID); err == nil {
 				old_2_new.getEntityType(_TYPE_ITEM)[int(_p_item.Item.ItemId)] = prev_item.ItemId
 
 				continue
@@ -290,11 +707,18 @@ func applyItemOperations(tnx *sql.Tx,
 			}
 
 			created_item, err := Store.CreateItemInTx(tnx, item)
-			if err != nil {
+			if rejected := _to_rejected_item(_p_item.Item, err); rejected != nil {
+				response.RejectedItems = append(response.RejectedItems, rejected)
+				continue
+			} else if err != nil {
 				return fmt.Errorf("error creating item %s", err.Error())
 			}
 			old_2_new.getEntityType(_TYPE_ITEM)[int(_p_item.Item.ItemId)] = created_item.ItemId
 
+			if err = addItemPriceHistoryInTx(tnx, nil, created_item); err != nil {
+				return err
+			}
+
 			rev := &models.ShEntityRevision{
 				CompanyId:        company_id,
 				EntityType:       models.REV_ENTITY_ITEM,
@@ -312,49 +736,83 @@ func applyItemOperations(tnx *sql.Tx,
 				item.CategoryId = new_category_id
 			}
 
-			item_to_update, err := Store.GetItemByIdInTx(tnx, item.ItemId)
-			if err != nil {
-				return fmt.Errorf("error retriving item:%d '%s'", item.ItemId, err.Error())
+			for attempt := 1; ; attempt++ {
+				err := applyItemUpdate(tnx, _p_item, item, response, is_manager, company_id)
+				if err == models.ErrVersionConflict && attempt < MAX_UPDATE_ATTEMPTS {
+					// it changed after we read it, go over it again on the new copy
+					continue
+				} else if err != nil {
+					return fmt.Errorf("error updating item:%d '%v'", item.ItemId, err.Error())
+				}
+				break
 			}
+		case sp.EntityRequest_DELETE:
+			// TODO: item delete not implemented yet
+		}
+	}
 
-			item_to_update.CategoryId = item.CategoryId
-			item_to_update.Name = item.Name
-			item_to_update.ItemCode = item.ItemCode
+	return nil
+}
 
-			item_to_update.UnitOfMeasurement = item.UnitOfMeasurement
-			item_to_update.HasDerivedUnit = item.HasDerivedUnit
-			item_to_update.DerivedName = item.DerivedName
-			item_to_update.DerivedFactor = item.DerivedFactor
-			item_to_update.ReorderLevel = item.ReorderLevel
+/**
+ * Applies the client's edit of the item on the server's copy. Returns
+ * models.ErrVersionConflict if the copy changed before it was written back.
+ */
+func applyItemUpdate(tnx *sql.Tx,
+	_p_item *sp.EntityRequest_RequestItem,
+	item *models.ShItem,
+	response *sp.EntityResponse,
+	is_manager bool, company_id int) error {
+
+	item_to_update, err := Store.GetItemByIdInTx(tnx, item.ItemId)
+	if err != nil {
+		return err
+	}
 
-			item_to_update.ModelYear = item.ModelYear
-			item_to_update.PartNumber = item.PartNumber
-			item_to_update.BarCode = item.BarCode
-			item_to_update.HasBarCode = item.HasBarCode
-			item_to_update.StatusFlag = item.StatusFlag
+	// it is only reported once the update goes through, a retry might see another copy
+	var conflict *sp.EntityResponse_ItemConflict
+	if isStaleUpdate(item.Version, item_to_update.Version) {
+		conflict = &sp.EntityResponse_ItemConflict{
+			Item:       _to_sp_item(item_to_update, is_manager),
+			Resolution: conflictResolution(),
+		}
+		if conflict.Resolution == sp.EntityResponse_REJECTED {
+			response.ItemConflicts = append(response.ItemConflicts, conflict)
+			return nil
+		}
+	}
 
-			if _, err = Store.UpdateItemInTx(tnx, item_to_update); err != nil {
-				return fmt.Errorf("error updating item:%d '%v'", item.ItemId, err.Error())
-			}
+	prev_item := *item_to_update
+	applyItemFieldMask(item_to_update, item, _p_item.FieldMask)
+	if !is_manager {
+		// they don't see the cost price, so they can't have changed it
+		item_to_update.CostPrice = prev_item.CostPrice
+	}
 
-			rev := &models.ShEntityRevision{
-				CompanyId:        company_id,
-				EntityType:       models.REV_ENTITY_ITEM,
-				ActionType:       models.REV_ACTION_UPDATE,
-				EntityAffectedId: item.ItemId,
-				AdditionalInfo:   -1,
-			}
+	_, err = Store.UpdateItemInTx(tnx, item_to_update)
+	if rejected := _to_rejected_item(_p_item.Item, err); rejected != nil {
+		response.RejectedItems = append(response.RejectedItems, rejected)
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if conflict != nil {
+		response.ItemConflicts = append(response.ItemConflicts, conflict)
+	}
+	if err = addItemPriceHistoryInTx(tnx, &prev_item, item_to_update); err != nil {
+		return err
+	}
 
-			_, err = Store.AddEntityRevisionInTx(tnx, rev)
-			if err != nil {
-				return err
-			}
-		case sp.EntityRequest_DELETE:
-			// TODO: item delete not implemented yet
-		}
+	rev := &models.ShEntityRevision{
+		CompanyId:        company_id,
+		EntityType:       models.REV_ENTITY_ITEM,
+		ActionType:       models.REV_ACTION_UPDATE,
+		EntityAffectedId: item.ItemId,
+		AdditionalInfo:   -1,
 	}
 
-	return nil
+	_, err = Store.AddEntityRevisionInTx(tnx, rev)
+	return err
 }
 
 func _to_sh_branch(sp_branch *sp.Branch) *models.ShBranch {
@@ -364,12 +822,14 @@ func _to_sh_branch(sp_branch *sp.Branch) *models.ShBranch {
 	m_branch.ClientUUID = sp_branch.UUID
 	m_branch.Name = sp_branch.Name
 	m_branch.StatusFlag = int(sp_branch.StatusFlag)
+	m_branch.Version = int(sp_branch.Version)
 
 	return m_branch
 }
 
 func applyBranchOperations(tnx *sql.Tx,
 	posted_branches []*sp.EntityRequest_RequestBranch,
+	response *sp.EntityResponse,
 	old_2_new OLD_ENTITY_ID_2_NEW,
 	company_id int) error {
 
@@ -379,7 +839,7 @@ func applyBranchOperations(tnx *sql.Tx,
 
 		switch _p_branch.Action {
 		case sp.EntityRequest_CREATE:
-			if prev_branch, err := Store.GetBranchByUUIDInTx(tnx, branch.ClientUUID); err == nil {
+			if prev_branch, err := Store.GetBranchByUUIDInTx(tnx, company_id, branch.ClientUUID); err == nil {
 				old_2_new.getEntityType(_TYPE_BRANCH)[branch.BranchId] = prev_branch.BranchId
 				continue
 			} else if err != models.ErrNoData {
@@ -405,37 +865,72 @@ func applyBranchOperations(tnx *sql.Tx,
 				return err
 			}
 		case sp.EntityRequest_UPDATE:
-			branch_to_update, err := Store.GetBranchByIdInTx(tnx, branch.BranchId)
-			if err != nil {
-				return fmt.Errorf("error retriving branch:%d '%s'", branch.BranchId, err.Error())
+			for attempt := 1; ; attempt++ {
+				err := applyBranchUpdate(tnx, branch, response, company_id)
+				if err == models.ErrVersionConflict && attempt < MAX_UPDATE_ATTEMPTS {
+					// it changed after we read it, go over it again on the new copy
+					continue
+				} else if err != nil {
+					return fmt.Errorf("error updating branch:%d '%v'", branch.BranchId, err.Error())
+				}
+				break
 			}
+		case sp.EntityRequest_DELETE:
+			// TODO: delete branch not yet implemented
+		}
+	}
 
-			branch_to_update.Name = branch.Name
-			branch_to_update.Location = branch.Location
-			branch_to_update.StatusFlag = branch.StatusFlag
+	return nil
+}
 
-			if _, err = Store.UpdateBranchInTx(tnx, branch_to_update); err != nil {
-				return fmt.Errorf("error updating branch:%d '%v'", branch.BranchId, err.Error())
-			}
+/**
+ * Applies the client's edit of the branch on the server's copy. Returns
+ * models.ErrVersionConflict if the copy changed before it was written back.
+ */
+func applyBranchUpdate(tnx *sql.Tx,
+	branch *models.ShBranch,
+	response *sp.EntityResponse,
+	company_id int) error {
 
-			rev := &models.ShEntityRevision{
-				CompanyId:        company_id,
-				EntityType:       models.REV_ENTITY_BRANCH,
-				ActionType:       models.REV_ACTION_UPDATE,
-				EntityAffectedId: branch.BranchId,
-				AdditionalInfo:   -1,
-			}
+	branch_to_update, err := Store.GetBranchByIdInTx(tnx, branch.BranchId)
+	if err != nil {
+		return err
+	}
 
-			_, err = Store.AddEntityRevisionInTx(tnx, rev)
-			if err != nil {
-				return err
-			}
-		case sp.EntityRequest_DELETE:
-			// TODO: delete branch not yet implemented
+	// it is only reported once the update goes through, a retry might see another copy
+	var conflict *sp.EntityResponse_BranchConflict
+	if isStaleUpdate(branch.Version, branch_to_update.Version) {
+		conflict = &sp.EntityResponse_BranchConflict{
+			Branch:     _to_sp_branch(branch_to_update),
+			Resolution: conflictResolution(),
+		}
+		if conflict.Resolution == sp.EntityResponse_REJECTED {
+			response.BranchConflicts = append(response.BranchConflicts, conflict)
+			return nil
 		}
 	}
 
-	return nil
+	branch_to_update.Name = branch.Name
+	branch_to_update.Location = branch.Location
+	branch_to_update.StatusFlag = branch.StatusFlag
+
+	if _, err = Store.UpdateBranchInTx(tnx, branch_to_update); err != nil {
+		return err
+	}
+	if conflict != nil {
+		response.BranchConflicts = append(response.BranchConflicts, conflict)
+	}
+
+	rev := &models.ShEntityRevision{
+		CompanyId:        company_id,
+		EntityType:       models.REV_ENTITY_BRANCH,
+		ActionType:       models.REV_ACTION_UPDATE,
+		EntityAffectedId: branch.BranchId,
+		AdditionalInfo:   -1,
+	}
+
+	_, err = Store.AddEntityRevisionInTx(tnx, rev)
+	return err
 }
 
 func _to_sh_branch_item(sp_branch_item *sp.BranchItem) *models.ShBranchItem {
@@ -445,10 +940,59 @@ func _to_sh_branch_item(sp_branch_item *sp.BranchItem) *models.ShBranchItem {
 	m_br_item.BranchId = int(sp_branch_item.BranchId)
 	m_br_item.ItemLocation = sp_branch_item.ShelfLocation
 	m_br_item.Quantity = sp_branch_item.Quantity
+	m_br_item.ReorderLevel = sp_branch_item.ReorderLevel
+	m_br_item.HasReorderLevel = sp_branch_item.HasReorderLevel
+	m_br_item.SellingPrice = sp_branch_item.SellingPrice
+	m_br_item.HasSellingPrice = sp_branch_item.HasSellingPrice
 
 	return m_br_item
 }
 
+/**
+ * Copies a field of the branch item the client sent onto the server's copy.
+ * The keys are the field names in sheket_proto.BranchItem, an override and its
+ * has-flag always change together.
+ */
+var branch_item_field_setters = map[string]func(dst, src *models.ShBranchItem){
+	"shelf_location": func(dst, src *models.ShBranchItem) { dst.ItemLocation = src.ItemLocation },
+	"reorder_level": func(dst, src *models.ShBranchItem) {
+		dst.ReorderLevel, dst.HasReorderLevel = src.ReorderLevel, src.HasReorderLevel
+	},
+	"has_reorder_level": func(dst, src *models.ShBranchItem) {
+		dst.ReorderLevel, dst.HasReorderLevel = src.ReorderLevel, src.HasReorderLevel
+	},
+	"selling_price": func(dst, src *models.ShBranchItem) {
+		dst.SellingPrice, dst.HasSellingPrice = src.SellingPrice, src.HasSellingPrice
+	},
+	"has_selling_price": func(dst, src *models.ShBranchItem) {
+		dst.SellingPrice, dst.HasSellingPrice = src.SellingPrice, src.HasSellingPrice
+	},
+}
+
+/**
+ * Updates the fields of dst named in the field_mask with the values in src.
+ * Clients that don't send a mask might not know about the overrides, so an
+ * empty mask only sets an override if the client sent its has-flag.
+ * The quantity is never updated, it is only affected through a transaction.
+ */
+func applyBranchItemFieldMask(dst, src *models.ShBranchItem, field_mask []string) {
+	if len(field_mask) == 0 {
+		field_mask = []string{"shelf_location"}
+		if src.HasReorderLevel {
+			field_mask = append(field_mask, "reorder_level")
+		}
+		if src.HasSellingPrice {
+			field_mask = append(field_mask, "selling_price")
+		}
+	}
+
+	for _, field := range field_mask {
+		if set_field, ok := branch_item_field_setters[field]; ok {
+			set_field(dst, src)
+		}
+	}
+}
+
 func applyBranchItemOperations(tnx *sql.Tx,
 	posted_branch_items []*sp.EntityRequest_RequestBranchItem,
 	old_2_new OLD_ENTITY_ID_2_NEW,
@@ -469,13 +1013,25 @@ func applyBranchItemOperations(tnx *sql.Tx,
 			}
 			if new_item_id, ok := old_2_new.getEntityType(_TYPE_ITEM)[item_id]; ok {
 				b_item.ItemId = new_item_id
+			} else if item_id < 0 {
+				// the item wasn't created, it was probably rejected
+				continue
 			}
 
-			b_item.Quantity = 0 // Start at 0 when adding it, let the transaction update it.
+			// the quantity only comes from the ledger, transactions might have
+			// already moved stock of the item in the branch before it was added
+			quantity, err := Store.GetStockLedgerQuantityInTx(tnx, company_id, b_item.BranchId, b_item.ItemId)
+			if err != nil {
+				return err
+			}
+			b_item.Quantity = quantity
 			if _, err := Store.AddItemToBranchInTx(tnx, b_item); err != nil {
 				return fmt.Errorf("error adding item:%d to branch:%d '%s'",
 					b_item.ItemId, b_item.BranchId, err.Error())
 			}
+			if err = addBranchPriceHistoryInTx(tnx, nil, b_item); err != nil {
+				return err
+			}
 
 			rev := &models.ShEntityRevision{
 				CompanyId:        company_id,
@@ -485,7 +1041,7 @@ func applyBranchItemOperations(tnx *sql.Tx,
 				AdditionalInfo:   b_item.ItemId,
 			}
 
-			_, err := Store.AddEntityRevisionInTx(tnx, rev)
+			_, err = Store.AddEntityRevisionInTx(tnx, rev)
 			if err != nil {
 				return err
 			}
@@ -506,14 +1062,16 @@ func applyBranchItemOperations(tnx *sql.Tx,
 				return fmt.Errorf("error retriving branchItem:(%d,%d) '%s'", branch_id, item_id, err.Error())
 			}
 
-			// quantity isn't directly updatable, it is only affected through a transaction.
-			// so only update the item location
-			previous_branch_item.ItemLocation = b_item.ItemLocation
+			prev_branch_item := *previous_branch_item
+			applyBranchItemFieldMask(previous_branch_item, b_item, _p_branch_item.FieldMask)
 
 			if _, err = Store.UpdateBranchItemInTx(tnx, previous_branch_item); err != nil {
 				return fmt.Errorf("error updating branchItem:(%d,%d) '%v'",
 					branch_id, item_id, err.Error())
 			}
+			if err = addBranchPriceHistoryInTx(tnx, &prev_branch_item, previous_branch_item); err != nil {
+				return err
+			}
 
 			rev := &models.ShEntityRevision{
 				CompanyId:        company_id,
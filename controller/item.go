@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"fmt"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+)
+
+func (s *SheketController) LookupItemByBarcode(c context.Context, request *sp.BarcodeLookupRequest) (response *sp.Item, err error) {
+	defer trace("LookupItemByBarcode")()
+
+	user_info, err := GetUserWithCompanyPermission(request.CompanyAuth)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "%v", err)
+	}
+
+	if len(request.BarCode) == 0 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v", fmt.Errorf("empty bar code"))
+	}
+
+	item, err := Store.GetItemByBarCode(user_info.CompanyId, request.BarCode)
+	if err == models.ErrNoData {
+		return nil, grpc.Errorf(codes.NotFound, "%v",
+			fmt.Errorf("no item with bar code %s", request.BarCode))
+	} else if err != nil {
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+
+	return _to_sp_item(item, user_info.Permission.HasManagerAccess()), nil
+}
+
+const (
+	// used if the client doesn't specify a page size
+	SEARCH_DEFAULT_PAGE_SIZE = 30
+
+	SEARCH_MAX_PAGE_SIZE = 200
+)
+
+func (s *SheketController) SearchItems(c context.Context, request *sp.SearchItemsRequest) (response *sp.SearchItemsResponse, err error) {
+	defer trace("SearchItems")()
+
+	user_info, err := GetUserWithCompanyPermission(request.CompanyAuth)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "%v", err)
+	}
+
+	if request.Offset < 0 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v", fmt.Errorf("negative offset"))
+	}
+
+	response, err = searchItems(user_info.CompanyId, user_info.Permission.HasManagerAccess(), request)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+	return response, nil
+}
+
+/**
+ * Returns the page of the company's items matching the request, the cost
+ * price is only included if show_cost is set.
+ */
+func searchItems(company_id int, show_cost bool, request *sp.SearchItemsRequest) (*sp.SearchItemsResponse, error) {
+	page_size := int(request.PageSize)
+	if page_size <= 0 {
+		page_size = SEARCH_DEFAULT_PAGE_SIZE
+	} else if page_size > SEARCH_MAX_PAGE_SIZE {
+		page_size = SEARCH_MAX_PAGE_SIZE
+	}
+
+	search := &models.ShItemSearch{
+		CompanyId:     company_id,
+		Query:         request.Query,
+		BranchId:      int(request.BranchId),
+		IncludeHidden: request.IncludeHidden,
+		Offset:        int(request.Offset),
+	}
+	if request.CategoryId != 0 {
+		search.CategoryId = To_Server_Category_Id(int(request.CategoryId))
+	}
+
+	items, has_more, err := Store.SearchItems(search, page_size)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &sp.SearchItemsResponse{HasMore: has_more}
+	for _, item := range items {
+		response.Items = append(response.Items, _to_sp_item(item, show_cost))
+	}
+	return response, nil
+}
+
+func (s *SheketController) GetItemPriceHistory(c context.Context, request *sp.ItemPriceHistoryRequest) (response *sp.ItemPriceHistoryResponse, err error) {
+	defer trace("GetItemPriceHistory")()
+
+	user_info, err := GetUserWithCompanyPermission(request.CompanyAuth)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "%v", err)
+	}
+
+	prices, err := Store.GetItemPriceHistory(user_info.CompanyId, int(request.ItemId))
+	if err != nil {
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+
+	show_cost := user_info.Permission.HasManagerAccess()
+	response = new(sp.ItemPriceHistoryResponse)
+	for _, price := range prices {
+		sp_price := &sp.ItemPriceHistoryResponse_ItemPrice{
+			BranchId:        int32(price.BranchId),
+			SellingPrice:    price.SellingPrice,
+			HasSellingPrice: price.HasSellingPrice,
+			EffectiveDate:   price.EffectiveDate,
+		}
+		if show_cost {
+			sp_price.CostPrice = price.CostPrice
+		}
+		response.Prices = append(response.Prices, sp_price)
+	}
+	return response, nil
+}
@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"sheket/server/models"
+	"testing"
+)
+
+func t_mask_item() *models.ShItem {
+	return &models.ShItem{
+		Name:              "brake pad",
+		ItemCode:          "BP-1",
+		CategoryId:        4,
+		UnitOfMeasurement: 2,
+		HasDerivedUnit:    true,
+		DerivedName:       "box",
+		DerivedFactor:     12,
+		StatusFlag:        models.STATUS_VISIBLE,
+		Units:             []*models.ShItemUnit{{UnitId: 3, Name: "carton", Factor: 48}},
+	}
+}
+
+func TestFieldMaskOnlyChangesNamedFields(t *testing.T) {
+	dst := t_mask_item()
+	src := &models.ShItem{Name: "front brake pad", DerivedName: "pack", DerivedFactor: 6}
+
+	applyItemFieldMask(dst, src, []string{"name", "derived_factor", "no_such_field"})
+
+	expected := t_mask_item()
+	expected.Name = "front brake pad"
+	expected.DerivedFactor = 6
+	if dst.Name != expected.Name || dst.DerivedFactor != expected.DerivedFactor {
+		t.Errorf("the named fields weren't changed, got %v", dst)
+	}
+	if dst.DerivedName != "box" || dst.ItemCode != "BP-1" || dst.CategoryId != 4 ||
+		!dst.HasDerivedUnit || dst.UnitOfMeasurement != 2 {
+		t.Errorf("fields not in the mask were changed, got %v", dst)
+	}
+}
+
+func TestFieldMaskUnitsNeedToBeSent(t *testing.T) {
+	dst := t_mask_item()
+
+	// the client named them but didn't send any, e.g: it doesn't know about them
+	applyItemFieldMask(dst, &models.ShItem{}, []string{"units"})
+	if len(dst.Units) != 1 {
+		t.Errorf("the units shouldn't be removed if they weren't sent, got %v", dst.Units)
+	}
+
+	// an empty list removes them
+	applyItemFieldMask(dst, &models.ShItem{Units: []*models.ShItemUnit{}}, []string{"units"})
+	if len(dst.Units) != 0 {
+		t.Errorf("expected the units to be removed, got %v", dst.Units)
+	}
+}
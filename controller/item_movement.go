@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"fmt"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	sp "sheket/server/sheketproto"
+)
+
+func itemMovements(company_id int, request *sp.ItemMovementsRequest) (*sp.ItemMovementsResponse, error) {
+	movements, err := Store.GetItemMovements(company_id, int(request.BranchId), int(request.ItemId),
+		request.StartDate, request.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(sp.ItemMovementsResponse)
+	for _, m := range movements {
+		response.Movements = append(response.Movements, &sp.ItemMovementsResponse_Movement{
+			TransId:       m.TransactionId,
+			Date:          m.Date,
+			TransType:     int32(m.TransType),
+			OtherBranchId: int32(m.OtherBranchId),
+			UserId:        int32(m.UserId),
+			TransNote:     m.TransNote,
+			ItemNote:      m.ItemNote,
+			IsVoid:        m.IsVoid,
+			Quantity:      m.Quantity,
+			Balance:       m.Balance,
+		})
+	}
+	return response, nil
+}
+
+func (s *SheketController) GetItemMovements(c context.Context, request *sp.ItemMovementsRequest) (response *sp.ItemMovementsResponse, err error) {
+	defer trace("GetItemMovements")()
+
+	user_info, err := GetUserWithCompanyPermission(request.CompanyAuth)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "%v", err)
+	}
+
+	// the balance shows the quantity
+	if !canSeeBranchQuantity(user_info.Permission, int(request.BranchId)) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "%v",
+			fmt.Errorf("you can't see the quantity in branch:%d", request.BranchId))
+	}
+	if request.StartDate != 0 && request.EndDate != 0 &&
+		request.StartDate > request.EndDate {
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v",
+			fmt.Errorf("start date is after end date"))
+	}
+
+	if response, err = itemMovements(user_info.CompanyId, request); err != nil {
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+	return response, nil
+}
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"database/sql"
+	"github.com/golang/mock/gomock"
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+	"testing"
+)
+
+func TestNonManagerCantChangeCostPrice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	save_store := Store
+	defer func() {
+		ctrl.Finish()
+		Store = save_store
+	}()
+
+	item_store := models.NewSimpleItemStore(nil)
+	price_store := models.NewMockItemPriceStore(ctrl)
+	mock := models.NewComposableShStoreMock(ctrl)
+	mock.ItemStore = item_store
+	mock.RevisionStore = models.NewSimpleRevisionStore(nil)
+	mock.ItemPriceStore = price_store
+	Store = mock
+
+	var history []*models.ShItemPrice
+	price_store.EXPECT().AddItemPriceInTx(gomock.Any(), gomock.Any()).
+		Do(func(tnx *sql.Tx, price *models.ShItemPrice) {
+			history = append(history, price)
+		}).Return(nil).Times(2)
+
+	created := t_full_sp_item()
+	created.CostPrice = 30
+	created.SellingPrice = 45
+	old_2_new := new_Old_2_New()
+	err := applyItemOperations(nil, []*sp.EntityRequest_RequestItem{
+		{Item: created, Action: sp.EntityRequest_CREATE},
+	}, new(sp.EntityResponse), old_2_new, t_item_company_id, true)
+	if err != nil {
+		t.Fatalf("create item: %v", err)
+	}
+	item_id := old_2_new.getEntityType(_TYPE_ITEM)[-1]
+
+	// the client doesn't know the cost, so it sends it as 0
+	updated := t_full_sp_item()
+	updated.ItemId = int32(item_id)
+	updated.SellingPrice = 50
+	err = applyItemOperations(nil, []*sp.EntityRequest_RequestItem{
+		{Item: updated, Action: sp.EntityRequest_UPDATE,
+			FieldMask: []string{"cost_price", "selling_price"}},
+	}, new(sp.EntityResponse), new_Old_2_New(), t_item_company_id, false)
+	if err != nil {
+		t.Fatalf("update item: %v", err)
+	}
+
+	item := item_store.Items[item_id]
+	if item.CostPrice != 30 || item.SellingPrice != 50 {
+		t.Errorf("expected cost 30 selling 50, got cost %v selling %v",
+			item.CostPrice, item.SellingPrice)
+	}
+	if len(history) != 2 || history[1].CostPrice != 30 || history[1].SellingPrice != 50 {
+		t.Errorf("wrong price history %v", history)
+	}
+	if sp_item := _to_sp_item(item, false); sp_item.CostPrice != 0 {
+		t.Errorf("the cost price shouldn't be visible, got %v", sp_item.CostPrice)
+	}
+}
+
+func TestUpdateWithoutMaskKeepsPrices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	save_store := Store
+	defer func() {
+		ctrl.Finish()
+		Store = save_store
+	}()
+
+	item_store := models.NewSimpleItemStore(nil)
+	price_store := models.NewSimpleItemPriceStore(nil)
+	mock := models.NewComposableShStoreMock(ctrl)
+	mock.ItemStore = item_store
+	mock.RevisionStore = models.NewSimpleRevisionStore(nil)
+	mock.ItemPriceStore = price_store
+	Store = mock
+
+	created := t_full_sp_item()
+	created.CostPrice = 30
+	created.SellingPrice = 45
+	old_2_new := new_Old_2_New()
+	err := applyItemOperations(nil, []*sp.EntityRequest_RequestItem{
+		{Item: created, Action: sp.EntityRequest_CREATE},
+	}, new(sp.EntityResponse), old_2_new, t_item_company_id, true)
+	if err != nil {
+		t.Fatalf("create item: %v", err)
+	}
+	item_id := old_2_new.getEntityType(_TYPE_ITEM)[-1]
+
+	// an old client doesn't know about prices, so it sends them as 0
+	updated := t_full_sp_item()
+	updated.ItemId = int32(item_id)
+	updated.Name = "renamed"
+	err = applyItemOperations(nil, []*sp.EntityRequest_RequestItem{
+		{Item: updated, Action: sp.EntityRequest_UPDATE},
+	}, new(sp.EntityResponse), new_Old_2_New(), t_item_company_id, true)
+	if err != nil {
+		t.Fatalf("update item: %v", err)
+	}
+
+	item := item_store.Items[item_id]
+	if item.Name != "renamed" || item.CostPrice != 30 || item.SellingPrice != 45 {
+		t.Errorf("expected the prices to stay at 30 and 45, got %q cost %v selling %v",
+			item.Name, item.CostPrice, item.SellingPrice)
+	}
+	if len(price_store.Prices) != 1 {
+		t.Errorf("expected only the created prices in the history, got %v", price_store.Prices)
+	}
+}
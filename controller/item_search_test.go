@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+	"testing"
+)
+
+func TestSearchItemsPage(t *testing.T) {
+	item_store, teardown := setup_item_store(t)
+	defer teardown()
+
+	for i, name := range []string{"brake pad", "brake disc", "brake fluid", "air filter"} {
+		item_store.CreateItem(&models.ShItem{CompanyId: t_item_company_id, Name: name,
+			CategoryId: models.SERVER_ROOT_CATEGORY_ID, StatusFlag: models.STATUS_VISIBLE,
+			CostPrice: float64(10 + i)})
+	}
+
+	response, err := searchItems(t_item_company_id, false,
+		&sp.SearchItemsRequest{Query: "brake", CategoryId: CLIENT_ROOT_CATEGORY_ID, PageSize: 2})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(response.Items) != 2 || !response.HasMore ||
+		response.Items[0].Name != "brake disc" || response.Items[1].Name != "brake fluid" {
+		t.Errorf("expected the first page of brakes, got %v", response)
+	}
+	if response.Items[0].CostPrice != 0 {
+		t.Errorf("cost price shown to a non-manager")
+	}
+
+	response, err = searchItems(t_item_company_id, true,
+		&sp.SearchItemsRequest{Query: "brake", PageSize: 2, Offset: 2})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(response.Items) != 1 || response.HasMore || response.Items[0].Name != "brake pad" {
+		t.Errorf("expected the last brake, got %v", response)
+	}
+	if response.Items[0].CostPrice != 10 {
+		t.Errorf("expected the cost price for a manager, got %v", response.Items[0].CostPrice)
+	}
+}
@@ -0,0 +1,205 @@
+package controller
+
+import (
+	"github.com/golang/mock/gomock"
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+	"testing"
+)
+
+const t_item_company_id = 3
+
+func setup_item_store(t *testing.T) (*models.SimpleItemStore, func()) {
+	ctrl := gomock.NewController(t)
+	save_store := Store
+
+	item_store := models.NewSimpleItemStore(nil)
+	mock := models.NewComposableShStoreMock(ctrl)
+	mock.ItemStore = item_store
+	mock.RevisionStore = models.NewSimpleRevisionStore(nil)
+	Store = mock
+
+	return item_store, func() {
+		ctrl.Finish()
+		Store = save_store
+	}
+}
+
+func t_full_sp_item() *sp.Item {
+	return &sp.Item{
+		ItemId:            -1,
+		UUID:              "c4f1c3f0-27a4-4b8a-9d1b-0e4c2f7f5a10",
+		Name:              "brake pad",
+		Code:              "BP-1",
+		CategoryId:        CLIENT_ROOT_CATEGORY_ID,
+		UnitOfMeasurement: 2,
+		HasDerivedUnit:    true,
+		DerivedName:       "box",
+		DerivedFactor:     12,
+		ReorderLevel:      5,
+		ModelYear:         "2012",
+		PartNumber:        "04465-33450",
+		BarCode:           "4987654321098",
+		HasBarCode:        true,
+		StatusFlag:        int32(models.STATUS_VISIBLE),
+	}
+}
+
+func check_extra_item_fields(t *testing.T, item *models.ShItem, expected *sp.Item) {
+	if item.ReorderLevel != expected.ReorderLevel {
+		t.Errorf("ReorderLevel: expected %v, got %v", expected.ReorderLevel, item.ReorderLevel)
+	}
+	if item.ModelYear != expected.ModelYear {
+		t.Errorf("ModelYear: expected %q, got %q", expected.ModelYear, item.ModelYear)
+	}
+	if item.PartNumber != expected.PartNumber {
+		t.Errorf("PartNumber: expected %q, got %q", expected.PartNumber, item.PartNumber)
+	}
+	if item.BarCode != expected.BarCode {
+		t.Errorf("BarCode: expected %q, got %q", expected.BarCode, item.BarCode)
+	}
+	if item.HasBarCode != expected.HasBarCode {
+		t.Errorf("HasBarCode: expected %v, got %v", expected.HasBarCode, item.HasBarCode)
+	}
+}
+
+func t_create_item(t *testing.T, item_store *models.SimpleItemStore) *models.ShItem {
+	posted := []*sp.EntityRequest_RequestItem{
+		{Item: t_full_sp_item(), Action: sp.EntityRequest_CREATE},
+	}
+	old_2_new := new_Old_2_New()
+	if err := applyItemOperations(nil, posted, new(sp.EntityResponse), old_2_new, t_item_company_id, true); err != nil {
+		t.Fatalf("create item: %v", err)
+	}
+	item_id, ok := old_2_new.getEntityType(_TYPE_ITEM)[-1]
+	if !ok {
+		t.Fatalf("the created item's id wasn't returned")
+	}
+	return item_store.Items[item_id]
+}
+
+func TestCreateItemKeepsAllFields(t *testing.T) {
+	item_store, teardown := setup_item_store(t)
+	defer teardown()
+
+	item := t_create_item(t, item_store)
+	check_extra_item_fields(t, item, t_full_sp_item())
+}
+
+func TestUpdateItemKeepsAllFields(t *testing.T) {
+	item_store, teardown := setup_item_store(t)
+	defer teardown()
+
+	item := t_create_item(t, item_store)
+
+	updated := t_full_sp_item()
+	updated.ItemId = int32(item.ItemId)
+	updated.ReorderLevel = 8
+	updated.ModelYear = "2014"
+	updated.PartNumber = "04465-33471"
+	updated.BarCode = "4987654321111"
+	updated.HasBarCode = false
+
+	posted := []*sp.EntityRequest_RequestItem{
+		{Item: updated, Action: sp.EntityRequest_UPDATE, FieldMask: []string{
+			"reorder_level", "model_year", "part_number", "bar_code", "has_bar_code"}},
+	}
+	if err := applyItemOperations(nil, posted, new(sp.EntityResponse), new_Old_2_New(), t_item_company_id, true); err != nil {
+		t.Fatalf("update item: %v", err)
+	}
+	check_extra_item_fields(t, item_store.Items[item.ItemId], updated)
+}
+
+func TestOldClientUpdateKeepsNewFields(t *testing.T) {
+	item_store, teardown := setup_item_store(t)
+	defer teardown()
+
+	item := t_create_item(t, item_store)
+
+	// a client from before the mask sends every field it knows about, the
+	// ones it doesn't know are zero
+	updated := &sp.Item{
+		ItemId:            int32(item.ItemId),
+		Name:              "front brake pad",
+		Code:              "BP-1",
+		CategoryId:        CLIENT_ROOT_CATEGORY_ID,
+		UnitOfMeasurement: 2,
+		HasDerivedUnit:    true,
+		DerivedName:       "box",
+		DerivedFactor:     12,
+		StatusFlag:        int32(models.STATUS_VISIBLE),
+	}
+	posted := []*sp.EntityRequest_RequestItem{
+		{Item: updated, Action: sp.EntityRequest_UPDATE},
+	}
+	if err := applyItemOperations(nil, posted, new(sp.EntityResponse), new_Old_2_New(), t_item_company_id, true); err != nil {
+		t.Fatalf("update item: %v", err)
+	}
+
+	stored := item_store.Items[item.ItemId]
+	if stored.Name != updated.Name {
+		t.Errorf("Name: expected %q, got %q", updated.Name, stored.Name)
+	}
+	check_extra_item_fields(t, stored, t_full_sp_item())
+}
+
+func TestMaskedUpdateDoesNotWipeOtherFields(t *testing.T) {
+	item_store, teardown := setup_item_store(t)
+	defer teardown()
+
+	item := t_create_item(t, item_store)
+
+	// the client only edited the name, so it sent nothing else
+	updated := &sp.Item{
+		ItemId: int32(item.ItemId),
+		Name:   "front brake pad",
+	}
+	posted := []*sp.EntityRequest_RequestItem{
+		{Item: updated, Action: sp.EntityRequest_UPDATE, FieldMask: []string{"name"}},
+	}
+	if err := applyItemOperations(nil, posted, new(sp.EntityResponse), new_Old_2_New(), t_item_company_id, true); err != nil {
+		t.Fatalf("update item: %v", err)
+	}
+
+	stored := item_store.Items[item.ItemId]
+	if stored.Name != updated.Name {
+		t.Errorf("Name: expected %q, got %q", updated.Name, stored.Name)
+	}
+	if stored.ItemCode != "BP-1" {
+		t.Errorf("ItemCode was changed to %q", stored.ItemCode)
+	}
+	check_extra_item_fields(t, stored, t_full_sp_item())
+}
+
+func TestSyncItemSendsAllFields(t *testing.T) {
+	item_store, teardown := setup_item_store(t)
+	defer teardown()
+
+	item := t_create_item(t, item_store)
+	Store.(*models.ComposableShStoreMock).RevisionStore = models.NewSimpleRevisionStore(
+		[]*models.ShEntityRevision{
+			{
+				CompanyId:        t_item_company_id,
+				RevisionNumber:   1,
+				EntityType:       models.REV_ENTITY_ITEM,
+				ActionType:       models.REV_ACTION_CREATE,
+				EntityAffectedId: item.ItemId,
+				AdditionalInfo:   -1,
+			},
+		})
+
+	response := new(sp.EntityResponse)
+	if err := fetchItemsSinceLastRev(new(sp.EntityRequest), response, t_item_company_id, true); err != nil {
+		t.Fatalf("sync items: %v", err)
+	}
+	if len(response.Items) != 1 {
+		t.Fatalf("expected 1 item, got %d", len(response.Items))
+	}
+
+	// convert it back so we can compare it with the same helper
+	synced := _to_sh_item(response.Items[0].Item)
+	check_extra_item_fields(t, synced, t_full_sp_item())
+	if response.Items[0].Item.CategoryId != CLIENT_ROOT_CATEGORY_ID {
+		t.Errorf("expected the client's root category id, got %d", response.Items[0].Item.CategoryId)
+	}
+}
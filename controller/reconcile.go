@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"database/sql"
+	"fmt"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+	"time"
+)
+
+/**
+ * Replays the company's transactions and compares the result with the stock
+ * ledger and the quantity of the branch items. If repair is set, the ledger
+ * gets correcting entries and the branch items are set to the expected quantity
+ * with a revision, so clients get the fixed numbers on their next sync.
+ *
+ * Everything is read in a single repeatable read transaction, so a transaction
+ * posted while this runs can't make its quantities look drifted. If it changes
+ * a branch item being repaired, the repair fails and can be run again.
+ */
+func ReconcileCompanyStock(company_id int, repair bool) ([]*models.ShStockDrift, error) {
+	tnx, err := Store.BeginRepeatableRead()
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := Store.GetCompanyTransactionsInTx(tnx, company_id)
+	if err != nil {
+		tnx.Rollback()
+		return nil, err
+	}
+	branch_items, err := Store.GetCompanyBranchItemsInTx(tnx, company_id)
+	if err != nil {
+		tnx.Rollback()
+		return nil, err
+	}
+	ledger, err := Store.GetStockLedgerQuantitiesInTx(tnx, company_id)
+	if err != nil {
+		tnx.Rollback()
+		return nil, err
+	}
+
+	drifts := models.FindStockDrift(models.ReplayStockQuantities(transactions),
+		ledger, branch_items)
+	if !repair || len(drifts) == 0 {
+		tnx.Rollback()
+		return drifts, nil
+	}
+
+	now := time.Now().Unix()
+	for _, drift := range drifts {
+		if err = repairStockDriftInTx(tnx, company_id, drift, now); err != nil {
+			tnx.Rollback()
+			return nil, err
+		}
+	}
+	if err = tnx.Commit(); err != nil {
+		return nil, err
+	}
+
+	// the branch items are synced with the transactions
+	ChangeHub.Publish(company_id, &sp.ChangeNotification{TransactionsChanged: true})
+
+	return drifts, nil
+}
+
+func repairStockDriftInTx(tnx *sql.Tx, company_id int, drift *models.ShStockDrift, date int64) error {
+	err := Store.AddStockLedgerEntriesInTx(tnx,
+		models.StockCorrectionEntries(company_id, drift, date))
+	if err != nil {
+		return err
+	}
+
+	action_type := models.REV_ACTION_UPDATE
+	if drift.HasBranchItem {
+		branch_item, err := Store.GetBranchItemInTx(tnx, drift.BranchId, drift.ItemId)
+		if err != nil {
+			return err
+		}
+		branch_item.Quantity = drift.ExpectedQuantity
+		if _, err = Store.UpdateBranchItemInTx(tnx, branch_item); err != nil {
+			return err
+		}
+	} else if drift.ExpectedQuantity != 0 {
+		action_type = models.REV_ACTION_CREATE
+		_, err = Store.AddItemToBranchInTx(tnx, &models.ShBranchItem{
+			CompanyId: company_id,
+			BranchId:  drift.BranchId,
+			ItemId:    drift.ItemId,
+			Quantity:  drift.ExpectedQuantity,
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		// only the ledger was off
+		return nil
+	}
+
+	rev := &models.ShEntityRevision{
+		CompanyId:        company_id,
+		EntityType:       models.REV_ENTITY_BRANCH_ITEM,
+		ActionType:       action_type,
+		EntityAffectedId: drift.BranchId,
+		AdditionalInfo:   drift.ItemId,
+	}
+	_, err = Store.AddEntityRevisionInTx(tnx, rev)
+	return err
+}
+
+func (s *SheketController) ReconcileStock(c context.Context, request *sp.ReconcileStockRequest) (response *sp.ReconcileStockResponse, err error) {
+	defer trace("ReconcileStock")()
+
+	user_info, err := GetUserWithCompanyPermission(request.CompanyAuth)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "%v", err)
+	}
+	if !user_info.Permission.HasManagerAccess() {
+		return nil, grpc.Errorf(codes.PermissionDenied, "%v",
+			fmt.Errorf("only managers can reconcile the stock"))
+	}
+
+	drifts, err := ReconcileCompanyStock(user_info.CompanyId, request.Repair)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+
+	response = new(sp.ReconcileStockResponse)
+	response.Repaired = request.Repair
+	for _, drift := range drifts {
+		response.Drifts = append(response.Drifts,
+			&sp.ReconcileStockResponse_StockDrift{
+				BranchId:         int32(drift.BranchId),
+				ItemId:           int32(drift.ItemId),
+				Quantity:         drift.Quantity,
+				LedgerQuantity:   drift.LedgerQuantity,
+				ExpectedQuantity: drift.ExpectedQuantity,
+			})
+	}
+
+	return response, nil
+}
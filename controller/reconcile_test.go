@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+	"testing"
+)
+
+// the reconcile reads and repairs in a single transaction, it should be
+// finished with commit if repair is set and rolled back otherwise
+func setup_reconcile_store(t *testing.T, repair bool) (*models.SimpleBranchItemStore, *models.SimpleRevisionStore, func()) {
+	ctrl := gomock.NewController(t)
+	save_store := Store
+
+	db, db_mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	db_mock.ExpectBegin()
+	if repair {
+		db_mock.ExpectCommit()
+	} else {
+		db_mock.ExpectRollback()
+	}
+	tnx, _ := db.Begin()
+
+	branch_item_store := models.NewSimpleBranchItemStore(nil)
+	revision_store := models.NewSimpleRevisionStore(nil)
+	source := models.NewMockSource(ctrl)
+
+	mock := models.NewComposableShStoreMock(ctrl)
+	mock.TransactionStore = models.NewSimpleTransactionStore()
+	mock.BranchItemStore = branch_item_store
+	mock.StockLedgerStore = models.NewSimpleStockLedgerStore(nil)
+	mock.RevisionStore = revision_store
+	mock.ItemPriceStore = models.NewSimpleItemPriceStore(nil)
+	mock.Source = source
+	Store = mock
+
+	// posting doesn't start a transaction, only the reconcile does
+	source.EXPECT().BeginRepeatableRead().Return(tnx, nil)
+
+	return branch_item_store, revision_store, func() {
+		ctrl.Finish()
+		if err := db_mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("%v", err)
+		}
+		db.Close()
+		Store = save_store
+	}
+}
+
+func t_post_purchase(t *testing.T, quantity float64) {
+	t_post_transactions(t, &sp.Transaction{
+		TransId:  -1,
+		BranchId: t_ledger_branch_a,
+		UUID:     "1f7c3a52-8e0d-4b6a-a9c1-3d5e7f902b14",
+		TransactionItems: []*sp.Transaction_TransItem{
+			{TransType: models.TRANS_TYPE_ADD_PURCHASED, ItemId: t_ledger_item_id, Quantity: quantity},
+		},
+	})
+}
+
+func TestReconcileRepairsDriftedBranchItem(t *testing.T) {
+	store, revisions, teardown := setup_reconcile_store(t, true)
+	defer teardown()
+
+	t_post_purchase(t, 6)
+	branch_item, _ := store.GetBranchItemInTx(nil, t_ledger_branch_a, t_ledger_item_id)
+	branch_item.Quantity = 0
+	posted_revisions := len(revisions.Revisions)
+
+	drifts, err := ReconcileCompanyStock(t_ledger_company_id, true)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(drifts) != 1 || drifts[0].ExpectedQuantity != 6 || drifts[0].LedgerQuantity != 6 {
+		t.Errorf("expected the branch item to drift from 6, got %v", drifts)
+	}
+	t_check_quantity(t, store, t_ledger_branch_a, 6)
+
+	added := revisions.Revisions[posted_revisions:]
+	if len(added) != 1 || added[0].EntityType != models.REV_ENTITY_BRANCH_ITEM ||
+		added[0].AdditionalInfo != t_ledger_item_id {
+		t.Errorf("expected a revision of the repaired branch item, got %v", added)
+	}
+}
+
+func TestReconcileWithoutRepairChangesNothing(t *testing.T) {
+	store, revisions, teardown := setup_reconcile_store(t, false)
+	defer teardown()
+
+	t_post_purchase(t, 6)
+	branch_item, _ := store.GetBranchItemInTx(nil, t_ledger_branch_a, t_ledger_item_id)
+	branch_item.Quantity = 2
+	posted_revisions := len(revisions.Revisions)
+
+	drifts, err := ReconcileCompanyStock(t_ledger_company_id, false)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(drifts) != 1 || drifts[0].Quantity != 2 {
+		t.Errorf("expected the drift of the branch item, got %v", drifts)
+	}
+	t_check_quantity(t, store, t_ledger_branch_a, 2)
+	if len(revisions.Revisions) != posted_revisions {
+		t.Errorf("expected no revision, got %v", revisions.Revisions[posted_revisions:])
+	}
+}
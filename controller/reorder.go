@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"fmt"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+)
+
+/**
+ * Managers can see the quantity in every branch, others only
+ * in the branches they've been given access to see quantity.
+ */
+func canSeeBranchQuantity(permission *models.UserPermission, branch_id int) bool {
+	if permission.HasManagerAccess() {
+		return true
+	}
+	for _, branch := range permission.Branches {
+		if branch.BranchId == branch_id &&
+			(branch.Access&models.BRANCH_ACCESS_SEE_QTY) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *SheketController) GetReorderReport(c context.Context, request *sp.ReorderReportRequest) (response *sp.ReorderReportResponse, err error) {
+	defer trace("GetReorderReport")()
+
+	user_info, err := GetUserWithCompanyPermission(request.CompanyAuth)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "%v", err)
+	}
+
+	var reorder_items []*models.ShReorderItem
+	if request.CompanyWide {
+		// the company wide total includes every branch
+		if !user_info.Permission.HasManagerAccess() {
+			return nil, grpc.Errorf(codes.PermissionDenied, "%v",
+				fmt.Errorf("only managers can see the company wide report"))
+		}
+		reorder_items, err = Store.GetCompanyReorderReport(user_info.CompanyId)
+	} else {
+		if request.BranchId != 0 &&
+			!canSeeBranchQuantity(user_info.Permission, int(request.BranchId)) {
+			return nil, grpc.Errorf(codes.PermissionDenied, "%v",
+				fmt.Errorf("you can't see the quantity in branch:%d", request.BranchId))
+		}
+		reorder_items, err = Store.GetBranchReorderReport(user_info.CompanyId, int(request.BranchId))
+	}
+	if err != nil {
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+
+	response = new(sp.ReorderReportResponse)
+	for _, reorder_item := range reorder_items {
+		// when reporting on every branch, skip those the user can't see
+		if !request.CompanyWide &&
+			!canSeeBranchQuantity(user_info.Permission, reorder_item.BranchId) {
+			continue
+		}
+		response.Items = append(response.Items,
+			&sp.ReorderReportResponse_ReorderItem{
+				BranchId:          int32(reorder_item.BranchId),
+				ItemId:            int32(reorder_item.ItemId),
+				Quantity:          reorder_item.Quantity,
+				ReorderLevel:      reorder_item.ReorderLevel,
+				SuggestedQuantity: reorder_item.SuggestedQuantity,
+			})
+	}
+
+	return response, nil
+}
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"fmt"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+)
+
+func salesReport(company_id int, request *sp.SalesReportRequest) (*sp.SalesReportResponse, error) {
+	totals, err := Store.GetSalesReport(&models.ShSalesReportFilter{
+		CompanyId: company_id,
+		GroupBy:   int(request.GroupBy),
+		BranchId:  int(request.BranchId),
+		StartDate: request.StartDate,
+		EndDate:   request.EndDate,
+		UTCOffset: request.UtcOffset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(sp.SalesReportResponse)
+	for _, total := range totals {
+		key := total.Key
+		if request.GroupBy == sp.SalesReportRequest_CATEGORY {
+			// the clients have their own id for the root category
+			key = int64(To_Client_Category_Id(int(key)))
+		}
+		response.Totals = append(response.Totals, &sp.SalesReportResponse_Total{
+			Key:      key,
+			Quantity: total.Quantity,
+			Amount:   total.Amount,
+		})
+	}
+	return response, nil
+}
+
+func (s *SheketController) GetSalesReport(c context.Context, request *sp.SalesReportRequest) (response *sp.SalesReportResponse, err error) {
+	defer trace("GetSalesReport")()
+
+	user_info, err := GetUserWithCompanyPermission(request.CompanyAuth)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "%v", err)
+	}
+
+	// the amounts are only for managers, same as the transactions
+	if !user_info.Permission.HasManagerAccess() {
+		return nil, grpc.Errorf(codes.PermissionDenied, "%v",
+			fmt.Errorf("only managers can view the sales"))
+	}
+
+	if request.GroupBy < sp.SalesReportRequest_ITEM ||
+		request.GroupBy > sp.SalesReportRequest_MONTH {
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v",
+			fmt.Errorf("unknown grouping:%d", request.GroupBy))
+	}
+	if request.StartDate != 0 && request.EndDate != 0 &&
+		request.StartDate > request.EndDate {
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v",
+			fmt.Errorf("start date is after end date"))
+	}
+
+	if response, err = salesReport(user_info.CompanyId, request); err != nil {
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+	return response, nil
+}
@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"github.com/golang/mock/gomock"
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+	"testing"
+)
+
+func TestSalesReportByCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	save_store := Store
+	defer func() {
+		ctrl.Finish()
+		Store = save_store
+	}()
+
+	report_store := models.NewMockReportStore(ctrl)
+	mock := models.NewComposableShStoreMock(ctrl)
+	mock.ReportStore = report_store
+	Store = mock
+
+	report_store.EXPECT().
+		GetSalesReport(&models.ShSalesReportFilter{
+			CompanyId: t_item_company_id,
+			GroupBy:   models.SALES_GROUP_CATEGORY,
+			BranchId:  2,
+			StartDate: 100,
+			EndDate:   200,
+		}).
+		Return([]*models.ShSalesTotal{
+			{Key: models.SERVER_ROOT_CATEGORY_ID, Quantity: 3, Amount: 45},
+			{Key: 8, Quantity: 1, Amount: 10},
+		}, nil)
+
+	response, err := salesReport(t_item_company_id, &sp.SalesReportRequest{
+		GroupBy:   sp.SalesReportRequest_CATEGORY,
+		BranchId:  2,
+		StartDate: 100,
+		EndDate:   200,
+	})
+	if err != nil {
+		t.Fatalf("sales report: %v", err)
+	}
+	if len(response.Totals) != 2 {
+		t.Fatalf("expected 2 totals, got %v", response.Totals)
+	}
+	if response.Totals[0].Key != CLIENT_ROOT_CATEGORY_ID || response.Totals[0].Amount != 45 {
+		t.Errorf("the root category wasn't translated %v", response.Totals[0])
+	}
+	if response.Totals[1].Key != 8 || response.Totals[1].Quantity != 1 {
+		t.Errorf("wrong total %v", response.Totals[1])
+	}
+}
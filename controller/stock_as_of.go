@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"fmt"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"math"
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+)
+
+/**
+ * Snapshots the quantities and the cost layers of the company at the date, so
+ * rebuilding the quantities or the valuation after it doesn't have to go
+ * through the whole history.
+ */
+func TakeStockSnapshot(company_id int, snapshot_date int64) error {
+	company, err := Store.GetCompanyById(company_id)
+	if err != nil {
+		return err
+	}
+	engine, _, err := costCompanyStock(company, math.MaxInt64, snapshot_date)
+	if err != nil {
+		return err
+	}
+
+	tnx, err := Store.Begin()
+	if err != nil {
+		return err
+	}
+	if err = Store.CreateStockSnapshotInTx(tnx, company_id, snapshot_date); err != nil {
+		tnx.Rollback()
+		return err
+	}
+	err = Store.CreateStockCostSnapshotInTx(tnx, company_id, models.CostingMethodOf(company),
+		snapshot_date, engine.Layers())
+	if err != nil {
+		tnx.Rollback()
+		return err
+	}
+	return tnx.Commit()
+}
+
+func (s *SheketController) GetStockAsOf(c context.Context, request *sp.StockAsOfRequest) (response *sp.StockAsOfResponse, err error) {
+	defer trace("GetStockAsOf")()
+
+	user_info, err := GetUserWithCompanyPermission(request.CompanyAuth)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "%v", err)
+	}
+
+	if request.BranchId != 0 &&
+		!canSeeBranchQuantity(user_info.Permission, int(request.BranchId)) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "%v",
+			fmt.Errorf("you can't see the quantity in branch:%d", request.BranchId))
+	}
+
+	quantities, err := Store.GetStockAsOf(user_info.CompanyId, int(request.BranchId), request.AsOf)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+
+	response = new(sp.StockAsOfResponse)
+	for _, q := range quantities {
+		// when asking for every branch, skip those the user can't see
+		if !canSeeBranchQuantity(user_info.Permission, q.BranchId) {
+			continue
+		}
+		response.Quantities = append(response.Quantities,
+			&sp.StockAsOfResponse_StockQuantity{
+				BranchId: int32(q.BranchId),
+				ItemId:   int32(q.ItemId),
+				Quantity: q.Quantity,
+			})
+	}
+
+	return response, nil
+}
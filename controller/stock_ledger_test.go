@@ -0,0 +1,229 @@
+package controller
+
+import (
+	"github.com/golang/mock/gomock"
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+	"testing"
+)
+
+const (
+	t_ledger_company_id = 5
+	t_ledger_item_id    = 11
+	t_ledger_branch_a   = 1
+	t_ledger_branch_b   = 2
+)
+
+func t_opening_entries(branch_id int, quantity float64) []*models.ShStockLedgerEntry {
+	return models.StockLedgerEntries(&models.ShTransaction{
+		CompanyId: t_ledger_company_id,
+		BranchId:  branch_id,
+		TransItems: []*models.ShTransactionItem{
+			{TransType: models.TRANS_TYPE_ADD_PURCHASED, ItemId: t_ledger_item_id, Quantity: quantity},
+		},
+	})
+}
+
+func setup_ledger_store(t *testing.T) (*models.SimpleBranchItemStore, func()) {
+	ctrl := gomock.NewController(t)
+	save_store := Store
+
+	var entries []*models.ShStockLedgerEntry
+	entries = append(entries, t_opening_entries(t_ledger_branch_a, 10)...)
+	entries = append(entries, t_opening_entries(t_ledger_branch_b, 4)...)
+
+	branch_item_store := models.NewSimpleBranchItemStore(
+		map[models.BranchItemPair]float64{
+			{BranchId: t_ledger_branch_a, ItemId: t_ledger_item_id}: 10,
+			{BranchId: t_ledger_branch_b, ItemId: t_ledger_item_id}: 4,
+		})
+
+	mock := models.NewComposableShStoreMock(ctrl)
+	mock.TransactionStore = models.NewSimpleTransactionStore()
+	mock.BranchItemStore = branch_item_store
+	mock.StockLedgerStore = models.NewSimpleStockLedgerStore(entries)
+	mock.RevisionStore = models.NewSimpleRevisionStore(nil)
+	mock.ItemPriceStore = models.NewSimpleItemPriceStore(nil)
+	Store = mock
+
+	return branch_item_store, func() {
+		ctrl.Finish()
+		Store = save_store
+	}
+}
+
+func t_post_transactions(t *testing.T, transactions ...*sp.Transaction) {
+	user_info := &UserCompanyPermission{
+		CompanyId: t_ledger_company_id,
+		User:      &models.User{UserId: 1},
+	}
+	affected, _, err := addTransactions(nil,
+		&sp.TransactionRequest{Transactions: transactions}, user_info)
+	if err != nil {
+		t.Fatalf("add transactions: %v", err)
+	}
+	if err = updateBranchItems(nil, affected, t_ledger_company_id); err != nil {
+		t.Fatalf("update branch items: %v", err)
+	}
+}
+
+func t_check_quantity(t *testing.T, store *models.SimpleBranchItemStore, branch_id int, expected float64) {
+	branch_item, err := store.GetBranchItemInTx(nil, branch_id, t_ledger_item_id)
+	if err != nil {
+		t.Fatalf("branch:%d, %v", branch_id, err)
+	}
+	if branch_item.Quantity != expected {
+		t.Errorf("branch:%d, expected quantity %v, got %v", branch_id, expected, branch_item.Quantity)
+	}
+}
+
+func TestTransferKeepsOtherBranchQuantity(t *testing.T) {
+	store, teardown := setup_ledger_store(t)
+	defer teardown()
+
+	t_post_transactions(t, &sp.Transaction{
+		TransId:  -1,
+		BranchId: t_ledger_branch_a,
+		UUID:     "5b0e2c71-9a44-4f1e-8d36-c2a7f14e9b03",
+		TransactionItems: []*sp.Transaction_TransItem{
+			{
+				TransType:     models.TRANS_TYPE_SUB_TRANSFER_TO_OTHER,
+				ItemId:        t_ledger_item_id,
+				OtherBranchId: t_ledger_branch_b,
+				Quantity:      3,
+			},
+		},
+	})
+
+	t_check_quantity(t, store, t_ledger_branch_a, 7)
+	t_check_quantity(t, store, t_ledger_branch_b, 7)
+}
+
+func TestQuantityFollowsLedgerAcrossTransactions(t *testing.T) {
+	store, teardown := setup_ledger_store(t)
+	defer teardown()
+
+	t_post_transactions(t,
+		&sp.Transaction{
+			TransId:  -1,
+			BranchId: t_ledger_branch_b,
+			UUID:     "a3f9d2c4-1e6b-4c8a-9b27-6d0e5f3a1c88",
+			TransactionItems: []*sp.Transaction_TransItem{
+				{TransType: models.TRANS_TYPE_ADD_TRANSFER_FROM_OTHER, ItemId: t_ledger_item_id,
+					OtherBranchId: t_ledger_branch_a, Quantity: 6},
+			},
+		},
+		&sp.Transaction{
+			TransId:  -2,
+			BranchId: t_ledger_branch_a,
+			UUID:     "e71c4b09-3d5a-4f62-8a1e-94b6c2d7f035",
+			TransactionItems: []*sp.Transaction_TransItem{
+				{TransType: models.TRANS_TYPE_SUB_CURRENT_BRANCH_SALE, ItemId: t_ledger_item_id, Quantity: 1},
+				{TransType: models.TRANS_TYPE_ADD_PURCHASED, ItemId: t_ledger_item_id, Quantity: 5},
+			},
+		})
+
+	t_check_quantity(t, store, t_ledger_branch_a, 8)
+	t_check_quantity(t, store, t_ledger_branch_b, 10)
+}
+
+func TestServerTransTypesCantBePosted(t *testing.T) {
+	store, teardown := setup_ledger_store(t)
+	defer teardown()
+
+	user_info := &UserCompanyPermission{
+		CompanyId: t_ledger_company_id,
+		User:      &models.User{UserId: 1},
+	}
+	for _, trans_type := range []int{
+		models.TRANS_TYPE_ADD_COUNT_CORRECTION,
+		models.TRANS_TYPE_ADD_TRANSFER_RECEIVED,
+		models.TRANS_TYPE_SUB_COUNT_CORRECTION,
+		models.TRANS_TYPE_SUB_TRANSFER_SENT,
+		models.TRANS_TYPE_SUB_TRANSIT_DISCREPANCY,
+	} {
+		request := &sp.TransactionRequest{Transactions: []*sp.Transaction{
+			{
+				TransId:  -1,
+				BranchId: t_ledger_branch_a,
+				UUID:     "7a3e1f90-c26d-4b58-8e04-d91b5c72a3f6",
+				TransactionItems: []*sp.Transaction_TransItem{
+					{TransType: int32(trans_type), ItemId: t_ledger_item_id, Quantity: 3},
+				},
+			},
+		}}
+		if _, _, err := addTransactions(nil, request, user_info); err == nil {
+			t.Errorf("expected trans type %d to be rejected", trans_type)
+		} else if _, ok := err.(*invalidTransactionError); !ok {
+			t.Errorf("trans type %d, expected an invalid transaction error, got %v", trans_type, err)
+		}
+	}
+	t_check_quantity(t, store, t_ledger_branch_a, 10)
+}
+
+func TestBranchItemUpdateOnlyWritesQuantity(t *testing.T) {
+	branch_items, teardown := setup_ledger_store(t)
+	defer teardown()
+
+	// the location was edited after the sync read the branch item
+	branch_items.UpdateBranchItemInTx(nil, &models.ShBranchItem{CompanyId: t_ledger_company_id,
+		BranchId: t_ledger_branch_a, ItemId: t_ledger_item_id, Quantity: 10, ItemLocation: "shelf 2"})
+	Store.AddStockLedgerEntriesInTx(nil, t_opening_entries(t_ledger_branch_a, 3))
+
+	affected := AffectedBranchItems{
+		{t_ledger_branch_a, t_ledger_item_id}: {
+			ShBranchItem: &models.ShBranchItem{CompanyId: t_ledger_company_id,
+				BranchId: t_ledger_branch_a, ItemId: t_ledger_item_id, Quantity: 10,
+				ItemLocation: "shelf 1"},
+			itemExistsInBranch: true,
+		},
+	}
+	if err := updateBranchItems(nil, affected, t_ledger_company_id); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	t_check_quantity(t, branch_items, t_ledger_branch_a, 13)
+	if item, _ := branch_items.GetBranchItemInTx(nil, t_ledger_branch_a, t_ledger_item_id); item.ItemLocation != "shelf 2" {
+		t.Errorf("expected the edited location to be kept, got %s", item.ItemLocation)
+	}
+}
+
+func TestBranchItemsAreLockedInOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	save_store := Store
+	defer func() { Store = save_store }()
+
+	branch_items := models.NewMockBranchItemStore(ctrl)
+	ledger := models.NewMockStockLedgerStore(ctrl)
+	mock := models.NewComposableShStoreMock(ctrl)
+	mock.BranchItemStore = branch_items
+	mock.StockLedgerStore = ledger
+	mock.RevisionStore = models.NewSimpleRevisionStore(nil)
+	Store = mock
+
+	affected := make(AffectedBranchItems)
+	for _, branch_id := range []int{t_ledger_branch_b, t_ledger_branch_a} {
+		affected[Pair_BranchItem{branch_id, t_ledger_item_id}] = &CachedBranchItem{
+			ShBranchItem: &models.ShBranchItem{CompanyId: t_ledger_company_id,
+				BranchId: branch_id, ItemId: t_ledger_item_id},
+			itemExistsInBranch: true,
+		}
+	}
+
+	// the ledger is only read once the branch item is locked
+	var calls []*gomock.Call
+	for _, branch_id := range []int{t_ledger_branch_a, t_ledger_branch_b} {
+		calls = append(calls,
+			branch_items.EXPECT().LockBranchItemInTx(gomock.Any(), branch_id, t_ledger_item_id).Return(nil),
+			ledger.EXPECT().GetStockLedgerQuantityInTx(gomock.Any(), t_ledger_company_id,
+				branch_id, t_ledger_item_id).Return(float64(branch_id), nil),
+			branch_items.EXPECT().UpdateBranchItemQuantityInTx(gomock.Any(), branch_id,
+				t_ledger_item_id, float64(branch_id)).Return(nil))
+	}
+	gomock.InOrder(calls...)
+
+	if err := updateBranchItems(nil, affected, t_ledger_company_id); err != nil {
+		t.Fatalf("%v", err)
+	}
+}
@@ -0,0 +1,255 @@
+package controller
+
+import (
+	"database/sql"
+	"fmt"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+	"time"
+)
+
+/**
+ * Managers can access every branch, others only the branches they've been given access to.
+ */
+func canAccessBranch(permission *models.UserPermission, branch_id int) bool {
+	if permission.HasManagerAccess() {
+		return true
+	}
+	for _, branch := range permission.Branches {
+		if branch.BranchId == branch_id {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+ * Records the counts in the open stock take of the branch, a stock take is
+ * opened if the branch doesn't have one. The quantity in the branch is recorded
+ * along with each count.
+ */
+func submitStockCountInTx(tnx *sql.Tx,
+	user_info *UserCompanyPermission,
+	branch_id int, counts []*sp.SubmitStockCountRequest_CountedItem) (*models.ShStockTake, error) {
+
+	company_id := user_info.CompanyId
+	user_id := user_info.User.UserId
+
+	take, err := Store.GetOpenStockTakeInTx(tnx, company_id, branch_id)
+	if err == models.ErrNoData {
+		take, err = Store.CreateStockTakeInTx(tnx, &models.ShStockTake{
+			CompanyId:   company_id,
+			BranchId:    branch_id,
+			Status:      models.STOCK_TAKE_OPEN,
+			CreatedBy:   user_id,
+			CreatedDate: time.Now().Unix(),
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, count := range counts {
+		recorded, err := Store.GetStockLedgerQuantityInTx(tnx, company_id, branch_id, int(count.ItemId))
+		if err != nil {
+			return nil, err
+		}
+		err = Store.SetStockTakeCountInTx(tnx, &models.ShStockTakeItem{
+			StockTakeId:      take.StockTakeId,
+			ItemId:           int(count.ItemId),
+			CountedQuantity:  count.CountedQuantity,
+			CountedBy:        user_id,
+			RecordedQuantity: recorded,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(counts) == 0 {
+		return take, nil
+	}
+	// re-fetch it to include the counts
+	return Store.GetStockTakeInTx(tnx, company_id, take.StockTakeId)
+}
+
+/**
+ * Posts the difference between the counted and the recorded quantities as a
+ * count correction transaction, then closes the stock take. The transaction is
+ * nil if every item was counted right. It fails with models.ErrStockTakeApproved
+ * if the stock take isn't open, even if it was approved concurrently.
+ */
+func approveStockTakeInTx(tnx *sql.Tx,
+	user_info *UserCompanyPermission,
+	stock_take_id int,
+	affected_branch_items AffectedBranchItems) (*models.ShStockTake, *models.ShTransaction, error) {
+
+	company_id := user_info.CompanyId
+
+	take, err := Store.GetStockTakeInTx(tnx, company_id, stock_take_id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if take.Status != models.STOCK_TAKE_OPEN {
+		return nil, nil, models.ErrStockTakeApproved
+	}
+
+	recorded := make(map[int]float64)
+	for _, item := range take.Items {
+		recorded[item.ItemId] = item.RecordedQuantity
+	}
+
+	now := time.Now().Unix()
+	var trans *models.ShTransaction
+	if corrections := models.StockTakeCorrections(take, recorded); len(corrections) > 0 {
+		trans = &models.ShTransaction{
+			CompanyId:  company_id,
+			UserId:     user_info.User.UserId,
+			BranchId:   take.BranchId,
+			Date:       now,
+			TransNote:  fmt.Sprintf("stock take %d", take.StockTakeId),
+			TransItems: corrections,
+		}
+		if trans, err = Store.CreateShTransactionInTx(tnx, trans); err != nil {
+			return nil, nil, err
+		}
+		if err = addTransactionToLedger(tnx, trans, affected_branch_items); err != nil {
+			return nil, nil, err
+		}
+		take.TransactionId = trans.TransactionId
+	}
+
+	take.Status = models.STOCK_TAKE_APPROVED
+	take.ApprovedBy = user_info.User.UserId
+	take.ApprovedDate = now
+	// only succeeds if it is still open, a concurrent approval rolls this one back
+	if take, err = Store.UpdateStockTakeInTx(tnx, take); err != nil {
+		return nil, nil, err
+	}
+	return take, trans, nil
+}
+
+func _to_sp_stock_take(take *models.ShStockTake) *sp.StockTake {
+	result := &sp.StockTake{
+		StockTakeId:  int32(take.StockTakeId),
+		BranchId:     int32(take.BranchId),
+		Status:       sp.StockTake_Status(take.Status),
+		CreatedBy:    int32(take.CreatedBy),
+		CreatedDate:  take.CreatedDate,
+		ApprovedBy:   int32(take.ApprovedBy),
+		ApprovedDate: take.ApprovedDate,
+	}
+	for _, item := range take.Items {
+		result.Items = append(result.Items, &sp.StockTake_Item{
+			ItemId:           int32(item.ItemId),
+			CountedQuantity:  item.CountedQuantity,
+			RecordedQuantity: item.RecordedQuantity,
+			CountedBy:        int32(item.CountedBy),
+		})
+	}
+	return result
+}
+
+func (s *SheketController) SubmitStockCount(c context.Context, request *sp.SubmitStockCountRequest) (response *sp.StockTake, err error) {
+	defer trace("SubmitStockCount")()
+
+	user_info, err := GetUserWithCompanyPermission(request.CompanyAuth)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "%v", err)
+	}
+	branch_id := int(request.BranchId)
+	if !canAccessBranch(user_info.Permission, branch_id) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "%v",
+			fmt.Errorf("you can't count branch:%d", branch_id))
+	}
+	for _, count := range request.Items {
+		if count.CountedQuantity < 0 {
+			return nil, grpc.Errorf(codes.InvalidArgument, "%v",
+				fmt.Errorf("item:%d can't have a negative count", count.ItemId))
+		}
+	}
+
+	tnx, err := Store.Begin()
+	if err != nil {
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+
+	branch, err := Store.GetBranchByIdInTx(tnx, branch_id)
+	if err != nil || branch.CompanyId != user_info.CompanyId {
+		tnx.Rollback()
+		return nil, grpc.Errorf(codes.NotFound, "%v",
+			fmt.Errorf("no branch:%d", branch_id))
+	}
+
+	take, err := submitStockCountInTx(tnx, user_info, branch_id, request.Items)
+	if err != nil {
+		tnx.Rollback()
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+	if err = tnx.Commit(); err != nil {
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+
+	response = _to_sp_stock_take(take)
+	if !canSeeBranchQuantity(user_info.Permission, branch_id) {
+		// counting doesn't give access to see the quantity
+		for _, item := range response.Items {
+			item.RecordedQuantity = 0
+		}
+	}
+	return response, nil
+}
+
+func (s *SheketController) ApproveStockTake(c context.Context, request *sp.ApproveStockTakeRequest) (response *sp.ApproveStockTakeResponse, err error) {
+	defer trace("ApproveStockTake")()
+
+	user_info, err := GetUserWithCompanyPermission(request.CompanyAuth)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "%v", err)
+	}
+	if !user_info.Permission.HasManagerAccess() {
+		return nil, grpc.Errorf(codes.PermissionDenied, "%v",
+			fmt.Errorf("only managers can approve stock takes"))
+	}
+
+	tnx, err := Store.Begin()
+	if err != nil {
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+
+	affected_branch_items := make(AffectedBranchItems)
+	take, trans, err := approveStockTakeInTx(tnx, user_info, int(request.StockTakeId),
+		affected_branch_items)
+	if err != nil {
+		tnx.Rollback()
+		switch err {
+		case models.ErrNoData:
+			return nil, grpc.Errorf(codes.NotFound, "%v",
+				fmt.Errorf("no stock take:%d", request.StockTakeId))
+		case models.ErrStockTakeApproved:
+			return nil, grpc.Errorf(codes.FailedPrecondition, "%v", err)
+		}
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+
+	if err = updateBranchItems(tnx, affected_branch_items, user_info.CompanyId); err != nil {
+		tnx.Rollback()
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+	if err = tnx.Commit(); err != nil {
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+
+	response = &sp.ApproveStockTakeResponse{
+		StockTake: _to_sp_stock_take(take),
+	}
+	if trans != nil {
+		ChangeHub.Publish(user_info.CompanyId,
+			&sp.ChangeNotification{TransactionsChanged: true})
+		response.Corrections = _to_sp_sync_transaction(trans)
+	}
+	return response, nil
+}
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+	"testing"
+)
+
+func setup_stock_take_store(t *testing.T) (*models.SimpleBranchItemStore, func()) {
+	branch_item_store, teardown := setup_ledger_store(t)
+	Store.(*models.ComposableShStoreMock).StockTakeStore = models.NewSimpleStockTakeStore()
+	return branch_item_store, teardown
+}
+
+func t_stock_take_manager() *UserCompanyPermission {
+	return &UserCompanyPermission{
+		CompanyId:  t_ledger_company_id,
+		User:       &models.User{UserId: 2},
+		Permission: &models.UserPermission{PermissionType: models.PERMISSION_TYPE_GENERAL_MANAGER},
+	}
+}
+
+func t_approve_stock_take(stock_take_id int) (*models.ShStockTake, *models.ShTransaction, error) {
+	affected := make(AffectedBranchItems)
+	take, trans, err := approveStockTakeInTx(nil, t_stock_take_manager(), stock_take_id, affected)
+	if err != nil {
+		return nil, nil, err
+	}
+	return take, trans, updateBranchItems(nil, affected, t_ledger_company_id)
+}
+
+func TestStockTakeCorrectsFromTheCount(t *testing.T) {
+	store, teardown := setup_stock_take_store(t)
+	defer teardown()
+
+	take, err := submitStockCountInTx(nil, t_stock_take_manager(), t_ledger_branch_a,
+		[]*sp.SubmitStockCountRequest_CountedItem{
+			{ItemId: t_ledger_item_id, CountedQuantity: 7},
+		})
+	if err != nil {
+		t.Fatalf("submit count: %v", err)
+	}
+	if take.Status != models.STOCK_TAKE_OPEN || len(take.Items) != 1 ||
+		take.Items[0].RecordedQuantity != 10 {
+		t.Fatalf("expected an open stock take recording 10, got %+v", take)
+	}
+
+	// sold after it was counted, the correction mustn't undo it
+	t_post_transactions(t, &sp.Transaction{
+		TransId:  -1,
+		BranchId: t_ledger_branch_a,
+		UUID:     "0c8e4f21-6b3d-4a97-b5e2-7d19a3c6f480",
+		TransactionItems: []*sp.Transaction_TransItem{
+			{TransType: models.TRANS_TYPE_SUB_CURRENT_BRANCH_SALE, ItemId: t_ledger_item_id, Quantity: 2},
+		},
+	})
+
+	approved, trans, err := t_approve_stock_take(take.StockTakeId)
+	if err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if approved.Status != models.STOCK_TAKE_APPROVED || trans == nil ||
+		approved.TransactionId != trans.TransactionId {
+		t.Fatalf("expected an approved stock take with the corrections, got %+v", approved)
+	}
+	if len(trans.TransItems) != 1 || trans.TransItems[0].TransType != models.TRANS_TYPE_SUB_COUNT_CORRECTION ||
+		trans.TransItems[0].Quantity != 3 {
+		t.Errorf("expected 3 to be written off, got %v", trans.TransItems)
+	}
+	t_check_quantity(t, store, t_ledger_branch_a, 5)
+	t_check_quantity(t, store, t_ledger_branch_b, 4)
+
+	if _, _, err = t_approve_stock_take(take.StockTakeId); err != models.ErrStockTakeApproved {
+		t.Errorf("expected ErrStockTakeApproved, got %v", err)
+	}
+	t_check_quantity(t, store, t_ledger_branch_a, 5)
+}
+
+func TestRecountReplacesRecordedQuantity(t *testing.T) {
+	store, teardown := setup_stock_take_store(t)
+	defer teardown()
+
+	counts := []*sp.SubmitStockCountRequest_CountedItem{
+		{ItemId: t_ledger_item_id, CountedQuantity: 4},
+	}
+	take, err := submitStockCountInTx(nil, t_stock_take_manager(), t_ledger_branch_b, counts)
+	if err != nil {
+		t.Fatalf("submit count: %v", err)
+	}
+
+	t_post_transactions(t, &sp.Transaction{
+		TransId:  -1,
+		BranchId: t_ledger_branch_b,
+		UUID:     "b4d27e90-1a5c-4f38-9e6b-2c80d5f317a9",
+		TransactionItems: []*sp.Transaction_TransItem{
+			{TransType: models.TRANS_TYPE_ADD_PURCHASED, ItemId: t_ledger_item_id, Quantity: 5},
+		},
+	})
+	counts[0].CountedQuantity = 9
+	recount, err := submitStockCountInTx(nil, t_stock_take_manager(), t_ledger_branch_b, counts)
+	if err != nil {
+		t.Fatalf("recount: %v", err)
+	}
+	if recount.StockTakeId != take.StockTakeId || len(recount.Items) != 1 ||
+		recount.Items[0].RecordedQuantity != 9 {
+		t.Fatalf("expected the recount to record 9 in the same stock take, got %+v", recount)
+	}
+
+	_, trans, err := t_approve_stock_take(take.StockTakeId)
+	if err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if trans != nil {
+		t.Errorf("expected no corrections, got %v", trans.TransItems)
+	}
+	t_check_quantity(t, store, t_ledger_branch_b, 9)
+}
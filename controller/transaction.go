@@ -2,11 +2,13 @@ package controller
 
 import (
 	"database/sql"
+	"fmt"
 	"golang.org/x/net/context"
 	"sheket/server/models"
 	sp "sheket/server/sheketproto"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc"
+	"sort"
 )
 
 type Pair_BranchItem struct {
@@ -17,36 +19,30 @@ type Pair_BranchItem struct {
 type AffectedBranchItems map[Pair_BranchItem]*CachedBranchItem
 
 /**
- * Since we will only be committing item changes only after every transaction
- * has been processed, we need to hold to the intermediate changes to the item
- * in memory. It is more efficient to update the object in memory than to
- * update it on datastore.
+ * The branch items whose quantity changed, they are only updated after every
+ * transaction has been processed because a group of transactions will affect
+ * a single item multiple times.
  */
 type CachedBranchItem struct {
 	*models.ShBranchItem
 
 	itemExistsInBranch bool
-	itemVisited        bool
 }
 
 /**
- * Searches the item if has already been used in a transaction before.
- * This is necessary because we are using the object in memory to track
- * changes made to the item. These changes will finally be committed
- * in a single update of the item in the datastore.
+ * Searches the item if has already been affected by a transaction before,
+ * otherwise it is fetched from the datastore and added to seenItems.
  */
 func searchBranchItemInCache(tnx *sql.Tx, seenItems map[Pair_BranchItem]*CachedBranchItem,
-	search_item *models.ShBranchItem) *CachedBranchItem {
+	search_item *models.ShBranchItem) (*CachedBranchItem, error) {
 
 	branch_id := search_item.BranchId
 	item_id := search_item.ItemId
 
 	if item, ok := seenItems[Pair_BranchItem{branch_id, item_id}]; ok {
-		return item
+		return item, nil
 	}
 
-	// we've not found the item, so query datastore and add it to seenItems
-
 	var cached_branch_item *CachedBranchItem
 	branch_item, err := Store.GetBranchItemInTx(tnx,
 		search_item.BranchId, search_item.ItemId)
@@ -55,36 +51,37 @@ func searchBranchItemInCache(tnx *sql.Tx, seenItems map[Pair_BranchItem]*CachedB
 		// the item doesn't exist in the branch-items list
 		cached_branch_item = &CachedBranchItem{
 			ShBranchItem:       search_item,
-			itemExistsInBranch: false,
-			itemVisited:        false}
+			itemExistsInBranch: false}
 	} else if err == nil {
 		cached_branch_item = &CachedBranchItem{
 			ShBranchItem:       branch_item,
-			itemExistsInBranch: true,
-			itemVisited:        false}
-	} else if err != nil {
-		// TODO: handle the error properly
-		// handle the case when the error is other type
+			itemExistsInBranch: true}
+	} else {
+		return nil, err
 	}
 
-	cached_branch_item.itemVisited = false
+	seenItems[Pair_BranchItem{branch_id, item_id}] = cached_branch_item
+	return cached_branch_item, nil
+}
 
-	if !cached_branch_item.itemExistsInBranch {
-		cached_branch_item.Quantity = float64(0)
-	}
+type _branchItemPairs []Pair_BranchItem
 
-	seenItems[Pair_BranchItem{branch_id, item_id}] = cached_branch_item
-	return cached_branch_item
+func (p _branchItemPairs) Len() int      { return len(p) }
+func (p _branchItemPairs) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p _branchItemPairs) Less(i, j int) bool {
+	if p[i].BranchId != p[j].BranchId {
+		return p[i].BranchId < p[j].BranchId
+	}
+	return p[i].ItemId < p[j].ItemId
 }
 
 /**
- * Updates the items in branches. This works by updating the items in memory
- * until all transactions are processed, then finally committing the changes
- * into the datastore. This is more efficient than updating the item in the
- * datastore as soon as we see a transaction that affects it because a group
- * of transactions will affect a single item multiple times. So, do the
- * intermediate updates on the object in memory and only finally commit that
- * after all transactions are processed.
+ * Updates the items in branches affected by the transactions. The quantity of
+ * an item is never changed in place, it is re-computed from the stock ledger
+ * which already has the entries of the transactions. The branch item is locked
+ * before the ledger is read, so a concurrent sync of the same item waits for
+ * this one and then sees its entries. They are locked in the same order so
+ * syncs don't deadlock each other.
  *
  * the {@args changed_branch_items} is a map with key of Pair{branch_id, item_id}
  */
@@ -92,13 +89,39 @@ func updateBranchItems(tnx *sql.Tx,
 	affected_branch_items AffectedBranchItems,
 	company_id int) error {
 
-	for pair_branch_item, cached_item := range affected_branch_items {
+	var pairs []Pair_BranchItem
+	for pair_branch_item := range affected_branch_items {
+		pairs = append(pairs, pair_branch_item)
+	}
+	sort.Sort(_branchItemPairs(pairs))
+
+	for _, pair_branch_item := range pairs {
+		cached_item := affected_branch_items[pair_branch_item]
+		if cached_item.itemExistsInBranch {
+			err := Store.LockBranchItemInTx(tnx, pair_branch_item.BranchId, pair_branch_item.ItemId)
+			if err != nil {
+				return err
+			}
+		}
+
+		quantity, err := Store.GetStockLedgerQuantityInTx(tnx, company_id,
+			pair_branch_item.BranchId, pair_branch_item.ItemId)
+		if err != nil {
+			return err
+		}
+		cached_item.Quantity = quantity
+
 		action_type := models.REV_ACTION_CREATE
 		if cached_item.itemExistsInBranch {
-			Store.UpdateBranchItemInTx(tnx, cached_item.ShBranchItem)
+			// the rest of the cached copy might be stale
+			err = Store.UpdateBranchItemQuantityInTx(tnx, pair_branch_item.BranchId,
+				pair_branch_item.ItemId, quantity)
 			action_type = models.REV_ACTION_UPDATE
 		} else {
-			Store.AddItemToBranchInTx(tnx, cached_item.ShBranchItem)
+			_, err = Store.AddItemToBranchInTx(tnx, cached_item.ShBranchItem)
+		}
+		if err != nil {
+			return err
 		}
 
 		rev := &models.ShEntityRevision{
@@ -109,7 +132,36 @@ func updateBranchItems(tnx *sql.Tx,
 			AdditionalInfo:   pair_branch_item.ItemId,
 		}
 
-		_, err := Store.AddEntityRevisionInTx(tnx, rev)
+		_, err = Store.AddEntityRevisionInTx(tnx, rev)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/**
+ * Records the stock movements of the transaction in the ledger, and marks the
+ * branch items whose quantity they change.
+ */
+func addTransactionToLedger(tnx *sql.Tx,
+	trans *models.ShTransaction,
+	affected_branch_items AffectedBranchItems) error {
+
+	entries := models.StockLedgerEntries(trans)
+	if err := Store.AddStockLedgerEntriesInTx(tnx, entries); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.Account != models.LEDGER_ACCOUNT_BRANCH {
+			continue
+		}
+		_, err := searchBranchItemInCache(tnx, affected_branch_items,
+			&models.ShBranchItem{
+				CompanyId: trans.CompanyId, BranchId: entry.BranchId,
+				ItemId: entry.ItemId,
+			})
 		if err != nil {
 			return err
 		}
@@ -117,6 +169,40 @@ func updateBranchItems(tnx *sql.Tx,
 	return nil
 }
 
+/**
+ * Sets the unit price of the item from its price at the transaction's date,
+ * it is left at 0 if the item didn't have a price then.
+ */
+func priceTransactionItemInTx(tnx *sql.Tx, trans *models.ShTransaction, item *models.ShTransactionItem) error {
+	price, err := Store.GetItemPriceAsOfInTx(tnx, trans.CompanyId, trans.BranchId, item.ItemId, trans.Date)
+	if err == models.ErrNoData {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	item.UnitPrice = models.DefaultUnitPrice(item.TransType, price)
+	return nil
+}
+
+/**
+ * Converts the quantity of the item to its base unit if it was posted in another unit.
+ */
+func convertTransactionUnitInTx(tnx *sql.Tx, company_id int, trans_item *models.ShTransactionItem) error {
+	var item *models.ShItem
+	if trans_item.UnitId != models.TRANS_UNIT_BASE {
+		var err error
+		item, err = Store.GetItemByIdInTx(tnx, trans_item.ItemId)
+		if err != nil || item.CompanyId != company_id {
+			return &invalidTransactionError{
+				fmt.Sprintf("no item:%d to convert the unit of", trans_item.ItemId)}
+		}
+	}
+	if err := models.ConvertToBaseUnit(item, trans_item); err != nil {
+		return &invalidTransactionError{err.Error()}
+	}
+	return nil
+}
+
 func addTransactions(tnx *sql.Tx,
 	request *sp.TransactionRequest,
 	user_info *UserCompanyPermission,
@@ -137,7 +223,7 @@ func addTransactions(tnx *sql.Tx,
 		 * get acknowledgement when posting and is trying to re-post, so just send
 		 * them the id.
 		 */
-		if prev_trans, err := Store.GetShTransactionByUUIDInTx(tnx, posted_trans.UUID); err == nil {
+		if prev_trans, err := Store.GetShTransactionByUUIDInTx(tnx, company_id, posted_trans.UUID); err == nil {
 			old_2_new[posted_trans.TransId] = prev_trans.TransactionId
 			continue
 		} else if err != models.ErrNoData {
@@ -154,17 +240,51 @@ func addTransactions(tnx *sql.Tx,
 		trans.Date = posted_trans.DateTime
 		trans.TransNote = posted_trans.TransNote
 		trans.ClientUUID = posted_trans.UUID
+		trans.LinkedTransId = posted_trans.LinkedTransId
+		if trans.LinkedTransId < 0 {
+			// it is linked to a transaction posted earlier in the request, which has a local id
+			linked_id, ok := old_2_new[trans.LinkedTransId]
+			if !ok {
+				return nil, nil, &invalidTransactionError{
+					fmt.Sprintf("no transaction:%d earlier in the request to link to",
+						posted_trans.LinkedTransId)}
+			}
+			trans.LinkedTransId = linked_id
+		}
 
 		for _, _item := range posted_trans.TransactionItems {
-			trans.TransItems = append(trans.TransItems,
-				&models.ShTransactionItem{
-					CompanyId:     company_id,
-					TransType:     int(_item.TransType),
-					ItemId:        int(_item.ItemId),
-					OtherBranchId: int(_item.OtherBranchId),
-					Quantity:      _item.Quantity,
-					ItemNote:      _item.ItemNote,
-				})
+			if models.IsServerTransType(int(_item.TransType)) {
+				return nil, nil, &invalidTransactionError{
+					fmt.Sprintf("item:%d, transaction type %d can't be posted, "+
+						"it is only posted by transfers and stock takes", _item.ItemId, _item.TransType)}
+			}
+			trans_item := &models.ShTransactionItem{
+				CompanyId:     company_id,
+				TransType:     int(_item.TransType),
+				ItemId:        int(_item.ItemId),
+				OtherBranchId: int(_item.OtherBranchId),
+				Quantity:      _item.Quantity,
+				ItemNote:      _item.ItemNote,
+				UnitPrice:     _item.UnitPrice,
+				Discount:      _item.Discount,
+				UnitId:        int(_item.UnitId),
+				UnitQuantity:  _item.UnitQuantity,
+			}
+			if err = convertTransactionUnitInTx(tnx, company_id, trans_item); err != nil {
+				return nil, nil, err
+			}
+			if !_item.HasPrice {
+				if err = priceTransactionItemInTx(tnx, trans, trans_item); err != nil {
+					return nil, nil, err
+				}
+			}
+			trans_item.LineTotal = models.LineTotal(trans_item)
+			trans.TransItems = append(trans.TransItems, trans_item)
+		}
+		if trans.LinkedTransId != 0 {
+			if err = checkTransactionLinkInTx(tnx, trans); err != nil {
+				return nil, nil, err
+			}
 		}
 		created, err := Store.CreateShTransactionInTx(tnx, trans)
 		if err != nil {
@@ -173,44 +293,8 @@ func addTransactions(tnx *sql.Tx,
 
 		old_2_new[posted_trans.TransId] = created.TransactionId
 
-		for _, trans_item := range created.TransItems {
-			branch_item := searchBranchItemInCache(tnx, affected_branch_items,
-				&models.ShBranchItem{
-					CompanyId: company_id, BranchId: int(posted_trans.BranchId),
-					ItemId: trans_item.ItemId,
-				})
-			branch_item.itemVisited = true
-
-			switch trans_item.TransType {
-			case models.TRANS_TYPE_ADD_PURCHASED:
-				branch_item.Quantity += trans_item.Quantity
-
-			case models.TRANS_TYPE_SUB_CURRENT_BRANCH_SALE:
-				branch_item.Quantity -= trans_item.Quantity
-
-			// these 2 affect another branch
-			// so, grab that branch and update it also
-			case models.TRANS_TYPE_ADD_TRANSFER_FROM_OTHER,
-				models.TRANS_TYPE_SUB_TRANSFER_TO_OTHER:
-
-				other_branch_item := searchBranchItemInCache(tnx, affected_branch_items,
-					&models.ShBranchItem{
-						CompanyId: company_id, BranchId: trans_item.OtherBranchId,
-						ItemId: trans_item.ItemId,
-					})
-				if !other_branch_item.itemVisited {
-					other_branch_item.Quantity = float64(0)
-				}
-				other_branch_item.itemVisited = true
-
-				if trans_item.TransType == models.TRANS_TYPE_ADD_TRANSFER_FROM_OTHER {
-					branch_item.Quantity += trans_item.Quantity
-					other_branch_item.Quantity -= trans_item.Quantity
-				} else if trans_item.TransType == models.TRANS_TYPE_SUB_TRANSFER_TO_OTHER {
-					branch_item.Quantity -= trans_item.Quantity
-					other_branch_item.Quantity += trans_item.Quantity
-				}
-			}
+		if err = addTransactionToLedger(tnx, created, affected_branch_items); err != nil {
+			return nil, nil, err
 		}
 	}
 
@@ -235,6 +319,9 @@ func (s *SheketController) SyncTransaction(c context.Context, request *sp.Transa
 
 	if affected_branch_items, old_2_new, err = addTransactions(tnx, request, user_info); err != nil {
 		tnx.Rollback()
+		if _, ok := err.(*invalidTransactionError); ok {
+			return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+		}
 		return nil, grpc.Errorf(codes.Internal, "%v", err)
 	}
 
@@ -243,7 +330,15 @@ func (s *SheketController) SyncTransaction(c context.Context, request *sp.Transa
 		tnx.Rollback()
 		return nil, grpc.Errorf(codes.Internal, "%v", err)
 	}
-	tnx.Commit()
+	if err = tnx.Commit(); err != nil {
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+
+	// re-posted transactions were already there, they don't affect any item
+	if len(affected_branch_items) > 0 {
+		ChangeHub.Publish(user_info.CompanyId,
+			&sp.ChangeNotification{TransactionsChanged: true})
+	}
 
 	response = new(sp.TransactionResponse)
 	for old_id, new_id := range old_2_new {
@@ -264,6 +359,10 @@ func (s *SheketController) SyncTransaction(c context.Context, request *sp.Transa
 		}
 	}
 
+	if err = fetchPendingTransfers(response, user_info); err != nil {
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+
 	return response, nil
 }
 
@@ -273,11 +372,14 @@ func fetchTransactionsSince(
 	old_2_new map[int64]int64,
 	company_id int) error {
 
-	transactions, err := Store.GetShTransactionSinceTransId(company_id, request.OldTransRev)
+	transactions, has_more, err := Store.GetShTransactionPageSinceTransId(company_id,
+		request.OldTransRev, int(request.PageSize))
 	if err != nil {
 		return err
 	}
 
+	response.HasMore = response.HasMore || has_more
+
 	max_trans_id := request.OldTransRev
 
 	for _, trans := range transactions {
@@ -292,30 +394,8 @@ func fetchTransactionsSince(
 			}
 		*/
 
-		var transItems []*sp.Transaction_TransItem
-		for _, _item := range trans.TransItems {
-			transItems = append(transItems,
-				&sp.Transaction_TransItem{
-					TransType:     int32(_item.TransType),
-					ItemId:        int32(_item.ItemId),
-					OtherBranchId: int32(_item.OtherBranchId),
-					Quantity:      _item.Quantity,
-					ItemNote:      _item.ItemNote,
-				})
-		}
-
 		response.Transactions = append(response.Transactions,
-			&sp.TransactionResponse_SyncTransaction{
-				UserId: int32(trans.UserId),
-				Transaction: &sp.Transaction{
-					TransId:          trans.TransactionId,
-					UUID:             trans.ClientUUID,
-					BranchId:         int32(trans.BranchId),
-					DateTime:         trans.Date,
-					TransNote:        trans.TransNote,
-					TransactionItems: transItems,
-				},
-			})
+			_to_sp_sync_transaction(trans))
 	}
 
 	response.NewTransRev = max_trans_id
@@ -323,24 +403,61 @@ func fetchTransactionsSince(
 	return nil
 }
 
+func _to_sp_sync_transaction(trans *models.ShTransaction) *sp.TransactionResponse_SyncTransaction {
+	var transItems []*sp.Transaction_TransItem
+	for _, _item := range trans.TransItems {
+		transItems = append(transItems,
+			&sp.Transaction_TransItem{
+				TransType:     int32(_item.TransType),
+				ItemId:        int32(_item.ItemId),
+				OtherBranchId: int32(_item.OtherBranchId),
+				Quantity:      _item.Quantity,
+				ItemNote:      _item.ItemNote,
+				HasPrice:      true,
+				UnitPrice:     _item.UnitPrice,
+				Discount:      _item.Discount,
+				LineTotal:     _item.LineTotal,
+				UnitId:        int32(_item.UnitId),
+				UnitQuantity:  _item.UnitQuantity,
+				UnitName:      _item.UnitName,
+			})
+	}
+
+	return &sp.TransactionResponse_SyncTransaction{
+		UserId: int32(trans.UserId),
+		Transaction: &sp.Transaction{
+			TransId:          trans.TransactionId,
+			UUID:             trans.ClientUUID,
+			BranchId:         int32(trans.BranchId),
+			DateTime:         trans.Date,
+			TransNote:        trans.TransNote,
+			TransactionItems: transItems,
+			LinkedTransId:    trans.LinkedTransId,
+			IsVoid:           trans.IsVoid,
+			VoidedByTransId:  trans.VoidedByTransId,
+		},
+	}
+}
+
 func fetchBranchItemsSinceRev(
 	request *sp.TransactionRequest,
 	response *sp.TransactionResponse,
 	old_2_new map[int64]int64,
 	company_id int) error {
 
-	max_rev, new_branch_item_revs, err := Store.GetRevisionsSince(
+	max_rev, new_branch_item_revs, has_more, err := Store.GetRevisionPageSince(
 		&models.ShEntityRevision{
 			CompanyId:      company_id,
 			EntityType:     models.REV_ENTITY_BRANCH_ITEM,
 			RevisionNumber: int(request.OldBranchItemRev),
-		})
+		}, int(request.PageSize))
 
 	if err != nil {
 		return err
 	}
 
 	response.NewBranchItemRev = int64(max_rev)
+	response.HasMore = response.HasMore || has_more
 
 	for _, branch_rev := range new_branch_item_revs {
 		branch_id := branch_rev.EntityAffectedId
@@ -364,6 +481,12 @@ func fetchBranchItemsSinceRev(
 					ItemId:        int32(item_id),
 					Quantity:      branch_item.Quantity,
 					ShelfLocation: branch_item.ItemLocation,
+
+					ReorderLevel:    branch_item.ReorderLevel,
+					HasReorderLevel: branch_item.HasReorderLevel,
+
+					SellingPrice:    branch_item.SellingPrice,
+					HasSellingPrice: branch_item.HasSellingPrice,
 				},
 			})
 	}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"fmt"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+)
+
+const (
+	// used if the client doesn't specify a page size
+	HISTORY_DEFAULT_PAGE_SIZE = 50
+
+	// no matter what the client asks, we won't send more than this at once
+	HISTORY_MAX_PAGE_SIZE = 500
+)
+
+/**
+ * Returns the page of the company's transactions before the request's cursor,
+ * newest first. The page's NextCursor is where the next page starts.
+ */
+func transactionHistory(company_id int, request *sp.TransactionHistoryRequest) (*sp.TransactionHistoryResponse, error) {
+	page_size := int(request.PageSize)
+	if page_size <= 0 {
+		page_size = HISTORY_DEFAULT_PAGE_SIZE
+	} else if page_size > HISTORY_MAX_PAGE_SIZE {
+		page_size = HISTORY_MAX_PAGE_SIZE
+	}
+
+	transactions, has_more, err := Store.GetShTransactionHistory(
+		&models.ShTransactionFilter{
+			CompanyId:     company_id,
+			BranchId:      int(request.BranchId),
+			UserId:        int(request.UserId),
+			TransType:     int(request.TransType),
+			StartDate:     request.StartDate,
+			EndDate:       request.EndDate,
+			BeforeTransId: request.Cursor,
+		}, page_size)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &sp.TransactionHistoryResponse{
+		HasMore: has_more,
+	}
+	for _, trans := range transactions {
+		response.Transactions = append(response.Transactions,
+			_to_sp_sync_transaction(trans))
+		// the transactions are newest first, so the last one has the smallest id
+		response.NextCursor = trans.TransactionId
+	}
+
+	return response, nil
+}
+
+func (s *SheketController) GetTransactionHistory(c context.Context, request *sp.TransactionHistoryRequest) (response *sp.TransactionHistoryResponse, err error) {
+	defer trace("GetTransactionHistory")()
+
+	user_info, err := GetUserWithCompanyPermission(request.CompanyAuth)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "%v", err)
+	}
+
+	// only managers get to see transactions, same as in SyncTransaction
+	if !user_info.Permission.HasManagerAccess() {
+		return nil, grpc.Errorf(codes.PermissionDenied, "%v",
+			fmt.Errorf("only managers can view transaction history"))
+	}
+
+	if request.StartDate != 0 && request.EndDate != 0 &&
+		request.StartDate > request.EndDate {
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v",
+			fmt.Errorf("start date is after end date"))
+	}
+
+	if response, err = transactionHistory(user_info.CompanyId, request); err != nil {
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+	return response, nil
+}
@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"github.com/golang/mock/gomock"
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+	"testing"
+)
+
+func setup_history_store(t *testing.T) (*models.MockTransactionStore, func()) {
+	ctrl := gomock.NewController(t)
+	save_store := Store
+
+	trans_store := models.NewMockTransactionStore(ctrl)
+	mock := models.NewComposableShStoreMock(ctrl)
+	mock.TransactionStore = trans_store
+	Store = mock
+
+	return trans_store, func() {
+		ctrl.Finish()
+		Store = save_store
+	}
+}
+
+func TestTransactionHistoryCursor(t *testing.T) {
+	trans_store, teardown := setup_history_store(t)
+	defer teardown()
+
+	trans_store.EXPECT().
+		GetShTransactionHistory(&models.ShTransactionFilter{
+			CompanyId:     t_item_company_id,
+			BranchId:      2,
+			BeforeTransId: 40,
+		}, HISTORY_DEFAULT_PAGE_SIZE).
+		Return([]*models.ShTransaction{
+			{TransactionId: 39}, {TransactionId: 35}, {TransactionId: 31},
+		}, true, nil)
+
+	response, err := transactionHistory(t_item_company_id, &sp.TransactionHistoryRequest{
+		BranchId: 2,
+		Cursor:   40,
+	})
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	if !response.HasMore || len(response.Transactions) != 3 {
+		t.Fatalf("expected a page of 3 with more, got %d, has_more:%v",
+			len(response.Transactions), response.HasMore)
+	}
+	// the next page continues before the oldest one sent
+	if response.NextCursor != 31 {
+		t.Errorf("expected the cursor at 31, got %d", response.NextCursor)
+	}
+}
+
+func TestTransactionHistoryPageSize(t *testing.T) {
+	trans_store, teardown := setup_history_store(t)
+	defer teardown()
+
+	filter := &models.ShTransactionFilter{CompanyId: t_item_company_id}
+	gomock.InOrder(
+		trans_store.EXPECT().GetShTransactionHistory(filter, 20).Return(nil, false, nil),
+		trans_store.EXPECT().GetShTransactionHistory(filter, HISTORY_MAX_PAGE_SIZE).Return(nil, false, nil),
+	)
+
+	for _, page_size := range []int32{20, HISTORY_MAX_PAGE_SIZE + 1} {
+		response, err := transactionHistory(t_item_company_id,
+			&sp.TransactionHistoryRequest{PageSize: page_size})
+		if err != nil {
+			t.Fatalf("history: %v", err)
+		}
+		// the last page doesn't move the cursor
+		if response.HasMore || response.NextCursor != 0 {
+			t.Errorf("expected the last page, got %v", response)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+	"testing"
+)
+
+func TestTransactionItemsArePriced(t *testing.T) {
+	_, teardown := setup_ledger_store(t)
+	defer teardown()
+
+	mock := Store.(*models.ComposableShStoreMock)
+	mock.ItemPriceStore = models.NewSimpleItemPriceStore([]*models.ShItemPrice{
+		{CompanyId: t_ledger_company_id, ItemId: t_ledger_item_id,
+			CostPrice: 6, SellingPrice: 9, HasSellingPrice: true, EffectiveDate: 100},
+		{CompanyId: t_ledger_company_id, ItemId: t_ledger_item_id, BranchId: t_ledger_branch_a,
+			SellingPrice: 10, HasSellingPrice: true, EffectiveDate: 200},
+	})
+
+	t_post_transactions(t,
+		// a purchase is at cost
+		&sp.Transaction{TransId: -1, BranchId: t_ledger_branch_a, DateTime: 150,
+			UUID: "3b1f7a52-0c84-4e6d-9a2b-d5e8f1c47a09",
+			TransactionItems: []*sp.Transaction_TransItem{
+				{TransType: models.TRANS_TYPE_ADD_PURCHASED, ItemId: t_ledger_item_id, Quantity: 3},
+			}},
+		// a sale is at the branch's selling price
+		&sp.Transaction{TransId: -2, BranchId: t_ledger_branch_a, DateTime: 250,
+			UUID: "a7d2e9c1-5f38-4b07-8e1a-6c4b9f2d0e53",
+			TransactionItems: []*sp.Transaction_TransItem{
+				{TransType: models.TRANS_TYPE_SUB_CURRENT_BRANCH_SALE, ItemId: t_ledger_item_id, Quantity: 2},
+			}},
+		// the client's price is kept
+		&sp.Transaction{TransId: -3, BranchId: t_ledger_branch_b, DateTime: 250,
+			UUID: "5e0c8b74-2d19-4f6a-b3e7-91a4c6d8f2b0",
+			TransactionItems: []*sp.Transaction_TransItem{
+				{TransType: models.TRANS_TYPE_SUB_CURRENT_BRANCH_SALE, ItemId: t_ledger_item_id, Quantity: 2,
+					HasPrice: true, UnitPrice: 8, Discount: 1},
+			}},
+		// there wasn't a price yet
+		&sp.Transaction{TransId: -4, BranchId: t_ledger_branch_b, DateTime: 50,
+			UUID: "c92f61d8-7a4e-4350-8b1c-e0d5a3f7b614",
+			TransactionItems: []*sp.Transaction_TransItem{
+				{TransType: models.TRANS_TYPE_SUB_CURRENT_BRANCH_SALE, ItemId: t_ledger_item_id, Quantity: 1},
+			}},
+	)
+
+	trans_store := mock.TransactionStore.(*models.SimpleTransactionStore)
+	expected := []struct {
+		unit_price float64
+		line_total float64
+	}{
+		{6, 18},
+		{10, 20},
+		{8, 15},
+		{0, 0},
+	}
+	for i, e := range expected {
+		item := trans_store.Transactions[int64(i+1)].TransItems[0]
+		if item.UnitPrice != e.unit_price || item.LineTotal != e.line_total {
+			t.Errorf("transaction %d: expected unit price %v total %v, got %v %v",
+				i+1, e.unit_price, e.line_total, item.UnitPrice, item.LineTotal)
+		}
+	}
+
+	synced := _to_sp_sync_transaction(trans_store.Transactions[3]).Transaction.TransactionItems[0]
+	if !synced.HasPrice || synced.Discount != 1 || synced.LineTotal != 15 {
+		t.Errorf("the price wasn't synced %v", synced)
+	}
+}
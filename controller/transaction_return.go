@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"database/sql"
+	"fmt"
+	"sheket/server/models"
+)
+
+/**
+ * A posted transaction that can't be accepted as is, the client needs to fix it.
+ */
+type invalidTransactionError struct {
+	msg string
+}
+
+func (e *invalidTransactionError) Error() string {
+	return e.msg
+}
+
+/**
+ * A return can be linked to the transaction it returns from. The linked
+ * transaction should be in the company, not be a void, and include the
+ * returned items: customer returns link to a sale, supplier returns to a purchase.
+ * An item can't be returned more than what is left of it after the earlier returns.
+ */
+func checkTransactionLinkInTx(tnx *sql.Tx, trans *models.ShTransaction) error {
+	linked, err := Store.GetShTransactionByIdInTx(tnx, trans.CompanyId, trans.LinkedTransId, true)
+	if err == models.ErrNoData {
+		return &invalidTransactionError{
+			fmt.Sprintf("no transaction:%d to link to", trans.LinkedTransId)}
+	} else if err != nil {
+		return err
+	}
+	if linked.IsVoid {
+		return &invalidTransactionError{
+			fmt.Sprintf("can't link to the void transaction:%d", linked.TransactionId)}
+	}
+
+	returning := make(map[int]float64)
+	for _, item := range trans.TransItems {
+		returned_from, ok := models.ReturnedFromType(item.TransType)
+		if !ok {
+			continue
+		}
+
+		found := false
+		for _, linked_item := range linked.TransItems {
+			if linked_item.ItemId == item.ItemId && linked_item.TransType == returned_from {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return &invalidTransactionError{
+				fmt.Sprintf("item:%d isn't in transaction:%d to return it",
+					item.ItemId, linked.TransactionId)}
+		}
+		returning[item.ItemId] += item.Quantity
+	}
+	if len(returning) == 0 {
+		return nil
+	}
+
+	earlier, err := Store.GetLinkedShTransactionsInTx(tnx, trans.CompanyId, linked.TransactionId)
+	if err != nil {
+		return err
+	}
+	returnable := models.ReturnableQuantities(linked, earlier)
+	for item_id, quantity := range returning {
+		if quantity > returnable[item_id] {
+			return &invalidTransactionError{
+				fmt.Sprintf("item:%d has only %v left to return from transaction:%d",
+					item_id, returnable[item_id], linked.TransactionId)}
+		}
+	}
+	return nil
+}
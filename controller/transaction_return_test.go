@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+	"testing"
+)
+
+func TestCustomerReturnLinkedToSale(t *testing.T) {
+	store, teardown := setup_ledger_store(t)
+	defer teardown()
+
+	// the return links to the sale by its local id, both are posted together
+	t_post_transactions(t,
+		&sp.Transaction{
+			TransId:  -1,
+			BranchId: t_ledger_branch_a,
+			UUID:     "6f1d2b8a-0c47-4e93-a5d2-3b9e7c14f860",
+			TransactionItems: []*sp.Transaction_TransItem{
+				{TransType: models.TRANS_TYPE_SUB_CURRENT_BRANCH_SALE, ItemId: t_ledger_item_id, Quantity: 5},
+			},
+		},
+		&sp.Transaction{
+			TransId:       -2,
+			BranchId:      t_ledger_branch_a,
+			UUID:          "a47c9e02-58b1-4f3d-8e6a-d2075b9c13f4",
+			LinkedTransId: -1,
+			TransactionItems: []*sp.Transaction_TransItem{
+				{TransType: models.TRANS_TYPE_ADD_CUSTOMER_RETURN, ItemId: t_ledger_item_id, Quantity: 2},
+			},
+		})
+	t_check_quantity(t, store, t_ledger_branch_a, 7)
+
+	sale, err := Store.GetShTransactionByUUIDInTx(nil, t_ledger_company_id,
+		"6f1d2b8a-0c47-4e93-a5d2-3b9e7c14f860")
+	if err != nil {
+		t.Fatalf("sale not found: %v", err)
+	}
+	ret, err := Store.GetShTransactionByUUIDInTx(nil, t_ledger_company_id,
+		"a47c9e02-58b1-4f3d-8e6a-d2075b9c13f4")
+	if err != nil {
+		t.Fatalf("return not found: %v", err)
+	}
+	if ret.LinkedTransId != sale.TransactionId {
+		t.Errorf("expected the return to link to %d, got %d", sale.TransactionId, ret.LinkedTransId)
+	}
+
+	sold := float64(0)
+	for _, trans := range []*models.ShTransaction{sale, ret} {
+		for _, item := range trans.TransItems {
+			sold += models.SoldQuantity(item)
+		}
+	}
+	if sold != 3 {
+		t.Errorf("expected the return to net out the sale to 3, got %v", sold)
+	}
+}
+
+func TestReturnLinkedToWrongTransaction(t *testing.T) {
+	_, teardown := setup_ledger_store(t)
+	defer teardown()
+
+	user_info := &UserCompanyPermission{
+		CompanyId: t_ledger_company_id,
+		User:      &models.User{UserId: 1},
+	}
+	request := &sp.TransactionRequest{Transactions: []*sp.Transaction{
+		{
+			TransId:  -1,
+			BranchId: t_ledger_branch_a,
+			UUID:     "c3e85a71-9d24-4b0f-b6a8-51f02e7d94ac",
+			TransactionItems: []*sp.Transaction_TransItem{
+				{TransType: models.TRANS_TYPE_SUB_CURRENT_BRANCH_SALE, ItemId: t_ledger_item_id, Quantity: 1},
+			},
+		},
+		{
+			TransId:       -2,
+			BranchId:      t_ledger_branch_a,
+			UUID:          "0b9f4d36-e812-47ca-9c55-8a3d6e21f7b0",
+			LinkedTransId: -1,
+			TransactionItems: []*sp.Transaction_TransItem{
+				// a supplier return needs a purchase, not a sale
+				{TransType: models.TRANS_TYPE_SUB_RETURN_TO_SUPPLIER, ItemId: t_ledger_item_id, Quantity: 1},
+			},
+		},
+	}}
+
+	_, _, err := addTransactions(nil, request, user_info)
+	if _, ok := err.(*invalidTransactionError); !ok {
+		t.Fatalf("expected an invalid transaction error, got %v", err)
+	}
+
+	request.Transactions[1].LinkedTransId = 1000
+	_, _, err = addTransactions(nil, request, user_info)
+	if _, ok := err.(*invalidTransactionError); !ok {
+		t.Fatalf("expected an invalid transaction error for a missing link, got %v", err)
+	}
+}
+
+func t_return_request(uid string, linked_trans_id int64, quantity float64) *sp.TransactionRequest {
+	return &sp.TransactionRequest{Transactions: []*sp.Transaction{
+		{
+			TransId:       -1,
+			BranchId:      t_ledger_branch_a,
+			UUID:          uid,
+			LinkedTransId: linked_trans_id,
+			TransactionItems: []*sp.Transaction_TransItem{
+				{TransType: models.TRANS_TYPE_ADD_CUSTOMER_RETURN, ItemId: t_ledger_item_id, Quantity: quantity},
+			},
+		},
+	}}
+}
+
+func TestReturnCantExceedWhatIsLeft(t *testing.T) {
+	store, teardown := setup_ledger_store(t)
+	defer teardown()
+
+	user_info := &UserCompanyPermission{
+		CompanyId: t_ledger_company_id,
+		User:      &models.User{UserId: 1},
+	}
+	t_post_transactions(t, &sp.Transaction{
+		TransId:  -1,
+		BranchId: t_ledger_branch_a,
+		UUID:     "5e2a9c70-4b1d-4f86-a3e7-0d9c8b61f524",
+		TransactionItems: []*sp.Transaction_TransItem{
+			{TransType: models.TRANS_TYPE_SUB_CURRENT_BRANCH_SALE, ItemId: t_ledger_item_id, Quantity: 5},
+		},
+	})
+	sale, err := Store.GetShTransactionByUUIDInTx(nil, t_ledger_company_id,
+		"5e2a9c70-4b1d-4f86-a3e7-0d9c8b61f524")
+	if err != nil {
+		t.Fatalf("sale not found: %v", err)
+	}
+
+	if _, _, err = addTransactions(nil,
+		t_return_request("91c4e6b3-2f07-4d5a-8b19-e6a3d70c25f8", sale.TransactionId, 6), user_info); err == nil {
+		t.Fatalf("expected returning more than was sold to fail")
+	} else if _, ok := err.(*invalidTransactionError); !ok {
+		t.Fatalf("expected an invalid transaction error, got %v", err)
+	}
+
+	t_post_transactions(t, t_return_request("91c4e6b3-2f07-4d5a-8b19-e6a3d70c25f8",
+		sale.TransactionId, 4).Transactions...)
+	first, err := Store.GetShTransactionByUUIDInTx(nil, t_ledger_company_id,
+		"91c4e6b3-2f07-4d5a-8b19-e6a3d70c25f8")
+	if err != nil {
+		t.Fatalf("return not found: %v", err)
+	}
+
+	// only 1 is left after the first return
+	if _, _, err = addTransactions(nil,
+		t_return_request("d8f05a2c-63e9-4b71-9c4d-1a7e2b9f06c3", sale.TransactionId, 2), user_info); err == nil {
+		t.Fatalf("expected returning more than is left to fail")
+	} else if _, ok := err.(*invalidTransactionError); !ok {
+		t.Fatalf("expected an invalid transaction error, got %v", err)
+	}
+
+	// voiding the first return frees its quantity
+	if _, err = t_void(t, first.TransactionId); err != nil {
+		t.Fatalf("void: %v", err)
+	}
+	t_post_transactions(t, t_return_request("d8f05a2c-63e9-4b71-9c4d-1a7e2b9f06c3",
+		sale.TransactionId, 5).Transactions...)
+	t_check_quantity(t, store, t_ledger_branch_a, 10)
+}
+
+func TestReturnLinkedToUnknownLocalId(t *testing.T) {
+	_, teardown := setup_ledger_store(t)
+	defer teardown()
+
+	user_info := &UserCompanyPermission{
+		CompanyId: t_ledger_company_id,
+		User:      &models.User{UserId: 1},
+	}
+	// there isn't a transaction -7 earlier in the request
+	request := t_return_request("2b7d9e41-c05a-4f83-96e2-8a1f4c3d70b5", -7, 1)
+	request.Transactions[0].TransId = -2
+	if _, _, err := addTransactions(nil, request, user_info); err == nil {
+		t.Fatalf("expected the link to fail")
+	} else if _, ok := err.(*invalidTransactionError); !ok {
+		t.Fatalf("expected an invalid transaction error, got %v", err)
+	}
+}
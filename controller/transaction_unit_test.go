@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+	"testing"
+)
+
+func TestTransactionUnitsAreConverted(t *testing.T) {
+	store, teardown := setup_ledger_store(t)
+	defer teardown()
+
+	mock := Store.(*models.ComposableShStoreMock)
+	mock.ItemStore = models.NewSimpleItemStore([]*models.ShItem{
+		{
+			ItemId:         t_ledger_item_id,
+			CompanyId:      t_ledger_company_id,
+			HasDerivedUnit: true,
+			DerivedName:    "box",
+			DerivedFactor:  12,
+			Units: []*models.ShItemUnit{
+				{UnitId: 3, ItemId: t_ledger_item_id, Name: "carton", Factor: 48},
+			},
+		},
+	})
+
+	t_post_transactions(t,
+		&sp.Transaction{TransId: -1, BranchId: t_ledger_branch_a,
+			UUID: "6a0e2f93-d4b1-4c58-a7e6-3f9b1c82d05e",
+			TransactionItems: []*sp.Transaction_TransItem{
+				{TransType: models.TRANS_TYPE_ADD_PURCHASED, ItemId: t_ledger_item_id,
+					UnitId: 3, UnitQuantity: 2},
+				{TransType: models.TRANS_TYPE_SUB_CURRENT_BRANCH_SALE, ItemId: t_ledger_item_id,
+					UnitId: models.TRANS_UNIT_DERIVED, UnitQuantity: 1},
+			}},
+	)
+	// 10 + 2 * 48 - 12
+	t_check_quantity(t, store, t_ledger_branch_a, 94)
+
+	trans_store := mock.TransactionStore.(*models.SimpleTransactionStore)
+	synced := _to_sp_sync_transaction(trans_store.Transactions[1]).Transaction.TransactionItems
+	if synced[0].UnitName != "carton" || synced[0].UnitQuantity != 2 || synced[0].Quantity != 96 {
+		t.Errorf("the carton wasn't kept %v", synced[0])
+	}
+	if synced[1].UnitName != "box" || synced[1].UnitQuantity != 1 || synced[1].Quantity != 12 {
+		t.Errorf("the box wasn't kept %v", synced[1])
+	}
+
+	user_info := &UserCompanyPermission{
+		CompanyId: t_ledger_company_id,
+		User:      &models.User{UserId: 1},
+	}
+	_, _, err := addTransactions(nil, &sp.TransactionRequest{Transactions: []*sp.Transaction{
+		{TransId: -2, BranchId: t_ledger_branch_a,
+			UUID: "d18b7e45-0a3c-4f62-9e2d-b5c4a6f0e731",
+			TransactionItems: []*sp.Transaction_TransItem{
+				{TransType: models.TRANS_TYPE_SUB_CURRENT_BRANCH_SALE, ItemId: t_ledger_item_id,
+					UnitId: 9, UnitQuantity: 1},
+			}},
+	}}, user_info)
+	if _, ok := err.(*invalidTransactionError); !ok {
+		t.Errorf("expected an invalid transaction error for an unknown unit, got %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+package controller
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+	"time"
+)
+
+var errVoidingVoid = errors.New("a void can't be voided")
+var errVoidingTransfer = errors.New("the transactions of a transfer can't be voided")
+var errVoidingReturned = errors.New("the transaction has returns, they need to be voided first")
+
+/**
+ * Posts the compensating transaction of the voided one. It has the same items
+ * with their quantities negated, so going through the ledger like any other
+ * transaction reverses the voided transaction's effects.
+ * If the transaction was already voided, the existing compensating transaction
+ * is returned, so a client re-trying the void doesn't void it twice.
+ */
+func voidTransactionInTx(tnx *sql.Tx,
+	user_info *UserCompanyPermission,
+	trans_id int64, reason string,
+	affected_branch_items AffectedBranchItems) (void *models.ShTransaction, err error) {
+
+	company_id := user_info.CompanyId
+
+	original, err := Store.GetShTransactionByIdInTx(tnx, company_id, trans_id, true)
+	if err != nil {
+		return nil, err
+	}
+	if original.IsVoid {
+		return nil, errVoidingVoid
+	}
+	for _, item := range original.TransItems {
+		switch item.TransType {
+		case models.TRANS_TYPE_SUB_TRANSFER_SENT, models.TRANS_TYPE_ADD_TRANSFER_RECEIVED,
+			models.TRANS_TYPE_SUB_TRANSIT_DISCREPANCY:
+			// it would leave the transfer's in-transit stock behind
+			return nil, errVoidingTransfer
+		}
+	}
+	if original.VoidedByTransId != 0 {
+		return Store.GetShTransactionByIdInTx(tnx, company_id, original.VoidedByTransId, true)
+	}
+
+	// the returns would stay in the ledger after the void put back everything
+	linked, err := Store.GetLinkedShTransactionsInTx(tnx, company_id, original.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	for _, ret := range linked {
+		if ret.IsVoid || ret.VoidedByTransId != 0 {
+			continue
+		}
+		for _, item := range ret.TransItems {
+			if _, ok := models.ReturnedFromType(item.TransType); ok {
+				return nil, errVoidingReturned
+			}
+		}
+	}
+
+	void = &models.ShTransaction{
+		CompanyId:     company_id,
+		UserId:        user_info.User.UserId,
+		BranchId:      original.BranchId,
+		Date:          time.Now().Unix(),
+		TransNote:     reason,
+		LinkedTransId: original.TransactionId,
+		IsVoid:        true,
+	}
+	for _, item := range original.TransItems {
+		void.TransItems = append(void.TransItems,
+			&models.ShTransactionItem{
+				CompanyId:     company_id,
+				TransType:     item.TransType,
+				ItemId:        item.ItemId,
+				OtherBranchId: item.OtherBranchId,
+				Quantity:      -item.Quantity,
+				ItemNote:      item.ItemNote,
+				// the line total is also negated, so it reverses the amount
+				UnitPrice:    item.UnitPrice,
+				Discount:     -item.Discount,
+				LineTotal:    -item.LineTotal,
+				UnitId:       item.UnitId,
+				UnitQuantity: -item.UnitQuantity,
+				UnitName:     item.UnitName,
+			})
+	}
+
+	if void, err = Store.CreateShTransactionInTx(tnx, void); err != nil {
+		return nil, err
+	}
+	// clients that synced the original before this don't get it again, they
+	// mark it voided from the void's LinkedTransId(see the proto's voided_by_trans_id)
+	if err = Store.SetShTransactionVoidedInTx(tnx, company_id,
+		original.TransactionId, void.TransactionId); err != nil {
+		return nil, err
+	}
+	if err = addTransactionToLedger(tnx, void, affected_branch_items); err != nil {
+		return nil, err
+	}
+	return void, nil
+}
+
+func (s *SheketController) VoidTransaction(c context.Context, request *sp.VoidTransactionRequest) (response *sp.VoidTransactionResponse, err error) {
+	defer trace("VoidTransaction")()
+
+	user_info, err := GetUserWithCompanyPermission(request.CompanyAuth)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "%v", err)
+	}
+	if !user_info.Permission.HasManagerAccess() {
+		return nil, grpc.Errorf(codes.PermissionDenied, "%v",
+			fmt.Errorf("only managers can void transactions"))
+	}
+	if request.Reason == "" {
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v",
+			fmt.Errorf("a reason is required to void a transaction"))
+	}
+
+	tnx, err := Store.Begin()
+	if err != nil {
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+
+	affected_branch_items := make(AffectedBranchItems)
+	void, err := voidTransactionInTx(tnx, user_info, request.TransId, request.Reason,
+		affected_branch_items)
+	if err != nil {
+		tnx.Rollback()
+		switch err {
+		case models.ErrNoData:
+			return nil, grpc.Errorf(codes.NotFound, "%v",
+				fmt.Errorf("no transaction:%d", request.TransId))
+		case errVoidingVoid, errVoidingTransfer, errVoidingReturned:
+			return nil, grpc.Errorf(codes.FailedPrecondition, "%v", err)
+		case models.ErrAlreadyVoided:
+			// someone else voided it in the meantime
+			return nil, grpc.Errorf(codes.Aborted, "%v", err)
+		}
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+
+	if err = updateBranchItems(tnx, affected_branch_items, user_info.CompanyId); err != nil {
+		tnx.Rollback()
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+	if err = tnx.Commit(); err != nil {
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+
+	ChangeHub.Publish(user_info.CompanyId,
+		&sp.ChangeNotification{TransactionsChanged: true})
+
+	response = &sp.VoidTransactionResponse{
+		VoidTransaction: _to_sp_sync_transaction(void),
+	}
+	return response, nil
+}
@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+	"testing"
+)
+
+func t_void(t *testing.T, trans_id int64) (*models.ShTransaction, error) {
+	user_info := &UserCompanyPermission{
+		CompanyId: t_ledger_company_id,
+		User:      &models.User{UserId: 2},
+	}
+	affected := make(AffectedBranchItems)
+	void, err := voidTransactionInTx(nil, user_info, trans_id, "sold by mistake", affected)
+	if err != nil {
+		return nil, err
+	}
+	if err = updateBranchItems(nil, affected, t_ledger_company_id); err != nil {
+		t.Fatalf("update branch items: %v", err)
+	}
+	return void, nil
+}
+
+func TestVoidReversesTransaction(t *testing.T) {
+	store, teardown := setup_ledger_store(t)
+	defer teardown()
+
+	t_post_transactions(t, &sp.Transaction{
+		TransId:  -1,
+		BranchId: t_ledger_branch_a,
+		UUID:     "2c8e5f17-b3a9-4d06-9e41-7f0a6c3d8b52",
+		TransactionItems: []*sp.Transaction_TransItem{
+			{TransType: models.TRANS_TYPE_SUB_CURRENT_BRANCH_SALE, ItemId: t_ledger_item_id, Quantity: 4},
+			{TransType: models.TRANS_TYPE_SUB_TRANSFER_TO_OTHER, ItemId: t_ledger_item_id,
+				OtherBranchId: t_ledger_branch_b, Quantity: 2},
+		},
+	})
+	t_check_quantity(t, store, t_ledger_branch_a, 4)
+	t_check_quantity(t, store, t_ledger_branch_b, 6)
+
+	trans, err := Store.GetShTransactionByUUIDInTx(nil, t_ledger_company_id,
+		"2c8e5f17-b3a9-4d06-9e41-7f0a6c3d8b52")
+	if err != nil {
+		t.Fatalf("posted transaction not found: %v", err)
+	}
+
+	void, err := t_void(t, trans.TransactionId)
+	if err != nil {
+		t.Fatalf("void: %v", err)
+	}
+	if !void.IsVoid || void.LinkedTransId != trans.TransactionId || void.UserId != 2 {
+		t.Errorf("the void isn't linked to the transaction: %+v", void)
+	}
+	if trans.VoidedByTransId != void.TransactionId {
+		t.Errorf("expected the transaction to be voided by %d, got %d",
+			void.TransactionId, trans.VoidedByTransId)
+	}
+	t_check_quantity(t, store, t_ledger_branch_a, 10)
+	t_check_quantity(t, store, t_ledger_branch_b, 4)
+
+	// re-trying the void doesn't reverse it twice
+	again, err := t_void(t, trans.TransactionId)
+	if err != nil {
+		t.Fatalf("re-trying the void: %v", err)
+	}
+	if again.TransactionId != void.TransactionId {
+		t.Errorf("expected the same void %d, got %d", void.TransactionId, again.TransactionId)
+	}
+	t_check_quantity(t, store, t_ledger_branch_a, 10)
+
+	if _, err = t_void(t, void.TransactionId); err != errVoidingVoid {
+		t.Errorf("expected voiding a void to fail, got %v", err)
+	}
+}
+
+func TestSaleWithReturnsCantBeVoided(t *testing.T) {
+	store, teardown := setup_ledger_store(t)
+	defer teardown()
+
+	t_post_transactions(t, &sp.Transaction{
+		TransId:  -1,
+		BranchId: t_ledger_branch_a,
+		UUID:     "b71e4d09-5c2a-4f38-8e63-a09d2f5c17b4",
+		TransactionItems: []*sp.Transaction_TransItem{
+			{TransType: models.TRANS_TYPE_SUB_CURRENT_BRANCH_SALE, ItemId: t_ledger_item_id, Quantity: 10},
+		},
+	})
+	sale, err := Store.GetShTransactionByUUIDInTx(nil, t_ledger_company_id,
+		"b71e4d09-5c2a-4f38-8e63-a09d2f5c17b4")
+	if err != nil {
+		t.Fatalf("sale not found: %v", err)
+	}
+	t_post_transactions(t, t_return_request("3f9a0c62-d84e-4b17-a5c9-6e2b1d7f40a8",
+		sale.TransactionId, 3).Transactions...)
+	ret, err := Store.GetShTransactionByUUIDInTx(nil, t_ledger_company_id,
+		"3f9a0c62-d84e-4b17-a5c9-6e2b1d7f40a8")
+	if err != nil {
+		t.Fatalf("return not found: %v", err)
+	}
+	t_check_quantity(t, store, t_ledger_branch_a, 3)
+
+	// voiding the sale would put back all 10 while the return keeps its 3
+	if _, err = t_void(t, sale.TransactionId); err != errVoidingReturned {
+		t.Fatalf("expected voiding a returned sale to fail, got %v", err)
+	}
+	if sale.VoidedByTransId != 0 {
+		t.Errorf("the sale shouldn't be voided")
+	}
+	t_check_quantity(t, store, t_ledger_branch_a, 3)
+
+	// once the return is voided, so can the sale
+	if _, err = t_void(t, ret.TransactionId); err != nil {
+		t.Fatalf("void the return: %v", err)
+	}
+	if _, err = t_void(t, sale.TransactionId); err != nil {
+		t.Fatalf("void the sale: %v", err)
+	}
+	t_check_quantity(t, store, t_ledger_branch_a, 10)
+}
@@ -0,0 +1,294 @@
+package controller
+
+import (
+	"database/sql"
+	"fmt"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+	"time"
+)
+
+/**
+ * Posts the transaction that takes the items out of the sending branch into
+ * the in-transit account, then records the transfer. Re-sending a transfer
+ * returns the one already sent.
+ */
+func sendTransferInTx(tnx *sql.Tx,
+	user_info *UserCompanyPermission,
+	transfer *models.ShTransfer,
+	affected_branch_items AffectedBranchItems) (*models.ShTransfer, error) {
+
+	company_id := user_info.CompanyId
+
+	if transfer.ClientUUID != "" {
+		prev, err := Store.GetTransferByUUIDInTx(tnx, company_id, transfer.ClientUUID)
+		if err == nil {
+			return prev, nil
+		} else if err != models.ErrNoData {
+			return nil, err
+		}
+	}
+
+	transfer.CompanyId = company_id
+	transfer.Status = models.TRANSFER_STATUS_SENT
+	transfer.SentBy = user_info.User.UserId
+	transfer.SentDate = time.Now().Unix()
+
+	trans := &models.ShTransaction{
+		CompanyId:  company_id,
+		UserId:     transfer.SentBy,
+		BranchId:   transfer.FromBranchId,
+		Date:       transfer.SentDate,
+		TransNote:  transfer.TransferNote,
+		TransItems: models.TransferSendItems(transfer),
+	}
+	trans, err := Store.CreateShTransactionInTx(tnx, trans)
+	if err != nil {
+		return nil, err
+	}
+	if err = addTransactionToLedger(tnx, trans, affected_branch_items); err != nil {
+		return nil, err
+	}
+
+	transfer.SendTransId = trans.TransactionId
+	return Store.CreateTransferInTx(tnx, transfer)
+}
+
+/**
+ * Posts the transaction that moves what arrived into the receiving branch,
+ * along with the discrepancies, then marks the transfer received.
+ * @args received	item_id -> the received quantity, the missing items are received as sent
+ */
+func receiveTransferInTx(tnx *sql.Tx,
+	user_info *UserCompanyPermission,
+	transfer *models.ShTransfer, received map[int]float64,
+	affected_branch_items AffectedBranchItems) (*models.ShTransfer, error) {
+
+	company_id := user_info.CompanyId
+
+	sent_items := make(map[int]bool)
+	for _, item := range transfer.Items {
+		sent_items[item.ItemId] = true
+		if quantity, ok := received[item.ItemId]; ok {
+			item.ReceivedQuantity = quantity
+		} else {
+			item.ReceivedQuantity = item.SentQuantity
+		}
+	}
+	for item_id := range received {
+		if !sent_items[item_id] {
+			return nil, &invalidTransactionError{
+				fmt.Sprintf("item:%d wasn't sent in transfer:%d", item_id, transfer.TransferId)}
+		}
+	}
+
+	transfer.ReceivedBy = user_info.User.UserId
+	transfer.ReceivedDate = time.Now().Unix()
+
+	trans := &models.ShTransaction{
+		CompanyId:     company_id,
+		UserId:        transfer.ReceivedBy,
+		BranchId:      transfer.ToBranchId,
+		Date:          transfer.ReceivedDate,
+		TransNote:     transfer.TransferNote,
+		LinkedTransId: transfer.SendTransId,
+		TransItems:    models.TransferReceiveItems(transfer),
+	}
+	trans, err := Store.CreateShTransactionInTx(tnx, trans)
+	if err != nil {
+		return nil, err
+	}
+	if err = addTransactionToLedger(tnx, trans, affected_branch_items); err != nil {
+		return nil, err
+	}
+
+	transfer.ReceiveTransId = trans.TransactionId
+	return Store.ReceiveTransferInTx(tnx, transfer)
+}
+
+func _to_sp_transfer(transfer *models.ShTransfer) *sp.Transfer {
+	result := &sp.Transfer{
+		TransferId:     int32(transfer.TransferId),
+		FromBranchId:   int32(transfer.FromBranchId),
+		ToBranchId:     int32(transfer.ToBranchId),
+		Status:         sp.Transfer_Status(transfer.Status),
+		Note:           transfer.TransferNote,
+		SentBy:         int32(transfer.SentBy),
+		SentDate:       transfer.SentDate,
+		SendTransId:    transfer.SendTransId,
+		ReceivedBy:     int32(transfer.ReceivedBy),
+		ReceivedDate:   transfer.ReceivedDate,
+		ReceiveTransId: transfer.ReceiveTransId,
+	}
+	for _, item := range transfer.Items {
+		result.Items = append(result.Items, &sp.Transfer_Item{
+			ItemId:           int32(item.ItemId),
+			SentQuantity:     item.SentQuantity,
+			ReceivedQuantity: item.ReceivedQuantity,
+		})
+	}
+	return result
+}
+
+/**
+ * Adds the pending transfers to or from the branches the user can access.
+ */
+func fetchPendingTransfers(response *sp.TransactionResponse, user_info *UserCompanyPermission) error {
+	transfers, err := Store.GetPendingTransfers(user_info.CompanyId)
+	if err != nil {
+		return err
+	}
+	for _, transfer := range transfers {
+		if !canAccessBranch(user_info.Permission, transfer.FromBranchId) &&
+			!canAccessBranch(user_info.Permission, transfer.ToBranchId) {
+			continue
+		}
+		response.PendingTransfers = append(response.PendingTransfers, _to_sp_transfer(transfer))
+	}
+	return nil
+}
+
+func (s *SheketController) SendTransfer(c context.Context, request *sp.SendTransferRequest) (response *sp.Transfer, err error) {
+	defer trace("SendTransfer")()
+
+	user_info, err := GetUserWithCompanyPermission(request.CompanyAuth)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "%v", err)
+	}
+
+	from_branch_id, to_branch_id := int(request.FromBranchId), int(request.ToBranchId)
+	if !canAccessBranch(user_info.Permission, from_branch_id) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "%v",
+			fmt.Errorf("you can't send from branch:%d", from_branch_id))
+	}
+	if from_branch_id == to_branch_id {
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v",
+			fmt.Errorf("can't transfer to the same branch"))
+	}
+	if len(request.Items) == 0 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "%v",
+			fmt.Errorf("the transfer doesn't have any items"))
+	}
+
+	transfer := &models.ShTransfer{
+		ClientUUID:   request.UUID,
+		FromBranchId: from_branch_id,
+		ToBranchId:   to_branch_id,
+		TransferNote: request.Note,
+	}
+	seen_items := make(map[int]bool)
+	for _, item := range request.Items {
+		if item.Quantity <= 0 || seen_items[int(item.ItemId)] {
+			return nil, grpc.Errorf(codes.InvalidArgument, "%v",
+				fmt.Errorf("item:%d should be sent once with a positive quantity", item.ItemId))
+		}
+		seen_items[int(item.ItemId)] = true
+		transfer.Items = append(transfer.Items, &models.ShTransferItem{
+			ItemId:       int(item.ItemId),
+			SentQuantity: item.Quantity,
+		})
+	}
+
+	tnx, err := Store.Begin()
+	if err != nil {
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+
+	for _, branch_id := range []int{from_branch_id, to_branch_id} {
+		branch, err := Store.GetBranchByIdInTx(tnx, branch_id)
+		if err != nil || branch.CompanyId != user_info.CompanyId {
+			tnx.Rollback()
+			return nil, grpc.Errorf(codes.NotFound, "%v",
+				fmt.Errorf("no branch:%d", branch_id))
+		}
+	}
+
+	affected_branch_items := make(AffectedBranchItems)
+	if transfer, err = sendTransferInTx(tnx, user_info, transfer, affected_branch_items); err != nil {
+		tnx.Rollback()
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+	if err = updateBranchItems(tnx, affected_branch_items, user_info.CompanyId); err != nil {
+		tnx.Rollback()
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+	if err = tnx.Commit(); err != nil {
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+
+	// the receiving branch gets the pending transfer on its next sync
+	ChangeHub.Publish(user_info.CompanyId,
+		&sp.ChangeNotification{TransactionsChanged: true})
+
+	return _to_sp_transfer(transfer), nil
+}
+
+func (s *SheketController) ReceiveTransfer(c context.Context, request *sp.ReceiveTransferRequest) (response *sp.Transfer, err error) {
+	defer trace("ReceiveTransfer")()
+
+	user_info, err := GetUserWithCompanyPermission(request.CompanyAuth)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "%v", err)
+	}
+
+	received := make(map[int]float64)
+	for _, item := range request.Items {
+		if item.Quantity < 0 {
+			return nil, grpc.Errorf(codes.InvalidArgument, "%v",
+				fmt.Errorf("item:%d can't be received with a negative quantity", item.ItemId))
+		}
+		received[int(item.ItemId)] = item.Quantity
+	}
+
+	tnx, err := Store.Begin()
+	if err != nil {
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+
+	transfer, err := Store.GetTransferInTx(tnx, user_info.CompanyId, int(request.TransferId))
+	if err != nil {
+		tnx.Rollback()
+		if err == models.ErrNoData {
+			return nil, grpc.Errorf(codes.NotFound, "%v",
+				fmt.Errorf("no transfer:%d", request.TransferId))
+		}
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+	if !canAccessBranch(user_info.Permission, transfer.ToBranchId) {
+		tnx.Rollback()
+		return nil, grpc.Errorf(codes.PermissionDenied, "%v",
+			fmt.Errorf("you can't receive in branch:%d", transfer.ToBranchId))
+	}
+	if transfer.Status != models.TRANSFER_STATUS_SENT {
+		tnx.Rollback()
+		return nil, grpc.Errorf(codes.FailedPrecondition, "%v", models.ErrTransferReceived)
+	}
+
+	affected_branch_items := make(AffectedBranchItems)
+	transfer, err = receiveTransferInTx(tnx, user_info, transfer, received, affected_branch_items)
+	if err != nil {
+		tnx.Rollback()
+		if _, ok := err.(*invalidTransactionError); ok {
+			return nil, grpc.Errorf(codes.InvalidArgument, "%v", err)
+		} else if err == models.ErrTransferReceived {
+			// someone else received it in the meantime
+			return nil, grpc.Errorf(codes.Aborted, "%v", err)
+		}
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+	if err = updateBranchItems(tnx, affected_branch_items, user_info.CompanyId); err != nil {
+		tnx.Rollback()
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+	if err = tnx.Commit(); err != nil {
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+
+	ChangeHub.Publish(user_info.CompanyId,
+		&sp.ChangeNotification{TransactionsChanged: true})
+
+	return _to_sp_transfer(transfer), nil
+}
@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+	"testing"
+)
+
+func setup_transfer_store(t *testing.T) (*models.SimpleBranchItemStore, func()) {
+	branch_item_store, teardown := setup_ledger_store(t)
+	Store.(*models.ComposableShStoreMock).TransferStore = models.NewSimpleTransferStore()
+	return branch_item_store, teardown
+}
+
+func t_transfer_user(branch_ids ...int) *UserCompanyPermission {
+	permission := &models.UserPermission{PermissionType: models.PERMISSION_TYPE_EMPLOYEE}
+	for _, branch_id := range branch_ids {
+		permission.Branches = append(permission.Branches,
+			models.BranchAccess{BranchId: branch_id, Access: models.BRANCH_ACCESS_SEE_QTY})
+	}
+	return &UserCompanyPermission{
+		CompanyId:  t_ledger_company_id,
+		User:       &models.User{UserId: 3},
+		Permission: permission,
+	}
+}
+
+func t_send_transfer(t *testing.T, uid string, quantity float64) *models.ShTransfer {
+	affected := make(AffectedBranchItems)
+	transfer, err := sendTransferInTx(nil, t_transfer_user(t_ledger_branch_a),
+		&models.ShTransfer{
+			ClientUUID:   uid,
+			FromBranchId: t_ledger_branch_a,
+			ToBranchId:   t_ledger_branch_b,
+			Items: []*models.ShTransferItem{
+				{ItemId: t_ledger_item_id, SentQuantity: quantity},
+			},
+		}, affected)
+	if err != nil {
+		t.Fatalf("send transfer: %v", err)
+	}
+	if err = updateBranchItems(nil, affected, t_ledger_company_id); err != nil {
+		t.Fatalf("update branch items: %v", err)
+	}
+	return transfer
+}
+
+func TestTransferIsInTransitUntilReceived(t *testing.T) {
+	store, teardown := setup_transfer_store(t)
+	defer teardown()
+
+	transfer := t_send_transfer(t, "9d3a6c15-f2e8-4b70-8c19-4e5b7a02d6f3", 6)
+	t_check_quantity(t, store, t_ledger_branch_a, 4)
+	t_check_quantity(t, store, t_ledger_branch_b, 4)
+
+	// re-sending it doesn't send it twice
+	if again := t_send_transfer(t, "9d3a6c15-f2e8-4b70-8c19-4e5b7a02d6f3", 6); again.TransferId != transfer.TransferId {
+		t.Errorf("expected the re-sent transfer to be %d, got %d", transfer.TransferId, again.TransferId)
+	}
+	t_check_quantity(t, store, t_ledger_branch_a, 4)
+
+	// both branches see it pending, others don't
+	for _, c := range []struct {
+		user    *UserCompanyPermission
+		pending int
+	}{
+		{t_transfer_user(t_ledger_branch_a), 1},
+		{t_transfer_user(t_ledger_branch_b), 1},
+		{t_transfer_user(3), 0},
+	} {
+		response := new(sp.TransactionResponse)
+		if err := fetchPendingTransfers(response, c.user); err != nil {
+			t.Fatalf("pending transfers: %v", err)
+		}
+		if len(response.PendingTransfers) != c.pending {
+			t.Errorf("expected %d pending transfers, got %d", c.pending, len(response.PendingTransfers))
+		}
+	}
+
+	// 1 of them broke on the way
+	affected := make(AffectedBranchItems)
+	transfer, err := receiveTransferInTx(nil, t_transfer_user(t_ledger_branch_b), transfer,
+		map[int]float64{t_ledger_item_id: 5}, affected)
+	if err != nil {
+		t.Fatalf("receive transfer: %v", err)
+	}
+	if err = updateBranchItems(nil, affected, t_ledger_company_id); err != nil {
+		t.Fatalf("update branch items: %v", err)
+	}
+	t_check_quantity(t, store, t_ledger_branch_a, 4)
+	t_check_quantity(t, store, t_ledger_branch_b, 9)
+
+	if transfer.Status != models.TRANSFER_STATUS_RECEIVED || transfer.Items[0].ReceivedQuantity != 5 {
+		t.Errorf("the transfer isn't received: %+v", transfer)
+	}
+
+	receipt, err := Store.GetShTransactionByIdInTx(nil, t_ledger_company_id, transfer.ReceiveTransId, true)
+	if err != nil {
+		t.Fatalf("receive transaction: %v", err)
+	}
+	if receipt.LinkedTransId != transfer.SendTransId {
+		t.Errorf("expected the receipt to link to %d, got %d", transfer.SendTransId, receipt.LinkedTransId)
+	}
+	in_transit := float64(0)
+	for _, trans_id := range []int64{transfer.SendTransId, transfer.ReceiveTransId} {
+		trans, _ := Store.GetShTransactionByIdInTx(nil, t_ledger_company_id, trans_id, true)
+		for _, entry := range models.StockLedgerEntries(trans) {
+			if entry.Account == models.LEDGER_ACCOUNT_IN_TRANSIT {
+				in_transit += entry.Quantity
+			}
+		}
+	}
+	if in_transit != 0 {
+		t.Errorf("expected nothing left in-transit, got %v", in_transit)
+	}
+
+	if _, err = t_void(t, transfer.SendTransId); err != errVoidingTransfer {
+		t.Errorf("expected the transfer's transaction not to be voided, got %v", err)
+	}
+}
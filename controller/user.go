@@ -135,6 +135,7 @@ func (s *SheketController) SyncCompanies(c context.Context, request *sp.SyncComp
 		company.CompanyName = company_permissions[i].CompanyInfo.CompanyName
 		company.Permission = company_permissions[i].Permission.EncodedPermission
 		company.PaymentId = generatePaymentId(&company_permissions[i].CompanyInfo)
+		company.CostingMethod = int32(models.CostingMethodOf(&company_permissions[i].CompanyInfo))
 
 		license, err := GenerateCompanyLicense(
 			company_permissions[i].CompanyInfo.CompanyId,
@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"fmt"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"math"
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+)
+
+/**
+ * Replays the transactions and the ledger corrections dated up to until
+ * through a costing engine using the company's costing method. The replay
+ * starts from the latest cost snapshot before the start date that isn't after
+ * until, only the transactions after the snapshot are returned.
+ * @args start	the transactions from this date on have to be replayed, e.g: for their sale costs
+ */
+func costCompanyStock(company *models.Company, start, until int64) (*models.CostingEngine, []*models.ShTransaction, error) {
+	method := models.CostingMethodOf(company)
+	engine := models.NewCostingEngine(method)
+
+	// a snapshot after until has stock that came in after it, e.g: when an
+	// older snapshot is taken after a newer one
+	before := start - 1
+	if until < before {
+		before = until
+	}
+	after := int64(math.MinInt64)
+	snapshot_date, layers, err := Store.GetStockCostSnapshot(company.CompanyId, method, before)
+	if err == nil {
+		engine.Restore(layers)
+		after = snapshot_date
+	} else if err != models.ErrNoData {
+		return nil, nil, err
+	}
+
+	if engine.CostPrices, err = Store.GetItemCostPrices(company.CompanyId); err != nil {
+		return nil, nil, err
+	}
+	transactions, err := Store.GetCompanyTransactionsBetween(company.CompanyId, after, until)
+	if err != nil {
+		return nil, nil, err
+	}
+	corrections, err := Store.GetStockLedgerCorrections(company.CompanyId, after, until)
+	if err != nil {
+		return nil, nil, err
+	}
+	engine.Replay(transactions, corrections)
+	return engine, transactions, nil
+}
+
+func inventoryValuation(company *models.Company, request *sp.InventoryValuationRequest) (*sp.InventoryValuationResponse, error) {
+	start := int64(math.MaxInt64)
+	if request.SalesEndDate != 0 {
+		start = request.SalesStartDate
+	}
+	engine, transactions, err := costCompanyStock(company, start, math.MaxInt64)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &sp.InventoryValuationResponse{
+		CostingMethod:  int32(models.CostingMethodOf(company)),
+		InTransitValue: engine.InTransitValue(),
+	}
+	for _, value := range engine.Valuation() {
+		if request.BranchId != 0 && value.BranchId != int(request.BranchId) {
+			continue
+		}
+		response.Items = append(response.Items, &sp.InventoryValuationResponse_ItemValue{
+			BranchId: int32(value.BranchId),
+			ItemId:   int32(value.ItemId),
+			Quantity: value.Quantity,
+			Value:    value.Value,
+		})
+		response.TotalValue += value.Value
+	}
+
+	if request.SalesEndDate == 0 {
+		return response, nil
+	}
+	for _, trans := range transactions {
+		cost, ok := engine.SaleCosts[trans.TransactionId]
+		if !ok || trans.Date < request.SalesStartDate || trans.Date > request.SalesEndDate {
+			continue
+		}
+		if request.BranchId != 0 && trans.BranchId != int(request.BranchId) {
+			continue
+		}
+		response.SaleCosts = append(response.SaleCosts, &sp.InventoryValuationResponse_SaleCost{
+			TransId: trans.TransactionId,
+			Cost:    cost,
+		})
+	}
+	return response, nil
+}
+
+func (s *SheketController) GetInventoryValuation(c context.Context, request *sp.InventoryValuationRequest) (response *sp.InventoryValuationResponse, err error) {
+	defer trace("GetInventoryValuation")()
+
+	user_info, err := GetUserWithCompanyPermission(request.CompanyAuth)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "%v", err)
+	}
+	if !user_info.Permission.HasManagerAccess() {
+		return nil, grpc.Errorf(codes.PermissionDenied, "%v",
+			fmt.Errorf("only managers can see the valuation"))
+	}
+
+	company, err := Store.GetCompanyById(user_info.CompanyId)
+	if err != nil {
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+
+	if response, err = inventoryValuation(company, request); err != nil {
+		return nil, grpc.Errorf(codes.Internal, "%v", err)
+	}
+	return response, nil
+}
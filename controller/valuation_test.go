@@ -0,0 +1,245 @@
+package controller
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"math"
+	"sheket/server/models"
+	sp "sheket/server/sheketproto"
+	"testing"
+)
+
+// the opening stock isn't part of a transaction, it is at the item's cost price
+const t_opening_cost = 4
+
+func setup_valuation_store(t *testing.T) func() {
+	_, teardown := setup_ledger_store(t)
+	Store.(*models.ComposableShStoreMock).ItemPriceStore = models.NewSimpleItemPriceStore(
+		[]*models.ShItemPrice{
+			{CompanyId: t_ledger_company_id, ItemId: t_ledger_item_id, CostPrice: t_opening_cost},
+		})
+	return teardown
+}
+
+func t_post_valuation_transactions(t *testing.T) {
+	t_post_transactions(t,
+		&sp.Transaction{TransId: -1, BranchId: t_ledger_branch_a, DateTime: 100,
+			UUID: "8d3e1b6a-4f27-4c90-a5d8-2b7e9c0f1a34",
+			TransactionItems: []*sp.Transaction_TransItem{
+				{TransType: models.TRANS_TYPE_ADD_PURCHASED, ItemId: t_ledger_item_id, Quantity: 4,
+					HasPrice: true, UnitPrice: 5},
+			}},
+		&sp.Transaction{TransId: -2, BranchId: t_ledger_branch_a, DateTime: 200,
+			UUID: "f14a7c2e-903b-4d6f-8e15-c6b2a9d04e78",
+			TransactionItems: []*sp.Transaction_TransItem{
+				{TransType: models.TRANS_TYPE_ADD_PURCHASED, ItemId: t_ledger_item_id, Quantity: 4,
+					HasPrice: true, UnitPrice: 7},
+			}},
+		&sp.Transaction{TransId: -3, BranchId: t_ledger_branch_a, DateTime: 300,
+			UUID: "2c69e0d5-b71f-4a83-9d4e-7f10b5a3c8e2",
+			TransactionItems: []*sp.Transaction_TransItem{
+				{TransType: models.TRANS_TYPE_SUB_CURRENT_BRANCH_SALE, ItemId: t_ledger_item_id, Quantity: 6,
+					HasPrice: true, UnitPrice: 12},
+			}},
+	)
+}
+
+func t_check_value(t *testing.T, what string, expected, got float64) {
+	if math.Abs(expected-got) > 1e-6 {
+		t.Errorf("%s: expected %v, got %v", what, expected, got)
+	}
+}
+
+func TestInventoryValuation(t *testing.T) {
+	teardown := setup_valuation_store(t)
+	defer teardown()
+
+	t_post_valuation_transactions(t)
+
+	// 10 @ 4 opening, then 4 @ 5 and 4 @ 7 are purchased and 6 sold
+	expected := []struct {
+		method    int
+		value     float64
+		sale_cost float64
+	}{
+		{models.COSTING_METHOD_FIFO, 4*4 + 4*5 + 4*7, 6 * 4},
+		{models.COSTING_METHOD_AVERAGE, 12 * 88.0 / 18, 6 * 88.0 / 18},
+	}
+	for _, e := range expected {
+		company := &models.Company{CompanyId: t_ledger_company_id, CostingMethod: e.method}
+		response, err := inventoryValuation(company, &sp.InventoryValuationRequest{
+			SalesStartDate: 250,
+			SalesEndDate:   350,
+		})
+		if err != nil {
+			t.Fatalf("method %d: %v", e.method, err)
+		}
+		if len(response.Items) != 2 || response.Items[0].Quantity != 12 {
+			t.Errorf("method %d: expected 12 items in branch %d, got %v", e.method, t_ledger_branch_a, response)
+		} else {
+			t_check_value(t, "value", e.value, response.Items[0].Value)
+		}
+		if len(response.SaleCosts) != 1 {
+			t.Errorf("method %d: expected a sale cost, got %v", e.method, response.SaleCosts)
+		} else {
+			t_check_value(t, "sale cost", e.sale_cost, response.SaleCosts[0].Cost)
+		}
+	}
+
+	response, err := inventoryValuation(&models.Company{CompanyId: t_ledger_company_id},
+		&sp.InventoryValuationRequest{BranchId: t_ledger_branch_b})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(response.Items) != 1 || response.Items[0].Quantity != 4 ||
+		response.TotalValue != 4*t_opening_cost || len(response.SaleCosts) != 0 {
+		t.Errorf("expected only the opening stock in branch %d, got %v", t_ledger_branch_b, response)
+	}
+}
+
+// each snapshot is taken in its own transaction
+func t_expect_snapshots(t *testing.T, company *models.Company, count int) func() {
+	mock := Store.(*models.ComposableShStoreMock)
+	mock.CompanyStore.(*models.MockCompanyStore).EXPECT().
+		GetCompanyById(company.CompanyId).Return(company, nil).Times(count)
+
+	db, db_mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	ctrl := gomock.NewController(t)
+	source := models.NewMockSource(ctrl)
+	for i := 0; i < count; i++ {
+		db_mock.ExpectBegin()
+	}
+	for i := 0; i < count; i++ {
+		db_mock.ExpectCommit()
+	}
+	for i := 0; i < count; i++ {
+		tnx, _ := db.Begin()
+		source.EXPECT().Begin().Return(tnx, nil)
+	}
+	mock.Source = source
+
+	return func() {
+		ctrl.Finish()
+		if err := db_mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("%v", err)
+		}
+		db.Close()
+	}
+}
+
+func TestValuationStartsFromSnapshot(t *testing.T) {
+	teardown := setup_valuation_store(t)
+	defer teardown()
+
+	t_post_valuation_transactions(t)
+	company := &models.Company{CompanyId: t_ledger_company_id, CostingMethod: models.COSTING_METHOD_FIFO}
+	defer t_expect_snapshots(t, company, 1)()
+
+	full, err := inventoryValuation(company, &sp.InventoryValuationRequest{})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if err = TakeStockSnapshot(t_ledger_company_id, 250); err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+
+	// only the sale after the snapshot is replayed
+	engine, transactions, err := costCompanyStock(company, math.MaxInt64, math.MaxInt64)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(transactions) != 1 || transactions[0].Date != 300 {
+		t.Errorf("expected only the sale to be replayed, got %v", transactions)
+	}
+	from_snapshot, err := inventoryValuation(company, &sp.InventoryValuationRequest{})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	t_check_value(t, "valuation from the snapshot", full.TotalValue, from_snapshot.TotalValue)
+	t_check_value(t, "engine", full.TotalValue, engine.Valuation()[0].Value+engine.Valuation()[1].Value)
+
+	// the sale costs before the snapshot need an earlier start
+	response, err := inventoryValuation(company, &sp.InventoryValuationRequest{
+		SalesStartDate: 50, SalesEndDate: 350})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(response.SaleCosts) != 1 {
+		t.Errorf("expected the sale cost, got %v", response.SaleCosts)
+	}
+
+	// a transaction posted with a date before the snapshot removes it
+	t_post_transactions(t, &sp.Transaction{TransId: -1, BranchId: t_ledger_branch_a, DateTime: 150,
+		UUID: "4a8c2e61-7d39-4b05-9f1e-c3b6d0a2e847",
+		TransactionItems: []*sp.Transaction_TransItem{
+			{TransType: models.TRANS_TYPE_ADD_PURCHASED, ItemId: t_ledger_item_id, Quantity: 2,
+				HasPrice: true, UnitPrice: 9},
+		}})
+	if _, transactions, err = costCompanyStock(company, math.MaxInt64, math.MaxInt64); err != nil {
+		t.Fatalf("%v", err)
+	} else if len(transactions) != 4 {
+		t.Errorf("expected the whole history to be replayed, got %d transactions", len(transactions))
+	}
+
+	// a branch item repaired by a reconcile is valued
+	ledger := Store.(*models.ComposableShStoreMock).StockLedgerStore.(*models.SimpleStockLedgerStore)
+	ledger.AddStockLedgerEntriesInTx(nil, models.StockCorrectionEntries(t_ledger_company_id,
+		&models.ShStockDrift{BranchId: t_ledger_branch_b, ItemId: t_ledger_item_id,
+			ExpectedQuantity: 6, LedgerQuantity: 4}, 400))
+	response, err = inventoryValuation(company, &sp.InventoryValuationRequest{BranchId: t_ledger_branch_b})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(response.Items) != 1 || response.Items[0].Quantity != 6 {
+		t.Errorf("expected the repaired 6 in branch %d, got %v", t_ledger_branch_b, response)
+	} else {
+		t_check_value(t, "repaired value", 6*t_opening_cost, response.TotalValue)
+	}
+}
+
+func TestOlderSnapshotTakenAfterNewerOne(t *testing.T) {
+	teardown := setup_valuation_store(t)
+	defer teardown()
+
+	t_post_valuation_transactions(t)
+	company := &models.Company{CompanyId: t_ledger_company_id, CostingMethod: models.COSTING_METHOD_FIFO}
+	defer t_expect_snapshots(t, company, 2)()
+
+	request := &sp.InventoryValuationRequest{SalesStartDate: 160, SalesEndDate: 350}
+	full, err := inventoryValuation(company, request)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// e.g: an operator back-fills the snapshot of an earlier date
+	if err = TakeStockSnapshot(t_ledger_company_id, 350); err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+	if err = TakeStockSnapshot(t_ledger_company_id, 150); err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+
+	// the snapshot at 150 only has the opening stock and the first purchase
+	engine, transactions, err := costCompanyStock(company, 160, math.MaxInt64)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(transactions) != 2 || transactions[0].Date != 200 {
+		t.Errorf("expected the transactions after 150 to be replayed, got %v", transactions)
+	}
+	values := engine.Valuation()
+	t_check_value(t, "value", full.TotalValue, values[0].Value+values[1].Value)
+
+	from_snapshot, err := inventoryValuation(company, request)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	t_check_value(t, "valuation from the snapshot", full.TotalValue, from_snapshot.TotalValue)
+	if len(full.SaleCosts) != 1 || len(from_snapshot.SaleCosts) != 1 {
+		t.Fatalf("expected the sale cost, got %v and %v", full.SaleCosts, from_snapshot.SaleCosts)
+	}
+	t_check_value(t, "sale cost", full.SaleCosts[0].Cost, from_snapshot.SaleCosts[0].Cost)
+}
@@ -12,6 +12,12 @@ type ShBranch struct {
 	Name       string
 	Location   string
 	StatusFlag int
+	Version    int
+}
+
+type BranchItemPair struct {
+	BranchId int
+	ItemId   int
 }
 
 type ShBranchItem struct {
@@ -20,23 +26,46 @@ type ShBranchItem struct {
 	ItemId       int
 	Quantity     float64
 	ItemLocation string
+
+	// If HasReorderLevel is set, ReorderLevel overrides the
+	// item's reorder level in this branch.
+	ReorderLevel    float64
+	HasReorderLevel bool
+
+	// If HasSellingPrice is set, SellingPrice overrides the
+	// item's selling price in this branch.
+	SellingPrice    float64
+	HasSellingPrice bool
+}
+
+// the override is stored as NULL if the branch doesn't have one
+func (b *ShBranchItem) nullReorderLevel() sql.NullFloat64 {
+	return sql.NullFloat64{Float64: b.ReorderLevel, Valid: b.HasReorderLevel}
+}
+
+func (b *ShBranchItem) nullSellingPrice() sql.NullFloat64 {
+	return sql.NullFloat64{Float64: b.SellingPrice, Valid: b.HasSellingPrice}
 }
 
 func (s *shStore) CreateBranchInTx(tnx *sql.Tx, b *ShBranch) (*ShBranch, error) {
 	err := tnx.QueryRow(
 		fmt.Sprintf("insert into %s "+
 			"(company_id, branch_name, location, client_uuid, %s) values "+
-			"($1, $2, $3, $4, $5) returning branch_id;", TABLE_BRANCH, _db_status_flag),
-		b.CompanyId, b.Name, b.Location, b.ClientUUID, b.StatusFlag).Scan(&b.BranchId)
+			"($1, $2, $3, $4, $5) returning branch_id, %s;", TABLE_BRANCH, _db_status_flag, _db_version),
+		b.CompanyId, b.Name, b.Location, b.ClientUUID, b.StatusFlag).Scan(&b.BranchId, &b.Version)
 	return b, err
 }
 
 func (s *shStore) UpdateBranchInTx(tnx *sql.Tx, b *ShBranch) (*ShBranch, error) {
-	_, err := tnx.Exec(
+	err := tnx.QueryRow(
 		fmt.Sprintf("update %s set "+
-			" branch_name = $1, location = $2, %s = $3 "+
-			" where branch_id = $4 ", TABLE_BRANCH, _db_status_flag),
-		b.Name, b.Location, b.StatusFlag, b.BranchId)
+			" branch_name = $1, location = $2, %s = $3, %s = %s + 1 "+
+			" where branch_id = $4 AND %s = $5 returning %s", TABLE_BRANCH, _db_status_flag,
+			_db_version, _db_version, _db_version, _db_version),
+		b.Name, b.Location, b.StatusFlag, b.BranchId, b.Version).Scan(&b.Version)
+	if err == sql.ErrNoRows {
+		return b, ErrVersionConflict
+	}
 	return b, err
 }
 
@@ -58,9 +87,9 @@ func (s *shStore) GetBranchByIdInTx(tnx *sql.Tx, id int) (*ShBranch, error) {
 	return branches[0], nil
 }
 
-func (s *shStore) GetBranchByUUIDInTx(tnx *sql.Tx, uid string) (*ShBranch, error) {
-	msg := fmt.Sprintf("no branch with that uuid:%s", uid)
-	branches, err := _queryBranchInTx(tnx, msg, "where client_uuid = $1", uid)
+func (s *shStore) GetBranchByUUIDInTx(tnx *sql.Tx, company_id int, uid string) (*ShBranch, error) {
+	msg := fmt.Sprintf("no branch with that uuid:%s in company:%d", uid, company_id)
+	branches, err := _queryBranchInTx(tnx, msg, "where company_id = $1 AND client_uuid = $2", company_id, uid)
 	if err != nil {
 		return nil, err
 	}
@@ -78,9 +107,10 @@ func (s *shStore) AddItemToBranchInTx(tnx *sql.Tx, item *ShBranchItem) (*ShBranc
 	if rows.Next() { // if the item already exists, overwrite it
 		rows.Close()
 		stmt := fmt.Sprintf("update %s set "+
-			"quantity = $1, item_location = $2 "+
-			"where branch_id = $3 and item_id = $4", TABLE_BRANCH_ITEM)
-		_, err = tnx.Exec(stmt, item.Quantity, item.ItemLocation, item.BranchId, item.ItemId)
+			"quantity = $1, item_location = $2, reorder_level = $3, selling_price = $4 "+
+			"where branch_id = $5 and item_id = $6", TABLE_BRANCH_ITEM)
+		_, err = tnx.Exec(stmt, item.Quantity, item.ItemLocation, item.nullReorderLevel(),
+			item.nullSellingPrice(), item.BranchId, item.ItemId)
 		if err != nil {
 			return nil, err
 		}
@@ -88,9 +118,10 @@ func (s *shStore) AddItemToBranchInTx(tnx *sql.Tx, item *ShBranchItem) (*ShBranc
 		rows.Close()
 		_, err = tnx.Exec(
 			fmt.Sprintf("insert into %s "+
-				"(company_id, branch_id, item_id, quantity, item_location) values "+
-				"($1, $2, $3, $4, $5)", TABLE_BRANCH_ITEM),
-			item.CompanyId, item.BranchId, item.ItemId, item.Quantity, item.ItemLocation)
+				"(company_id, branch_id, item_id, quantity, item_location, reorder_level, selling_price) values "+
+				"($1, $2, $3, $4, $5, $6, $7)", TABLE_BRANCH_ITEM),
+			item.CompanyId, item.BranchId, item.ItemId, item.Quantity, item.ItemLocation,
+			item.nullReorderLevel(), item.nullSellingPrice())
 		if err != nil {
 			return nil, err
 		}
@@ -101,15 +132,56 @@ func (s *shStore) AddItemToBranchInTx(tnx *sql.Tx, item *ShBranchItem) (*ShBranc
 
 func (s *shStore) UpdateBranchItemInTx(tnx *sql.Tx, item *ShBranchItem) (*ShBranchItem, error) {
 	_, err := tnx.Exec(fmt.Sprintf("update %s set "+
-		"quantity = $1, item_location = $2 "+
-		"where branch_id = $3 and item_id = $4", TABLE_BRANCH_ITEM),
-		item.Quantity, item.ItemLocation, item.BranchId, item.ItemId)
+		"quantity = $1, item_location = $2, reorder_level = $3, selling_price = $4 "+
+		"where branch_id = $5 and item_id = $6", TABLE_BRANCH_ITEM),
+		item.Quantity, item.ItemLocation, item.nullReorderLevel(), item.nullSellingPrice(),
+		item.BranchId, item.ItemId)
 	if err != nil {
 		return nil, err
 	}
 	return item, nil
 }
 
+/**
+ * Locks the branch item until the transaction ends, so the quantity can be
+ * computed from the ledger without a concurrent sync writing over it.
+ * Returns ErrNoData if the item isn't in the branch.
+ */
+func (s *shStore) LockBranchItemInTx(tnx *sql.Tx, branch_id, item_id int) error {
+	var quantity float64
+	err := tnx.QueryRow(fmt.Sprintf("select quantity from %s "+
+		"where branch_id = $1 and item_id = $2 for update", TABLE_BRANCH_ITEM),
+		branch_id, item_id).Scan(&quantity)
+	if err == sql.ErrNoRows {
+		return ErrNoData
+	} else if err != nil {
+		return fmt.Errorf("err locking item:%d in branch:%d %v", item_id, branch_id, err)
+	}
+	return nil
+}
+
+/**
+ * Only writes the quantity, the location and overrides might have been edited since they were read.
+ */
+func (s *shStore) UpdateBranchItemQuantityInTx(tnx *sql.Tx, branch_id, item_id int, quantity float64) error {
+	_, err := tnx.Exec(fmt.Sprintf("update %s set quantity = $1 "+
+		"where branch_id = $2 and item_id = $3", TABLE_BRANCH_ITEM),
+		quantity, branch_id, item_id)
+	if err != nil {
+		return fmt.Errorf("err updating quantity of item:%d in branch:%d %v", item_id, branch_id, err)
+	}
+	return nil
+}
+
+func (s *shStore) GetCompanyBranchItemsInTx(tnx *sql.Tx, company_id int) ([]*ShBranchItem, error) {
+	msg := fmt.Sprintf("err fetching branch items of company:%d", company_id)
+	items, err := _queryBranchItemInTx(tnx, msg, "where company_id = $1", company_id)
+	if err == ErrNoData {
+		return nil, nil
+	}
+	return items, err
+}
+
 func (s *shStore) GetBranchItem(branch_id, item_id int) (*ShBranchItem, error) {
 	msg := fmt.Sprintf("err fetching item:%d in branch:%d", item_id, branch_id)
 	items, err := _queryBranchItem(s, msg, "where branch_id = $1 and item_id = $2",
@@ -136,8 +208,8 @@ func (s *shStore) GetBranchItemInTx(tnx *sql.Tx, branch_id, item_id int) (*ShBra
 func _queryBranch(s *shStore, err_msg string, where_stmt string, args ...interface{}) ([]*ShBranch, error) {
 	var result []*ShBranch
 
-	query := fmt.Sprintf("select company_id, branch_id, branch_name, location, client_uuid, %s from %s",
-		_db_status_flag, TABLE_BRANCH)
+	query := fmt.Sprintf("select company_id, branch_id, branch_name, location, client_uuid, %s, %s from %s",
+		_db_status_flag, _db_version, TABLE_BRANCH)
 	sort_by := " ORDER BY branch_id desc"
 
 	var rows *sql.Rows
@@ -161,6 +233,7 @@ func _queryBranch(s *shStore, err_msg string, where_stmt string, args ...interfa
 			&b.Location,
 			&b.ClientUUID,
 			&b.StatusFlag,
+			&b.Version,
 		); err == sql.ErrNoRows {
 			// no-op
 		} else if err != nil {
@@ -179,8 +252,8 @@ func _queryBranch(s *shStore, err_msg string, where_stmt string, args ...interfa
 func _queryBranchInTx(tnx *sql.Tx, err_msg string, where_stmt string, args ...interface{}) ([]*ShBranch, error) {
 	var result []*ShBranch
 
-	query := fmt.Sprintf("select company_id, branch_id, branch_name, location, client_uuid, %s from %s",
-		_db_status_flag, TABLE_BRANCH)
+	query := fmt.Sprintf("select company_id, branch_id, branch_name, location, client_uuid, %s, %s from %s",
+		_db_status_flag, _db_version, TABLE_BRANCH)
 	sort_by := " ORDER BY branch_id desc"
 
 	var rows *sql.Rows
@@ -205,6 +278,7 @@ func _queryBranchInTx(tnx *sql.Tx, err_msg string, where_stmt string, args ...in
 			&b.Location,
 			&b.ClientUUID,
 			&b.StatusFlag,
+			&b.Version,
 		)
 		if err == sql.ErrNoRows {
 			// no-op
@@ -224,7 +298,7 @@ func _queryBranchInTx(tnx *sql.Tx, err_msg string, where_stmt string, args ...in
 func _queryBranchItem(s *shStore, err_msg string, where_stmt string, args ...interface{}) ([]*ShBranchItem, error) {
 	var result []*ShBranchItem
 
-	query := fmt.Sprintf("select company_id, branch_id, item_id, quantity, item_location from %s",
+	query := fmt.Sprintf("select company_id, branch_id, item_id, quantity, item_location, reorder_level, selling_price from %s",
 		TABLE_BRANCH_ITEM)
 	sort_by := " ORDER BY branch_id desc"
 
@@ -243,17 +317,22 @@ func _queryBranchItem(s *shStore, err_msg string, where_stmt string, args ...int
 
 	for rows.Next() {
 		b := new(ShBranchItem)
+		var reorder_level, selling_price sql.NullFloat64
 		if err := rows.Scan(
 			&b.CompanyId,
 			&b.BranchId,
 			&b.ItemId,
 			&b.Quantity,
 			&b.ItemLocation,
+			&reorder_level,
+			&selling_price,
 		); err == sql.ErrNoRows {
 			// no-op
 		} else if err != nil {
 			return nil, fmt.Errorf("%s %v", err_msg, err.Error())
 		} else {
+			b.ReorderLevel, b.HasReorderLevel = reorder_level.Float64, reorder_level.Valid
+			b.SellingPrice, b.HasSellingPrice = selling_price.Float64, selling_price.Valid
 			result = append(result, b)
 		}
 	}
@@ -268,7 +347,7 @@ func _queryBranchItem(s *shStore, err_msg string, where_stmt string, args ...int
 func _queryBranchItemInTx(tnx *sql.Tx, err_msg string, where_stmt string, args ...interface{}) ([]*ShBranchItem, error) {
 	var result []*ShBranchItem
 
-	query := fmt.Sprintf("select company_id, branch_id, item_id, quantity, item_location from %s",
+	query := fmt.Sprintf("select company_id, branch_id, item_id, quantity, item_location, reorder_level, selling_price from %s",
 		TABLE_BRANCH_ITEM)
 	sort_by := " ORDER BY branch_id desc"
 
@@ -287,17 +366,22 @@ func _queryBranchItemInTx(tnx *sql.Tx, err_msg string, where_stmt string, args .
 
 	for rows.Next() {
 		b := new(ShBranchItem)
+		var reorder_level, selling_price sql.NullFloat64
 		if err := rows.Scan(
 			&b.CompanyId,
 			&b.BranchId,
 			&b.ItemId,
 			&b.Quantity,
 			&b.ItemLocation,
+			&reorder_level,
+			&selling_price,
 		); err == sql.ErrNoRows {
 			// no-op
 		} else if err != nil {
 			return nil, fmt.Errorf("%s %v", err_msg, err.Error())
 		} else {
+			b.ReorderLevel, b.HasReorderLevel = reorder_level.Float64, reorder_level.Valid
+			b.SellingPrice, b.HasSellingPrice = selling_price.Float64, selling_price.Valid
 			result = append(result, b)
 		}
 	}
@@ -16,6 +16,8 @@ type ShCategory struct {
 	CompanyId  int
 	ParentId   int
 	Name       string
+	StatusFlag int
+	Version    int
 }
 
 type ShBranchCategory struct {
@@ -45,20 +47,26 @@ func runInTransaction(s *shStore, f func(*sql.Tx) (*ShCategory, error)) (*ShCate
 func (s *shStore) CreateCategoryInTx(tnx *sql.Tx, category *ShCategory) (*ShCategory, error) {
 	err := tnx.QueryRow(
 		fmt.Sprintf("insert into %s "+
-			"(company_id, name, parent_id, client_uuid) values "+
-			"($1, $2, $3, $4) returning category_id;", TABLE_CATEGORY),
-		category.CompanyId, category.Name, category.ParentId, category.ClientUUID).
-		Scan(&category.CategoryId)
+			"(company_id, name, parent_id, client_uuid, %s) values "+
+			"($1, $2, $3, $4, $5) returning category_id, %s;",
+			TABLE_CATEGORY, _db_status_flag, _db_version),
+		category.CompanyId, category.Name, category.ParentId, category.ClientUUID,
+		category.StatusFlag).
+		Scan(&category.CategoryId, &category.Version)
 	return category, err
 }
 
 func (s *shStore) UpdateCategoryInTx(tnx *sql.Tx, category *ShCategory) (*ShCategory, error) {
-	_, err := tnx.Exec(
+	err := tnx.QueryRow(
 		fmt.Sprintf("update %s set "+
-			"name = $1, parent_id = $2 "+
-			"where category_id = $3", TABLE_CATEGORY),
-		category.Name, category.ParentId,
-		category.CategoryId)
+			"name = $1, parent_id = $2, %s = $3, %s = %s + 1 "+
+			"where category_id = $4 AND %s = $5 returning %s", TABLE_CATEGORY,
+			_db_status_flag, _db_version, _db_version, _db_version, _db_version),
+		category.Name, category.ParentId, category.StatusFlag,
+		category.CategoryId, category.Version).Scan(&category.Version)
+	if err == sql.ErrNoRows {
+		return category, ErrVersionConflict
+	}
 	return category, err
 }
 
@@ -69,9 +77,120 @@ func (s *shStore) DeleteCategoryInTx(tnx *sql.Tx, category_id int) error {
 	return err
 }
 
-func (s *shStore) GetCategoryByUUIDInTx(tnx *sql.Tx, uid string) (*ShCategory, error) {
-	msg := fmt.Sprintf("no category with that uuid:%s", uid)
-	category, err := _queryCategoryInTx(tnx, msg, "where client_uuid = $1", uid)
+/**
+ * Moves the direct sub-categories and items of the category under new_parent_id.
+ * Returns the ids of the categories and items that were moved.
+ */
+func (s *shStore) MoveCategoryChildrenInTx(tnx *sql.Tx, category_id, new_parent_id int) (moved_categories []int, moved_items []int, err error) {
+	rows, err := tnx.Query(
+		fmt.Sprintf("update %s set parent_id = $1, %s = %s + 1 "+
+			"where parent_id = $2 returning category_id",
+			TABLE_CATEGORY, _db_version, _db_version),
+		new_parent_id, category_id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error moving sub-categories of category:%d %v", category_id, err)
+	}
+	if moved_categories, err = _scanIds(rows); err != nil {
+		return nil, nil, err
+	}
+
+	rows, err = tnx.Query(
+		fmt.Sprintf("update %s set %s = $1, %s = %s + 1 "+
+			"where %s = $2 returning %s",
+			TABLE_INVENTORY_ITEM, _db_item_category_id, _db_version, _db_version,
+			_db_item_category_id, _db_item_id),
+		new_parent_id, category_id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error moving items of category:%d %v", category_id, err)
+	}
+	if moved_items, err = _scanIds(rows); err != nil {
+		return nil, nil, err
+	}
+
+	return moved_categories, moved_items, nil
+}
+
+/**
+ * A category is empty if it doesn't have any sub-categories or items.
+ */
+func (s *shStore) IsCategoryEmptyInTx(tnx *sql.Tx, category_id int) (bool, error) {
+	rows, err := tnx.Query(
+		fmt.Sprintf("select category_id from %s where parent_id = $1 "+
+			"union all "+
+			"select %s from %s where %s = $1 limit 1",
+			TABLE_CATEGORY, _db_item_id, TABLE_INVENTORY_ITEM, _db_item_category_id),
+		category_id)
+	if err != nil {
+		return false, fmt.Errorf("error checking if category:%d is empty %v", category_id, err)
+	}
+	defer rows.Close()
+
+	return !rows.Next(), nil
+}
+
+// selects the category and all of its descendants as "subtree"
+const _category_subtree_query = "with recursive subtree(category_id) as ( " +
+	"	select category_id from " + TABLE_CATEGORY + " where category_id = $1 " +
+	"	union " +
+	"	select c.category_id from " + TABLE_CATEGORY + " c " +
+	"	inner join subtree t on c.parent_id = t.category_id " +
+	") "
+
+/**
+ * Deletes the category with all its descendants. Items can't be deleted because
+ * transactions refer to them, so the items in the subtree are hidden and moved under
+ * items_parent_id. Returns the ids of the deleted categories and of the moved items.
+ */
+func (s *shStore) DeleteCategorySubtreeInTx(tnx *sql.Tx, category_id, items_parent_id int) (deleted_categories []int, moved_items []int, err error) {
+	rows, err := tnx.Query(
+		fmt.Sprintf(_category_subtree_query+
+			"update %s set %s = $2, %s = $3, %s = %s + 1 "+
+			"where %s in (select category_id from subtree) returning %s",
+			TABLE_INVENTORY_ITEM, _db_item_category_id, _db_status_flag, _db_version, _db_version,
+			_db_item_category_id, _db_item_id),
+		category_id, items_parent_id, STATUS_IN_VISIBLE)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error moving items under category:%d %v", category_id, err)
+	}
+	if moved_items, err = _scanIds(rows); err != nil {
+		return nil, nil, err
+	}
+
+	// the whole subtree is deleted in a single statement, so the
+	// parent_id references are only checked after all of it is gone
+	rows, err = tnx.Query(
+		fmt.Sprintf(_category_subtree_query+
+			"delete from %s where category_id in (select category_id from subtree) "+
+			"returning category_id", TABLE_CATEGORY),
+		category_id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error deleting sub-categories of category:%d %v", category_id, err)
+	}
+	if deleted_categories, err = _scanIds(rows); err != nil {
+		return nil, nil, err
+	}
+
+	return deleted_categories, moved_items, nil
+}
+
+// reads the single integer column of each row, it closes the rows
+func _scanIds(rows *sql.Rows) ([]int, error) {
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (s *shStore) GetCategoryByUUIDInTx(tnx *sql.Tx, company_id int, uid string) (*ShCategory, error) {
+	msg := fmt.Sprintf("no category with that uuid:%s in company:%d", uid, company_id)
+	category, err := _queryCategoryInTx(tnx, msg, "where company_id = $1 AND client_uuid = $2", company_id, uid)
 	if err != nil {
 		return nil, err
 	}
@@ -94,9 +213,33 @@ func (s *shStore) GetCategoryByIdInTx(tnx *sql.Tx, id int) (*ShCategory, error)
 	return category[0], nil
 }
 
+/**
+ * Checks if ancestor_id is category_id or one of its ancestors. Used to
+ * stop a category from being moved under itself, which would create a cycle.
+ */
+func (s *shStore) IsCategoryAncestorInTx(tnx *sql.Tx, ancestor_id, category_id int) (bool, error) {
+	rows, err := tnx.Query(
+		fmt.Sprintf("with recursive ancestors(category_id, parent_id) as ( "+
+			"	select category_id, parent_id from %s where category_id = $1 "+
+			// union(not union all) stops if there already is a cycle
+			"	union "+
+			"	select c.category_id, c.parent_id from %s c "+
+			"	inner join ancestors a on c.category_id = a.parent_id "+
+			") select category_id from ancestors where category_id = $2",
+			TABLE_CATEGORY, TABLE_CATEGORY),
+		category_id, ancestor_id)
+	if err != nil {
+		return false, fmt.Errorf("error checking ancestors of category:%d %v", category_id, err)
+	}
+	defer rows.Close()
+
+	return rows.Next(), nil
+}
+
 func _queryCategoryInTx(tnx *sql.Tx, err_msg string, where_stmt string, args ...interface{}) ([]*ShCategory, error) {
 	var result []*ShCategory
-	query := fmt.Sprintf("select category_id, company_id, name, parent_id, client_uuid from %s", TABLE_CATEGORY)
+	query := fmt.Sprintf("select category_id, company_id, name, parent_id, client_uuid, %s, %s from %s",
+		_db_status_flag, _db_version, TABLE_CATEGORY)
 	sort_by := " ORDER BY category_id asc"
 
 	var rows *sql.Rows
@@ -120,6 +263,8 @@ func _queryCategoryInTx(tnx *sql.Tx, err_msg string, where_stmt string, args ...
 			&c.Name,
 			&c.ParentId,
 			&c.ClientUUID,
+			&c.StatusFlag,
+			&c.Version,
 		)
 		if err != nil {
 			if err == sql.ErrNoRows {
@@ -189,6 +334,37 @@ func (s *shStore) DeleteBranchCategoryInTx(tnx *sql.Tx, branch_id, category_id i
 	return err
 }
 
+/**
+ * Removes the category(and its sub-categories if include_subtree is set) from
+ * the branches it was added to. Deleting the category would also do that with
+ * a cascade, this returns the removed rows so they can be synced.
+ */
+func (s *shStore) DeleteCategoryFromBranchesInTx(tnx *sql.Tx, category_id int, include_subtree bool) ([]*ShBranchCategory, error) {
+	query := fmt.Sprintf("delete from %s where category_id = $1 "+
+		"returning company_id, branch_id, category_id", TABLE_BRANCH_CATEGORY)
+	if include_subtree {
+		query = fmt.Sprintf(_category_subtree_query+
+			"delete from %s where category_id in (select category_id from subtree) "+
+			"returning company_id, branch_id, category_id", TABLE_BRANCH_CATEGORY)
+	}
+
+	rows, err := tnx.Query(query, category_id)
+	if err != nil {
+		return nil, fmt.Errorf("error removing category:%d from branches %v", category_id, err)
+	}
+	defer rows.Close()
+
+	var result []*ShBranchCategory
+	for rows.Next() {
+		c := new(ShBranchCategory)
+		if err := rows.Scan(&c.CompanyId, &c.BranchId, &c.CategoryId); err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+	return result, rows.Err()
+}
+
 func _queryBranchCategory(s *shStore, err_msg string, where_stmt string, args ...interface{}) ([]*ShBranchCategory, error) {
 	var result []*ShBranchCategory
 
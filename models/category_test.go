@@ -0,0 +1,56 @@
+package models
+
+import "testing"
+
+func TestIsCategoryAncestor(t *testing.T) {
+	db, store, teardown := t_fake_store(t)
+	defer teardown()
+
+	db.expect("with recursive ancestors").returns("category_id", t_row(int64(4)))
+	db.expect("with recursive ancestors").returns("category_id")
+
+	tnx, err := store.Begin()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer tnx.Rollback()
+
+	if is_ancestor, err := store.IsCategoryAncestorInTx(tnx, 4, 9); err != nil || !is_ancestor {
+		t.Errorf("expected 4 to be an ancestor, got %v %v", is_ancestor, err)
+	}
+	// the walk starts from the category, then looks for the ancestor
+	if args := db.args[0]; len(args) != 2 || args[0] != int64(9) || args[1] != int64(4) {
+		t.Errorf("expected the category then the ancestor, got %v", args)
+	}
+
+	if is_ancestor, err := store.IsCategoryAncestorInTx(tnx, 5, 9); err != nil || is_ancestor {
+		t.Errorf("expected 5 not to be an ancestor, got %v %v", is_ancestor, err)
+	}
+}
+
+func TestDeleteCategoryFromBranches(t *testing.T) {
+	db, store, teardown := t_fake_store(t)
+	defer teardown()
+
+	db.expect("delete from "+TABLE_BRANCH_CATEGORY+" where category_id = $1 returning").
+		returns("company_id, branch_id, category_id", t_row(int64(3), int64(1), int64(7)))
+	db.expect("with recursive subtree").
+		returns("company_id, branch_id, category_id",
+			t_row(int64(3), int64(1), int64(7)), t_row(int64(3), int64(2), int64(8)))
+
+	tnx, err := store.Begin()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer tnx.Rollback()
+
+	removed, err := store.DeleteCategoryFromBranchesInTx(tnx, 7, false)
+	if err != nil || len(removed) != 1 || removed[0].BranchId != 1 || removed[0].CategoryId != 7 {
+		t.Errorf("expected category 7 removed from branch 1, got %v %v", removed, err)
+	}
+
+	removed, err = store.DeleteCategoryFromBranchesInTx(tnx, 7, true)
+	if err != nil || len(removed) != 2 || removed[1].BranchId != 2 || removed[1].CategoryId != 8 {
+		t.Errorf("expected the subtree removed from both branches, got %v %v", removed, err)
+	}
+}
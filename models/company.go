@@ -11,8 +11,16 @@ type Company struct {
 	CompanyId      int
 	CompanyName    string
 	EncodedPayment string
+
+	// how the stock is valued, see CostingEngine
+	CostingMethod int
 }
 
+const (
+	COSTING_METHOD_FIFO    = 1 // what came in first is taken out first
+	COSTING_METHOD_AVERAGE = 2 // everything on hand is at the weighted average cost
+)
+
 const (
 	PAYMENT_CONTRACT_NONE               = 0
 	PAYMENT_CONTRACT_LIMITED_FREE       = 1
@@ -35,12 +43,22 @@ type PaymentInfo struct {
 	ItemLimit     int
 }
 
+/**
+ * Companies that haven't chosen a costing method use FIFO.
+ */
+func CostingMethodOf(c *Company) int {
+	if c.CostingMethod == COSTING_METHOD_AVERAGE {
+		return COSTING_METHOD_AVERAGE
+	}
+	return COSTING_METHOD_FIFO
+}
+
 func (b *shStore) CreateCompanyInTx(tnx *sql.Tx, u *User, c *Company) (*Company, error) {
 	err := tnx.QueryRow(
 		fmt.Sprintf("insert into %s "+
-			"(company_name, encoded_payment) values "+
-			"($1, $2) returning company_id;", TABLE_COMPANY),
-		c.CompanyName, c.EncodedPayment).Scan(&c.CompanyId)
+			"(company_name, encoded_payment, costing_method) values "+
+			"($1, $2, $3) returning company_id;", TABLE_COMPANY),
+		c.CompanyName, c.EncodedPayment, CostingMethodOf(c)).Scan(&c.CompanyId)
 	if err != nil {
 		return nil, err
 	}
@@ -51,9 +69,9 @@ func (b *shStore) CreateCompanyInTx(tnx *sql.Tx, u *User, c *Company) (*Company,
 func (b *shStore) UpdateCompanyInTx(tnx *sql.Tx, c *Company) (*Company, error) {
 	_, err := tnx.Exec(
 		fmt.Sprintf("update %s set "+
-			" company_name = $1, encoded_payment = $2 "+
-			" where company_id = $3 ", TABLE_COMPANY),
-		c.CompanyName, c.EncodedPayment,
+			" company_name = $1, encoded_payment = $2, costing_method = $3 "+
+			" where company_id = $4 ", TABLE_COMPANY),
+		c.CompanyName, c.EncodedPayment, CostingMethodOf(c),
 		c.CompanyId)
 	return c, err
 }
@@ -67,10 +85,18 @@ func (b *shStore) GetCompanyById(id int) (*Company, error) {
 	return companies[0], nil
 }
 
+func (b *shStore) ListCompanies() ([]*Company, error) {
+	companies, err := _queryCompany(b, "listing companies", "")
+	if err == ErrNoData {
+		return nil, nil
+	}
+	return companies, err
+}
+
 func _queryCompany(s *shStore, err_msg string, where_stmt string, args ...interface{}) ([]*Company, error) {
 	var result []*Company
 
-	query := fmt.Sprintf("select company_id, company_name, encoded_payment from %s", TABLE_COMPANY)
+	query := fmt.Sprintf("select company_id, company_name, encoded_payment, costing_method from %s", TABLE_COMPANY)
 	sort_by := " ORDER BY company_id desc"
 
 	var rows *sql.Rows
@@ -92,7 +118,7 @@ func _queryCompany(s *shStore, err_msg string, where_stmt string, args ...interf
 	 *
 	 * See: https://github.com/go-sql-driver/mysql/issues/34
 	 */
-	var _company_id sql.NullInt64
+	var _company_id, _costing_method sql.NullInt64
 	var _name, _encoded_payment sql.NullString
 
 	for rows.Next() {
@@ -100,6 +126,7 @@ func _queryCompany(s *shStore, err_msg string, where_stmt string, args ...interf
 			&_company_id,
 			&_name,
 			&_encoded_payment,
+			&_costing_method,
 		); err == sql.ErrNoRows {
 			// no-op
 		} else if err != nil {
@@ -109,6 +136,7 @@ func _queryCompany(s *shStore, err_msg string, where_stmt string, args ...interf
 			c.CompanyId = int(_company_id.Int64)
 			c.CompanyName = _name.String
 			c.EncodedPayment = _encoded_payment.String
+			c.CostingMethod = int(_costing_method.Int64)
 			result = append(result, c)
 		}
 	}
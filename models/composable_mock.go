@@ -13,6 +13,11 @@ type ComposableShStoreMock struct {
 	ItemStore
 	BranchStore
 	BranchItemStore
+	StockLedgerStore
+	StockTakeStore
+	TransferStore
+	ItemPriceStore
+	ReportStore
 	BranchCategoryStore
 	CompanyStore
 	UserStore
@@ -28,6 +33,11 @@ func NewComposableShStoreMock(ctrl *gomock.Controller) *ComposableShStoreMock {
 	c.ItemStore = NewMockItemStore(ctrl)
 	c.BranchStore = NewMockBranchStore(ctrl)
 	c.BranchItemStore = NewMockBranchItemStore(ctrl)
+	c.StockLedgerStore = NewMockStockLedgerStore(ctrl)
+	c.StockTakeStore = NewMockStockTakeStore(ctrl)
+	c.TransferStore = NewMockTransferStore(ctrl)
+	c.ItemPriceStore = NewMockItemPriceStore(ctrl)
+	c.ReportStore = NewMockReportStore(ctrl)
 	c.BranchCategoryStore = NewMockBranchCategoryStore(ctrl)
 	c.CompanyStore = NewMockCompanyStore(ctrl)
 	c.UserStore = NewMockUserStore(ctrl)
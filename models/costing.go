@@ -0,0 +1,395 @@
+package models
+
+import "sort"
+
+/**
+ * Values the stock by replaying the transactions and the ledger corrections by
+ * date. Each branch keeps the cost layers of the items it has on hand, items moving
+ * between branches take their cost layers along, so do transfers while they
+ * are in-transit. Stock coming from outside the company is at its unit price
+ * if it was purchased, at the current cost otherwise. e.g: a customer return
+ * If neither is known, it is at the item's cost price.
+ *
+ * Replaying a long history is slow, so the cost layers are snapshot along with
+ * the quantities(see stock_snapshot.go) and the replay starts from the latest one.
+ */
+type CostingEngine struct {
+	method    int
+	positions map[_costKey]*_costPosition
+
+	// item_id -> the item's cost price, for stock that comes in without a cost
+	CostPrices map[int]float64
+
+	// transaction_id -> the cost of the items it sold, customer returns reduce it
+	SaleCosts map[int64]float64
+}
+
+/**
+ * A cost layer of an item in an account, the snapshot of the engine is a
+ * list of them. A position without layers has a single one with a 0 quantity,
+ * so its last cost and deficit are kept.
+ */
+type ShCostLayer struct {
+	Account  int
+	BranchId int
+	ItemId   int
+
+	Quantity float64
+	UnitCost float64
+
+	// of the position, repeated on each of its layers
+	LastCost float64
+	Deficit  float64
+}
+
+/**
+ * The quantity and cost of an item in a branch.
+ */
+type ShStockValue struct {
+	BranchId int
+	ItemId   int
+	Quantity float64
+	Value    float64
+}
+
+type _costKey struct {
+	account   int
+	branch_id int
+	item_id   int
+}
+
+type _costLayer struct {
+	quantity  float64
+	unit_cost float64
+}
+
+type _costPosition struct {
+	// for FIFO the oldest is first, for weighted-average there is at most one
+	layers []*_costLayer
+	// the cost of the latest stock that came in
+	last_cost float64
+	// what was taken out over what was on hand, it was costed at last_cost
+	deficit float64
+}
+
+func NewCostingEngine(method int) *CostingEngine {
+	return &CostingEngine{
+		method:     method,
+		positions:  make(map[_costKey]*_costPosition),
+		CostPrices: make(map[int]float64),
+		SaleCosts:  make(map[int64]float64),
+	}
+}
+
+/**
+ * Returns the cost layers of every position, ordered by account, branch then item.
+ */
+func (e *CostingEngine) Layers() []*ShCostLayer {
+	var keys []_costKey
+	for key := range e.positions {
+		keys = append(keys, key)
+	}
+	sort.Sort(_costKeys(keys))
+
+	var result []*ShCostLayer
+	for _, key := range keys {
+		p := e.positions[key]
+		layer := ShCostLayer{Account: key.account, BranchId: key.branch_id, ItemId: key.item_id,
+			LastCost: p.last_cost, Deficit: p.deficit}
+		if len(p.layers) == 0 {
+			result = append(result, &layer)
+		}
+		for _, l := range p.layers {
+			layer.Quantity, layer.UnitCost = l.quantity, l.unit_cost
+			copied := layer
+			result = append(result, &copied)
+		}
+	}
+	return result
+}
+
+/**
+ * Starts the engine from the layers of a snapshot, see Layers.
+ */
+func (e *CostingEngine) Restore(layers []*ShCostLayer) {
+	for _, layer := range layers {
+		p := e._position(layer.Account, layer.BranchId, layer.ItemId)
+		p.last_cost, p.deficit = layer.LastCost, layer.Deficit
+		if _quantityDrifted(layer.Quantity, 0) {
+			p.layers = append(p.layers, &_costLayer{layer.Quantity, layer.UnitCost})
+		}
+	}
+}
+
+/**
+ * The stock in the branches and in-transit has a cost, the other accounts are
+ * outside of the company's stock.
+ */
+func _isCostedAccount(account int) bool {
+	return account == LEDGER_ACCOUNT_BRANCH || account == LEDGER_ACCOUNT_IN_TRANSIT
+}
+
+func (e *CostingEngine) _position(account, branch_id, item_id int) *_costPosition {
+	key := _costKey{account, branch_id, item_id}
+	p, ok := e.positions[key]
+	if !ok {
+		p = new(_costPosition)
+		e.positions[key] = p
+	}
+	return p
+}
+
+func (p *_costPosition) quantity() float64 {
+	quantity := -p.deficit
+	for _, layer := range p.layers {
+		quantity += layer.quantity
+	}
+	return quantity
+}
+
+func (p *_costPosition) value() float64 {
+	value := -p.deficit * p.last_cost
+	for _, layer := range p.layers {
+		value += layer.quantity * layer.unit_cost
+	}
+	return value
+}
+
+/**
+ * The cost of a unit on hand, or of the latest if nothing is on hand.
+ */
+func (p *_costPosition) unitCost() float64 {
+	var quantity, value float64
+	for _, layer := range p.layers {
+		quantity += layer.quantity
+		value += layer.quantity * layer.unit_cost
+	}
+	if !_quantityDrifted(quantity, 0) {
+		return p.last_cost
+	}
+	return value / quantity
+}
+
+func (p *_costPosition) add(method int, quantity, unit_cost float64) {
+	p.last_cost = unit_cost
+
+	// the deficit was already costed, it is now covered
+	covered := quantity
+	if p.deficit < covered {
+		covered = p.deficit
+	}
+	p.deficit -= covered
+	quantity -= covered
+	if !_quantityDrifted(quantity, 0) {
+		return
+	}
+
+	if method == COSTING_METHOD_AVERAGE && len(p.layers) > 0 {
+		layer := p.layers[0]
+		total := layer.quantity*layer.unit_cost + quantity*unit_cost
+		layer.quantity += quantity
+		layer.unit_cost = total / layer.quantity
+		return
+	}
+	p.layers = append(p.layers, &_costLayer{quantity, unit_cost})
+}
+
+/**
+ * Takes out the quantity, the oldest layers first. Returns the cost layers
+ * of what was taken out.
+ */
+func (p *_costPosition) take(quantity float64) []*_costLayer {
+	var taken []*_costLayer
+	for len(p.layers) > 0 && _quantityDrifted(quantity, 0) {
+		layer := p.layers[0]
+		if layer.quantity > quantity {
+			layer.quantity -= quantity
+			taken = append(taken, &_costLayer{quantity, layer.unit_cost})
+			return taken
+		}
+		taken = append(taken, layer)
+		quantity -= layer.quantity
+		p.layers = p.layers[1:]
+	}
+	if _quantityDrifted(quantity, 0) {
+		// there wasn't enough on hand, the rest is at the latest cost
+		p.deficit += quantity
+		taken = append(taken, &_costLayer{quantity, p.last_cost})
+	}
+	return taken
+}
+
+func _layersCost(layers []*_costLayer) float64 {
+	var cost float64
+	for _, layer := range layers {
+		cost += layer.quantity * layer.unit_cost
+	}
+	return cost
+}
+
+/**
+ * Returns the entry the stock left and the one it entered.
+ */
+func _ledgerDirection(a, b *ShStockLedgerEntry) (from, to *ShStockLedgerEntry) {
+	if b.Quantity < 0 {
+		// e.g: a void moves the items back
+		return b, a
+	}
+	return a, b
+}
+
+/**
+ * Moves the item between the accounts, returns the cost layers of what moved.
+ * @args unit_cost	the cost of stock coming into the company, 0 if it isn't known
+ */
+func (e *CostingEngine) _move(item_id int, from, to *ShStockLedgerEntry, unit_cost float64) []*_costLayer {
+	quantity := to.Quantity
+
+	var layers []*_costLayer
+	if _isCostedAccount(from.Account) {
+		layers = e._position(from.Account, from.BranchId, item_id).take(quantity)
+	} else if _isCostedAccount(to.Account) {
+		if unit_cost == 0 {
+			unit_cost = e._position(to.Account, to.BranchId, item_id).unitCost()
+		}
+		if unit_cost == 0 {
+			unit_cost = e.CostPrices[item_id]
+		}
+		layers = []*_costLayer{{quantity, unit_cost}}
+	}
+
+	if _isCostedAccount(to.Account) {
+		p := e._position(to.Account, to.BranchId, item_id)
+		for _, layer := range layers {
+			p.add(e.method, layer.quantity, layer.unit_cost)
+		}
+	}
+	return layers
+}
+
+/**
+ * Moves the cost of the transaction's items between the accounts. The
+ * transactions should be posted in order.
+ */
+func (e *CostingEngine) Post(trans *ShTransaction) {
+	for _, trans_item := range trans.TransItems {
+		// the ledger knows where each item moves from and to
+		moves := StockLedgerEntries(&ShTransaction{
+			CompanyId:     trans.CompanyId,
+			TransactionId: trans.TransactionId,
+			BranchId:      trans.BranchId,
+			Date:          trans.Date,
+			TransItems:    []*ShTransactionItem{trans_item},
+		})
+		if len(moves) != 2 {
+			continue
+		}
+
+		var unit_cost float64
+		if trans_item.TransType == TRANS_TYPE_ADD_PURCHASED {
+			unit_cost = trans_item.UnitPrice
+			if unit_cost == 0 {
+				// it was purchased without a price
+				unit_cost = e.CostPrices[trans_item.ItemId]
+			}
+		}
+		from, to := _ledgerDirection(moves[0], moves[1])
+		layers := e._move(trans_item.ItemId, from, to, unit_cost)
+
+		if SoldQuantity(trans_item) != 0 {
+			cost := _layersCost(layers)
+			if _isCostedAccount(to.Account) {
+				// it came back to the branch
+				cost = -cost
+			}
+			e.SaleCosts[trans.TransactionId] += cost
+		}
+	}
+}
+
+/**
+ * Moves the cost of ledger entries that aren't part of a transaction, e.g: the
+ * corrections of ReconcileStock. The entries are pairs, stock leaves the first
+ * and enters the second.
+ */
+func (e *CostingEngine) PostCorrections(entries []*ShStockLedgerEntry) {
+	for i := 0; i+1 < len(entries); i += 2 {
+		from, to := _ledgerDirection(entries[i], entries[i+1])
+		e._move(from.ItemId, from, to, 0)
+	}
+}
+
+/**
+ * Posts the transactions and the corrections by date, a correction is posted
+ * after the transactions of its date. Both should be sorted by date.
+ */
+func (e *CostingEngine) Replay(transactions []*ShTransaction, corrections []*ShStockLedgerEntry) {
+	for _, trans := range transactions {
+		n := 0
+		for n+1 < len(corrections) && corrections[n].Date < trans.Date {
+			n += 2
+		}
+		e.PostCorrections(corrections[:n])
+		corrections = corrections[n:]
+		e.Post(trans)
+	}
+	e.PostCorrections(corrections)
+}
+
+/**
+ * Returns the value of the stock in each branch, ordered by branch then item.
+ */
+func (e *CostingEngine) Valuation() []*ShStockValue {
+	var result []*ShStockValue
+	for key, p := range e.positions {
+		if key.account != LEDGER_ACCOUNT_BRANCH {
+			continue
+		}
+		result = append(result, &ShStockValue{
+			BranchId: key.branch_id,
+			ItemId:   key.item_id,
+			Quantity: p.quantity(),
+			Value:    p.value(),
+		})
+	}
+	sort.Sort(_stockValuesByBranch(result))
+	return result
+}
+
+/**
+ * Returns the value of the stock that is in-transit between branches.
+ */
+func (e *CostingEngine) InTransitValue() float64 {
+	var value float64
+	for key, p := range e.positions {
+		if key.account == LEDGER_ACCOUNT_IN_TRANSIT {
+			value += p.value()
+		}
+	}
+	return value
+}
+
+type _stockValuesByBranch []*ShStockValue
+
+func (s _stockValuesByBranch) Len() int      { return len(s) }
+func (s _stockValuesByBranch) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s _stockValuesByBranch) Less(i, j int) bool {
+	if s[i].BranchId != s[j].BranchId {
+		return s[i].BranchId < s[j].BranchId
+	}
+	return s[i].ItemId < s[j].ItemId
+}
+
+type _costKeys []_costKey
+
+func (k _costKeys) Len() int      { return len(k) }
+func (k _costKeys) Swap(i, j int) { k[i], k[j] = k[j], k[i] }
+func (k _costKeys) Less(i, j int) bool {
+	if k[i].account != k[j].account {
+		return k[i].account < k[j].account
+	}
+	if k[i].branch_id != k[j].branch_id {
+		return k[i].branch_id < k[j].branch_id
+	}
+	return k[i].item_id < k[j].item_id
+}
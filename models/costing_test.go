@@ -0,0 +1,206 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+const (
+	t_cost_branch_a = 1
+	t_cost_branch_b = 2
+	t_cost_item     = 7
+)
+
+func t_cost_trans(trans_id int64, branch_id int, items ...*ShTransactionItem) *ShTransaction {
+	return &ShTransaction{
+		TransactionId: trans_id,
+		BranchId:      branch_id,
+		TransItems:    items,
+	}
+}
+
+func t_cost_item_of(trans_type int, quantity, unit_price float64) *ShTransactionItem {
+	return &ShTransactionItem{
+		TransType: trans_type,
+		ItemId:    t_cost_item,
+		Quantity:  quantity,
+		UnitPrice: unit_price,
+	}
+}
+
+// purchases 10 @ 5 then 10 @ 8, sells 15
+func t_cost_purchases_and_sale() []*ShTransaction {
+	return []*ShTransaction{
+		t_cost_trans(1, t_cost_branch_a, t_cost_item_of(TRANS_TYPE_ADD_PURCHASED, 10, 5)),
+		t_cost_trans(2, t_cost_branch_a, t_cost_item_of(TRANS_TYPE_ADD_PURCHASED, 10, 8)),
+		t_cost_trans(3, t_cost_branch_a, t_cost_item_of(TRANS_TYPE_SUB_CURRENT_BRANCH_SALE, 15, 20)),
+	}
+}
+
+func t_check_cost(t *testing.T, what string, expected, got float64) {
+	if math.Abs(expected-got) > 1e-6 {
+		t.Errorf("%s: expected %v, got %v", what, expected, got)
+	}
+}
+
+func t_branch_value(engine *CostingEngine, branch_id int) *ShStockValue {
+	for _, value := range engine.Valuation() {
+		if value.BranchId == branch_id && value.ItemId == t_cost_item {
+			return value
+		}
+	}
+	return &ShStockValue{}
+}
+
+func TestFIFOCosting(t *testing.T) {
+	engine := NewCostingEngine(COSTING_METHOD_FIFO)
+	for _, trans := range t_cost_purchases_and_sale() {
+		engine.Post(trans)
+	}
+
+	// 10 @ 5 + 5 @ 8
+	t_check_cost(t, "sale cost", 90, engine.SaleCosts[3])
+	value := t_branch_value(engine, t_cost_branch_a)
+	t_check_cost(t, "quantity", 5, value.Quantity)
+	t_check_cost(t, "value", 40, value.Value)
+}
+
+func TestAverageCosting(t *testing.T) {
+	engine := NewCostingEngine(COSTING_METHOD_AVERAGE)
+	for _, trans := range t_cost_purchases_and_sale() {
+		engine.Post(trans)
+	}
+
+	// the average is 6.5
+	t_check_cost(t, "sale cost", 97.5, engine.SaleCosts[3])
+	value := t_branch_value(engine, t_cost_branch_a)
+	t_check_cost(t, "quantity", 5, value.Quantity)
+	t_check_cost(t, "value", 32.5, value.Value)
+}
+
+func TestCostingReturnsAndVoids(t *testing.T) {
+	engine := NewCostingEngine(COSTING_METHOD_FIFO)
+	for _, trans := range t_cost_purchases_and_sale() {
+		engine.Post(trans)
+	}
+
+	// a customer returns 2, they come back at the current cost
+	engine.Post(t_cost_trans(4, t_cost_branch_a,
+		t_cost_item_of(TRANS_TYPE_ADD_CUSTOMER_RETURN, 2, 20)))
+	t_check_cost(t, "return cost", -16, engine.SaleCosts[4])
+
+	// voiding the sale brings the rest back
+	engine.Post(t_cost_trans(5, t_cost_branch_a,
+		t_cost_item_of(TRANS_TYPE_SUB_CURRENT_BRANCH_SALE, -15, 20)))
+	t_check_cost(t, "void cost", -120, engine.SaleCosts[5])
+
+	value := t_branch_value(engine, t_cost_branch_a)
+	t_check_cost(t, "quantity", 22, value.Quantity)
+	t_check_cost(t, "value", 176, value.Value)
+}
+
+func TestCostingFollowsTransfers(t *testing.T) {
+	engine := NewCostingEngine(COSTING_METHOD_FIFO)
+	engine.Post(t_cost_trans(1, t_cost_branch_a, t_cost_item_of(TRANS_TYPE_ADD_PURCHASED, 10, 5)))
+	engine.Post(t_cost_trans(2, t_cost_branch_a, t_cost_item_of(TRANS_TYPE_ADD_PURCHASED, 10, 8)))
+
+	// the oldest go in-transit
+	send := t_cost_item_of(TRANS_TYPE_SUB_TRANSFER_SENT, 12, 0)
+	send.OtherBranchId = t_cost_branch_b
+	engine.Post(t_cost_trans(3, t_cost_branch_a, send))
+	t_check_cost(t, "in-transit", 66, engine.InTransitValue())
+
+	receive := t_cost_item_of(TRANS_TYPE_ADD_TRANSFER_RECEIVED, 12, 0)
+	receive.OtherBranchId = t_cost_branch_a
+	engine.Post(t_cost_trans(4, t_cost_branch_b, receive))
+	t_check_cost(t, "in-transit after receipt", 0, engine.InTransitValue())
+
+	// branch b sells the ones that were bought first
+	engine.Post(t_cost_trans(5, t_cost_branch_b,
+		t_cost_item_of(TRANS_TYPE_SUB_CURRENT_BRANCH_SALE, 10, 20)))
+	t_check_cost(t, "sale cost", 50, engine.SaleCosts[5])
+
+	t_check_cost(t, "branch a", 64, t_branch_value(engine, t_cost_branch_a).Value)
+	t_check_cost(t, "branch b", 16, t_branch_value(engine, t_cost_branch_b).Value)
+}
+
+func TestCostingSellingMoreThanOnHand(t *testing.T) {
+	engine := NewCostingEngine(COSTING_METHOD_FIFO)
+	engine.Post(t_cost_trans(1, t_cost_branch_a, t_cost_item_of(TRANS_TYPE_ADD_PURCHASED, 2, 5)))
+	engine.Post(t_cost_trans(2, t_cost_branch_a,
+		t_cost_item_of(TRANS_TYPE_SUB_CURRENT_BRANCH_SALE, 3, 20)))
+
+	// the missing one is at the latest cost
+	t_check_cost(t, "sale cost", 15, engine.SaleCosts[2])
+	value := t_branch_value(engine, t_cost_branch_a)
+	t_check_cost(t, "quantity", -1, value.Quantity)
+	t_check_cost(t, "value", -5, value.Value)
+
+	// the next purchase covers it first
+	engine.Post(t_cost_trans(3, t_cost_branch_a, t_cost_item_of(TRANS_TYPE_ADD_PURCHASED, 4, 6)))
+	value = t_branch_value(engine, t_cost_branch_a)
+	t_check_cost(t, "quantity", 3, value.Quantity)
+	t_check_cost(t, "value", 18, value.Value)
+}
+
+func TestCostingWithoutUnitPrice(t *testing.T) {
+	engine := NewCostingEngine(COSTING_METHOD_FIFO)
+	engine.CostPrices[t_cost_item] = 6
+
+	// it was purchased without a price, it is at the item's cost price
+	engine.Post(t_cost_trans(1, t_cost_branch_a, t_cost_item_of(TRANS_TYPE_ADD_PURCHASED, 10, 0)))
+	t_check_cost(t, "value", 60, t_branch_value(engine, t_cost_branch_a).Value)
+
+	// so is stock found in a branch that never had a cost
+	engine.Post(t_cost_trans(2, t_cost_branch_b, t_cost_item_of(TRANS_TYPE_ADD_FOUND, 2, 0)))
+	t_check_cost(t, "found value", 12, t_branch_value(engine, t_cost_branch_b).Value)
+}
+
+func TestCostingReplaysCorrectionsByDate(t *testing.T) {
+	engine := NewCostingEngine(COSTING_METHOD_FIFO)
+	transactions := t_cost_purchases_and_sale()
+	for i, trans := range transactions {
+		trans.Date = int64(100 * (i + 1))
+	}
+	// a reconcile found 3 more in the branch after the first purchase, they
+	// come in at the current cost so the sale takes them after the first purchase
+	corrections := StockCorrectionEntries(0, &ShStockDrift{BranchId: t_cost_branch_a,
+		ItemId: t_cost_item, ExpectedQuantity: 13, LedgerQuantity: 10}, 150)
+
+	engine.Replay(transactions, corrections)
+
+	// 10 @ 5 + 3 @ 5 + 2 @ 8
+	t_check_cost(t, "sale cost", 81, engine.SaleCosts[3])
+	value := t_branch_value(engine, t_cost_branch_a)
+	t_check_cost(t, "quantity", 8, value.Quantity)
+	t_check_cost(t, "value", 64, value.Value)
+}
+
+func TestCostingRestoresLayers(t *testing.T) {
+	engine := NewCostingEngine(COSTING_METHOD_FIFO)
+	engine.Post(t_cost_trans(1, t_cost_branch_a, t_cost_item_of(TRANS_TYPE_ADD_PURCHASED, 10, 5)))
+	engine.Post(t_cost_trans(2, t_cost_branch_a, t_cost_item_of(TRANS_TYPE_ADD_PURCHASED, 10, 8)))
+	// branch b sells what it doesn't have, it only has a deficit
+	engine.Post(t_cost_trans(3, t_cost_branch_b, t_cost_item_of(TRANS_TYPE_ADD_PURCHASED, 1, 4)))
+	engine.Post(t_cost_trans(4, t_cost_branch_b,
+		t_cost_item_of(TRANS_TYPE_SUB_CURRENT_BRANCH_SALE, 3, 20)))
+
+	restored := NewCostingEngine(COSTING_METHOD_FIFO)
+	restored.Restore(engine.Layers())
+
+	// both continue the same
+	for _, e := range []*CostingEngine{engine, restored} {
+		e.Post(t_cost_trans(5, t_cost_branch_a,
+			t_cost_item_of(TRANS_TYPE_SUB_CURRENT_BRANCH_SALE, 12, 20)))
+		e.Post(t_cost_trans(6, t_cost_branch_b, t_cost_item_of(TRANS_TYPE_ADD_PURCHASED, 3, 6)))
+	}
+	t_check_cost(t, "sale cost", engine.SaleCosts[5], restored.SaleCosts[5])
+	t_check_cost(t, "sale cost", 66, restored.SaleCosts[5])
+	for _, branch_id := range []int{t_cost_branch_a, t_cost_branch_b} {
+		expected, got := t_branch_value(engine, branch_id), t_branch_value(restored, branch_id)
+		if *expected != *got {
+			t.Errorf("branch %d, expected %+v, got %+v", branch_id, expected, got)
+		}
+	}
+	t_check_cost(t, "branch b", 6, t_branch_value(restored, t_cost_branch_b).Value)
+}
@@ -21,6 +21,15 @@ const (
 	TABLE_TRANSACTION      = "s_business_transaction"
 	TABLE_TRANSACTION_ITEM = "s_business_transaction_item"
 	TABLE_ENTITY_REVISION  = "s_table_entity_revision"
+	TABLE_STOCK_LEDGER     = "s_stock_ledger"
+	TABLE_STOCK_SNAPSHOT   = "s_stock_snapshot"
+	TABLE_COST_SNAPSHOT    = "s_stock_cost_snapshot"
+	TABLE_STOCK_TAKE       = "s_stock_take"
+	TABLE_STOCK_TAKE_ITEM  = "s_stock_take_item"
+	TABLE_TRANSFER         = "s_transfer"
+	TABLE_TRANSFER_ITEM    = "s_transfer_item"
+	TABLE_ITEM_PRICE       = "s_item_price"
+	TABLE_ITEM_UNIT        = "s_item_unit"
 )
 
 // Objects that implement this interface can be used as
@@ -98,6 +107,18 @@ func ConnectDbStore() (*dbStore, error) {
 		}
 	}
 
+	// for statements that can fail on an existing database that still works
+	// without them, e.g. an index that needs a migration from useful_stuff first
+	try_exec := func(q string, args ...interface{}) {
+		if err != nil {
+			return
+		}
+
+		if _, exec_err := db.Exec(q, args...); exec_err != nil {
+			fmt.Printf("'%s' '%s', skipping it\n", q, exec_err.Error())
+		}
+	}
+
 	exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s ( "+
 		// user-table
 		"user_id				SERIAL PRIMARY KEY, "+
@@ -116,7 +137,8 @@ func ConnectDbStore() (*dbStore, error) {
 		// company-table
 		"company_id		SERIAL PRIMARY KEY, "+
 		"company_name	TEXT NOT NULL, "+
-		"encoded_payment	TEXT); ", TABLE_COMPANY))
+		"encoded_payment	TEXT, "+
+		"costing_method		INTEGER NOT NULL DEFAULT %d); ", TABLE_COMPANY, COSTING_METHOD_FIFO))
 
 	exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s ( "+
 		// user-permission-table
@@ -133,6 +155,7 @@ func ConnectDbStore() (*dbStore, error) {
 		"branch_name	TEXT NOT NULL, "+
 		"location 		TEXT, "+
 		_db_status_flag+" INTEGER DEFAULT %d, "+
+		_db_version+" INTEGER NOT NULL DEFAULT 1, "+
 
 		"UNIQUE(company_id, branch_name));",
 		TABLE_BRANCH, TABLE_COMPANY, STATUS_VISIBLE))
@@ -143,8 +166,10 @@ func ConnectDbStore() (*dbStore, error) {
 		"client_uuid	uuid, "+
 		"company_id		INTEGER REFERENCES %s(company_id), "+
 		"name			TEXT NOT NULL, "+
+		_db_status_flag+" INTEGER DEFAULT %d, "+
+		_db_version+" INTEGER NOT NULL DEFAULT 1, "+
 		"parent_id		INTEGER REFERENCES %s(category_id));",
-		TABLE_CATEGORY, TABLE_COMPANY, TABLE_CATEGORY))
+		TABLE_CATEGORY, TABLE_COMPANY, STATUS_VISIBLE, TABLE_CATEGORY))
 	if err = checkRootCategoryCreated(db); err != nil {
 		return nil, err
 	}
@@ -178,10 +203,24 @@ func ConnectDbStore() (*dbStore, error) {
 		_db_item_part_number+" TEXT, "+
 		_db_item_bar_code+" TEXT, "+
 		_db_item_has_bar_code+" bool, "+
-		_db_status_flag+" INTEGER DEFAULT %d"+
+		_db_status_flag+" INTEGER DEFAULT %d, "+
+		_db_version+" INTEGER NOT NULL DEFAULT 1, "+
+
+		_db_item_cost_price+" REAL NOT NULL DEFAULT 0, "+
+		_db_item_selling_price+" REAL NOT NULL DEFAULT 0"+
 		"); ",
 		TABLE_INVENTORY_ITEM, TABLE_COMPANY, SERVER_ROOT_CATEGORY_ID, TABLE_CATEGORY, STATUS_VISIBLE))
 
+	// bar codes are unique within a company, items without one have it empty.
+	// Databases with duplicate bar codes need migrate_unique_item_bar_code first,
+	// until then duplicates are only caught by _checkUniqueItemCodesInTx.
+	try_exec(fmt.Sprintf("create unique index if not exists %s on %s (%s, %s) "+
+		"where %s <> '';",
+		_idx_item_bar_code, TABLE_INVENTORY_ITEM,
+		_db_item_company_id, _db_item_bar_code, _db_item_bar_code))
+
+	createItemSearchIndexes(db, exec, try_exec)
+
 	exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s ( "+
 		// branch-item table
 		"company_id		INTEGER REFERENCES %s(company_id), "+
@@ -189,9 +228,41 @@ func ConnectDbStore() (*dbStore, error) {
 		"item_id		INTEGER references %s("+_db_item_id+"), "+
 		"quantity		REAL NOT NULL, "+
 		"item_location		TEXT, "+
+		// if not null, overrides the item's reorder level in this branch
+		"reorder_level		REAL, "+
+		// if not null, overrides the item's selling price in this branch
+		"selling_price		REAL, "+
 		"unique(branch_id, item_id));",
 		TABLE_BRANCH_ITEM, TABLE_COMPANY, TABLE_BRANCH, TABLE_INVENTORY_ITEM))
 
+	// the units of an item other than its derived unit, see item_unit.go
+	exec(fmt.Sprintf("create table if not exists %s ( "+
+		"unit_id			SERIAL PRIMARY KEY, "+
+		"company_id			INTEGER REFERENCES %s(company_id), "+
+		"item_id			INTEGER REFERENCES %s(item_id), "+
+		"unit_name			TEXT NOT NULL, "+
+		"factor				REAL NOT NULL);",
+		TABLE_ITEM_UNIT, TABLE_COMPANY, TABLE_INVENTORY_ITEM))
+
+	exec(fmt.Sprintf("create index if not exists %s_item_idx on %s (item_id);",
+		TABLE_ITEM_UNIT, TABLE_ITEM_UNIT))
+
+	// the history of item prices, see item_price.go
+	exec(fmt.Sprintf("create table if not exists %s ( "+
+		"price_id			SERIAL PRIMARY KEY, "+
+		"company_id			INTEGER REFERENCES %s(company_id), "+
+		"item_id			INTEGER REFERENCES %s(item_id), "+
+		// null for the item's prices, set for a branch's override
+		"branch_id			INTEGER REFERENCES %s(branch_id), "+
+		"cost_price			REAL, "+
+		"selling_price		REAL, "+
+		"effective_date		INTEGER NOT NULL);",
+		TABLE_ITEM_PRICE, TABLE_COMPANY, TABLE_INVENTORY_ITEM, TABLE_BRANCH))
+
+	exec(fmt.Sprintf("create index if not exists %s_item_idx "+
+		"on %s (company_id, item_id, effective_date);",
+		TABLE_ITEM_PRICE, TABLE_ITEM_PRICE))
+
 	/**
 	 */
 	exec(fmt.Sprintf("create table if not exists %s ( "+
@@ -202,8 +273,37 @@ func ConnectDbStore() (*dbStore, error) {
 		"branch_id				INTEGER REFERENCES %s(branch_id), "+
 		"user_id				INTEGER REFERENCES %s(user_id), "+
 		"t_date 				INTEGER, "+
-		"trans_note				TEXT);",
-		TABLE_TRANSACTION, TABLE_COMPANY, TABLE_BRANCH, TABLE_USER))
+		"trans_note				TEXT, "+
+		// see ShTransaction for the meaning of these
+		"linked_trans_id		INTEGER REFERENCES %s(transaction_id), "+
+		"is_void				BOOL NOT NULL DEFAULT false, "+
+		"voided_by_trans_id		INTEGER REFERENCES %s(transaction_id));",
+		TABLE_TRANSACTION, TABLE_COMPANY, TABLE_BRANCH, TABLE_USER,
+		TABLE_TRANSACTION, TABLE_TRANSACTION))
+
+	// speeds-up browsing the transaction history by date
+	exec(fmt.Sprintf("create index if not exists %s_company_date_idx "+
+		"on %s (company_id, t_date);",
+		TABLE_TRANSACTION, TABLE_TRANSACTION))
+
+	/**
+	 * A client_uuid lets a device re-post an entity it didn't get an acknowledgement
+	 * for, it only needs to be unique within a company as the lookups are scoped by it.
+	 * Databases with duplicates from before need migrate_unique_client_uuid first,
+	 * until then the lookup before inserting is what keeps re-posts from duplicating.
+	 */
+	for _, table := range []string{TABLE_BRANCH, TABLE_CATEGORY, TABLE_INVENTORY_ITEM, TABLE_TRANSACTION} {
+		q := fmt.Sprintf("create unique index if not exists %s_company_uuid_idx "+
+			"on %s (company_id, client_uuid);",
+			table, table)
+		if err != nil {
+			break
+		}
+		if _, exec_err := db.Exec(q); exec_err != nil {
+			fmt.Printf("'%s' '%s', skipping it. Remove the duplicates with "+
+				"useful_stuff/migrate_unique_client_uuid.txt\n", q, exec_err.Error())
+		}
+	}
 
 	/**
 	 * Transaction items looks like
@@ -225,6 +325,8 @@ func ConnectDbStore() (*dbStore, error) {
 	 * {@column other_branch_id} if the transaction affects the inventory of
 	 * 						other branches, (e.g: if it mentions warehouse inventory this will be the warehouse id}
 	 * {@column quantity} is the number of {@column item_id} in the transaction
+	 * {@column line_total} is quantity * unit_price - discount
+	 * {@column unit_quantity} is the quantity in the unit it was posted in, see item_unit.go
 	 */
 	exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s ( "+
 		// transaction-items table
@@ -234,9 +336,132 @@ func ConnectDbStore() (*dbStore, error) {
 		"item_id			INTEGER REFERENCES %s(item_id), "+
 		"other_branch_id 	INTEGER, "+
 		"quantity 			REAL NOT NULL, "+
-		"item_note	 		TEXT);",
+		"item_note	 		TEXT, "+
+		"unit_price			REAL NOT NULL DEFAULT 0, "+
+		"discount			REAL NOT NULL DEFAULT 0, "+
+		"line_total			REAL NOT NULL DEFAULT 0, "+
+		"unit_id			INTEGER NOT NULL DEFAULT 0, "+
+		"unit_quantity		REAL, "+
+		"unit_name			TEXT);",
 		TABLE_TRANSACTION_ITEM, TABLE_COMPANY, TABLE_TRANSACTION, TABLE_INVENTORY_ITEM))
 
+	/**
+	 * The stock ledger, see stock_ledger.go
+	 * {@column branch_id} is null for the accounts outside the company
+	 * {@column quantity} is positive if the stock entered the account, negative if it left
+	 */
+	exec(fmt.Sprintf("create table if not exists %s ( "+
+		"entry_id			SERIAL PRIMARY KEY, "+
+		"company_id			integer references %s(company_id), "+
+		"transaction_id 	INTEGER REFERENCES %s(transaction_id), "+
+		"item_id			INTEGER REFERENCES %s(item_id), "+
+		"account			INTEGER NOT NULL, "+
+		"branch_id			INTEGER REFERENCES %s(branch_id), "+
+		"quantity 			REAL NOT NULL, "+
+		"t_date 			INTEGER);",
+		TABLE_STOCK_LEDGER, TABLE_COMPANY, TABLE_TRANSACTION, TABLE_INVENTORY_ITEM, TABLE_BRANCH))
+
+	// speeds-up summing the quantity of an item in a branch
+	exec(fmt.Sprintf("create index if not exists %s_branch_item_idx "+
+		"on %s (company_id, branch_id, item_id);",
+		TABLE_STOCK_LEDGER, TABLE_STOCK_LEDGER))
+
+	// speeds-up summing the entries up to a date
+	exec(fmt.Sprintf("create index if not exists %s_company_date_idx "+
+		"on %s (company_id, t_date);",
+		TABLE_STOCK_LEDGER, TABLE_STOCK_LEDGER))
+
+	/**
+	 * The quantity of every item in every branch at {@column snapshot_date}, see stock_snapshot.go
+	 */
+	exec(fmt.Sprintf("create table if not exists %s ( "+
+		"company_id			integer references %s(company_id), "+
+		"snapshot_date 		INTEGER NOT NULL, "+
+		"branch_id			INTEGER REFERENCES %s(branch_id), "+
+		"item_id			INTEGER REFERENCES %s(item_id), "+
+		"quantity 			REAL NOT NULL, "+
+		"unique(company_id, snapshot_date, branch_id, item_id));",
+		TABLE_STOCK_SNAPSHOT, TABLE_COMPANY, TABLE_BRANCH, TABLE_INVENTORY_ITEM))
+
+	/**
+	 * The cost layers of every item at {@column snapshot_date}, see costing.go
+	 */
+	exec(fmt.Sprintf("create table if not exists %s ( "+
+		"company_id			integer references %s(company_id), "+
+		"snapshot_date 		INTEGER NOT NULL, "+
+		"costing_method		INTEGER NOT NULL, "+
+		"account			INTEGER NOT NULL, "+
+		"branch_id			INTEGER REFERENCES %s(branch_id), "+
+		"item_id			INTEGER REFERENCES %s(item_id), "+
+		"layer				INTEGER NOT NULL, "+
+		"quantity 			REAL NOT NULL, "+
+		"unit_cost 			REAL NOT NULL, "+
+		"last_cost 			REAL NOT NULL, "+
+		"deficit 			REAL NOT NULL, "+
+		"unique(company_id, snapshot_date, costing_method, account, branch_id, item_id, layer));",
+		TABLE_COST_SNAPSHOT, TABLE_COMPANY, TABLE_BRANCH, TABLE_INVENTORY_ITEM))
+
+	// see stock_take.go
+	exec(fmt.Sprintf("create table if not exists %s ( "+
+		"stock_take_id		SERIAL PRIMARY KEY, "+
+		"company_id			integer references %s(company_id), "+
+		"branch_id			INTEGER REFERENCES %s(branch_id), "+
+		"status				INTEGER NOT NULL, "+
+		"created_by			INTEGER REFERENCES %s(user_id), "+
+		"created_date		INTEGER, "+
+		"approved_by		INTEGER, "+
+		"approved_date		INTEGER, "+
+		"transaction_id		INTEGER REFERENCES %s(transaction_id));",
+		TABLE_STOCK_TAKE, TABLE_COMPANY, TABLE_BRANCH, TABLE_USER, TABLE_TRANSACTION))
+
+	// a branch can only be counted by one stock take at a time
+	exec(fmt.Sprintf("create unique index if not exists %s_open_idx "+
+		"on %s (branch_id) where status = %d;",
+		TABLE_STOCK_TAKE, TABLE_STOCK_TAKE, STOCK_TAKE_OPEN))
+
+	exec(fmt.Sprintf("create table if not exists %s ( "+
+		"stock_take_id		INTEGER REFERENCES %s(stock_take_id), "+
+		"item_id			INTEGER REFERENCES %s(item_id), "+
+		"counted_quantity	REAL NOT NULL, "+
+		"counted_by			INTEGER REFERENCES %s(user_id), "+
+		"recorded_quantity	REAL, "+
+		"unique(stock_take_id, item_id));",
+		TABLE_STOCK_TAKE_ITEM, TABLE_STOCK_TAKE, TABLE_INVENTORY_ITEM, TABLE_USER))
+
+	// see transfer.go
+	exec(fmt.Sprintf("create table if not exists %s ( "+
+		"transfer_id		SERIAL PRIMARY KEY, "+
+		"company_id			integer references %s(company_id), "+
+		"client_uuid		uuid, "+
+		"from_branch_id		INTEGER REFERENCES %s(branch_id), "+
+		"to_branch_id		INTEGER REFERENCES %s(branch_id), "+
+		"status				INTEGER NOT NULL, "+
+		"transfer_note		TEXT, "+
+		"sent_by			INTEGER REFERENCES %s(user_id), "+
+		"sent_date			INTEGER, "+
+		"send_trans_id		INTEGER REFERENCES %s(transaction_id), "+
+		"received_by		INTEGER, "+
+		"received_date		INTEGER, "+
+		"receive_trans_id	INTEGER REFERENCES %s(transaction_id));",
+		TABLE_TRANSFER, TABLE_COMPANY, TABLE_BRANCH, TABLE_BRANCH, TABLE_USER,
+		TABLE_TRANSACTION, TABLE_TRANSACTION))
+
+	exec(fmt.Sprintf("create unique index if not exists %s_company_uuid_idx "+
+		"on %s (company_id, client_uuid);",
+		TABLE_TRANSFER, TABLE_TRANSFER))
+
+	exec(fmt.Sprintf("create index if not exists %s_pending_idx "+
+		"on %s (company_id) where status = %d;",
+		TABLE_TRANSFER, TABLE_TRANSFER, TRANSFER_STATUS_SENT))
+
+	exec(fmt.Sprintf("create table if not exists %s ( "+
+		"transfer_id		INTEGER REFERENCES %s(transfer_id), "+
+		"item_id			INTEGER REFERENCES %s(item_id), "+
+		"sent_quantity		REAL NOT NULL, "+
+		"received_quantity	REAL, "+
+		"unique(transfer_id, item_id));",
+		TABLE_TRANSFER_ITEM, TABLE_TRANSFER, TABLE_INVENTORY_ITEM))
+
 	exec(fmt.Sprintf("create table if not exists %s ( "+
 		"company_id			integer references %s(company_id), "+
 		"revision_number 	integer not null, "+
@@ -0,0 +1,33 @@
+package models
+
+import "testing"
+
+func TestUpdateOnChangedVersionConflicts(t *testing.T) {
+	db, store, teardown := t_fake_store(t)
+	defer teardown()
+
+	// the version moved on, so nothing matches
+	db.expect("where branch_id = $4 AND  version  = $5").returns("version")
+	db.expect("where category_id = $4 AND  version  = $5").returns("version", t_row(int64(6)))
+
+	tnx, err := store.Begin()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer tnx.Rollback()
+
+	branch := &ShBranch{BranchId: 2, Name: "merkato", Version: 4}
+	if _, err = store.UpdateBranchInTx(tnx, branch); err != ErrVersionConflict {
+		t.Errorf("expected a version conflict, got %v", err)
+	}
+	if args := db.args[0]; len(args) != 5 || args[4] != int64(4) {
+		t.Errorf("expected the update to be on version 4, got %v", args)
+	}
+
+	category := &ShCategory{CategoryId: 7, Name: "brakes", Version: 5}
+	if _, err = store.UpdateCategoryInTx(tnx, category); err != nil {
+		t.Errorf("%v", err)
+	} else if category.Version != 6 {
+		t.Errorf("expected the new version 6, got %d", category.Version)
+	}
+}
@@ -0,0 +1,202 @@
+package models
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+/**
+ * A database/sql driver that answers statements from a list of expectations,
+ * in the order they were added. It stands in for postgres in the tests, so it
+ * only checks which statements a store function runs and feeds back the rows
+ * it would have got. The SQL itself is never interpreted.
+ */
+type t_fake_db struct {
+	t *testing.T
+
+	mu       sync.Mutex
+	expected []*t_fake_statement
+	// the queries and args of every statement that ran, in order
+	queries []string
+	args    [][]driver.Value
+}
+
+type t_fake_statement struct {
+	// the statement has to contain it
+	query_part string
+
+	columns       []string
+	rows          [][]driver.Value
+	rows_affected int64
+	err           error
+	// the rows end with it instead of io.EOF
+	rows_err error
+}
+
+func (e *t_fake_statement) returns(columns string, rows ...[]driver.Value) *t_fake_statement {
+	e.columns = _cols(columns)
+	e.rows = rows
+	return e
+}
+
+func (e *t_fake_statement) affects(rows_affected int64) *t_fake_statement {
+	e.rows_affected = rows_affected
+	return e
+}
+
+func (e *t_fake_statement) fails(err error) *t_fake_statement {
+	e.err = err
+	return e
+}
+
+// the rows returned so far are read, then iterating them fails, e.g: the connection dropped
+func (e *t_fake_statement) breaks(err error) *t_fake_statement {
+	e.rows_err = err
+	return e
+}
+
+func _cols(s string) []string {
+	subs := strings.Split(s, ",")
+	for i := 0; i < len(subs); i++ {
+		subs[i] = strings.TrimSpace(subs[i])
+	}
+	return subs
+}
+
+func t_row(values ...driver.Value) []driver.Value {
+	return values
+}
+
+var (
+	t_fake_dbs_mu sync.Mutex
+	t_fake_dbs    = make(map[string]*t_fake_db)
+)
+
+func init() {
+	sql.Register("sheket_fake", t_fake_driver{})
+}
+
+/**
+ * Returns a store on top of a new fake db, the teardown fails the test if
+ * some of the expected statements didn't run.
+ */
+func t_fake_store(t *testing.T) (*t_fake_db, *shStore, func()) {
+	fake := &t_fake_db{t: t}
+
+	t_fake_dbs_mu.Lock()
+	name := fmt.Sprintf("%s-%d", t.Name(), len(t_fake_dbs))
+	t_fake_dbs[name] = fake
+	t_fake_dbs_mu.Unlock()
+
+	db, err := sql.Open("sheket_fake", name)
+	if err != nil {
+		t.Fatalf("open fake db %v", err)
+	}
+
+	return fake, &shStore{db}, func() {
+		db.Close()
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+		for _, e := range fake.expected {
+			t.Errorf("expected statement didn't run: %q", e.query_part)
+		}
+	}
+}
+
+func (f *t_fake_db) expect(query_part string) *t_fake_statement {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	e := &t_fake_statement{query_part: query_part}
+	f.expected = append(f.expected, e)
+	return e
+}
+
+func (f *t_fake_db) next(query string, args []driver.Value) (*t_fake_statement, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(f.expected) == 0 {
+		f.t.Errorf("unexpected statement %q", query)
+		return nil, fmt.Errorf("unexpected statement")
+	}
+	e := f.expected[0]
+	if !strings.Contains(query, e.query_part) {
+		f.t.Errorf("expected a statement with %q, got %q", e.query_part, query)
+		return nil, fmt.Errorf("unexpected statement")
+	}
+	f.expected = f.expected[1:]
+	f.queries = append(f.queries, query)
+	f.args = append(f.args, args)
+	return e, e.err
+}
+
+type t_fake_driver struct{}
+
+func (t_fake_driver) Open(name string) (driver.Conn, error) {
+	t_fake_dbs_mu.Lock()
+	defer t_fake_dbs_mu.Unlock()
+	return &t_fake_conn{t_fake_dbs[name]}, nil
+}
+
+type t_fake_conn struct {
+	db *t_fake_db
+}
+
+func (c *t_fake_conn) Prepare(query string) (driver.Stmt, error) {
+	return &t_fake_stmt{c.db, query}, nil
+}
+func (c *t_fake_conn) Close() error              { return nil }
+func (c *t_fake_conn) Begin() (driver.Tx, error) { return c, nil }
+func (c *t_fake_conn) Commit() error             { return nil }
+func (c *t_fake_conn) Rollback() error           { return nil }
+
+type t_fake_stmt struct {
+	db    *t_fake_db
+	query string
+}
+
+func (s *t_fake_stmt) Close() error  { return nil }
+func (s *t_fake_stmt) NumInput() int { return -1 }
+
+func (s *t_fake_stmt) Exec(args []driver.Value) (driver.Result, error) {
+	e, err := s.db.next(s.query, args)
+	if err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(e.rows_affected), nil
+}
+
+func (s *t_fake_stmt) Query(args []driver.Value) (driver.Rows, error) {
+	e, err := s.db.next(s.query, args)
+	if err != nil {
+		return nil, err
+	}
+	return &t_fake_rows{columns: e.columns, rows: e.rows, err: e.rows_err}, nil
+}
+
+type t_fake_rows struct {
+	columns []string
+	rows    [][]driver.Value
+	err     error
+}
+
+func (r *t_fake_rows) Columns() []string { return r.columns }
+func (r *t_fake_rows) Close() error      { return nil }
+
+func (r *t_fake_rows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		if r.err != nil {
+			return r.err
+		}
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
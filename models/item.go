@@ -3,6 +3,7 @@ package models
 import (
 	"database/sql"
 	"fmt"
+	"github.com/lib/pq"
 	"strings"
 )
 
@@ -20,12 +21,22 @@ type ShItem struct {
 	DerivedFactor     float64
 	ReorderLevel      float64
 
+	// the units other than DerivedName, they are saved along with the item
+	Units []*ShItemUnit
+
+	// the selling price can be overridden per branch, see ShBranchItem
+	CostPrice    float64
+	SellingPrice float64
+
 	ModelYear  string
 	PartNumber string
 	BarCode    string
 	HasBarCode bool
 
 	StatusFlag int
+
+	// incremented on every update, used to detect conflicting edits
+	Version int
 }
 
 const (
@@ -42,12 +53,86 @@ const (
 	_db_item_derived_factor   = " derived_factor "
 	_db_item_reorder_level    = " reorder_level "
 
+	_db_item_cost_price    = " cost_price "
+	_db_item_selling_price = " selling_price "
+
 	_db_item_model_year   = " model_year "
 	_db_item_part_number  = " part_number "
 	_db_item_bar_code     = " bar_code "
 	_db_item_has_bar_code = " has_bar_code "
+
+	_idx_item_bar_code = "s_inventory_item_company_bar_code_idx"
 )
 
+// If set to 1, item codes must also be unique within a company. It is off by default
+// because some companies use the same code for different variations of an item.
+var unique_item_codes = getEnvironmentConstant("UNIQUE_ITEM_CODES", 0) == 1
+
+/**
+ * Checks that no other item in the company uses the item's bar code(or item code).
+ * This is checked before writing the item because a failed insert/update
+ * aborts the whole transaction.
+ */
+func _checkUniqueItemCodesInTx(tnx *sql.Tx, item *ShItem) error {
+	if len(item.BarCode) > 0 {
+		msg := fmt.Sprintf("checking bar code of item:%d", item.ItemId)
+		_, err := _queryInventoryItemsInTx(tnx, msg,
+			"where "+_db_item_company_id+" = $1 AND "+_db_item_bar_code+" = $2 AND "+
+				_db_item_id+" <> $3",
+			item.CompanyId, item.BarCode, item.ItemId)
+		if err == nil {
+			return ErrDuplicateBarCode
+		} else if err != ErrNoData {
+			return err
+		}
+	}
+
+	if unique_item_codes && len(item.ItemCode) > 0 {
+		msg := fmt.Sprintf("checking item code of item:%d", item.ItemId)
+		_, err := _queryInventoryItemsInTx(tnx, msg,
+			"where "+_db_item_company_id+" = $1 AND "+_db_item_code+" = $2 AND "+
+				_db_item_id+" <> $3",
+			item.CompanyId, item.ItemCode, item.ItemId)
+		if err == nil {
+			return ErrDuplicateItemCode
+		} else if err != ErrNoData {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// converts a violation of the bar code index(if another device just added the
+// same bar code) to ErrDuplicateBarCode
+func _checkBarCodeViolation(err error) error {
+	if pq_err, ok := err.(*pq.Error); ok &&
+		pq_err.Code.Name() == "unique_violation" &&
+		pq_err.Constraint == _idx_item_bar_code {
+		return ErrDuplicateBarCode
+	}
+	return err
+}
+
+/**
+ * Runs write inside a savepoint. On postgres a failed statement aborts the
+ * whole transaction, rolling back to the savepoint keeps the transaction
+ * usable so the caller can reject just this item and carry on.
+ */
+func _writeInSavepointInTx(tnx *sql.Tx, name string, write func() error) error {
+	if _, err := tnx.Exec("savepoint " + name); err != nil {
+		return err
+	}
+	if err := write(); err != nil {
+		if _, rb_err := tnx.Exec("rollback to savepoint " + name); rb_err != nil {
+			return rb_err
+		}
+		return err
+	}
+	_, err := tnx.Exec("release savepoint " + name)
+	return err
+}
+
 const (
 	// Shared across entities that can have this field. Currently used to track if is
 	// visible/invisible. This is an integer, default value is STATUS_VISIBLE.
@@ -55,6 +140,11 @@ const (
 
 	STATUS_VISIBLE    int = 1
 	STATUS_IN_VISIBLE int = 2
+
+	// Shared across entities that can be edited from multiple devices. It starts
+	// at 1 and is incremented on every update, so a client that sends an older
+	// version has edited a stale copy.
+	_db_version = " version "
 )
 
 func _checkItemArrError(items []*ShItem, err error) ([]*ShItem, error) {
@@ -73,63 +163,96 @@ func _checkItemArrError(items []*ShItem, err error) ([]*ShItem, error) {
 }
 
 func (s *shStore) CreateItemInTx(tnx *sql.Tx, item *ShItem) (*ShItem, error) {
-	err := tnx.QueryRow(
-		"insert into "+TABLE_INVENTORY_ITEM+" ( "+
-			_db_item_client_uuid+", "+
-			_db_item_company_id+", "+
-			_db_item_category_id+", "+
-			_db_item_name+", "+
-			_db_item_code+", "+
-
-			_db_item_units+", "+
-			_db_item_has_derived_unit+", "+
-			_db_item_derived_name+", "+
-			_db_item_derived_factor+", "+
-			_db_item_reorder_level+", "+
-
-			_db_item_model_year+", "+
-			_db_item_part_number+", "+
-			_db_item_bar_code+", "+
-			_db_item_has_bar_code+", "+
-			_db_status_flag+") VALUES "+
-			"($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15) "+
-			"returning "+_db_item_id+";",
-		item.ClientUUID, item.CompanyId, item.CategoryId, item.Name, item.ItemCode,
-		item.UnitOfMeasurement, item.HasDerivedUnit, item.DerivedName, item.DerivedFactor, item.ReorderLevel,
-		item.ModelYear, item.PartNumber, item.BarCode, item.HasBarCode, item.StatusFlag).
-		Scan(&item.ItemId)
-	return item, err
+	if err := _checkUniqueItemCodesInTx(tnx, item); err != nil {
+		return nil, err
+	}
+
+	err := _writeInSavepointInTx(tnx, "item_write", func() error {
+		return _checkBarCodeViolation(tnx.QueryRow(
+			"insert into "+TABLE_INVENTORY_ITEM+" ( "+
+				_db_item_client_uuid+", "+
+				_db_item_company_id+", "+
+				_db_item_category_id+", "+
+				_db_item_name+", "+
+				_db_item_code+", "+
+
+				_db_item_units+", "+
+				_db_item_has_derived_unit+", "+
+				_db_item_derived_name+", "+
+				_db_item_derived_factor+", "+
+				_db_item_reorder_level+", "+
+
+				_db_item_model_year+", "+
+				_db_item_part_number+", "+
+				_db_item_bar_code+", "+
+				_db_item_has_bar_code+", "+
+				_db_status_flag+", "+
+
+				_db_item_cost_price+", "+
+				_db_item_selling_price+") VALUES "+
+				"($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17) "+
+				"returning "+_db_item_id+", "+_db_version+";",
+			item.ClientUUID, item.CompanyId, item.CategoryId, item.Name, item.ItemCode,
+			item.UnitOfMeasurement, item.HasDerivedUnit, item.DerivedName, item.DerivedFactor, item.ReorderLevel,
+			item.ModelYear, item.PartNumber, item.BarCode, item.HasBarCode, item.StatusFlag,
+			item.CostPrice, item.SellingPrice).
+			Scan(&item.ItemId, &item.Version))
+	})
+	if err != nil {
+		return item, err
+	}
+	return item, _saveItemUnitsInTx(tnx, item)
 }
 
 func (s *shStore) UpdateItemInTx(tnx *sql.Tx, item *ShItem) (*ShItem, error) {
-	_, err := tnx.Exec(
-		"update "+TABLE_INVENTORY_ITEM+" set "+
-			_db_item_name+" = $1, "+
-			_db_item_code+" = $2, "+
-			_db_item_category_id+" = $3, "+
-
-			_db_item_units+" = $4, "+
-			_db_item_has_derived_unit+" = $5, "+
-			_db_item_derived_name+" = $6, "+
-			_db_item_derived_factor+" = $7, "+
-			_db_item_reorder_level+" = $8, "+
-
-			_db_item_model_year+" = $9, "+
-			_db_item_part_number+" = $10, "+
-			_db_item_bar_code+" = $11, "+
-			_db_item_has_bar_code+" = $12, "+
-			_db_status_flag+" = $13 "+
-			" where "+_db_item_id+" = $14",
-		item.Name, item.ItemCode, item.CategoryId,
-		item.UnitOfMeasurement, item.HasDerivedUnit, item.DerivedName, item.DerivedFactor, item.ReorderLevel,
-		item.ModelYear, item.PartNumber, item.BarCode, item.HasBarCode, item.StatusFlag,
-		item.ItemId)
-	return item, err
+	if err := _checkUniqueItemCodesInTx(tnx, item); err != nil {
+		return nil, err
+	}
+
+	err := _writeInSavepointInTx(tnx, "item_write", func() error {
+		err := tnx.QueryRow(
+			"update "+TABLE_INVENTORY_ITEM+" set "+
+				_db_item_name+" = $1, "+
+				_db_item_code+" = $2, "+
+				_db_item_category_id+" = $3, "+
+
+				_db_item_units+" = $4, "+
+				_db_item_has_derived_unit+" = $5, "+
+				_db_item_derived_name+" = $6, "+
+				_db_item_derived_factor+" = $7, "+
+				_db_item_reorder_level+" = $8, "+
+
+				_db_item_model_year+" = $9, "+
+				_db_item_part_number+" = $10, "+
+				_db_item_bar_code+" = $11, "+
+				_db_item_has_bar_code+" = $12, "+
+				_db_status_flag+" = $13, "+
+
+				_db_item_cost_price+" = $14, "+
+				_db_item_selling_price+" = $15, "+
+				_db_version+" = "+_db_version+" + 1 "+
+				" where "+_db_item_id+" = $16 AND "+_db_version+" = $17 "+
+				"returning "+_db_version,
+			item.Name, item.ItemCode, item.CategoryId,
+			item.UnitOfMeasurement, item.HasDerivedUnit, item.DerivedName, item.DerivedFactor, item.ReorderLevel,
+			item.ModelYear, item.PartNumber, item.BarCode, item.HasBarCode, item.StatusFlag,
+			item.CostPrice, item.SellingPrice,
+			item.ItemId, item.Version).Scan(&item.Version)
+		if err == sql.ErrNoRows {
+			// another update changed the version since it was read
+			return ErrVersionConflict
+		}
+		return _checkBarCodeViolation(err)
+	})
+	if err != nil {
+		return item, err
+	}
+	return item, _saveItemUnitsInTx(tnx, item)
 }
 
-func (s *shStore) GetItemByUUIDInTx(tnx *sql.Tx, uid string) (*ShItem, error) {
-	msg := fmt.Sprintf("no item with that uuid:%s", uid)
-	items, err := _queryInventoryItemsInTx(tnx, msg, "where client_uuid = $1", uid)
+func (s *shStore) GetItemByUUIDInTx(tnx *sql.Tx, company_id int, uid string) (*ShItem, error) {
+	msg := fmt.Sprintf("no item with that uuid:%s in company:%d", uid, company_id)
+	items, err := _queryInventoryItemsInTx(tnx, msg, "where company_id = $1 AND client_uuid = $2", company_id, uid)
 
 	items, err = _checkItemArrError(items, err)
 	if err != nil {
@@ -151,6 +274,20 @@ func (s *shStore) GetItemById(id int) (*ShItem, error) {
 	return items[0], nil
 }
 
+func (s *shStore) GetItemByBarCode(company_id int, bar_code string) (*ShItem, error) {
+	msg := fmt.Sprintf("no item with bar code %s", bar_code)
+	items, err := _queryInventoryItems(s, msg,
+		"where "+_db_item_company_id+" = $1 AND "+_db_item_bar_code+" = $2",
+		company_id, bar_code)
+
+	items, err = _checkItemArrError(items, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return items[0], nil
+}
+
 func (s *shStore) GetItemByIdInTx(tnx *sql.Tx, id int) (*ShItem, error) {
 	msg := fmt.Sprintf("no item with that id %d", id)
 	items, err := _queryInventoryItemsInTx(tnx, msg, "where item_id = $1", id)
@@ -176,17 +313,24 @@ func _get_item_columns() string {
 				_db_item_units, _db_item_has_derived_unit, _db_item_derived_name, _db_item_derived_factor,
 				_db_item_reorder_level,
 				_db_item_model_year, _db_item_part_number, _db_item_bar_code, _db_item_has_bar_code,
-				_db_status_flag,
+				_db_status_flag, _db_version,
+				_db_item_cost_price, _db_item_selling_price,
 			},
 			", "),
 	)
 }
 
 func _queryInventoryItems(s *shStore, err_msg string, where_stmt string, args ...interface{}) ([]*ShItem, error) {
+	return _querySortedInventoryItems(s, err_msg, " ORDER BY "+_db_item_id+" desc", where_stmt, args...)
+}
+
+/**
+ * @args sort_by	the ORDER BY(and optional LIMIT/OFFSET) clause
+ */
+func _querySortedInventoryItems(s *shStore, err_msg string, sort_by string, where_stmt string, args ...interface{}) ([]*ShItem, error) {
 	var result []*ShItem
 
 	query := "select " + _get_item_columns() + " from " + TABLE_INVENTORY_ITEM
-	sort_by := " ORDER BY " + _db_item_id + " desc"
 
 	var rows *sql.Rows
 	var err error
@@ -220,6 +364,9 @@ func _queryInventoryItems(s *shStore, err_msg string, where_stmt string, args ..
 			&i.BarCode,
 			&i.HasBarCode,
 			&i.StatusFlag,
+			&i.Version,
+			&i.CostPrice,
+			&i.SellingPrice,
 		)
 		if err != nil {
 			if err == sql.ErrNoRows {
@@ -230,11 +377,12 @@ func _queryInventoryItems(s *shStore, err_msg string, where_stmt string, args ..
 
 		result = append(result, i)
 	}
+	rows.Close()
 
 	if len(result) == 0 {
 		return nil, ErrNoData
 	}
-	return result, nil
+	return result, _queryItemUnits(s.Query, result)
 }
 
 func _queryInventoryItemsInTx(tnx *sql.Tx, err_msg string, where_stmt string, args ...interface{}) ([]*ShItem, error) {
@@ -275,6 +423,9 @@ func _queryInventoryItemsInTx(tnx *sql.Tx, err_msg string, where_stmt string, ar
 			&i.BarCode,
 			&i.HasBarCode,
 			&i.StatusFlag,
+			&i.Version,
+			&i.CostPrice,
+			&i.SellingPrice,
 		)
 		if err != nil {
 			if err == sql.ErrNoRows {
@@ -285,9 +436,10 @@ func _queryInventoryItemsInTx(tnx *sql.Tx, err_msg string, where_stmt string, ar
 
 		result = append(result, i)
 	}
+	rows.Close()
 
 	if len(result) == 0 {
 		return nil, ErrNoData
 	}
-	return result, nil
+	return result, _queryItemUnits(tnx.Query, result)
 }
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func TestBarCodeViolationRollsBackToSavepoint(t *testing.T) {
+	db, store, teardown := t_fake_store(t)
+	defer teardown()
+
+	// another device added the bar code after it was checked
+	db.expect("bar_code  = $2").returns("item_id")
+	db.expect("savepoint item_write")
+	db.expect("insert into " + TABLE_INVENTORY_ITEM).
+		fails(&pq.Error{Code: "23505", Constraint: _idx_item_bar_code})
+	db.expect("rollback to savepoint item_write")
+
+	// the transaction is still usable for the next item
+	db.expect("savepoint item_write")
+	db.expect("insert into "+TABLE_INVENTORY_ITEM).
+		returns("item_id, version", t_row(int64(12), int64(1)))
+	db.expect("release savepoint item_write")
+	db.expect("select unit_id from " + TABLE_ITEM_UNIT).returns("unit_id")
+
+	tnx, err := store.Begin()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer tnx.Rollback()
+
+	item := &ShItem{CompanyId: 3, Name: "filter", BarCode: "0123"}
+	if _, err = store.CreateItemInTx(tnx, item); err != ErrDuplicateBarCode {
+		t.Errorf("expected a duplicate bar code, got %v", err)
+	}
+
+	item = &ShItem{CompanyId: 3, Name: "pad"}
+	if _, err = store.CreateItemInTx(tnx, item); err != nil {
+		t.Errorf("%v", err)
+	} else if item.ItemId != 12 {
+		t.Errorf("expected item 12, got %d", item.ItemId)
+	}
+}
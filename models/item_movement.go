@@ -0,0 +1,188 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+/**
+ * A change of an item's quantity in a branch, along with what it was after the change.
+ * Corrections of the ledger aren't part of any transaction, so they only
+ * have the quantity and the date.
+ */
+type ShItemMovement struct {
+	TransactionId int64
+	Date          int64
+
+	TransType int
+	// the branch the item moved from or to, if it moved between branches
+	OtherBranchId int
+	UserId        int
+	TransNote     string
+	ItemNote      string
+	IsVoid        bool
+
+	// positive if it came in, negative if it left
+	Quantity float64
+	Balance  float64
+
+	// the branch of the transaction, it is the counterpart if the
+	// movement came from another branch's transaction
+	TransBranchId int
+}
+
+/**
+ * Fills in the type, counterpart and note of each movement from the
+ * transaction item that caused it. A transaction can have the same item more
+ * than once, so the item that moves the same quantity in the branch is used.
+ * @args trans_items	transaction_id -> its items of the movements' item
+ */
+func MatchItemMovements(movements []*ShItemMovement, branch_id int,
+	trans_items map[int64][]*ShTransactionItem) {
+
+	used := make(map[*ShTransactionItem]bool)
+	for _, movement := range movements {
+		if movement.TransactionId == 0 {
+			continue
+		}
+		for _, item := range trans_items[movement.TransactionId] {
+			if used[item] {
+				continue
+			}
+			entries := StockLedgerEntries(&ShTransaction{
+				BranchId:   movement.TransBranchId,
+				TransItems: []*ShTransactionItem{item},
+			})
+			matched := false
+			for _, entry := range entries {
+				if entry.Account == LEDGER_ACCOUNT_BRANCH && entry.BranchId == branch_id &&
+					!_quantityDrifted(entry.Quantity, movement.Quantity) {
+					matched = true
+				}
+			}
+			if !matched {
+				continue
+			}
+
+			used[item] = true
+			movement.TransType = item.TransType
+			movement.ItemNote = item.ItemNote
+			if branch_id == movement.TransBranchId {
+				movement.OtherBranchId = item.OtherBranchId
+			} else {
+				movement.OtherBranchId = movement.TransBranchId
+			}
+			break
+		}
+	}
+}
+
+/**
+ * Returns the movements of the item in the branch ordered by date, those on the
+ * same date in the order they were posted. The balance counts every movement,
+ * including those before start_date. The zero value of the dates means don't filter on it.
+ */
+func (s *shStore) GetItemMovements(company_id, branch_id, item_id int, start_date, end_date int64) ([]*ShItemMovement, error) {
+	msg := fmt.Sprintf("company:%d, movements of item:%d in branch:%d", company_id, item_id, branch_id)
+
+	args := []interface{}{company_id, LEDGER_ACCOUNT_BRANCH, branch_id, item_id}
+	where_stmt := "where l.company_id = $1 AND l.account = $2 AND l.branch_id = $3 AND l.item_id = $4"
+
+	var balance float64
+	if start_date != 0 {
+		var opening sql.NullFloat64
+		err := s.QueryRow(fmt.Sprintf("select sum(l.quantity) from %s l ", TABLE_STOCK_LEDGER)+
+			where_stmt+" AND l.t_date < $5", append(args, start_date)...).Scan(&opening)
+		if err != nil {
+			return nil, fmt.Errorf("%s %v", msg, err)
+		}
+		balance = opening.Float64
+	}
+
+	add_condition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		where_stmt += fmt.Sprintf(" AND "+condition, len(args))
+	}
+	if start_date != 0 {
+		add_condition("l.t_date >= $%d", start_date)
+	}
+	if end_date != 0 {
+		add_condition("l.t_date <= $%d", end_date)
+	}
+
+	rows, err := s.Query(fmt.Sprintf("select l.transaction_id, l.t_date, l.quantity, "+
+		"t.branch_id, t.user_id, t.trans_note, t.is_void from %s l "+
+		"left join %s t on (l.transaction_id = t.transaction_id) ",
+		TABLE_STOCK_LEDGER, TABLE_TRANSACTION)+where_stmt+
+		" ORDER BY l.t_date asc, l.entry_id asc", args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s %v", msg, err)
+	}
+
+	var result []*ShItemMovement
+	has_transactions := false
+	for rows.Next() {
+		m := new(ShItemMovement)
+		var trans_id, date, trans_branch_id, user_id sql.NullInt64
+		var trans_note sql.NullString
+		var is_void sql.NullBool
+		if err := rows.Scan(&trans_id, &date, &m.Quantity,
+			&trans_branch_id, &user_id, &trans_note, &is_void); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("%s %v", msg, err)
+		}
+		m.TransactionId = trans_id.Int64
+		m.Date = date.Int64
+		m.TransBranchId = int(trans_branch_id.Int64)
+		m.UserId = int(user_id.Int64)
+		m.TransNote = trans_note.String
+		m.IsVoid = is_void.Bool
+
+		balance += m.Quantity
+		m.Balance = balance
+		result = append(result, m)
+
+		if m.TransactionId != 0 {
+			has_transactions = true
+		}
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		// the balance would be off from the missing movements
+		return nil, fmt.Errorf("%s %v", msg, err)
+	}
+	if !has_transactions {
+		return result, nil
+	}
+
+	// the transactions of the movements are selected the same way, $4 is the item_id
+	rows, err = s.Query(fmt.Sprintf("select transaction_id, trans_type, other_branch_id, "+
+		"quantity, item_note from %s where item_id = $4 AND transaction_id in "+
+		"(select l.transaction_id from %s l ",
+		TABLE_TRANSACTION_ITEM, TABLE_STOCK_LEDGER)+where_stmt+")", args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s %v", msg, err)
+	}
+	defer rows.Close()
+
+	trans_items := make(map[int64][]*ShTransactionItem)
+	for rows.Next() {
+		i := &ShTransactionItem{CompanyId: company_id, ItemId: item_id}
+		var other_branch_id sql.NullInt64
+		var item_note sql.NullString
+		if err := rows.Scan(&i.TransactionId, &i.TransType, &other_branch_id,
+			&i.Quantity, &item_note); err != nil {
+			return nil, fmt.Errorf("%s %v", msg, err)
+		}
+		i.OtherBranchId = int(other_branch_id.Int64)
+		i.ItemNote = item_note.String
+		trans_items[i.TransactionId] = append(trans_items[i.TransactionId], i)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s %v", msg, err)
+	}
+
+	MatchItemMovements(result, branch_id, trans_items)
+	return result, nil
+}
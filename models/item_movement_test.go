@@ -0,0 +1,136 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMatchItemMovements(t *testing.T) {
+	const branch_id, other_branch_id = 1, 2
+
+	movements := []*ShItemMovement{
+		// a correction of the ledger
+		{Quantity: 5},
+		// the transaction has the item twice
+		{TransactionId: 10, TransBranchId: branch_id, Quantity: -3},
+		{TransactionId: 10, TransBranchId: branch_id, Quantity: 4},
+		// the other branch transferred it here
+		{TransactionId: 11, TransBranchId: other_branch_id, Quantity: 2},
+	}
+	trans_items := map[int64][]*ShTransactionItem{
+		10: {
+			{TransType: TRANS_TYPE_ADD_PURCHASED, Quantity: 4, ItemNote: "bought"},
+			{TransType: TRANS_TYPE_SUB_CURRENT_BRANCH_SALE, Quantity: 3, ItemNote: "sold"},
+		},
+		11: {
+			{TransType: TRANS_TYPE_SUB_TRANSFER_TO_OTHER, Quantity: 2, OtherBranchId: branch_id},
+		},
+	}
+	MatchItemMovements(movements, branch_id, trans_items)
+
+	expected := []struct {
+		trans_type   int
+		other_branch int
+		note         string
+	}{
+		{0, 0, ""},
+		{TRANS_TYPE_SUB_CURRENT_BRANCH_SALE, 0, "sold"},
+		{TRANS_TYPE_ADD_PURCHASED, 0, "bought"},
+		{TRANS_TYPE_SUB_TRANSFER_TO_OTHER, other_branch_id, ""},
+	}
+	for i, e := range expected {
+		m := movements[i]
+		if m.TransType != e.trans_type || m.OtherBranchId != e.other_branch || m.ItemNote != e.note {
+			t.Errorf("movement %d: expected %+v, got %+v", i, e, m)
+		}
+	}
+}
+
+func TestItemMovementsBalanceFromBeforeStart(t *testing.T) {
+	db, store, teardown := t_fake_store(t)
+	defer teardown()
+
+	db.expect("select sum(l.quantity)").returns("sum", t_row(7.0))
+	db.expect("left join "+TABLE_TRANSACTION).
+		returns("transaction_id, t_date, quantity, branch_id, user_id, trans_note, is_void",
+			t_row(int64(10), int64(150), -3.0, int64(1), int64(2), "sold", false),
+			t_row(nil, int64(160), 5.0, nil, nil, nil, nil),
+			t_row(int64(11), int64(170), 2.0, int64(1), int64(2), nil, false))
+	db.expect("transaction_id in (select l.transaction_id").
+		returns("transaction_id, trans_type, other_branch_id, quantity, item_note",
+			t_row(int64(10), int64(TRANS_TYPE_SUB_CURRENT_BRANCH_SALE), nil, 3.0, nil),
+			t_row(int64(11), int64(TRANS_TYPE_ADD_PURCHASED), nil, 2.0, "bought"))
+
+	movements, err := store.GetItemMovements(5, 1, 11, 100, 200)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	expected := []struct {
+		trans_type int
+		balance    float64
+	}{
+		{TRANS_TYPE_SUB_CURRENT_BRANCH_SALE, 4},
+		{0, 9},
+		{TRANS_TYPE_ADD_PURCHASED, 11},
+	}
+	if len(movements) != len(expected) {
+		t.Fatalf("expected %d movements, got %d", len(expected), len(movements))
+	}
+	for i, e := range expected {
+		if movements[i].TransType != e.trans_type || movements[i].Balance != e.balance {
+			t.Errorf("movement %d: expected %+v, got %+v", i, e, movements[i])
+		}
+	}
+
+	// the opening balance is before the start, the movements are between the dates
+	if args := db.args[0]; len(args) != 5 || args[4] != int64(100) ||
+		!strings.Contains(db.queries[0], "l.t_date < $5") {
+		t.Errorf("expected the balance before the start, got %v", args)
+	}
+	for _, n := range []int{1, 2} {
+		if args := db.args[n]; len(args) != 6 || args[4] != int64(100) || args[5] != int64(200) ||
+			!strings.Contains(db.queries[n], "l.t_date >= $5 AND l.t_date <= $6") {
+			t.Errorf("query %d: expected the movements between the dates, got %v", n, args)
+		}
+	}
+}
+
+func TestItemMovementsWithoutDates(t *testing.T) {
+	db, store, teardown := t_fake_store(t)
+	defer teardown()
+
+	// only corrections, the transaction items aren't read
+	db.expect("left join "+TABLE_TRANSACTION).
+		returns("transaction_id, t_date, quantity, branch_id, user_id, trans_note, is_void",
+			t_row(nil, int64(150), 4.0, nil, nil, nil, nil),
+			t_row(nil, int64(160), -1.0, nil, nil, nil, nil))
+
+	movements, err := store.GetItemMovements(5, 1, 11, 0, 0)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(movements) != 2 || movements[0].Balance != 4 || movements[1].Balance != 3 {
+		t.Errorf("expected the balance from the first movement, got %v", movements)
+	}
+	if len(db.queries) != 1 || len(db.args[0]) != 4 {
+		t.Errorf("expected a single unfiltered query, got %v", db.queries)
+	}
+}
+
+func TestItemMovementsFailIfRowsBreak(t *testing.T) {
+	db, store, teardown := t_fake_store(t)
+	defer teardown()
+
+	db.expect("left join "+TABLE_TRANSACTION).
+		returns("transaction_id, t_date, quantity, branch_id, user_id, trans_note, is_void",
+			t_row(int64(10), int64(150), 4.0, int64(1), int64(2), nil, false)).
+		breaks(errors.New("connection reset"))
+
+	if movements, err := store.GetItemMovements(5, 1, 11, 0, 0); err == nil {
+		t.Errorf("expected an error instead of a partial list, got %v", movements)
+	}
+	if len(db.queries) != 1 {
+		t.Errorf("expected the transaction items not to be read, got %v", db.queries)
+	}
+}
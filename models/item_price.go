@@ -0,0 +1,162 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+/**
+ * A change of an item's prices. The history lets the price at a past date
+ * be found, e.g: to price a transaction posted late.
+ * Entries with a BranchId are changes to the branch's selling price override,
+ * they don't have a cost price.
+ */
+type ShItemPrice struct {
+	CompanyId int
+	ItemId    int
+	BranchId  int // 0 for the item's prices
+
+	CostPrice float64
+	// for branch entries, not having it means the override was removed
+	SellingPrice    float64
+	HasSellingPrice bool
+
+	EffectiveDate int64
+}
+
+/**
+ * Returns the prices of the item in the branch at the date, the branch's
+ * override takes precedence over the item's selling price.
+ * @args history	the item's price changes, the latest first
+ */
+func EffectivePrice(history []*ShItemPrice, branch_id int, date int64) *ShItemPrice {
+	var item_price, branch_price *ShItemPrice
+	for _, price := range history {
+		if price.EffectiveDate > date {
+			continue
+		}
+		if price.BranchId == 0 {
+			if item_price == nil || price.EffectiveDate > item_price.EffectiveDate {
+				item_price = price
+			}
+		} else if price.BranchId == branch_id {
+			if branch_price == nil || price.EffectiveDate > branch_price.EffectiveDate {
+				branch_price = price
+			}
+		}
+	}
+
+	if item_price == nil {
+		return nil
+	}
+	result := &ShItemPrice{
+		CompanyId:       item_price.CompanyId,
+		ItemId:          item_price.ItemId,
+		BranchId:        branch_id,
+		CostPrice:       item_price.CostPrice,
+		SellingPrice:    item_price.SellingPrice,
+		HasSellingPrice: true,
+		EffectiveDate:   item_price.EffectiveDate,
+	}
+	if branch_price != nil && branch_price.HasSellingPrice {
+		result.SellingPrice = branch_price.SellingPrice
+		if branch_price.EffectiveDate > result.EffectiveDate {
+			result.EffectiveDate = branch_price.EffectiveDate
+		}
+	}
+	return result
+}
+
+func (s *shStore) AddItemPriceInTx(tnx *sql.Tx, price *ShItemPrice) error {
+	var branch_id sql.NullInt64
+	var cost_price sql.NullFloat64
+	selling_price := sql.NullFloat64{Float64: price.SellingPrice, Valid: price.HasSellingPrice}
+	if price.BranchId == 0 {
+		// the item always has both prices
+		cost_price = sql.NullFloat64{Float64: price.CostPrice, Valid: true}
+		selling_price.Valid = true
+	} else {
+		branch_id = sql.NullInt64{Int64: int64(price.BranchId), Valid: true}
+	}
+
+	_, err := tnx.Exec(fmt.Sprintf("insert into %s "+
+		"(company_id, item_id, branch_id, cost_price, selling_price, effective_date) values "+
+		"($1, $2, $3, $4, $5, $6)", TABLE_ITEM_PRICE),
+		price.CompanyId, price.ItemId, branch_id, cost_price, selling_price,
+		price.EffectiveDate)
+	return err
+}
+
+/**
+ * Returns the price changes of the item, the latest first.
+ */
+func (s *shStore) GetItemPriceHistory(company_id, item_id int) ([]*ShItemPrice, error) {
+	return _queryItemPrices(s.Query, company_id, item_id)
+}
+
+/**
+ * Returns the prices of the item in the branch at the date, ErrNoData if it didn't have any.
+ */
+func (s *shStore) GetItemPriceAsOfInTx(tnx *sql.Tx, company_id, branch_id, item_id int, date int64) (*ShItemPrice, error) {
+	history, err := _queryItemPrices(tnx.Query, company_id, item_id)
+	if err != nil {
+		return nil, err
+	}
+	if price := EffectivePrice(history, branch_id, date); price != nil {
+		return price, nil
+	}
+	return nil, ErrNoData
+}
+
+/**
+ * Returns the cost price of every item of the company, item_id -> cost price.
+ */
+func (s *shStore) GetItemCostPrices(company_id int) (map[int]float64, error) {
+	rows, err := s.Query(fmt.Sprintf("select item_id, cost_price from %s "+
+		"where company_id = $1", TABLE_INVENTORY_ITEM), company_id)
+	if err != nil {
+		return nil, fmt.Errorf("company:%d, cost prices %v", company_id, err)
+	}
+	defer rows.Close()
+
+	prices := make(map[int]float64)
+	for rows.Next() {
+		var item_id int
+		var cost_price float64
+		if err := rows.Scan(&item_id, &cost_price); err != nil {
+			return nil, err
+		}
+		prices[item_id] = cost_price
+	}
+	return prices, rows.Err()
+}
+
+func _queryItemPrices(query_fn func(string, ...interface{}) (*sql.Rows, error),
+	company_id, item_id int) ([]*ShItemPrice, error) {
+
+	rows, err := query_fn(fmt.Sprintf("select company_id, item_id, branch_id, "+
+		"cost_price, selling_price, effective_date from %s "+
+		"where company_id = $1 and item_id = $2 "+
+		"ORDER BY effective_date desc, price_id desc", TABLE_ITEM_PRICE),
+		company_id, item_id)
+	if err != nil {
+		return nil, fmt.Errorf("company:%d, price history of item:%d %v", company_id, item_id, err)
+	}
+	defer rows.Close()
+
+	var result []*ShItemPrice
+	for rows.Next() {
+		p := new(ShItemPrice)
+		var branch_id sql.NullInt64
+		var cost_price, selling_price sql.NullFloat64
+		if err := rows.Scan(&p.CompanyId, &p.ItemId, &branch_id,
+			&cost_price, &selling_price, &p.EffectiveDate); err != nil {
+			return nil, err
+		}
+		p.BranchId = int(branch_id.Int64)
+		p.CostPrice = cost_price.Float64
+		p.SellingPrice, p.HasSellingPrice = selling_price.Float64, selling_price.Valid
+		result = append(result, p)
+	}
+	return result, rows.Err()
+}
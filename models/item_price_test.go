@@ -0,0 +1,48 @@
+package models
+
+import "testing"
+
+func TestEffectivePrice(t *testing.T) {
+	// the latest first
+	history := []*ShItemPrice{
+		// the override in branch 2 is removed
+		{ItemId: 4, BranchId: 2, EffectiveDate: 500},
+		{ItemId: 4, CostPrice: 12, SellingPrice: 20, HasSellingPrice: true, EffectiveDate: 400},
+		{ItemId: 4, BranchId: 3, SellingPrice: 17, HasSellingPrice: true, EffectiveDate: 300},
+		{ItemId: 4, BranchId: 2, SellingPrice: 18, HasSellingPrice: true, EffectiveDate: 200},
+		{ItemId: 4, CostPrice: 10, SellingPrice: 15, HasSellingPrice: true, EffectiveDate: 100},
+	}
+
+	if price := EffectivePrice(history, 2, 50); price != nil {
+		t.Errorf("the item didn't have a price yet, got %+v", price)
+	}
+
+	expected := []struct {
+		branch_id int
+		date      int64
+		cost      float64
+		selling   float64
+	}{
+		{1, 100, 10, 15},
+		{2, 150, 10, 15},
+		// the branch's override
+		{2, 200, 10, 18},
+		// the item's price changed, but the override still holds
+		{2, 450, 12, 18},
+		{2, 500, 12, 20},
+		{3, 350, 10, 17},
+		{3, 1000, 12, 17},
+		{1, 1000, 12, 20},
+	}
+	for _, e := range expected {
+		price := EffectivePrice(history, e.branch_id, e.date)
+		if price == nil {
+			t.Errorf("branch %d at %d: no price", e.branch_id, e.date)
+			continue
+		}
+		if price.CostPrice != e.cost || price.SellingPrice != e.selling {
+			t.Errorf("branch %d at %d: expected cost %v selling %v, got %+v",
+				e.branch_id, e.date, e.cost, e.selling, price)
+		}
+	}
+}
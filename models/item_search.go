@@ -0,0 +1,142 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+const (
+	// The text an item is searched by. The search indexes are built on this
+	// expression, so it should be used as-is in queries for them to be used.
+	_item_search_text = " (coalesce(" + _db_item_name + ", '') || ' ' || " +
+		"coalesce(" + _db_item_code + ", '') || ' ' || " +
+		"coalesce(" + _db_item_part_number + ", '') || ' ' || " +
+		"coalesce(" + _db_item_bar_code + ", '')) "
+
+	_item_search_tsvector = " to_tsvector('simple', " + _item_search_text + ") "
+)
+
+// Set when the database has the pg_trgm extension, without it SearchItems
+// can't match similar words(typos) and only does substring and whole word
+// matches. The extension is created by migrate_add_item_search.
+var trigram_search = false
+
+/**
+ * Creates the indexes used by SearchItems. Trigrams handle partial and
+ * misspelled words, the full-text index handles whole words.
+ */
+func createItemSearchIndexes(db *sql.DB, exec, try_exec func(string, ...interface{})) {
+	err := db.QueryRow("select exists (select 1 from pg_extension " +
+		"where extname = 'pg_trgm')").Scan(&trigram_search)
+	if err != nil {
+		fmt.Printf("can't check for pg_trgm '%s'\n", err.Error())
+		trigram_search = false
+	}
+
+	if trigram_search {
+		try_exec(fmt.Sprintf("create index if not exists %s_search_trgm_idx "+
+			"on %s using gin (%s gin_trgm_ops);",
+			TABLE_INVENTORY_ITEM, TABLE_INVENTORY_ITEM, _item_search_text))
+	} else {
+		fmt.Printf("pg_trgm isn't installed, item search won't match similar words\n")
+	}
+
+	exec(fmt.Sprintf("create index if not exists %s_search_fts_idx "+
+		"on %s using gin (%s);",
+		TABLE_INVENTORY_ITEM, TABLE_INVENTORY_ITEM, _item_search_tsvector))
+}
+
+type ShItemSearch struct {
+	CompanyId int
+
+	// matched against the name, code, part number and bar code. If empty,
+	// every item that passes the filters is returned sorted by name.
+	Query string
+
+	// the zero value means don't filter on it
+	CategoryId int
+	BranchId   int // only items that exist in the branch
+
+	IncludeHidden bool
+
+	Offset int
+}
+
+// escapes the characters that have a special meaning in a LIKE pattern
+func _escapeLikePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+/**
+ * Returns at most page_size items matching the search, the most relevant first.
+ * An exact match of the code or bar code comes before anything else.
+ */
+func (s *shStore) SearchItems(search *ShItemSearch, page_size int) (items []*ShItem, has_more bool, err error) {
+	where_stmt := "where " + _db_item_company_id + " = $1"
+	args := []interface{}{search.CompanyId}
+
+	add_condition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		where_stmt += " AND " + strings.Replace(condition, "$?", fmt.Sprintf("$%d", len(args)), -1)
+	}
+
+	if !search.IncludeHidden {
+		add_condition(_db_status_flag+" = $?", STATUS_VISIBLE)
+	}
+	if search.CategoryId != 0 {
+		add_condition(_db_item_category_id+" = $?", search.CategoryId)
+	}
+	if search.BranchId != 0 {
+		add_condition(fmt.Sprintf("EXISTS (select 1 from %s bi where bi.item_id = %s.%s AND bi.branch_id = $?)",
+			TABLE_BRANCH_ITEM, TABLE_INVENTORY_ITEM, _db_item_id), search.BranchId)
+	}
+
+	sort_by := " ORDER BY " + _db_item_name + " asc, " + _db_item_id + " desc"
+
+	query := strings.TrimSpace(search.Query)
+	if len(query) > 0 {
+		args = append(args, "%"+_escapeLikePattern(query)+"%")
+		like := fmt.Sprintf("$%d", len(args))
+		args = append(args, query)
+		q := fmt.Sprintf("$%d", len(args))
+
+		ts_query := "plainto_tsquery('simple', " + q + ")"
+		rank := " ts_rank(" + _item_search_tsvector + ", " + ts_query + ") "
+
+		// a substring, a similar word(typos) or a whole word match
+		if trigram_search {
+			where_stmt += " AND (" + _item_search_text + " ILIKE " + like +
+				" OR " + _item_search_text + " % " + q +
+				" OR " + _item_search_tsvector + " @@ " + ts_query + ")"
+			rank = " greatest(similarity(" + _item_search_text + ", " + q + "), " + rank + ") "
+		} else {
+			where_stmt += " AND (" + _item_search_text + " ILIKE " + like +
+				" OR " + _item_search_tsvector + " @@ " + ts_query + ")"
+		}
+
+		sort_by = " ORDER BY (" + _db_item_code + " = " + q + " OR " + _db_item_bar_code + " = " + q + ") desc, " +
+			rank + " desc, " + _db_item_id + " desc"
+	}
+
+	if page_size > 0 {
+		// fetch one more so we know if there is something after the page
+		sort_by += fmt.Sprintf(" LIMIT %d", page_size+1)
+	}
+	if search.Offset > 0 {
+		sort_by += fmt.Sprintf(" OFFSET %d", search.Offset)
+	}
+
+	msg := fmt.Sprintf("company:%d, item search", search.CompanyId)
+	items, err = _querySortedInventoryItems(s, msg, sort_by, where_stmt, args...)
+	if err == ErrNoData {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+
+	if page_size > 0 && len(items) > page_size {
+		return items[:page_size], true, nil
+	}
+	return items, false, nil
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+)
+
+const t_item_columns = "item_id, client_uuid, company_id, category_id, name, code, " +
+	"units, has_derived_unit, derived_name, derived_factor, reorder_level, " +
+	"model_year, part_number, bar_code, has_bar_code, status_flag, version, cost_price, selling_price"
+
+func t_item_row(item_id int64, name string) []driver.Value {
+	return t_row(item_id, "", int64(3), int64(SERVER_ROOT_CATEGORY_ID), name, "",
+		int64(1), false, "", float64(0), float64(0),
+		"", "", "", false, int64(STATUS_VISIBLE), int64(1), float64(0), float64(0))
+}
+
+func t_search_items(t *testing.T, trigrams bool) (query string, items []*ShItem, has_more bool) {
+	db, store, teardown := t_fake_store(t)
+	defer teardown()
+
+	save_trigram_search := trigram_search
+	trigram_search = trigrams
+	defer func() { trigram_search = save_trigram_search }()
+
+	db.expect("from "+TABLE_INVENTORY_ITEM).
+		returns(t_item_columns, t_item_row(9, "brake pad"), t_item_row(8, "brake disc"))
+	db.expect("from " + TABLE_ITEM_UNIT).returns("unit_id, item_id, unit_name, factor")
+
+	items, has_more, err := store.SearchItems(&ShItemSearch{CompanyId: 3, Query: " brake "}, 1)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	args := db.args[0]
+	if len(args) != 4 || args[2] != "%brake%" || args[3] != "brake" {
+		t.Errorf("expected the company, status, pattern and query args, got %v", args)
+	}
+	return db.queries[0], items, has_more
+}
+
+func TestSearchItemsWithTrigrams(t *testing.T) {
+	query, items, has_more := t_search_items(t, true)
+	if !strings.Contains(query, "similarity(") || !strings.Contains(query, " % $4") {
+		t.Errorf("expected a trigram search, got %q", query)
+	}
+	if !has_more || len(items) != 1 || items[0].ItemId != 9 {
+		t.Errorf("expected item 9 and more after it, got %v %v", items, has_more)
+	}
+}
+
+func TestSearchItemsWithoutTrigrams(t *testing.T) {
+	query, items, has_more := t_search_items(t, false)
+	if strings.Contains(query, "similarity(") || strings.Contains(query, " % ") {
+		t.Errorf("trigram functions used without pg_trgm %q", query)
+	}
+	if !strings.Contains(query, "ILIKE $3") || !strings.Contains(query, "plainto_tsquery('simple', $4)") {
+		t.Errorf("expected a substring and whole word search, got %q", query)
+	}
+	if !has_more || len(items) != 1 {
+		t.Errorf("expected a single item and more after it, got %v %v", items, has_more)
+	}
+}
+
+func TestSimpleSearchItems(t *testing.T) {
+	store := NewSimpleItemStore([]*ShItem{
+		{ItemId: 1, CompanyId: 3, Name: "brake pad", BarCode: "0042", StatusFlag: STATUS_VISIBLE},
+		{ItemId: 2, CompanyId: 3, Name: "abs sensor", ItemCode: "0042", StatusFlag: STATUS_VISIBLE},
+		{ItemId: 3, CompanyId: 3, Name: "air filter", PartNumber: "X0042", StatusFlag: STATUS_VISIBLE},
+		{ItemId: 4, CompanyId: 3, Name: "hidden", BarCode: "10042", StatusFlag: STATUS_IN_VISIBLE},
+		{ItemId: 5, CompanyId: 4, Name: "other company", BarCode: "0042", StatusFlag: STATUS_VISIBLE},
+	})
+
+	items, has_more, err := store.SearchItems(&ShItemSearch{CompanyId: 3, Query: "0042"}, 2)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	// exact matches first, then by name
+	if len(items) != 2 || items[0].ItemId != 2 || items[1].ItemId != 1 || !has_more {
+		t.Errorf("expected items 2, 1 and more, got %v %v", items, has_more)
+	}
+
+	items, has_more, _ = store.SearchItems(&ShItemSearch{CompanyId: 3, Query: "0042",
+		IncludeHidden: true, Offset: 2}, 2)
+	if len(items) != 2 || items[0].ItemId != 3 || items[1].ItemId != 4 || has_more {
+		t.Errorf("expected items 3, 4 and nothing more, got %v %v", items, has_more)
+	}
+}
@@ -0,0 +1,149 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+/**
+ * A unit other than the item's derived unit it is counted in, e.g: a box of 12.
+ */
+type ShItemUnit struct {
+	UnitId int
+	ItemId int
+	Name   string
+	// the number of the item's UnitOfMeasurement in one of this unit
+	Factor float64
+}
+
+// the units of a transaction item that aren't an ShItemUnit
+const (
+	TRANS_UNIT_BASE    = 0  // the item's UnitOfMeasurement
+	TRANS_UNIT_DERIVED = -1 // the item's DerivedName unit
+)
+
+/**
+ * Sets the quantity of the transaction item in the item's base unit, from
+ * the quantity in the unit it was posted in. The name of the unit is also
+ * kept, so it can be shown even if the unit changes later.
+ * The item isn't needed for the base unit.
+ */
+func ConvertToBaseUnit(item *ShItem, trans_item *ShTransactionItem) error {
+	var name string
+	var factor float64
+
+	switch trans_item.UnitId {
+	case TRANS_UNIT_BASE:
+		// the quantity is already in it
+		trans_item.UnitName = ""
+		trans_item.UnitQuantity = trans_item.Quantity
+		return nil
+	case TRANS_UNIT_DERIVED:
+		if !item.HasDerivedUnit {
+			return fmt.Errorf("item:%d doesn't have a derived unit", item.ItemId)
+		}
+		name, factor = item.DerivedName, item.DerivedFactor
+	default:
+		found := false
+		for _, unit := range item.Units {
+			if unit.UnitId == trans_item.UnitId {
+				name, factor, found = unit.Name, unit.Factor, true
+			}
+		}
+		if !found {
+			return fmt.Errorf("item:%d doesn't have unit:%d", item.ItemId, trans_item.UnitId)
+		}
+	}
+	if factor <= 0 {
+		return fmt.Errorf("unit %s of item:%d doesn't have a valid factor", name, item.ItemId)
+	}
+
+	trans_item.UnitName = name
+	trans_item.Quantity = trans_item.UnitQuantity * factor
+	return nil
+}
+
+/**
+ * Makes the units of the item in the db the same as item.Units. New units
+ * don't have an id, they are assigned one.
+ */
+func _saveItemUnitsInTx(tnx *sql.Tx, item *ShItem) error {
+	rows, err := tnx.Query(fmt.Sprintf("select unit_id from %s where item_id = $1", TABLE_ITEM_UNIT),
+		item.ItemId)
+	if err != nil {
+		return err
+	}
+	prev_units := make(map[int]bool)
+	for rows.Next() {
+		var unit_id int
+		if err := rows.Scan(&unit_id); err != nil {
+			rows.Close()
+			return err
+		}
+		prev_units[unit_id] = true
+	}
+	rows.Close()
+
+	for _, unit := range item.Units {
+		unit.ItemId = item.ItemId
+		if prev_units[unit.UnitId] {
+			delete(prev_units, unit.UnitId)
+			_, err = tnx.Exec(fmt.Sprintf("update %s set unit_name = $1, factor = $2 "+
+				"where unit_id = $3", TABLE_ITEM_UNIT),
+				unit.Name, unit.Factor, unit.UnitId)
+		} else {
+			err = tnx.QueryRow(fmt.Sprintf("insert into %s "+
+				"(company_id, item_id, unit_name, factor) values ($1, $2, $3, $4) "+
+				"returning unit_id", TABLE_ITEM_UNIT),
+				item.CompanyId, unit.ItemId, unit.Name, unit.Factor).Scan(&unit.UnitId)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for unit_id := range prev_units {
+		_, err = tnx.Exec(fmt.Sprintf("delete from %s where unit_id = $1", TABLE_ITEM_UNIT), unit_id)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/**
+ * Sets the Units of the items.
+ */
+func _queryItemUnits(query_fn func(string, ...interface{}) (*sql.Rows, error), items []*ShItem) error {
+	var item_ids []interface{}
+	var place_holders string
+	by_id := make(map[int]*ShItem)
+	for i, item := range items {
+		item_ids = append(item_ids, item.ItemId)
+		if i > 0 {
+			place_holders += ", "
+		}
+		place_holders += fmt.Sprintf("$%d", i+1)
+		by_id[item.ItemId] = item
+	}
+	if len(item_ids) == 0 {
+		return nil
+	}
+
+	rows, err := query_fn(fmt.Sprintf("select unit_id, item_id, unit_name, factor from %s "+
+		"where item_id in (%s) ORDER BY unit_id asc", TABLE_ITEM_UNIT, place_holders), item_ids...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		unit := new(ShItemUnit)
+		if err := rows.Scan(&unit.UnitId, &unit.ItemId, &unit.Name, &unit.Factor); err != nil {
+			return err
+		}
+		item := by_id[unit.ItemId]
+		item.Units = append(item.Units, unit)
+	}
+	return rows.Err()
+}
@@ -0,0 +1,48 @@
+package models
+
+import "testing"
+
+func TestConvertToBaseUnit(t *testing.T) {
+	item := &ShItem{
+		ItemId:         4,
+		HasDerivedUnit: true,
+		DerivedName:    "box",
+		DerivedFactor:  12,
+		Units: []*ShItemUnit{
+			{UnitId: 7, ItemId: 4, Name: "carton", Factor: 48},
+		},
+	}
+
+	expected := []struct {
+		unit_id       int
+		quantity      float64
+		unit_quantity float64
+		base_quantity float64
+		unit_name     string
+	}{
+		// the quantity is already in the base unit
+		{TRANS_UNIT_BASE, 5, 0, 5, ""},
+		{TRANS_UNIT_DERIVED, 0, 2, 24, "box"},
+		{7, 0, 1.5, 72, "carton"},
+	}
+	for _, e := range expected {
+		trans_item := &ShTransactionItem{ItemId: 4, UnitId: e.unit_id,
+			Quantity: e.quantity, UnitQuantity: e.unit_quantity}
+		if err := ConvertToBaseUnit(item, trans_item); err != nil {
+			t.Errorf("unit %d: %v", e.unit_id, err)
+			continue
+		}
+		if trans_item.Quantity != e.base_quantity || trans_item.UnitName != e.unit_name {
+			t.Errorf("unit %d: expected %v in %q, got %v in %q", e.unit_id,
+				e.base_quantity, e.unit_name, trans_item.Quantity, trans_item.UnitName)
+		}
+	}
+
+	if err := ConvertToBaseUnit(item, &ShTransactionItem{UnitId: 8, UnitQuantity: 1}); err == nil {
+		t.Errorf("expected an error for a unit the item doesn't have")
+	}
+	item.HasDerivedUnit = false
+	if err := ConvertToBaseUnit(item, &ShTransactionItem{UnitId: TRANS_UNIT_DERIVED, UnitQuantity: 1}); err == nil {
+		t.Errorf("expected an error for an item without a derived unit")
+	}
+}
@@ -62,15 +62,36 @@ func (_mr *_MockTransactionStoreRecorder) GetShTransactionById(arg0, arg1, arg2
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetShTransactionById", arg0, arg1, arg2)
 }
 
-func (_m *MockTransactionStore) GetShTransactionByUUIDInTx(_param0 *sql.Tx, _param1 string) (*ShTransaction, error) {
-	ret := _m.ctrl.Call(_m, "GetShTransactionByUUIDInTx", _param0, _param1)
+func (_m *MockTransactionStore) GetShTransactionByUUIDInTx(_param0 *sql.Tx, _param1 int, _param2 string) (*ShTransaction, error) {
+	ret := _m.ctrl.Call(_m, "GetShTransactionByUUIDInTx", _param0, _param1, _param2)
 	ret0, _ := ret[0].(*ShTransaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-func (_mr *_MockTransactionStoreRecorder) GetShTransactionByUUIDInTx(arg0, arg1 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetShTransactionByUUIDInTx", arg0, arg1)
+func (_mr *_MockTransactionStoreRecorder) GetShTransactionByUUIDInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetShTransactionByUUIDInTx", arg0, arg1, arg2)
+}
+
+func (_m *MockTransactionStore) GetShTransactionByIdInTx(tnx *sql.Tx, company_id int, trans_id int64, fetch_items bool) (*ShTransaction, error) {
+	ret := _m.ctrl.Call(_m, "GetShTransactionByIdInTx", tnx, company_id, trans_id, fetch_items)
+	ret0, _ := ret[0].(*ShTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockTransactionStoreRecorder) GetShTransactionByIdInTx(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetShTransactionByIdInTx", arg0, arg1, arg2, arg3)
+}
+
+func (_m *MockTransactionStore) SetShTransactionVoidedInTx(tnx *sql.Tx, company_id int, trans_id int64, voided_by_trans_id int64) error {
+	ret := _m.ctrl.Call(_m, "SetShTransactionVoidedInTx", tnx, company_id, trans_id, voided_by_trans_id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockTransactionStoreRecorder) SetShTransactionVoidedInTx(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetShTransactionVoidedInTx", arg0, arg1, arg2, arg3)
 }
 
 func (_m *MockTransactionStore) GetShTransactionSinceTransId(company_id int, prev_trans_id int64) ([]*ShTransaction, error) {
@@ -84,6 +105,63 @@ func (_mr *_MockTransactionStoreRecorder) GetShTransactionSinceTransId(arg0, arg
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetShTransactionSinceTransId", arg0, arg1)
 }
 
+func (_m *MockTransactionStore) GetCompanyTransactionsInTx(tnx *sql.Tx, company_id int) ([]*ShTransaction, error) {
+	ret := _m.ctrl.Call(_m, "GetCompanyTransactionsInTx", tnx, company_id)
+	ret0, _ := ret[0].([]*ShTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockTransactionStoreRecorder) GetCompanyTransactionsInTx(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetCompanyTransactionsInTx", arg0, arg1)
+}
+
+func (_m *MockTransactionStore) GetLinkedShTransactionsInTx(tnx *sql.Tx, company_id int, trans_id int64) ([]*ShTransaction, error) {
+	ret := _m.ctrl.Call(_m, "GetLinkedShTransactionsInTx", tnx, company_id, trans_id)
+	ret0, _ := ret[0].([]*ShTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockTransactionStoreRecorder) GetLinkedShTransactionsInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetLinkedShTransactionsInTx", arg0, arg1, arg2)
+}
+
+func (_m *MockTransactionStore) GetCompanyTransactionsBetween(company_id int, after int64, until int64) ([]*ShTransaction, error) {
+	ret := _m.ctrl.Call(_m, "GetCompanyTransactionsBetween", company_id, after, until)
+	ret0, _ := ret[0].([]*ShTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockTransactionStoreRecorder) GetCompanyTransactionsBetween(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetCompanyTransactionsBetween", arg0, arg1, arg2)
+}
+
+func (_m *MockTransactionStore) GetShTransactionPageSinceTransId(company_id int, prev_trans_id int64, page_size int) ([]*ShTransaction, bool, error) {
+	ret := _m.ctrl.Call(_m, "GetShTransactionPageSinceTransId", company_id, prev_trans_id, page_size)
+	ret0, _ := ret[0].([]*ShTransaction)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+func (_mr *_MockTransactionStoreRecorder) GetShTransactionPageSinceTransId(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetShTransactionPageSinceTransId", arg0, arg1, arg2)
+}
+
+func (_m *MockTransactionStore) GetShTransactionHistory(filter *ShTransactionFilter, page_size int) ([]*ShTransaction, bool, error) {
+	ret := _m.ctrl.Call(_m, "GetShTransactionHistory", filter, page_size)
+	ret0, _ := ret[0].([]*ShTransaction)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+func (_mr *_MockTransactionStoreRecorder) GetShTransactionHistory(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetShTransactionHistory", arg0, arg1)
+}
+
 // Mock of ItemStore interface
 type MockItemStore struct {
 	ctrl     *gomock.Controller
@@ -149,15 +227,15 @@ func (_mr *_MockItemStoreRecorder) GetItemById(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetItemById", arg0)
 }
 
-func (_m *MockItemStore) GetItemByUUIDInTx(_param0 *sql.Tx, _param1 string) (*ShItem, error) {
-	ret := _m.ctrl.Call(_m, "GetItemByUUIDInTx", _param0, _param1)
+func (_m *MockItemStore) GetItemByUUIDInTx(_param0 *sql.Tx, _param1 int, _param2 string) (*ShItem, error) {
+	ret := _m.ctrl.Call(_m, "GetItemByUUIDInTx", _param0, _param1, _param2)
 	ret0, _ := ret[0].(*ShItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-func (_mr *_MockItemStoreRecorder) GetItemByUUIDInTx(arg0, arg1 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetItemByUUIDInTx", arg0, arg1)
+func (_mr *_MockItemStoreRecorder) GetItemByUUIDInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetItemByUUIDInTx", arg0, arg1, arg2)
 }
 
 func (_m *MockItemStore) GetItemByIdInTx(_param0 *sql.Tx, _param1 int) (*ShItem, error) {
@@ -171,6 +249,29 @@ func (_mr *_MockItemStoreRecorder) GetItemByIdInTx(arg0, arg1 interface{}) *gomo
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetItemByIdInTx", arg0, arg1)
 }
 
+func (_m *MockItemStore) GetItemByBarCode(company_id int, bar_code string) (*ShItem, error) {
+	ret := _m.ctrl.Call(_m, "GetItemByBarCode", company_id, bar_code)
+	ret0, _ := ret[0].(*ShItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockItemStoreRecorder) GetItemByBarCode(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetItemByBarCode", arg0, arg1)
+}
+
+func (_m *MockItemStore) SearchItems(search *ShItemSearch, page_size int) ([]*ShItem, bool, error) {
+	ret := _m.ctrl.Call(_m, "SearchItems", search, page_size)
+	ret0, _ := ret[0].([]*ShItem)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+func (_mr *_MockItemStoreRecorder) SearchItems(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SearchItems", arg0, arg1)
+}
+
 // Mock of BranchStore interface
 type MockBranchStore struct {
 	ctrl     *gomock.Controller
@@ -225,15 +326,15 @@ func (_mr *_MockBranchStoreRecorder) UpdateBranchInTx(arg0, arg1 interface{}) *g
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "UpdateBranchInTx", arg0, arg1)
 }
 
-func (_m *MockBranchStore) GetBranchByUUIDInTx(_param0 *sql.Tx, _param1 string) (*ShBranch, error) {
-	ret := _m.ctrl.Call(_m, "GetBranchByUUIDInTx", _param0, _param1)
+func (_m *MockBranchStore) GetBranchByUUIDInTx(_param0 *sql.Tx, _param1 int, _param2 string) (*ShBranch, error) {
+	ret := _m.ctrl.Call(_m, "GetBranchByUUIDInTx", _param0, _param1, _param2)
 	ret0, _ := ret[0].(*ShBranch)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-func (_mr *_MockBranchStoreRecorder) GetBranchByUUIDInTx(arg0, arg1 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetBranchByUUIDInTx", arg0, arg1)
+func (_mr *_MockBranchStoreRecorder) GetBranchByUUIDInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetBranchByUUIDInTx", arg0, arg1, arg2)
 }
 
 func (_m *MockBranchStore) GetBranchById(_param0 int) (*ShBranch, error) {
@@ -334,6 +435,424 @@ func (_mr *_MockBranchItemStoreRecorder) UpdateBranchItemInTx(arg0, arg1 interfa
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "UpdateBranchItemInTx", arg0, arg1)
 }
 
+func (_m *MockBranchItemStore) LockBranchItemInTx(tnx *sql.Tx, branch_id int, item_id int) error {
+	ret := _m.ctrl.Call(_m, "LockBranchItemInTx", tnx, branch_id, item_id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockBranchItemStoreRecorder) LockBranchItemInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "LockBranchItemInTx", arg0, arg1, arg2)
+}
+
+func (_m *MockBranchItemStore) UpdateBranchItemQuantityInTx(tnx *sql.Tx, branch_id int, item_id int, quantity float64) error {
+	ret := _m.ctrl.Call(_m, "UpdateBranchItemQuantityInTx", tnx, branch_id, item_id, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockBranchItemStoreRecorder) UpdateBranchItemQuantityInTx(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "UpdateBranchItemQuantityInTx", arg0, arg1, arg2, arg3)
+}
+
+func (_m *MockBranchItemStore) GetCompanyBranchItemsInTx(tnx *sql.Tx, company_id int) ([]*ShBranchItem, error) {
+	ret := _m.ctrl.Call(_m, "GetCompanyBranchItemsInTx", tnx, company_id)
+	ret0, _ := ret[0].([]*ShBranchItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockBranchItemStoreRecorder) GetCompanyBranchItemsInTx(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetCompanyBranchItemsInTx", arg0, arg1)
+}
+
+func (_m *MockBranchItemStore) GetBranchReorderReport(company_id int, branch_id int) ([]*ShReorderItem, error) {
+	ret := _m.ctrl.Call(_m, "GetBranchReorderReport", company_id, branch_id)
+	ret0, _ := ret[0].([]*ShReorderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockBranchItemStoreRecorder) GetBranchReorderReport(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetBranchReorderReport", arg0, arg1)
+}
+
+func (_m *MockBranchItemStore) GetCompanyReorderReport(company_id int) ([]*ShReorderItem, error) {
+	ret := _m.ctrl.Call(_m, "GetCompanyReorderReport", company_id)
+	ret0, _ := ret[0].([]*ShReorderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockBranchItemStoreRecorder) GetCompanyReorderReport(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetCompanyReorderReport", arg0)
+}
+
+// Mock of StockLedgerStore interface
+type MockStockLedgerStore struct {
+	ctrl     *gomock.Controller
+	recorder *_MockStockLedgerStoreRecorder
+}
+
+// Recorder for MockStockLedgerStore (not exported)
+type _MockStockLedgerStoreRecorder struct {
+	mock *MockStockLedgerStore
+}
+
+func NewMockStockLedgerStore(ctrl *gomock.Controller) *MockStockLedgerStore {
+	mock := &MockStockLedgerStore{ctrl: ctrl}
+	mock.recorder = &_MockStockLedgerStoreRecorder{mock}
+	return mock
+}
+
+func (_m *MockStockLedgerStore) EXPECT() *_MockStockLedgerStoreRecorder {
+	return _m.recorder
+}
+
+func (_m *MockStockLedgerStore) AddStockLedgerEntriesInTx(tnx *sql.Tx, entries []*ShStockLedgerEntry) error {
+	ret := _m.ctrl.Call(_m, "AddStockLedgerEntriesInTx", tnx, entries)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockStockLedgerStoreRecorder) AddStockLedgerEntriesInTx(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "AddStockLedgerEntriesInTx", arg0, arg1)
+}
+
+func (_m *MockStockLedgerStore) GetStockLedgerQuantityInTx(tnx *sql.Tx, company_id int, branch_id int, item_id int) (float64, error) {
+	ret := _m.ctrl.Call(_m, "GetStockLedgerQuantityInTx", tnx, company_id, branch_id, item_id)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockStockLedgerStoreRecorder) GetStockLedgerQuantityInTx(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetStockLedgerQuantityInTx", arg0, arg1, arg2, arg3)
+}
+
+func (_m *MockStockLedgerStore) GetStockLedgerQuantitiesInTx(tnx *sql.Tx, company_id int) (map[BranchItemPair]float64, error) {
+	ret := _m.ctrl.Call(_m, "GetStockLedgerQuantitiesInTx", tnx, company_id)
+	ret0, _ := ret[0].(map[BranchItemPair]float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockStockLedgerStoreRecorder) GetStockLedgerQuantitiesInTx(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetStockLedgerQuantitiesInTx", arg0, arg1)
+}
+
+func (_m *MockStockLedgerStore) GetStockAsOf(company_id int, branch_id int, as_of int64) ([]*ShStockQuantity, error) {
+	ret := _m.ctrl.Call(_m, "GetStockAsOf", company_id, branch_id, as_of)
+	ret0, _ := ret[0].([]*ShStockQuantity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockStockLedgerStoreRecorder) GetStockAsOf(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetStockAsOf", arg0, arg1, arg2)
+}
+
+func (_m *MockStockLedgerStore) CreateStockSnapshotInTx(tnx *sql.Tx, company_id int, snapshot_date int64) error {
+	ret := _m.ctrl.Call(_m, "CreateStockSnapshotInTx", tnx, company_id, snapshot_date)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockStockLedgerStoreRecorder) CreateStockSnapshotInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "CreateStockSnapshotInTx", arg0, arg1, arg2)
+}
+
+func (_m *MockStockLedgerStore) GetStockLedgerCorrections(company_id int, after int64, until int64) ([]*ShStockLedgerEntry, error) {
+	ret := _m.ctrl.Call(_m, "GetStockLedgerCorrections", company_id, after, until)
+	ret0, _ := ret[0].([]*ShStockLedgerEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockStockLedgerStoreRecorder) GetStockLedgerCorrections(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetStockLedgerCorrections", arg0, arg1, arg2)
+}
+
+func (_m *MockStockLedgerStore) GetStockCostSnapshot(company_id int, costing_method int, before int64) (int64, []*ShCostLayer, error) {
+	ret := _m.ctrl.Call(_m, "GetStockCostSnapshot", company_id, costing_method, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].([]*ShCostLayer)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+func (_mr *_MockStockLedgerStoreRecorder) GetStockCostSnapshot(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetStockCostSnapshot", arg0, arg1, arg2)
+}
+
+func (_m *MockStockLedgerStore) CreateStockCostSnapshotInTx(tnx *sql.Tx, company_id int, costing_method int, snapshot_date int64, layers []*ShCostLayer) error {
+	ret := _m.ctrl.Call(_m, "CreateStockCostSnapshotInTx", tnx, company_id, costing_method, snapshot_date, layers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockStockLedgerStoreRecorder) CreateStockCostSnapshotInTx(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "CreateStockCostSnapshotInTx", arg0, arg1, arg2, arg3, arg4)
+}
+
+// Mock of StockTakeStore interface
+type MockStockTakeStore struct {
+	ctrl     *gomock.Controller
+	recorder *_MockStockTakeStoreRecorder
+}
+
+// Recorder for MockStockTakeStore (not exported)
+type _MockStockTakeStoreRecorder struct {
+	mock *MockStockTakeStore
+}
+
+func NewMockStockTakeStore(ctrl *gomock.Controller) *MockStockTakeStore {
+	mock := &MockStockTakeStore{ctrl: ctrl}
+	mock.recorder = &_MockStockTakeStoreRecorder{mock}
+	return mock
+}
+
+func (_m *MockStockTakeStore) EXPECT() *_MockStockTakeStoreRecorder {
+	return _m.recorder
+}
+
+func (_m *MockStockTakeStore) CreateStockTakeInTx(tnx *sql.Tx, take *ShStockTake) (*ShStockTake, error) {
+	ret := _m.ctrl.Call(_m, "CreateStockTakeInTx", tnx, take)
+	ret0, _ := ret[0].(*ShStockTake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockStockTakeStoreRecorder) CreateStockTakeInTx(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "CreateStockTakeInTx", arg0, arg1)
+}
+
+func (_m *MockStockTakeStore) UpdateStockTakeInTx(tnx *sql.Tx, take *ShStockTake) (*ShStockTake, error) {
+	ret := _m.ctrl.Call(_m, "UpdateStockTakeInTx", tnx, take)
+	ret0, _ := ret[0].(*ShStockTake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockStockTakeStoreRecorder) UpdateStockTakeInTx(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "UpdateStockTakeInTx", arg0, arg1)
+}
+
+func (_m *MockStockTakeStore) SetStockTakeCountInTx(tnx *sql.Tx, item *ShStockTakeItem) error {
+	ret := _m.ctrl.Call(_m, "SetStockTakeCountInTx", tnx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockStockTakeStoreRecorder) SetStockTakeCountInTx(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetStockTakeCountInTx", arg0, arg1)
+}
+
+func (_m *MockStockTakeStore) GetStockTakeInTx(tnx *sql.Tx, company_id int, stock_take_id int) (*ShStockTake, error) {
+	ret := _m.ctrl.Call(_m, "GetStockTakeInTx", tnx, company_id, stock_take_id)
+	ret0, _ := ret[0].(*ShStockTake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockStockTakeStoreRecorder) GetStockTakeInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetStockTakeInTx", arg0, arg1, arg2)
+}
+
+func (_m *MockStockTakeStore) GetOpenStockTakeInTx(tnx *sql.Tx, company_id int, branch_id int) (*ShStockTake, error) {
+	ret := _m.ctrl.Call(_m, "GetOpenStockTakeInTx", tnx, company_id, branch_id)
+	ret0, _ := ret[0].(*ShStockTake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockStockTakeStoreRecorder) GetOpenStockTakeInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetOpenStockTakeInTx", arg0, arg1, arg2)
+}
+
+// Mock of TransferStore interface
+type MockTransferStore struct {
+	ctrl     *gomock.Controller
+	recorder *_MockTransferStoreRecorder
+}
+
+// Recorder for MockTransferStore (not exported)
+type _MockTransferStoreRecorder struct {
+	mock *MockTransferStore
+}
+
+func NewMockTransferStore(ctrl *gomock.Controller) *MockTransferStore {
+	mock := &MockTransferStore{ctrl: ctrl}
+	mock.recorder = &_MockTransferStoreRecorder{mock}
+	return mock
+}
+
+func (_m *MockTransferStore) EXPECT() *_MockTransferStoreRecorder {
+	return _m.recorder
+}
+
+func (_m *MockTransferStore) CreateTransferInTx(tnx *sql.Tx, transfer *ShTransfer) (*ShTransfer, error) {
+	ret := _m.ctrl.Call(_m, "CreateTransferInTx", tnx, transfer)
+	ret0, _ := ret[0].(*ShTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockTransferStoreRecorder) CreateTransferInTx(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "CreateTransferInTx", arg0, arg1)
+}
+
+func (_m *MockTransferStore) ReceiveTransferInTx(tnx *sql.Tx, transfer *ShTransfer) (*ShTransfer, error) {
+	ret := _m.ctrl.Call(_m, "ReceiveTransferInTx", tnx, transfer)
+	ret0, _ := ret[0].(*ShTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockTransferStoreRecorder) ReceiveTransferInTx(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ReceiveTransferInTx", arg0, arg1)
+}
+
+func (_m *MockTransferStore) GetTransferInTx(tnx *sql.Tx, company_id int, transfer_id int) (*ShTransfer, error) {
+	ret := _m.ctrl.Call(_m, "GetTransferInTx", tnx, company_id, transfer_id)
+	ret0, _ := ret[0].(*ShTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockTransferStoreRecorder) GetTransferInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetTransferInTx", arg0, arg1, arg2)
+}
+
+func (_m *MockTransferStore) GetTransferByUUIDInTx(tnx *sql.Tx, company_id int, uid string) (*ShTransfer, error) {
+	ret := _m.ctrl.Call(_m, "GetTransferByUUIDInTx", tnx, company_id, uid)
+	ret0, _ := ret[0].(*ShTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockTransferStoreRecorder) GetTransferByUUIDInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetTransferByUUIDInTx", arg0, arg1, arg2)
+}
+
+func (_m *MockTransferStore) GetPendingTransfers(company_id int) ([]*ShTransfer, error) {
+	ret := _m.ctrl.Call(_m, "GetPendingTransfers", company_id)
+	ret0, _ := ret[0].([]*ShTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockTransferStoreRecorder) GetPendingTransfers(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetPendingTransfers", arg0)
+}
+
+// Mock of ItemPriceStore interface
+type MockItemPriceStore struct {
+	ctrl     *gomock.Controller
+	recorder *_MockItemPriceStoreRecorder
+}
+
+// Recorder for MockItemPriceStore (not exported)
+type _MockItemPriceStoreRecorder struct {
+	mock *MockItemPriceStore
+}
+
+func NewMockItemPriceStore(ctrl *gomock.Controller) *MockItemPriceStore {
+	mock := &MockItemPriceStore{ctrl: ctrl}
+	mock.recorder = &_MockItemPriceStoreRecorder{mock}
+	return mock
+}
+
+func (_m *MockItemPriceStore) EXPECT() *_MockItemPriceStoreRecorder {
+	return _m.recorder
+}
+
+func (_m *MockItemPriceStore) AddItemPriceInTx(tnx *sql.Tx, price *ShItemPrice) error {
+	ret := _m.ctrl.Call(_m, "AddItemPriceInTx", tnx, price)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockItemPriceStoreRecorder) AddItemPriceInTx(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "AddItemPriceInTx", arg0, arg1)
+}
+
+func (_m *MockItemPriceStore) GetItemPriceHistory(company_id int, item_id int) ([]*ShItemPrice, error) {
+	ret := _m.ctrl.Call(_m, "GetItemPriceHistory", company_id, item_id)
+	ret0, _ := ret[0].([]*ShItemPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockItemPriceStoreRecorder) GetItemPriceHistory(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetItemPriceHistory", arg0, arg1)
+}
+
+func (_m *MockItemPriceStore) GetItemPriceAsOfInTx(tnx *sql.Tx, company_id int, branch_id int, item_id int, date int64) (*ShItemPrice, error) {
+	ret := _m.ctrl.Call(_m, "GetItemPriceAsOfInTx", tnx, company_id, branch_id, item_id, date)
+	ret0, _ := ret[0].(*ShItemPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockItemPriceStoreRecorder) GetItemPriceAsOfInTx(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetItemPriceAsOfInTx", arg0, arg1, arg2, arg3, arg4)
+}
+
+func (_m *MockItemPriceStore) GetItemCostPrices(company_id int) (map[int]float64, error) {
+	ret := _m.ctrl.Call(_m, "GetItemCostPrices", company_id)
+	ret0, _ := ret[0].(map[int]float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockItemPriceStoreRecorder) GetItemCostPrices(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetItemCostPrices", arg0)
+}
+
+// Mock of ReportStore interface
+type MockReportStore struct {
+	ctrl     *gomock.Controller
+	recorder *_MockReportStoreRecorder
+}
+
+// Recorder for MockReportStore (not exported)
+type _MockReportStoreRecorder struct {
+	mock *MockReportStore
+}
+
+func NewMockReportStore(ctrl *gomock.Controller) *MockReportStore {
+	mock := &MockReportStore{ctrl: ctrl}
+	mock.recorder = &_MockReportStoreRecorder{mock}
+	return mock
+}
+
+func (_m *MockReportStore) EXPECT() *_MockReportStoreRecorder {
+	return _m.recorder
+}
+
+func (_m *MockReportStore) GetSalesReport(filter *ShSalesReportFilter) ([]*ShSalesTotal, error) {
+	ret := _m.ctrl.Call(_m, "GetSalesReport", filter)
+	ret0, _ := ret[0].([]*ShSalesTotal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockReportStoreRecorder) GetSalesReport(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetSalesReport", arg0)
+}
+
+func (_m *MockReportStore) GetItemMovements(company_id int, branch_id int, item_id int, start_date int64, end_date int64) ([]*ShItemMovement, error) {
+	ret := _m.ctrl.Call(_m, "GetItemMovements", company_id, branch_id, item_id, start_date, end_date)
+	ret0, _ := ret[0].([]*ShItemMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockReportStoreRecorder) GetItemMovements(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetItemMovements", arg0, arg1, arg2, arg3, arg4)
+}
+
 // Mock of CompanyStore interface
 type MockCompanyStore struct {
 	ctrl     *gomock.Controller
@@ -388,6 +907,17 @@ func (_mr *_MockCompanyStoreRecorder) GetCompanyById(arg0 interface{}) *gomock.C
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetCompanyById", arg0)
 }
 
+func (_m *MockCompanyStore) ListCompanies() ([]*Company, error) {
+	ret := _m.ctrl.Call(_m, "ListCompanies")
+	ret0, _ := ret[0].([]*Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockCompanyStoreRecorder) ListCompanies() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ListCompanies")
+}
+
 func (_m *MockCompanyStore) UpdateCompanyInTx(_param0 *sql.Tx, _param1 *Company) (*Company, error) {
 	ret := _m.ctrl.Call(_m, "UpdateCompanyInTx", _param0, _param1)
 	ret0, _ := ret[0].(*Company)
@@ -573,6 +1103,19 @@ func (_mr *_MockRevisionStoreRecorder) GetRevisionsSince(arg0 interface{}) *gomo
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetRevisionsSince", arg0)
 }
 
+func (_m *MockRevisionStore) GetRevisionPageSince(start_from *ShEntityRevision, page_size int) (int, []*ShEntityRevision, bool, error) {
+	ret := _m.ctrl.Call(_m, "GetRevisionPageSince", start_from, page_size)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]*ShEntityRevision)
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+func (_mr *_MockRevisionStoreRecorder) GetRevisionPageSince(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetRevisionPageSince", arg0, arg1)
+}
+
 // Mock of Source interface
 type MockSource struct {
 	ctrl     *gomock.Controller
@@ -615,6 +1158,17 @@ func (_mr *_MockSourceRecorder) Begin() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Begin")
 }
 
+func (_m *MockSource) BeginRepeatableRead() (*sql.Tx, error) {
+	ret := _m.ctrl.Call(_m, "BeginRepeatableRead")
+	ret0, _ := ret[0].(*sql.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockSourceRecorder) BeginRepeatableRead() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "BeginRepeatableRead")
+}
+
 // Mock of CategoryStore interface
 type MockCategoryStore struct {
 	ctrl     *gomock.Controller
@@ -669,15 +1223,15 @@ func (_mr *_MockCategoryStoreRecorder) GetCategoryByIdInTx(arg0, arg1 interface{
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetCategoryByIdInTx", arg0, arg1)
 }
 
-func (_m *MockCategoryStore) GetCategoryByUUIDInTx(_param0 *sql.Tx, _param1 string) (*ShCategory, error) {
-	ret := _m.ctrl.Call(_m, "GetCategoryByUUIDInTx", _param0, _param1)
+func (_m *MockCategoryStore) GetCategoryByUUIDInTx(_param0 *sql.Tx, _param1 int, _param2 string) (*ShCategory, error) {
+	ret := _m.ctrl.Call(_m, "GetCategoryByUUIDInTx", _param0, _param1, _param2)
 	ret0, _ := ret[0].(*ShCategory)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-func (_mr *_MockCategoryStoreRecorder) GetCategoryByUUIDInTx(arg0, arg1 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetCategoryByUUIDInTx", arg0, arg1)
+func (_mr *_MockCategoryStoreRecorder) GetCategoryByUUIDInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetCategoryByUUIDInTx", arg0, arg1, arg2)
 }
 
 func (_m *MockCategoryStore) UpdateCategoryInTx(_param0 *sql.Tx, _param1 *ShCategory) (*ShCategory, error) {
@@ -701,6 +1255,52 @@ func (_mr *_MockCategoryStoreRecorder) DeleteCategoryInTx(arg0, arg1 interface{}
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "DeleteCategoryInTx", arg0, arg1)
 }
 
+func (_m *MockCategoryStore) IsCategoryAncestorInTx(tnx *sql.Tx, ancestor_id int, category_id int) (bool, error) {
+	ret := _m.ctrl.Call(_m, "IsCategoryAncestorInTx", tnx, ancestor_id, category_id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockCategoryStoreRecorder) IsCategoryAncestorInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "IsCategoryAncestorInTx", arg0, arg1, arg2)
+}
+
+func (_m *MockCategoryStore) IsCategoryEmptyInTx(tnx *sql.Tx, category_id int) (bool, error) {
+	ret := _m.ctrl.Call(_m, "IsCategoryEmptyInTx", tnx, category_id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockCategoryStoreRecorder) IsCategoryEmptyInTx(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "IsCategoryEmptyInTx", arg0, arg1)
+}
+
+func (_m *MockCategoryStore) MoveCategoryChildrenInTx(tnx *sql.Tx, category_id int, new_parent_id int) ([]int, []int, error) {
+	ret := _m.ctrl.Call(_m, "MoveCategoryChildrenInTx", tnx, category_id, new_parent_id)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].([]int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+func (_mr *_MockCategoryStoreRecorder) MoveCategoryChildrenInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "MoveCategoryChildrenInTx", arg0, arg1, arg2)
+}
+
+func (_m *MockCategoryStore) DeleteCategorySubtreeInTx(tnx *sql.Tx, category_id int, items_parent_id int) ([]int, []int, error) {
+	ret := _m.ctrl.Call(_m, "DeleteCategorySubtreeInTx", tnx, category_id, items_parent_id)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].([]int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+func (_mr *_MockCategoryStoreRecorder) DeleteCategorySubtreeInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "DeleteCategorySubtreeInTx", arg0, arg1, arg2)
+}
+
 // Mock of BranchCategoryStore interface
 type MockBranchCategoryStore struct {
 	ctrl     *gomock.Controller
@@ -765,6 +1365,17 @@ func (_mr *_MockBranchCategoryStoreRecorder) DeleteBranchCategoryInTx(arg0, arg1
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "DeleteBranchCategoryInTx", arg0, arg1, arg2)
 }
 
+func (_m *MockBranchCategoryStore) DeleteCategoryFromBranchesInTx(tnx *sql.Tx, category_id int, include_subtree bool) ([]*ShBranchCategory, error) {
+	ret := _m.ctrl.Call(_m, "DeleteCategoryFromBranchesInTx", tnx, category_id, include_subtree)
+	ret0, _ := ret[0].([]*ShBranchCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockBranchCategoryStoreRecorder) DeleteCategoryFromBranchesInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "DeleteCategoryFromBranchesInTx", arg0, arg1, arg2)
+}
+
 // Mock of ShStore interface
 type MockShStore struct {
 	ctrl     *gomock.Controller
@@ -815,30 +1426,108 @@ func (_m *MockShStore) GetShTransactionById(company_id int, trans_id int64, fetc
 	return ret0, ret1
 }
 
-func (_mr *_MockShStoreRecorder) GetShTransactionById(arg0, arg1, arg2 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetShTransactionById", arg0, arg1, arg2)
+func (_mr *_MockShStoreRecorder) GetShTransactionById(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetShTransactionById", arg0, arg1, arg2)
+}
+
+func (_m *MockShStore) GetShTransactionByUUIDInTx(_param0 *sql.Tx, _param1 int, _param2 string) (*ShTransaction, error) {
+	ret := _m.ctrl.Call(_m, "GetShTransactionByUUIDInTx", _param0, _param1, _param2)
+	ret0, _ := ret[0].(*ShTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) GetShTransactionByUUIDInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetShTransactionByUUIDInTx", arg0, arg1, arg2)
+}
+
+func (_m *MockShStore) GetShTransactionByIdInTx(tnx *sql.Tx, company_id int, trans_id int64, fetch_items bool) (*ShTransaction, error) {
+	ret := _m.ctrl.Call(_m, "GetShTransactionByIdInTx", tnx, company_id, trans_id, fetch_items)
+	ret0, _ := ret[0].(*ShTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) GetShTransactionByIdInTx(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetShTransactionByIdInTx", arg0, arg1, arg2, arg3)
+}
+
+func (_m *MockShStore) SetShTransactionVoidedInTx(tnx *sql.Tx, company_id int, trans_id int64, voided_by_trans_id int64) error {
+	ret := _m.ctrl.Call(_m, "SetShTransactionVoidedInTx", tnx, company_id, trans_id, voided_by_trans_id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockShStoreRecorder) SetShTransactionVoidedInTx(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetShTransactionVoidedInTx", arg0, arg1, arg2, arg3)
+}
+
+func (_m *MockShStore) GetShTransactionSinceTransId(company_id int, prev_trans_id int64) ([]*ShTransaction, error) {
+	ret := _m.ctrl.Call(_m, "GetShTransactionSinceTransId", company_id, prev_trans_id)
+	ret0, _ := ret[0].([]*ShTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) GetShTransactionSinceTransId(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetShTransactionSinceTransId", arg0, arg1)
+}
+
+func (_m *MockShStore) GetCompanyTransactionsInTx(tnx *sql.Tx, company_id int) ([]*ShTransaction, error) {
+	ret := _m.ctrl.Call(_m, "GetCompanyTransactionsInTx", tnx, company_id)
+	ret0, _ := ret[0].([]*ShTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) GetCompanyTransactionsInTx(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetCompanyTransactionsInTx", arg0, arg1)
+}
+
+func (_m *MockShStore) GetLinkedShTransactionsInTx(tnx *sql.Tx, company_id int, trans_id int64) ([]*ShTransaction, error) {
+	ret := _m.ctrl.Call(_m, "GetLinkedShTransactionsInTx", tnx, company_id, trans_id)
+	ret0, _ := ret[0].([]*ShTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) GetLinkedShTransactionsInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetLinkedShTransactionsInTx", arg0, arg1, arg2)
 }
 
-func (_m *MockShStore) GetShTransactionByUUIDInTx(_param0 *sql.Tx, _param1 string) (*ShTransaction, error) {
-	ret := _m.ctrl.Call(_m, "GetShTransactionByUUIDInTx", _param0, _param1)
-	ret0, _ := ret[0].(*ShTransaction)
+func (_m *MockShStore) GetCompanyTransactionsBetween(company_id int, after int64, until int64) ([]*ShTransaction, error) {
+	ret := _m.ctrl.Call(_m, "GetCompanyTransactionsBetween", company_id, after, until)
+	ret0, _ := ret[0].([]*ShTransaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-func (_mr *_MockShStoreRecorder) GetShTransactionByUUIDInTx(arg0, arg1 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetShTransactionByUUIDInTx", arg0, arg1)
+func (_mr *_MockShStoreRecorder) GetCompanyTransactionsBetween(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetCompanyTransactionsBetween", arg0, arg1, arg2)
 }
 
-func (_m *MockShStore) GetShTransactionSinceTransId(company_id int, prev_trans_id int64) ([]*ShTransaction, error) {
-	ret := _m.ctrl.Call(_m, "GetShTransactionSinceTransId", company_id, prev_trans_id)
+func (_m *MockShStore) GetShTransactionPageSinceTransId(company_id int, prev_trans_id int64, page_size int) ([]*ShTransaction, bool, error) {
+	ret := _m.ctrl.Call(_m, "GetShTransactionPageSinceTransId", company_id, prev_trans_id, page_size)
 	ret0, _ := ret[0].([]*ShTransaction)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-func (_mr *_MockShStoreRecorder) GetShTransactionSinceTransId(arg0, arg1 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetShTransactionSinceTransId", arg0, arg1)
+func (_mr *_MockShStoreRecorder) GetShTransactionPageSinceTransId(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetShTransactionPageSinceTransId", arg0, arg1, arg2)
+}
+
+func (_m *MockShStore) GetShTransactionHistory(filter *ShTransactionFilter, page_size int) ([]*ShTransaction, bool, error) {
+	ret := _m.ctrl.Call(_m, "GetShTransactionHistory", filter, page_size)
+	ret0, _ := ret[0].([]*ShTransaction)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+func (_mr *_MockShStoreRecorder) GetShTransactionHistory(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetShTransactionHistory", arg0, arg1)
 }
 
 func (_m *MockShStore) CreateItem(_param0 *ShItem) (*ShItem, error) {
@@ -885,15 +1574,15 @@ func (_mr *_MockShStoreRecorder) GetItemById(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetItemById", arg0)
 }
 
-func (_m *MockShStore) GetItemByUUIDInTx(_param0 *sql.Tx, _param1 string) (*ShItem, error) {
-	ret := _m.ctrl.Call(_m, "GetItemByUUIDInTx", _param0, _param1)
+func (_m *MockShStore) GetItemByUUIDInTx(_param0 *sql.Tx, _param1 int, _param2 string) (*ShItem, error) {
+	ret := _m.ctrl.Call(_m, "GetItemByUUIDInTx", _param0, _param1, _param2)
 	ret0, _ := ret[0].(*ShItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-func (_mr *_MockShStoreRecorder) GetItemByUUIDInTx(arg0, arg1 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetItemByUUIDInTx", arg0, arg1)
+func (_mr *_MockShStoreRecorder) GetItemByUUIDInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetItemByUUIDInTx", arg0, arg1, arg2)
 }
 
 func (_m *MockShStore) GetItemByIdInTx(_param0 *sql.Tx, _param1 int) (*ShItem, error) {
@@ -907,6 +1596,29 @@ func (_mr *_MockShStoreRecorder) GetItemByIdInTx(arg0, arg1 interface{}) *gomock
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetItemByIdInTx", arg0, arg1)
 }
 
+func (_m *MockShStore) GetItemByBarCode(company_id int, bar_code string) (*ShItem, error) {
+	ret := _m.ctrl.Call(_m, "GetItemByBarCode", company_id, bar_code)
+	ret0, _ := ret[0].(*ShItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) GetItemByBarCode(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetItemByBarCode", arg0, arg1)
+}
+
+func (_m *MockShStore) SearchItems(search *ShItemSearch, page_size int) ([]*ShItem, bool, error) {
+	ret := _m.ctrl.Call(_m, "SearchItems", search, page_size)
+	ret0, _ := ret[0].([]*ShItem)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+func (_mr *_MockShStoreRecorder) SearchItems(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SearchItems", arg0, arg1)
+}
+
 func (_m *MockShStore) CreateCategoryInTx(_param0 *sql.Tx, _param1 *ShCategory) (*ShCategory, error) {
 	ret := _m.ctrl.Call(_m, "CreateCategoryInTx", _param0, _param1)
 	ret0, _ := ret[0].(*ShCategory)
@@ -940,15 +1652,15 @@ func (_mr *_MockShStoreRecorder) GetCategoryByIdInTx(arg0, arg1 interface{}) *go
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetCategoryByIdInTx", arg0, arg1)
 }
 
-func (_m *MockShStore) GetCategoryByUUIDInTx(_param0 *sql.Tx, _param1 string) (*ShCategory, error) {
-	ret := _m.ctrl.Call(_m, "GetCategoryByUUIDInTx", _param0, _param1)
+func (_m *MockShStore) GetCategoryByUUIDInTx(_param0 *sql.Tx, _param1 int, _param2 string) (*ShCategory, error) {
+	ret := _m.ctrl.Call(_m, "GetCategoryByUUIDInTx", _param0, _param1, _param2)
 	ret0, _ := ret[0].(*ShCategory)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-func (_mr *_MockShStoreRecorder) GetCategoryByUUIDInTx(arg0, arg1 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetCategoryByUUIDInTx", arg0, arg1)
+func (_mr *_MockShStoreRecorder) GetCategoryByUUIDInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetCategoryByUUIDInTx", arg0, arg1, arg2)
 }
 
 func (_m *MockShStore) UpdateCategoryInTx(_param0 *sql.Tx, _param1 *ShCategory) (*ShCategory, error) {
@@ -972,6 +1684,52 @@ func (_mr *_MockShStoreRecorder) DeleteCategoryInTx(arg0, arg1 interface{}) *gom
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "DeleteCategoryInTx", arg0, arg1)
 }
 
+func (_m *MockShStore) IsCategoryAncestorInTx(tnx *sql.Tx, ancestor_id int, category_id int) (bool, error) {
+	ret := _m.ctrl.Call(_m, "IsCategoryAncestorInTx", tnx, ancestor_id, category_id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) IsCategoryAncestorInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "IsCategoryAncestorInTx", arg0, arg1, arg2)
+}
+
+func (_m *MockShStore) IsCategoryEmptyInTx(tnx *sql.Tx, category_id int) (bool, error) {
+	ret := _m.ctrl.Call(_m, "IsCategoryEmptyInTx", tnx, category_id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) IsCategoryEmptyInTx(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "IsCategoryEmptyInTx", arg0, arg1)
+}
+
+func (_m *MockShStore) MoveCategoryChildrenInTx(tnx *sql.Tx, category_id int, new_parent_id int) ([]int, []int, error) {
+	ret := _m.ctrl.Call(_m, "MoveCategoryChildrenInTx", tnx, category_id, new_parent_id)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].([]int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+func (_mr *_MockShStoreRecorder) MoveCategoryChildrenInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "MoveCategoryChildrenInTx", arg0, arg1, arg2)
+}
+
+func (_m *MockShStore) DeleteCategorySubtreeInTx(tnx *sql.Tx, category_id int, items_parent_id int) ([]int, []int, error) {
+	ret := _m.ctrl.Call(_m, "DeleteCategorySubtreeInTx", tnx, category_id, items_parent_id)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].([]int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+func (_mr *_MockShStoreRecorder) DeleteCategorySubtreeInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "DeleteCategorySubtreeInTx", arg0, arg1, arg2)
+}
+
 func (_m *MockShStore) AddCategoryToBranchInTx(_param0 *sql.Tx, _param1 *ShBranchCategory) (*ShBranchCategory, error) {
 	ret := _m.ctrl.Call(_m, "AddCategoryToBranchInTx", _param0, _param1)
 	ret0, _ := ret[0].(*ShBranchCategory)
@@ -1015,6 +1773,17 @@ func (_mr *_MockShStoreRecorder) DeleteBranchCategoryInTx(arg0, arg1, arg2 inter
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "DeleteBranchCategoryInTx", arg0, arg1, arg2)
 }
 
+func (_m *MockShStore) DeleteCategoryFromBranchesInTx(tnx *sql.Tx, category_id int, include_subtree bool) ([]*ShBranchCategory, error) {
+	ret := _m.ctrl.Call(_m, "DeleteCategoryFromBranchesInTx", tnx, category_id, include_subtree)
+	ret0, _ := ret[0].([]*ShBranchCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) DeleteCategoryFromBranchesInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "DeleteCategoryFromBranchesInTx", arg0, arg1, arg2)
+}
+
 func (_m *MockShStore) CreateBranch(_param0 *ShBranch) (*ShBranch, error) {
 	ret := _m.ctrl.Call(_m, "CreateBranch", _param0)
 	ret0, _ := ret[0].(*ShBranch)
@@ -1048,15 +1817,15 @@ func (_mr *_MockShStoreRecorder) UpdateBranchInTx(arg0, arg1 interface{}) *gomoc
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "UpdateBranchInTx", arg0, arg1)
 }
 
-func (_m *MockShStore) GetBranchByUUIDInTx(_param0 *sql.Tx, _param1 string) (*ShBranch, error) {
-	ret := _m.ctrl.Call(_m, "GetBranchByUUIDInTx", _param0, _param1)
+func (_m *MockShStore) GetBranchByUUIDInTx(_param0 *sql.Tx, _param1 int, _param2 string) (*ShBranch, error) {
+	ret := _m.ctrl.Call(_m, "GetBranchByUUIDInTx", _param0, _param1, _param2)
 	ret0, _ := ret[0].(*ShBranch)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-func (_mr *_MockShStoreRecorder) GetBranchByUUIDInTx(arg0, arg1 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetBranchByUUIDInTx", arg0, arg1)
+func (_mr *_MockShStoreRecorder) GetBranchByUUIDInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetBranchByUUIDInTx", arg0, arg1, arg2)
 }
 
 func (_m *MockShStore) GetBranchById(_param0 int) (*ShBranch, error) {
@@ -1136,6 +1905,319 @@ func (_mr *_MockShStoreRecorder) UpdateBranchItemInTx(arg0, arg1 interface{}) *g
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "UpdateBranchItemInTx", arg0, arg1)
 }
 
+func (_m *MockShStore) LockBranchItemInTx(tnx *sql.Tx, branch_id int, item_id int) error {
+	ret := _m.ctrl.Call(_m, "LockBranchItemInTx", tnx, branch_id, item_id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockShStoreRecorder) LockBranchItemInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "LockBranchItemInTx", arg0, arg1, arg2)
+}
+
+func (_m *MockShStore) UpdateBranchItemQuantityInTx(tnx *sql.Tx, branch_id int, item_id int, quantity float64) error {
+	ret := _m.ctrl.Call(_m, "UpdateBranchItemQuantityInTx", tnx, branch_id, item_id, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockShStoreRecorder) UpdateBranchItemQuantityInTx(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "UpdateBranchItemQuantityInTx", arg0, arg1, arg2, arg3)
+}
+
+func (_m *MockShStore) GetCompanyBranchItemsInTx(tnx *sql.Tx, company_id int) ([]*ShBranchItem, error) {
+	ret := _m.ctrl.Call(_m, "GetCompanyBranchItemsInTx", tnx, company_id)
+	ret0, _ := ret[0].([]*ShBranchItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) GetCompanyBranchItemsInTx(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetCompanyBranchItemsInTx", arg0, arg1)
+}
+
+func (_m *MockShStore) GetBranchReorderReport(company_id int, branch_id int) ([]*ShReorderItem, error) {
+	ret := _m.ctrl.Call(_m, "GetBranchReorderReport", company_id, branch_id)
+	ret0, _ := ret[0].([]*ShReorderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) GetBranchReorderReport(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetBranchReorderReport", arg0, arg1)
+}
+
+func (_m *MockShStore) GetCompanyReorderReport(company_id int) ([]*ShReorderItem, error) {
+	ret := _m.ctrl.Call(_m, "GetCompanyReorderReport", company_id)
+	ret0, _ := ret[0].([]*ShReorderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) GetCompanyReorderReport(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetCompanyReorderReport", arg0)
+}
+
+func (_m *MockShStore) AddStockLedgerEntriesInTx(tnx *sql.Tx, entries []*ShStockLedgerEntry) error {
+	ret := _m.ctrl.Call(_m, "AddStockLedgerEntriesInTx", tnx, entries)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockShStoreRecorder) AddStockLedgerEntriesInTx(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "AddStockLedgerEntriesInTx", arg0, arg1)
+}
+
+func (_m *MockShStore) GetStockLedgerQuantityInTx(tnx *sql.Tx, company_id int, branch_id int, item_id int) (float64, error) {
+	ret := _m.ctrl.Call(_m, "GetStockLedgerQuantityInTx", tnx, company_id, branch_id, item_id)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) GetStockLedgerQuantityInTx(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetStockLedgerQuantityInTx", arg0, arg1, arg2, arg3)
+}
+
+func (_m *MockShStore) GetStockLedgerQuantitiesInTx(tnx *sql.Tx, company_id int) (map[BranchItemPair]float64, error) {
+	ret := _m.ctrl.Call(_m, "GetStockLedgerQuantitiesInTx", tnx, company_id)
+	ret0, _ := ret[0].(map[BranchItemPair]float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) GetStockLedgerQuantitiesInTx(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetStockLedgerQuantitiesInTx", arg0, arg1)
+}
+
+func (_m *MockShStore) GetStockAsOf(company_id int, branch_id int, as_of int64) ([]*ShStockQuantity, error) {
+	ret := _m.ctrl.Call(_m, "GetStockAsOf", company_id, branch_id, as_of)
+	ret0, _ := ret[0].([]*ShStockQuantity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) GetStockAsOf(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetStockAsOf", arg0, arg1, arg2)
+}
+
+func (_m *MockShStore) CreateStockSnapshotInTx(tnx *sql.Tx, company_id int, snapshot_date int64) error {
+	ret := _m.ctrl.Call(_m, "CreateStockSnapshotInTx", tnx, company_id, snapshot_date)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockShStoreRecorder) CreateStockSnapshotInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "CreateStockSnapshotInTx", arg0, arg1, arg2)
+}
+
+func (_m *MockShStore) GetStockLedgerCorrections(company_id int, after int64, until int64) ([]*ShStockLedgerEntry, error) {
+	ret := _m.ctrl.Call(_m, "GetStockLedgerCorrections", company_id, after, until)
+	ret0, _ := ret[0].([]*ShStockLedgerEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) GetStockLedgerCorrections(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetStockLedgerCorrections", arg0, arg1, arg2)
+}
+
+func (_m *MockShStore) GetStockCostSnapshot(company_id int, costing_method int, before int64) (int64, []*ShCostLayer, error) {
+	ret := _m.ctrl.Call(_m, "GetStockCostSnapshot", company_id, costing_method, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].([]*ShCostLayer)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+func (_mr *_MockShStoreRecorder) GetStockCostSnapshot(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetStockCostSnapshot", arg0, arg1, arg2)
+}
+
+func (_m *MockShStore) CreateStockCostSnapshotInTx(tnx *sql.Tx, company_id int, costing_method int, snapshot_date int64, layers []*ShCostLayer) error {
+	ret := _m.ctrl.Call(_m, "CreateStockCostSnapshotInTx", tnx, company_id, costing_method, snapshot_date, layers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockShStoreRecorder) CreateStockCostSnapshotInTx(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "CreateStockCostSnapshotInTx", arg0, arg1, arg2, arg3, arg4)
+}
+
+func (_m *MockShStore) CreateStockTakeInTx(tnx *sql.Tx, take *ShStockTake) (*ShStockTake, error) {
+	ret := _m.ctrl.Call(_m, "CreateStockTakeInTx", tnx, take)
+	ret0, _ := ret[0].(*ShStockTake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) CreateStockTakeInTx(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "CreateStockTakeInTx", arg0, arg1)
+}
+
+func (_m *MockShStore) UpdateStockTakeInTx(tnx *sql.Tx, take *ShStockTake) (*ShStockTake, error) {
+	ret := _m.ctrl.Call(_m, "UpdateStockTakeInTx", tnx, take)
+	ret0, _ := ret[0].(*ShStockTake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) UpdateStockTakeInTx(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "UpdateStockTakeInTx", arg0, arg1)
+}
+
+func (_m *MockShStore) SetStockTakeCountInTx(tnx *sql.Tx, item *ShStockTakeItem) error {
+	ret := _m.ctrl.Call(_m, "SetStockTakeCountInTx", tnx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockShStoreRecorder) SetStockTakeCountInTx(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetStockTakeCountInTx", arg0, arg1)
+}
+
+func (_m *MockShStore) GetStockTakeInTx(tnx *sql.Tx, company_id int, stock_take_id int) (*ShStockTake, error) {
+	ret := _m.ctrl.Call(_m, "GetStockTakeInTx", tnx, company_id, stock_take_id)
+	ret0, _ := ret[0].(*ShStockTake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) GetStockTakeInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetStockTakeInTx", arg0, arg1, arg2)
+}
+
+func (_m *MockShStore) GetOpenStockTakeInTx(tnx *sql.Tx, company_id int, branch_id int) (*ShStockTake, error) {
+	ret := _m.ctrl.Call(_m, "GetOpenStockTakeInTx", tnx, company_id, branch_id)
+	ret0, _ := ret[0].(*ShStockTake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) GetOpenStockTakeInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetOpenStockTakeInTx", arg0, arg1, arg2)
+}
+
+func (_m *MockShStore) CreateTransferInTx(tnx *sql.Tx, transfer *ShTransfer) (*ShTransfer, error) {
+	ret := _m.ctrl.Call(_m, "CreateTransferInTx", tnx, transfer)
+	ret0, _ := ret[0].(*ShTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) CreateTransferInTx(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "CreateTransferInTx", arg0, arg1)
+}
+
+func (_m *MockShStore) ReceiveTransferInTx(tnx *sql.Tx, transfer *ShTransfer) (*ShTransfer, error) {
+	ret := _m.ctrl.Call(_m, "ReceiveTransferInTx", tnx, transfer)
+	ret0, _ := ret[0].(*ShTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) ReceiveTransferInTx(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ReceiveTransferInTx", arg0, arg1)
+}
+
+func (_m *MockShStore) GetTransferInTx(tnx *sql.Tx, company_id int, transfer_id int) (*ShTransfer, error) {
+	ret := _m.ctrl.Call(_m, "GetTransferInTx", tnx, company_id, transfer_id)
+	ret0, _ := ret[0].(*ShTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) GetTransferInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetTransferInTx", arg0, arg1, arg2)
+}
+
+func (_m *MockShStore) GetTransferByUUIDInTx(tnx *sql.Tx, company_id int, uid string) (*ShTransfer, error) {
+	ret := _m.ctrl.Call(_m, "GetTransferByUUIDInTx", tnx, company_id, uid)
+	ret0, _ := ret[0].(*ShTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) GetTransferByUUIDInTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetTransferByUUIDInTx", arg0, arg1, arg2)
+}
+
+func (_m *MockShStore) GetPendingTransfers(company_id int) ([]*ShTransfer, error) {
+	ret := _m.ctrl.Call(_m, "GetPendingTransfers", company_id)
+	ret0, _ := ret[0].([]*ShTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) GetPendingTransfers(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetPendingTransfers", arg0)
+}
+
+func (_m *MockShStore) AddItemPriceInTx(tnx *sql.Tx, price *ShItemPrice) error {
+	ret := _m.ctrl.Call(_m, "AddItemPriceInTx", tnx, price)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockShStoreRecorder) AddItemPriceInTx(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "AddItemPriceInTx", arg0, arg1)
+}
+
+func (_m *MockShStore) GetItemPriceHistory(company_id int, item_id int) ([]*ShItemPrice, error) {
+	ret := _m.ctrl.Call(_m, "GetItemPriceHistory", company_id, item_id)
+	ret0, _ := ret[0].([]*ShItemPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) GetItemPriceHistory(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetItemPriceHistory", arg0, arg1)
+}
+
+func (_m *MockShStore) GetItemPriceAsOfInTx(tnx *sql.Tx, company_id int, branch_id int, item_id int, date int64) (*ShItemPrice, error) {
+	ret := _m.ctrl.Call(_m, "GetItemPriceAsOfInTx", tnx, company_id, branch_id, item_id, date)
+	ret0, _ := ret[0].(*ShItemPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) GetItemPriceAsOfInTx(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetItemPriceAsOfInTx", arg0, arg1, arg2, arg3, arg4)
+}
+
+func (_m *MockShStore) GetItemCostPrices(company_id int) (map[int]float64, error) {
+	ret := _m.ctrl.Call(_m, "GetItemCostPrices", company_id)
+	ret0, _ := ret[0].(map[int]float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) GetItemCostPrices(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetItemCostPrices", arg0)
+}
+
+func (_m *MockShStore) GetSalesReport(filter *ShSalesReportFilter) ([]*ShSalesTotal, error) {
+	ret := _m.ctrl.Call(_m, "GetSalesReport", filter)
+	ret0, _ := ret[0].([]*ShSalesTotal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) GetSalesReport(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetSalesReport", arg0)
+}
+
+func (_m *MockShStore) GetItemMovements(company_id int, branch_id int, item_id int, start_date int64, end_date int64) ([]*ShItemMovement, error) {
+	ret := _m.ctrl.Call(_m, "GetItemMovements", company_id, branch_id, item_id, start_date, end_date)
+	ret0, _ := ret[0].([]*ShItemMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) GetItemMovements(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetItemMovements", arg0, arg1, arg2, arg3, arg4)
+}
+
 func (_m *MockShStore) CreateCompany(u *User, c *Company) (*Company, error) {
 	ret := _m.ctrl.Call(_m, "CreateCompany", u, c)
 	ret0, _ := ret[0].(*Company)
@@ -1169,6 +2251,17 @@ func (_mr *_MockShStoreRecorder) GetCompanyById(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetCompanyById", arg0)
 }
 
+func (_m *MockShStore) ListCompanies() ([]*Company, error) {
+	ret := _m.ctrl.Call(_m, "ListCompanies")
+	ret0, _ := ret[0].([]*Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) ListCompanies() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ListCompanies")
+}
+
 func (_m *MockShStore) UpdateCompanyInTx(_param0 *sql.Tx, _param1 *Company) (*Company, error) {
 	ret := _m.ctrl.Call(_m, "UpdateCompanyInTx", _param0, _param1)
 	ret0, _ := ret[0].(*Company)
@@ -1312,6 +2405,19 @@ func (_mr *_MockShStoreRecorder) GetRevisionsSince(arg0 interface{}) *gomock.Cal
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetRevisionsSince", arg0)
 }
 
+func (_m *MockShStore) GetRevisionPageSince(start_from *ShEntityRevision, page_size int) (int, []*ShEntityRevision, bool, error) {
+	ret := _m.ctrl.Call(_m, "GetRevisionPageSince", start_from, page_size)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]*ShEntityRevision)
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+func (_mr *_MockShStoreRecorder) GetRevisionPageSince(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetRevisionPageSince", arg0, arg1)
+}
+
 func (_m *MockShStore) GetDataStore() DataStore {
 	ret := _m.ctrl.Call(_m, "GetDataStore")
 	ret0, _ := ret[0].(DataStore)
@@ -1332,3 +2438,14 @@ func (_m *MockShStore) Begin() (*sql.Tx, error) {
 func (_mr *_MockShStoreRecorder) Begin() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Begin")
 }
+
+func (_m *MockShStore) BeginRepeatableRead() (*sql.Tx, error) {
+	ret := _m.ctrl.Call(_m, "BeginRepeatableRead")
+	ret0, _ := ret[0].(*sql.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockShStoreRecorder) BeginRepeatableRead() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "BeginRepeatableRead")
+}
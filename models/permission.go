@@ -189,7 +189,7 @@ func (b *shStore) GetUserPermission(u *User, company_id int) (*UserPermission, e
 func (b *shStore) GetUserCompanyPermissions(u *User) ([]*Pair_Company_UserPermission, error) {
 	var result []*Pair_Company_UserPermission
 	query := fmt.Sprintf(
-		"select c.company_id, c.company_name, c.encoded_payment, "+
+		"select c.company_id, c.company_name, c.encoded_payment, c.costing_method, "+
 			" p.company_id, p.user_id, p.permission "+
 			" FROM %s AS c INNER JOIN %s AS p ON (c.company_id = p.company_id) "+
 			" WHERE p.user_id = $1",
@@ -208,6 +208,7 @@ func (b *shStore) GetUserCompanyPermissions(u *User) ([]*Pair_Company_UserPermis
 			&pc.CompanyInfo.CompanyId,
 			&pc.CompanyInfo.CompanyName,
 			&pc.CompanyInfo.EncodedPayment,
+			&pc.CompanyInfo.CostingMethod,
 
 			&pc.Permission.CompanyId,
 			&pc.Permission.UserId,
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"math"
+	"sort"
+)
+
+/**
+ * An item in a branch whose quantity doesn't match what its transaction
+ * history says it should be.
+ */
+type ShStockDrift struct {
+	BranchId int
+	ItemId   int
+
+	// the quantity the branch item has, false HasBranchItem means the item
+	// isn't in the branch even though transactions moved its stock
+	Quantity      float64
+	HasBranchItem bool
+
+	LedgerQuantity float64
+
+	// from replaying the transaction history
+	ExpectedQuantity float64
+}
+
+// quantities are stored as REAL, so they are only compared up to this
+const _STOCK_DRIFT_TOLERANCE = 1e-4
+
+func _quantityDrifted(a, b float64) bool {
+	return math.Abs(a-b) > _STOCK_DRIFT_TOLERANCE
+}
+
+type _stockDriftsByBranch []*ShStockDrift
+
+func (d _stockDriftsByBranch) Len() int      { return len(d) }
+func (d _stockDriftsByBranch) Swap(i, j int) { d[i], d[j] = d[j], d[i] }
+func (d _stockDriftsByBranch) Less(i, j int) bool {
+	if d[i].BranchId != d[j].BranchId {
+		return d[i].BranchId < d[j].BranchId
+	}
+	return d[i].ItemId < d[j].ItemId
+}
+
+/**
+ * Returns the quantity each (branch, item) should have after the transactions.
+ */
+func ReplayStockQuantities(transactions []*ShTransaction) map[BranchItemPair]float64 {
+	quantities := make(map[BranchItemPair]float64)
+	for _, trans := range transactions {
+		for _, entry := range StockLedgerEntries(trans) {
+			if entry.Account != LEDGER_ACCOUNT_BRANCH {
+				continue
+			}
+			quantities[BranchItemPair{entry.BranchId, entry.ItemId}] += entry.Quantity
+		}
+	}
+	return quantities
+}
+
+/**
+ * Compares the branch items and the ledger against the replayed quantities.
+ * A branch item without any transaction is expected to have 0.
+ * The drifts are sorted by branch then item.
+ */
+func FindStockDrift(expected, ledger map[BranchItemPair]float64,
+	branch_items []*ShBranchItem) []*ShStockDrift {
+
+	drifts := make(map[BranchItemPair]*ShStockDrift)
+	get_drift := func(pair BranchItemPair) *ShStockDrift {
+		if drift, ok := drifts[pair]; ok {
+			return drift
+		}
+		drift := &ShStockDrift{
+			BranchId:         pair.BranchId,
+			ItemId:           pair.ItemId,
+			LedgerQuantity:   ledger[pair],
+			ExpectedQuantity: expected[pair],
+		}
+		drifts[pair] = drift
+		return drift
+	}
+
+	for _, branch_item := range branch_items {
+		drift := get_drift(BranchItemPair{branch_item.BranchId, branch_item.ItemId})
+		drift.Quantity = branch_item.Quantity
+		drift.HasBranchItem = true
+	}
+	for pair := range expected {
+		get_drift(pair)
+	}
+	for pair := range ledger {
+		get_drift(pair)
+	}
+
+	var result []*ShStockDrift
+	for _, drift := range drifts {
+		if _quantityDrifted(drift.LedgerQuantity, drift.ExpectedQuantity) ||
+			_quantityDrifted(drift.Quantity, drift.ExpectedQuantity) {
+			result = append(result, drift)
+		}
+	}
+
+	sort.Sort(_stockDriftsByBranch(result))
+	return result
+}
+
+/**
+ * The ledger entries that bring the ledger's quantity to the expected one,
+ * the difference is taken from(or given to) the outside of the company.
+ */
+func StockCorrectionEntries(company_id int, drift *ShStockDrift, date int64) []*ShStockLedgerEntry {
+	diff := drift.ExpectedQuantity - drift.LedgerQuantity
+	if !_quantityDrifted(diff, 0) {
+		return nil
+	}
+	return []*ShStockLedgerEntry{
+		{
+			CompanyId: company_id, ItemId: drift.ItemId,
+			Account: LEDGER_ACCOUNT_EXTERNAL, Quantity: -diff, Date: date,
+		},
+		{
+			CompanyId: company_id, ItemId: drift.ItemId,
+			Account: LEDGER_ACCOUNT_BRANCH, BranchId: drift.BranchId, Quantity: diff, Date: date,
+		},
+	}
+}
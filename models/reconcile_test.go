@@ -0,0 +1,57 @@
+package models
+
+import "testing"
+
+func TestFindStockDrift(t *testing.T) {
+	transactions := []*ShTransaction{
+		{
+			CompanyId: 1,
+			BranchId:  1,
+			TransItems: []*ShTransactionItem{
+				{TransType: TRANS_TYPE_ADD_PURCHASED, ItemId: 5, Quantity: 10},
+				{TransType: TRANS_TYPE_SUB_TRANSFER_TO_OTHER, ItemId: 5, OtherBranchId: 2, Quantity: 3},
+			},
+		},
+	}
+	expected := ReplayStockQuantities(transactions)
+	if expected[BranchItemPair{1, 5}] != 7 || expected[BranchItemPair{2, 5}] != 3 {
+		t.Fatalf("wrong replayed quantities %v", expected)
+	}
+
+	ledger := map[BranchItemPair]float64{
+		{1, 5}: 7,
+		// the ledger is missing the transfer's entry
+		{2, 5}: 0,
+	}
+	branch_items := []*ShBranchItem{
+		// it was reset to 0, the bug the ledger fixed
+		{BranchId: 1, ItemId: 5, Quantity: 0},
+		// matches, an item without transactions has 0
+		{BranchId: 1, ItemId: 6, Quantity: 0},
+	}
+
+	drifts := FindStockDrift(expected, ledger, branch_items)
+	if len(drifts) != 2 {
+		t.Fatalf("expected 2 drifts, got %d", len(drifts))
+	}
+
+	if d := drifts[0]; d.BranchId != 1 || d.ItemId != 5 || !d.HasBranchItem ||
+		d.Quantity != 0 || d.LedgerQuantity != 7 || d.ExpectedQuantity != 7 {
+		t.Errorf("wrong drift of branch 1 %+v", d)
+	}
+	if d := drifts[1]; d.BranchId != 2 || d.ItemId != 5 || d.HasBranchItem ||
+		d.LedgerQuantity != 0 || d.ExpectedQuantity != 3 {
+		t.Errorf("wrong drift of branch 2 %+v", d)
+	}
+
+	if entries := StockCorrectionEntries(1, drifts[0], 0); len(entries) != 0 {
+		t.Errorf("the ledger of branch 1 is right, expected no correction got %d", len(entries))
+	}
+	entries := StockCorrectionEntries(1, drifts[1], 0)
+	if len(entries) != 2 || entries[0].Quantity+entries[1].Quantity != 0 {
+		t.Fatalf("expected a balanced correction, got %v", entries)
+	}
+	if entries[1].Account != LEDGER_ACCOUNT_BRANCH || entries[1].BranchId != 2 || entries[1].Quantity != 3 {
+		t.Errorf("wrong correction of branch 2 %+v", entries[1])
+	}
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+/**
+ * An item whose quantity has dropped to or below its reorder level.
+ */
+type ShReorderItem struct {
+	// 0 if the report is company-wide
+	BranchId int
+	ItemId   int
+
+	Quantity     float64
+	ReorderLevel float64
+
+	SuggestedQuantity float64
+}
+
+/**
+ * We don't track a maximum stock level, so we suggest ordering enough
+ * to bring the stock back to twice the reorder level.
+ */
+func SuggestedOrderQuantity(quantity, reorder_level float64) float64 {
+	suggested := 2*reorder_level - quantity
+	if suggested < 0 {
+		return 0
+	}
+	return suggested
+}
+
+/**
+ * Returns the items at or below their reorder level in each branch. The branch's
+ * override of the reorder level is used if it has one. Items without a reorder
+ * level are never reported.
+ * @args branch_id	limits the report to a single branch, 0 reports every branch
+ */
+func (s *shStore) GetBranchReorderReport(company_id, branch_id int) ([]*ShReorderItem, error) {
+	where_stmt := "where bi.company_id = $1"
+	args := []interface{}{company_id, STATUS_VISIBLE}
+	if branch_id != 0 {
+		args = append(args, branch_id)
+		where_stmt += " AND bi.branch_id = $3"
+	}
+
+	query := fmt.Sprintf("select bi.branch_id, bi.item_id, bi.quantity, effective_level from ( "+
+		"	select bi.branch_id, bi.item_id, bi.quantity, "+
+		"	coalesce(bi.reorder_level, i.%s) as effective_level "+
+		"	from %s bi inner join %s i on bi.item_id = i.%s "+
+		"	%s AND i.%s = $2 "+
+		") as bi where effective_level > 0 AND bi.quantity <= effective_level "+
+		"ORDER BY bi.branch_id asc, bi.item_id asc",
+		_db_item_reorder_level,
+		TABLE_BRANCH_ITEM, TABLE_INVENTORY_ITEM, _db_item_id,
+		where_stmt, _db_status_flag)
+
+	return _queryReorderItems(s, query, args...)
+}
+
+/**
+ * Returns the items whose total quantity across all branches is at or below
+ * the item's reorder level. Branch overrides don't apply to the total.
+ */
+func (s *shStore) GetCompanyReorderReport(company_id int) ([]*ShReorderItem, error) {
+	query := fmt.Sprintf("select 0, i.%s, coalesce(sum(bi.quantity), 0) as quantity, i.%s "+
+		"from %s i left join %s bi on bi.item_id = i.%s "+
+		"where i.%s = $1 AND i.%s = $2 AND i.%s > 0 "+
+		"group by i.%s, i.%s "+
+		"having coalesce(sum(bi.quantity), 0) <= i.%s "+
+		"ORDER BY i.%s asc",
+		_db_item_id, _db_item_reorder_level,
+		TABLE_INVENTORY_ITEM, TABLE_BRANCH_ITEM, _db_item_id,
+		_db_item_company_id, _db_status_flag, _db_item_reorder_level,
+		_db_item_id, _db_item_reorder_level,
+		_db_item_reorder_level,
+		_db_item_id)
+
+	return _queryReorderItems(s, query, company_id, STATUS_VISIBLE)
+}
+
+func _queryReorderItems(s *shStore, query string, args ...interface{}) ([]*ShReorderItem, error) {
+	var result []*ShReorderItem
+
+	rows, err := s.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("reorder report error %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		r := new(ShReorderItem)
+		if err := rows.Scan(
+			&r.BranchId,
+			&r.ItemId,
+			&r.Quantity,
+			&r.ReorderLevel,
+		); err == sql.ErrNoRows {
+			// no-op
+		} else if err != nil {
+			return nil, fmt.Errorf("reorder report scan error %v", err)
+		} else {
+			r.SuggestedQuantity = SuggestedOrderQuantity(r.Quantity, r.ReorderLevel)
+			result = append(result, r)
+		}
+	}
+
+	return result, nil
+}
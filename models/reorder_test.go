@@ -0,0 +1,44 @@
+package models
+
+import "testing"
+
+func TestSuggestedOrderQuantity(t *testing.T) {
+	expected := []struct {
+		quantity, reorder_level, suggested float64
+	}{
+		{0, 5, 10},
+		{3, 5, 7},
+		// at the level, it still needs topping up
+		{5, 5, 5},
+		{12, 5, 0},
+		// oversold
+		{-2, 5, 12},
+	}
+	for _, e := range expected {
+		if got := SuggestedOrderQuantity(e.quantity, e.reorder_level); got != e.suggested {
+			t.Errorf("quantity %v at level %v: expected %v, got %v",
+				e.quantity, e.reorder_level, e.suggested, got)
+		}
+	}
+}
+
+func TestBranchReorderReport(t *testing.T) {
+	db, store, teardown := t_fake_store(t)
+	defer teardown()
+
+	// the second item's level is the branch's override
+	db.expect("coalesce(bi.reorder_level, i.").returns("branch_id, item_id, quantity, effective_level",
+		t_row(int64(2), int64(9), 1.0, 4.0),
+		t_row(int64(2), int64(11), 3.0, 20.0))
+
+	items, err := store.GetBranchReorderReport(t_rev_company_id, 2)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(items) != 2 || items[0].SuggestedQuantity != 7 || items[1].SuggestedQuantity != 37 {
+		t.Errorf("wrong suggestions %v", items)
+	}
+	if args := db.args[0]; len(args) != 3 || args[2] != int64(2) {
+		t.Errorf("expected the report to be limited to branch 2, got %v", args)
+	}
+}
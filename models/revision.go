@@ -35,6 +35,9 @@ const (
 	REV_ENTITY_BRANCH_CATEGORY int = 6
 )
 
+// Used as a page size if you want every revision.
+const REV_NO_PAGE_LIMIT = 0
+
 func (s *shStore) AddEntityRevisionInTx(tnx *sql.Tx, rev *ShEntityRevision) (*ShEntityRevision, error) {
 	rows, err := tnx.Query(
 		fmt.Sprintf("select MAX(revision_number) from %s "+
@@ -77,18 +80,34 @@ func (s *shStore) AddEntityRevisionInTx(tnx *sql.Tx, rev *ShEntityRevision) (*Sh
 }
 
 func (s *shStore) GetRevisionsSince(prev_rev *ShEntityRevision) (int, []*ShEntityRevision, error) {
+	max_rev, result, _, err := s.GetRevisionPageSince(prev_rev, REV_NO_PAGE_LIMIT)
+	return max_rev, result, err
+}
+
+func (s *shStore) GetRevisionPageSince(prev_rev *ShEntityRevision, page_size int) (int, []*ShEntityRevision, bool, error) {
 	var result []*ShEntityRevision
 
-	rows, err := s.Query(
-		fmt.Sprintf("select distinct on (affected_id, additional_info) "+
-			" company_id, revision_number, entity_type, action_type, "+
-			" affected_id, additional_info from %s "+
-			" where company_id = $1 AND entity_type = $2 AND "+
-			" revision_number > $3 ",
-			TABLE_ENTITY_REVISION),
-		prev_rev.CompanyId, prev_rev.EntityType, prev_rev.RevisionNumber)
+	// only the latest revision of each entity is interesting, and those
+	// are returned in the order they happened so the revision of the
+	// last one in the page can be used to continue from.
+	query := fmt.Sprintf("select company_id, revision_number, entity_type, action_type, "+
+		" affected_id, additional_info from ("+
+		"	select distinct on (affected_id, additional_info) "+
+		"	company_id, revision_number, entity_type, action_type, "+
+		"	affected_id, additional_info from %s "+
+		"	where company_id = $1 AND entity_type = $2 AND "+
+		"	revision_number > $3 "+
+		"	order by affected_id, additional_info, revision_number desc"+
+		") as latest order by revision_number asc",
+		TABLE_ENTITY_REVISION)
+	if page_size > 0 {
+		// fetch one more so we know if there is something after the page
+		query += fmt.Sprintf(" limit %d", page_size+1)
+	}
+
+	rows, err := s.Query(query, prev_rev.CompanyId, prev_rev.EntityType, prev_rev.RevisionNumber)
 	if err != nil {
-		return prev_rev.RevisionNumber, nil, fmt.Errorf("Revision query error : %s", err.Error())
+		return prev_rev.RevisionNumber, nil, false, fmt.Errorf("Revision query error : %s", err.Error())
 	}
 
 	for rows.Next() {
@@ -105,7 +124,7 @@ func (s *shStore) GetRevisionsSince(prev_rev *ShEntityRevision) (int, []*ShEntit
 			// no-op
 		} else if err != nil {
 			rows.Close()
-			return prev_rev.RevisionNumber, nil, fmt.Errorf("Revision Scan error : %s", err.Error())
+			return prev_rev.RevisionNumber, nil, false, fmt.Errorf("Revision Scan error : %s", err.Error())
 		} else {
 			result = append(result, rev)
 		}
@@ -114,12 +133,18 @@ func (s *shStore) GetRevisionsSince(prev_rev *ShEntityRevision) (int, []*ShEntit
 	// so that the Exec statement can use it immediately
 	rows.Close()
 
+	if page_size > 0 && len(result) > page_size {
+		result = result[:page_size]
+		// continue from the last revision in the page
+		return result[len(result)-1].RevisionNumber, result, true, nil
+	}
+
 	rows, err = s.Query(
 		fmt.Sprintf("select max(revision_number) from %s "+
 			" where company_id = $1 AND entity_type = $2", TABLE_ENTITY_REVISION),
 		prev_rev.CompanyId, prev_rev.EntityType)
 	if err != nil {
-		return prev_rev.RevisionNumber, nil, fmt.Errorf("Revision query error : %s", err.Error())
+		return prev_rev.RevisionNumber, nil, false, fmt.Errorf("Revision query error : %s", err.Error())
 	}
 
 	defer rows.Close()
@@ -132,5 +157,5 @@ func (s *shStore) GetRevisionsSince(prev_rev *ShEntityRevision) (int, []*ShEntit
 		}
 	}
 
-	return max_rev, result, nil
+	return max_rev, result, false, nil
 }
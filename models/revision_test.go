@@ -0,0 +1,82 @@
+package models
+
+import (
+	"database/sql/driver"
+	"testing"
+)
+
+func t_revision_row(rev_number, affected_id int) []driver.Value {
+	return []driver.Value{int64(t_rev_company_id), int64(rev_number), int64(REV_ENTITY_ITEM),
+		int64(REV_ACTION_UPDATE), int64(affected_id), int64(-1)}
+}
+
+const t_rev_company_id = 3
+
+const t_rev_columns = "company_id, revision_number, entity_type, action_type, affected_id, additional_info"
+
+func TestRevisionPageHasMore(t *testing.T) {
+	db, store, teardown := t_fake_store(t)
+	defer teardown()
+
+	// one more than the page is fetched
+	db.expect("limit 3").returns(t_rev_columns,
+		t_revision_row(5, 10),
+		t_revision_row(7, 11),
+		t_revision_row(8, 12))
+
+	max_rev, revs, has_more, err := store.GetRevisionPageSince(&ShEntityRevision{
+		CompanyId: t_rev_company_id, EntityType: REV_ENTITY_ITEM, RevisionNumber: 4}, 2)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if !has_more || len(revs) != 2 {
+		t.Fatalf("expected a full page with more after it, got %d revisions, has_more:%v", len(revs), has_more)
+	}
+	// the next page continues after the last one sent, not after the peeked one
+	if max_rev != 7 {
+		t.Errorf("expected to continue from 7, got %d", max_rev)
+	}
+}
+
+func TestRevisionPageExactlyFull(t *testing.T) {
+	db, store, teardown := t_fake_store(t)
+	defer teardown()
+
+	db.expect("limit 3").returns(t_rev_columns,
+		t_revision_row(5, 10),
+		t_revision_row(7, 11))
+	// older revisions of the entities were skipped, so the max can be past the last one
+	db.expect("select max(revision_number)").returns("max", t_row(int64(9)))
+
+	max_rev, revs, has_more, err := store.GetRevisionPageSince(&ShEntityRevision{
+		CompanyId: t_rev_company_id, EntityType: REV_ENTITY_ITEM, RevisionNumber: 4}, 2)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if has_more || len(revs) != 2 {
+		t.Errorf("expected the last page, got %d revisions, has_more:%v", len(revs), has_more)
+	}
+	if max_rev != 9 {
+		t.Errorf("expected the max revision 9, got %d", max_rev)
+	}
+}
+
+func TestRevisionPageWithoutLimit(t *testing.T) {
+	db, store, teardown := t_fake_store(t)
+	defer teardown()
+
+	db.expect("order by revision_number asc").returns(t_rev_columns)
+	db.expect("select max(revision_number)").returns("max", t_row(nil))
+
+	max_rev, revs, has_more, err := store.GetRevisionPageSince(&ShEntityRevision{
+		CompanyId: t_rev_company_id, EntityType: REV_ENTITY_ITEM, RevisionNumber: 4}, REV_NO_PAGE_LIMIT)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if has_more || len(revs) != 0 {
+		t.Errorf("expected nothing, got %d revisions, has_more:%v", len(revs), has_more)
+	}
+	if max_rev != 0 {
+		t.Errorf("expected 0 for a company without revisions, got %d", max_rev)
+	}
+}
@@ -0,0 +1,175 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// what the sales are totaled by
+const (
+	SALES_GROUP_ITEM     = 1
+	SALES_GROUP_CATEGORY = 2
+	SALES_GROUP_BRANCH   = 3
+	SALES_GROUP_USER     = 4
+	SALES_GROUP_DAY      = 5
+	SALES_GROUP_WEEK     = 6 // weeks start on monday
+	SALES_GROUP_MONTH    = 7
+)
+
+/**
+ * The zero value of each field other than CompanyId and GroupBy means don't filter on it.
+ */
+type ShSalesReportFilter struct {
+	CompanyId int
+	GroupBy   int
+
+	BranchId int
+
+	// inclusive bounds on the transaction's date
+	StartDate int64
+	EndDate   int64
+
+	// seconds east of UTC, the periods start at midnight in this offset
+	UTCOffset int64
+}
+
+/**
+ * The net sales of a group, customer returns are taken off.
+ * The Key is the id of the item, category, branch or user the sales are grouped by.
+ * For periods, it is the date the period starts at.
+ */
+type ShSalesTotal struct {
+	Key      int64
+	Quantity float64
+	Amount   float64
+}
+
+/**
+ * Returns the SQL expression the rows are grouped by, the offset is the
+ * placeholder of the filter's UTCOffset. Periods are truncated to their
+ * first day in the offset, see _salesPeriodStart for the key.
+ */
+func _salesGroupKey(group_by int, offset string) (string, error) {
+	period := func(field string) string {
+		return fmt.Sprintf("extract(epoch from date_trunc('%s', "+
+			"to_timestamp(t.t_date + %s) at time zone 'UTC'))::bigint",
+			field, offset)
+	}
+
+	switch group_by {
+	case SALES_GROUP_ITEM:
+		return "i.item_id", nil
+	case SALES_GROUP_CATEGORY:
+		return "it.category_id", nil
+	case SALES_GROUP_BRANCH:
+		return "t.branch_id", nil
+	case SALES_GROUP_USER:
+		return "t.user_id", nil
+	case SALES_GROUP_DAY:
+		return period("day"), nil
+	case SALES_GROUP_WEEK:
+		return period("week"), nil
+	case SALES_GROUP_MONTH:
+		return period("month"), nil
+	}
+	return "", fmt.Errorf("unknown sales grouping:%d", group_by)
+}
+
+/**
+ * Returns the date of midnight in the utc_offset the period starts at. The
+ * bucket is what _salesGroupKey gives, the period's first day as if it were in UTC.
+ */
+func _salesPeriodStart(bucket, utc_offset int64) int64 {
+	year, month, day := time.Unix(bucket, 0).UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0,
+		time.FixedZone("", int(utc_offset))).Unix()
+}
+
+type _salesTotalsByKey []*ShSalesTotal
+
+func (s _salesTotalsByKey) Len() int           { return len(s) }
+func (s _salesTotalsByKey) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s _salesTotalsByKey) Less(i, j int) bool { return s[i].Key < s[j].Key }
+
+/**
+ * Totals the sales matching the filter, ordered by the key. Voided sales and
+ * the voids are left out, a void is dated when it was posted so it would
+ * otherwise fall in a different period than the sale.
+ */
+func (s *shStore) GetSalesReport(filter *ShSalesReportFilter) ([]*ShSalesTotal, error) {
+	args := []interface{}{filter.CompanyId}
+	key, err := _salesGroupKey(filter.GroupBy, "$2")
+	if err != nil {
+		return nil, err
+	}
+	if filter.GroupBy >= SALES_GROUP_DAY {
+		// the periods use it as $2
+		args = append(args, filter.UTCOffset)
+	}
+
+	where_stmt := fmt.Sprintf("where t.company_id = $1 AND i.trans_type in (%d, %d) "+
+		"AND t.voided_by_trans_id is null AND not t.is_void",
+		TRANS_TYPE_SUB_CURRENT_BRANCH_SALE, TRANS_TYPE_ADD_CUSTOMER_RETURN)
+	add_condition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		where_stmt += fmt.Sprintf(" AND "+condition, len(args))
+	}
+	if filter.BranchId != 0 {
+		add_condition("t.branch_id = $%d", filter.BranchId)
+	}
+	if filter.StartDate != 0 {
+		add_condition("t.t_date >= $%d", filter.StartDate)
+	}
+	if filter.EndDate != 0 {
+		add_condition("t.t_date <= $%d", filter.EndDate)
+	}
+
+	query := fmt.Sprintf("select %s, i.trans_type, sum(i.quantity), sum(i.line_total) from %s t "+
+		"inner join %s i on (t.transaction_id = i.transaction_id) "+
+		"inner join %s it on (i.item_id = it.item_id) ",
+		key, TABLE_TRANSACTION, TABLE_TRANSACTION_ITEM, TABLE_INVENTORY_ITEM) +
+		where_stmt + " GROUP BY 1, 2"
+
+	rows, err := s.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("company:%d, sales report %v", filter.CompanyId, err)
+	}
+	defer rows.Close()
+
+	totals := make(map[int64]*ShSalesTotal)
+	for rows.Next() {
+		var key sql.NullInt64
+		var trans_type int
+		var quantity, amount float64
+		if err := rows.Scan(&key, &trans_type, &quantity, &amount); err != nil {
+			return nil, fmt.Errorf("company:%d, sales report %v", filter.CompanyId, err)
+		}
+		if filter.GroupBy >= SALES_GROUP_DAY {
+			key.Int64 = _salesPeriodStart(key.Int64, filter.UTCOffset)
+		}
+
+		total, ok := totals[key.Int64]
+		if !ok {
+			total = &ShSalesTotal{Key: key.Int64}
+			totals[key.Int64] = total
+		}
+		// see SoldQuantity, customer returns net out the sales
+		if trans_type == TRANS_TYPE_ADD_CUSTOMER_RETURN {
+			quantity, amount = -quantity, -amount
+		}
+		total.Quantity += quantity
+		total.Amount += amount
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("company:%d, sales report %v", filter.CompanyId, err)
+	}
+
+	var result []*ShSalesTotal
+	for _, total := range totals {
+		result = append(result, total)
+	}
+	sort.Sort(_salesTotalsByKey(result))
+	return result, nil
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const t_sales_company_id = 5
+
+func TestSalesReportNetsReturns(t *testing.T) {
+	db, store, teardown := t_fake_store(t)
+	defer teardown()
+
+	db.expect("from "+TABLE_TRANSACTION+" t inner join").
+		returns("item_id, trans_type, sum, sum",
+			t_row(int64(12), int64(TRANS_TYPE_SUB_CURRENT_BRANCH_SALE), 2.0, 30.0),
+			t_row(int64(11), int64(TRANS_TYPE_SUB_CURRENT_BRANCH_SALE), 5.0, 50.0),
+			t_row(int64(11), int64(TRANS_TYPE_ADD_CUSTOMER_RETURN), 1.0, 10.0))
+
+	totals, err := store.GetSalesReport(&ShSalesReportFilter{
+		CompanyId: t_sales_company_id, GroupBy: SALES_GROUP_ITEM, BranchId: 3})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(totals) != 2 ||
+		*totals[0] != (ShSalesTotal{Key: 11, Quantity: 4, Amount: 40}) ||
+		*totals[1] != (ShSalesTotal{Key: 12, Quantity: 2, Amount: 30}) {
+		t.Errorf("expected the return taken off item 11, got %v %v", totals[0], totals[1])
+	}
+
+	query := db.queries[0]
+	if !strings.Contains(query, "t.voided_by_trans_id is null AND not t.is_void") {
+		t.Errorf("expected voided sales and voids left out, got %s", query)
+	}
+	if args := db.args[0]; len(args) != 2 || args[1] != int64(3) {
+		t.Errorf("expected the branch filter, got %v", args)
+	}
+}
+
+func TestSalesReportPeriods(t *testing.T) {
+	// 3 hours east of UTC
+	const offset = 3 * 60 * 60
+	zone := time.FixedZone("", offset)
+
+	// postgres truncates to the period's first day, the date is as if it were in UTC
+	march_1 := time.Date(2017, time.March, 1, 0, 0, 0, 0, time.UTC).Unix()
+	march_6 := time.Date(2017, time.March, 6, 0, 0, 0, 0, time.UTC).Unix()
+
+	expected := []struct {
+		group_by int
+		field    string
+	}{
+		{SALES_GROUP_DAY, "day"},
+		{SALES_GROUP_WEEK, "week"},
+		{SALES_GROUP_MONTH, "month"},
+	}
+	for _, e := range expected {
+		db, store, teardown := t_fake_store(t)
+
+		db.expect("date_trunc('"+e.field+"', to_timestamp(t.t_date + $2)").
+			returns("date_part, trans_type, sum, sum",
+				t_row(march_6, int64(TRANS_TYPE_SUB_CURRENT_BRANCH_SALE), 1.0, 10.0),
+				t_row(march_1, int64(TRANS_TYPE_SUB_CURRENT_BRANCH_SALE), 3.0, 30.0),
+				t_row(march_1, int64(TRANS_TYPE_ADD_CUSTOMER_RETURN), 1.0, 10.0))
+
+		totals, err := store.GetSalesReport(&ShSalesReportFilter{
+			CompanyId: t_sales_company_id, GroupBy: e.group_by, UTCOffset: offset, StartDate: 100})
+		if err != nil {
+			t.Fatalf("group %d: %v", e.group_by, err)
+		}
+
+		// the keys are midnight in the offset
+		first := time.Date(2017, time.March, 1, 0, 0, 0, 0, zone).Unix()
+		second := time.Date(2017, time.March, 6, 0, 0, 0, 0, zone).Unix()
+		if len(totals) != 2 ||
+			*totals[0] != (ShSalesTotal{Key: first, Quantity: 2, Amount: 20}) ||
+			*totals[1] != (ShSalesTotal{Key: second, Quantity: 1, Amount: 10}) {
+			t.Errorf("group %d: expected the periods of march 1 and 6, got %v", e.group_by, totals)
+		}
+		if args := db.args[0]; len(args) != 3 || args[1] != int64(offset) || args[2] != int64(100) {
+			t.Errorf("group %d: expected the offset as $2, got %v", e.group_by, args)
+		}
+		teardown()
+	}
+}
@@ -10,32 +10,65 @@ import (
 // if this is the type. If it ain't that means shit has gone wrong.
 var ErrNoData = errors.New("sheket: no data found")
 
+// Returned when creating/updating an item with a bar code(or item code)
+// that another item in the company already has.
+var ErrDuplicateBarCode = errors.New("sheket: duplicate bar code")
+var ErrDuplicateItemCode = errors.New("sheket: duplicate item code")
+var ErrAlreadyVoided = errors.New("sheket: transaction already voided")
+var ErrTransferReceived = errors.New("sheket: transfer already received")
+var ErrStockTakeApproved = errors.New("sheket: stock take already approved")
+
+// Returned when updating an entity that was changed since its version was read.
+var ErrVersionConflict = errors.New("sheket: entity changed by another update")
+
 type TransactionStore interface {
 	CreateShTransactionInTx(*sql.Tx, *ShTransaction) (*ShTransaction, error)
 	AddShTransactionItemInTx(*sql.Tx, *ShTransaction, *ShTransactionItem) (*ShTransactionItem, error)
 
 	// @args fetch_items 	whether you want the items in the transaction
 	GetShTransactionById(company_id int, trans_id int64, fetch_items bool) (*ShTransaction, error)
-	GetShTransactionByUUIDInTx(*sql.Tx, string) (*ShTransaction, error)
+	GetShTransactionByIdInTx(tnx *sql.Tx, company_id int, trans_id int64, fetch_items bool) (*ShTransaction, error)
+	GetShTransactionByUUIDInTx(tnx *sql.Tx, company_id int, uid string) (*ShTransaction, error)
+
+	// @args voided_by_trans_id	the compensating transaction
+	SetShTransactionVoidedInTx(tnx *sql.Tx, company_id int, trans_id int64, voided_by_trans_id int64) error
 
 	GetShTransactionSinceTransId(company_id int, prev_trans_id int64) (trans []*ShTransaction, err error)
+	// every transaction of the company with its items, oldest first
+	GetCompanyTransactionsInTx(tnx *sql.Tx, company_id int) (trans []*ShTransaction, err error)
+	// the transactions dated in (after, until] with their items, by date then id
+	GetCompanyTransactionsBetween(company_id int, after, until int64) (trans []*ShTransaction, err error)
+	// the transactions whose linked_trans_id is trans_id, with their items
+	GetLinkedShTransactionsInTx(tnx *sql.Tx, company_id int, trans_id int64) (trans []*ShTransaction, err error)
+	// fetches at most page_size transactions, has_more is true if there are other transactions after those
+	GetShTransactionPageSinceTransId(company_id int, prev_trans_id int64, page_size int) (trans []*ShTransaction, has_more bool, err error)
+
+	// returns the transactions matching the filter, newest first
+	GetShTransactionHistory(filter *ShTransactionFilter, page_size int) (trans []*ShTransaction, has_more bool, err error)
 }
 
 type ItemStore interface {
 	CreateItemInTx(*sql.Tx, *ShItem) (*ShItem, error)
 
+	// only updates the item if it is still at item.Version, ErrVersionConflict otherwise
 	UpdateItemInTx(*sql.Tx, *ShItem) (*ShItem, error)
 
 	GetItemById(int) (*ShItem, error)
-	GetItemByUUIDInTx(*sql.Tx, string) (*ShItem, error)
+	GetItemByUUIDInTx(tnx *sql.Tx, company_id int, uid string) (*ShItem, error)
 	GetItemByIdInTx(*sql.Tx, int) (*ShItem, error)
+
+	GetItemByBarCode(company_id int, bar_code string) (*ShItem, error)
+
+	// returns the items matching the search, has_more is true if there are more after the page
+	SearchItems(search *ShItemSearch, page_size int) (items []*ShItem, has_more bool, err error)
 }
 
 type BranchStore interface {
 	CreateBranchInTx(*sql.Tx, *ShBranch) (*ShBranch, error)
+	// See UpdateItemInTx
 	UpdateBranchInTx(*sql.Tx, *ShBranch) (*ShBranch, error)
 
-	GetBranchByUUIDInTx(*sql.Tx, string) (*ShBranch, error)
+	GetBranchByUUIDInTx(tnx *sql.Tx, company_id int, uid string) (*ShBranch, error)
 	GetBranchById(int) (*ShBranch, error)
 	GetBranchByIdInTx(*sql.Tx, int) (*ShBranch, error)
 }
@@ -48,6 +81,73 @@ type BranchItemStore interface {
 	GetBranchItem(branch_id, item_id int) (*ShBranchItem, error)
 	GetBranchItemInTx(tnx *sql.Tx, branch_id, item_id int) (*ShBranchItem, error)
 	UpdateBranchItemInTx(*sql.Tx, *ShBranchItem) (*ShBranchItem, error)
+	// ErrNoData if the item isn't in the branch
+	LockBranchItemInTx(tnx *sql.Tx, branch_id, item_id int) error
+	UpdateBranchItemQuantityInTx(tnx *sql.Tx, branch_id, item_id int, quantity float64) error
+	// the items in every branch of the company
+	GetCompanyBranchItemsInTx(tnx *sql.Tx, company_id int) ([]*ShBranchItem, error)
+
+	// @args branch_id	0 reports on every branch
+	GetBranchReorderReport(company_id, branch_id int) ([]*ShReorderItem, error)
+	GetCompanyReorderReport(company_id int) ([]*ShReorderItem, error)
+}
+
+type StockLedgerStore interface {
+	AddStockLedgerEntriesInTx(tnx *sql.Tx, entries []*ShStockLedgerEntry) error
+
+	// the quantity of the item in the branch, i.e: the sum of the branch's entries of the item
+	GetStockLedgerQuantityInTx(tnx *sql.Tx, company_id, branch_id, item_id int) (float64, error)
+	// the quantity of every item in every branch of the company
+	GetStockLedgerQuantitiesInTx(tnx *sql.Tx, company_id int) (map[BranchItemPair]float64, error)
+
+	// @args branch_id	0 returns the quantity in every branch
+	GetStockAsOf(company_id, branch_id int, as_of int64) ([]*ShStockQuantity, error)
+	CreateStockSnapshotInTx(tnx *sql.Tx, company_id int, snapshot_date int64) error
+
+	// the ledger entries that aren't part of a transaction dated in (after, until], by date
+	GetStockLedgerCorrections(company_id int, after, until int64) ([]*ShStockLedgerEntry, error)
+
+	// ErrNoData if there isn't a snapshot on or before the date taken with the costing method
+	GetStockCostSnapshot(company_id, costing_method int, before int64) (snapshot_date int64, layers []*ShCostLayer, err error)
+	CreateStockCostSnapshotInTx(tnx *sql.Tx, company_id, costing_method int, snapshot_date int64, layers []*ShCostLayer) error
+}
+
+type StockTakeStore interface {
+	CreateStockTakeInTx(tnx *sql.Tx, take *ShStockTake) (*ShStockTake, error)
+	UpdateStockTakeInTx(tnx *sql.Tx, take *ShStockTake) (*ShStockTake, error)
+	SetStockTakeCountInTx(tnx *sql.Tx, item *ShStockTakeItem) error
+
+	// the stock takes are returned with their items
+	GetStockTakeInTx(tnx *sql.Tx, company_id, stock_take_id int) (*ShStockTake, error)
+	GetOpenStockTakeInTx(tnx *sql.Tx, company_id, branch_id int) (*ShStockTake, error)
+}
+
+type ItemPriceStore interface {
+	AddItemPriceInTx(tnx *sql.Tx, price *ShItemPrice) error
+	GetItemPriceHistory(company_id, item_id int) ([]*ShItemPrice, error)
+	GetItemPriceAsOfInTx(tnx *sql.Tx, company_id, branch_id, item_id int, date int64) (*ShItemPrice, error)
+	// item_id -> the item's current cost price
+	GetItemCostPrices(company_id int) (map[int]float64, error)
+}
+
+/**
+ * Reports aggregated in the db, so the clients don't have to fetch
+ * every transaction to total them.
+ */
+type ReportStore interface {
+	GetSalesReport(filter *ShSalesReportFilter) ([]*ShSalesTotal, error)
+	GetItemMovements(company_id, branch_id, item_id int, start_date, end_date int64) ([]*ShItemMovement, error)
+}
+
+type TransferStore interface {
+	// the items are created along with the transfer
+	CreateTransferInTx(tnx *sql.Tx, transfer *ShTransfer) (*ShTransfer, error)
+	ReceiveTransferInTx(tnx *sql.Tx, transfer *ShTransfer) (*ShTransfer, error)
+
+	// the transfers are returned with their items
+	GetTransferInTx(tnx *sql.Tx, company_id, transfer_id int) (*ShTransfer, error)
+	GetTransferByUUIDInTx(tnx *sql.Tx, company_id int, uid string) (*ShTransfer, error)
+	GetPendingTransfers(company_id int) ([]*ShTransfer, error)
 }
 
 type CompanyStore interface {
@@ -57,6 +157,8 @@ type CompanyStore interface {
 	// The CALLER needs to rollback the transaction if error occurs
 	CreateCompanyInTx(*sql.Tx, *User, *Company) (*Company, error)
 	GetCompanyById(int) (*Company, error)
+	// every company, used by the admin tools
+	ListCompanies() ([]*Company, error)
 
 	UpdateCompanyInTx(*sql.Tx, *Company) (*Company, error)
 }
@@ -91,6 +193,9 @@ type RevisionStore interface {
 
 	// returns changes since the start revision
 	GetRevisionsSince(start_from *ShEntityRevision) (latest_rev int, since []*ShEntityRevision, err error)
+	// same as GetRevisionsSince but returns at most page_size revisions, if there
+	// are more, latest_rev is the revision of the last one returned so it can be continued from
+	GetRevisionPageSince(start_from *ShEntityRevision, page_size int) (latest_rev int, since []*ShEntityRevision, has_more bool, err error)
 }
 
 type Source interface {
@@ -99,16 +204,27 @@ type Source interface {
 	// used to start transactions
 	// queries the DataStore
 	Begin() (*sql.Tx, error)
+
+	// all reads of the transaction see the same snapshot, see shStore.BeginRepeatableRead
+	BeginRepeatableRead() (*sql.Tx, error)
 }
 
 type CategoryStore interface {
 	CreateCategoryInTx(*sql.Tx, *ShCategory) (*ShCategory, error)
 	GetCategoryById(int) (*ShCategory, error)
 	GetCategoryByIdInTx(*sql.Tx, int) (*ShCategory, error)
-	GetCategoryByUUIDInTx(*sql.Tx, string) (*ShCategory, error)
+	GetCategoryByUUIDInTx(tnx *sql.Tx, company_id int, uid string) (*ShCategory, error)
 
+	// See UpdateItemInTx
 	UpdateCategoryInTx(*sql.Tx, *ShCategory) (*ShCategory, error)
 	DeleteCategoryInTx(*sql.Tx, int) (error)
+
+	// true if ancestor_id is category_id itself or one of its ancestors
+	IsCategoryAncestorInTx(tnx *sql.Tx, ancestor_id, category_id int) (bool, error)
+
+	IsCategoryEmptyInTx(tnx *sql.Tx, category_id int) (bool, error)
+	MoveCategoryChildrenInTx(tnx *sql.Tx, category_id, new_parent_id int) (moved_categories []int, moved_items []int, err error)
+	DeleteCategorySubtreeInTx(tnx *sql.Tx, category_id, items_parent_id int) (deleted_categories []int, moved_items []int, err error)
 }
 
 type BranchCategoryStore interface {
@@ -118,6 +234,7 @@ type BranchCategoryStore interface {
 	GetBranchCategoryInTx(tnx *sql.Tx, branch_id, category_id int) (*ShBranchCategory, error)
 
 	DeleteBranchCategoryInTx(tnx *sql.Tx, branch_id, category_id int) (error)
+	DeleteCategoryFromBranchesInTx(tnx *sql.Tx, category_id int, include_subtree bool) ([]*ShBranchCategory, error)
 }
 
 type ShStore interface {
@@ -127,6 +244,11 @@ type ShStore interface {
 	BranchCategoryStore
 	BranchStore
 	BranchItemStore
+	StockLedgerStore
+	StockTakeStore
+	TransferStore
+	ItemPriceStore
+	ReportStore
 	CompanyStore
 	UserStore
 	RevisionStore
@@ -151,3 +273,21 @@ func (s *shStore) GetDataStore() DataStore {
 func (s *shStore) Begin() (*sql.Tx, error) {
 	return s.DataStore.Begin()
 }
+
+/**
+ * Begins a transaction whose reads all see the database as it was at its first
+ * read. Updating a row another transaction changed after that fails with a
+ * serialization error, so the caller should retry the whole transaction.
+ */
+func (s *shStore) BeginRepeatableRead() (*sql.Tx, error) {
+	tnx, err := s.DataStore.Begin()
+	if err != nil {
+		return nil, err
+	}
+	// it has to come before any other statement of the transaction
+	if _, err = tnx.Exec("set transaction isolation level repeatable read"); err != nil {
+		tnx.Rollback()
+		return nil, err
+	}
+	return tnx, nil
+}
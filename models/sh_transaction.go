@@ -15,6 +15,15 @@ type ShTransaction struct {
 	Date      int64
 	TransNote string
 
+	// The transaction this one is linked to, e.g: the one it voids.
+	LinkedTransId int64
+	// This is the compensating transaction of a void, its items have the
+	// quantities of the voided transaction negated so they reverse its effects.
+	// The user who voided it is its UserId, and the reason is its TransNote.
+	IsVoid bool
+	// The compensating transaction if this was voided, 0 otherwise.
+	VoidedByTransId int64
+
 	TransItems []*ShTransactionItem
 }
 
@@ -26,6 +35,17 @@ type ShTransactionItem struct {
 	OtherBranchId int
 	Quantity      float64
 	ItemNote      string
+
+	// what a unit was priced at, the discount is taken off the whole line
+	UnitPrice float64
+	Discount  float64
+	LineTotal float64
+
+	// the unit the quantity was posted in, Quantity is always in the
+	// item's base unit. See ConvertToBaseUnit
+	UnitId       int
+	UnitQuantity float64
+	UnitName     string
 }
 
 const (
@@ -34,18 +54,150 @@ const (
 	//TODO: update the constants
 	TRANS_TYPE_ADD_TRANSFER_FROM_OTHER = 3 // Increase stock from transfer from another branch
 
+	TRANS_TYPE_ADD_FOUND             = 4 // Increase stock by items that were found, e.g: thought to be lost
+	TRANS_TYPE_ADD_COUNT_CORRECTION  = 5 // Increase stock because a count found more than recorded
+	TRANS_TYPE_ADD_CUSTOMER_RETURN   = 6 // Increase stock by items a customer returned, it nets out the sale
+	TRANS_TYPE_ADD_TRANSFER_RECEIVED = 7 // Increase stock by receiving a transfer that was in-transit, see ShTransfer
+
 	TRANS_TYPE_SUB_CURRENT_BRANCH_SALE = 11 // Decrease stock by selling current branch inventory
 	TRANS_TYPE_SUB_TRANSFER_TO_OTHER   = 12 // Decrease stock by sending inventory to other branch
+	TRANS_TYPE_SUB_DAMAGE              = 13 // Decrease stock by writing-off damaged items
+	TRANS_TYPE_SUB_LOSS                = 14 // Decrease stock by writing-off lost or stolen items
+	TRANS_TYPE_SUB_COUNT_CORRECTION    = 15 // Decrease stock because a count found less than recorded
+	TRANS_TYPE_SUB_RETURN_TO_SUPPLIER  = 16 // Decrease stock by returning purchased merchandise
+	TRANS_TYPE_SUB_TRANSFER_SENT       = 17 // Decrease stock by sending a transfer, it is in-transit until received
+	TRANS_TYPE_SUB_TRANSIT_DISCREPANCY = 18 // Decrease in-transit stock by what didn't arrive, the branch's stock doesn't change
 )
 
+/**
+ * Returns true for the types only the server posts: transfers, their
+ * discrepancies and stock take corrections. Clients can't post them directly.
+ */
+func IsServerTransType(trans_type int) bool {
+	switch trans_type {
+	case TRANS_TYPE_ADD_COUNT_CORRECTION, TRANS_TYPE_SUB_COUNT_CORRECTION,
+		TRANS_TYPE_ADD_TRANSFER_RECEIVED, TRANS_TYPE_SUB_TRANSFER_SENT,
+		TRANS_TYPE_SUB_TRANSIT_DISCREPANCY:
+		return true
+	}
+	return false
+}
+
+/**
+ * Returns the quantity the item adds to the sales, customer returns
+ * are negative so they net out the sale they return.
+ */
+func SoldQuantity(item *ShTransactionItem) float64 {
+	switch item.TransType {
+	case TRANS_TYPE_SUB_CURRENT_BRANCH_SALE:
+		return item.Quantity
+	case TRANS_TYPE_ADD_CUSTOMER_RETURN:
+		return -item.Quantity
+	}
+	return 0
+}
+
+/**
+ * Returns the type of the items a return reverses, customer returns reverse
+ * sales and returns to supplier reverse purchases. ok is false for other types.
+ */
+func ReturnedFromType(trans_type int) (returned_from int, ok bool) {
+	switch trans_type {
+	case TRANS_TYPE_ADD_CUSTOMER_RETURN:
+		return TRANS_TYPE_SUB_CURRENT_BRANCH_SALE, true
+	case TRANS_TYPE_SUB_RETURN_TO_SUPPLIER:
+		return TRANS_TYPE_ADD_PURCHASED, true
+	}
+	return 0, false
+}
+
+/**
+ * Returns the quantity of each item that can still be returned from the
+ * transaction, net of the returns already linked to it. Voided returns
+ * aren't counted, nothing can be returned from a voided transaction.
+ * @args linked	the transactions linking to trans, with their items
+ */
+func ReturnableQuantities(trans *ShTransaction, linked []*ShTransaction) map[int]float64 {
+	returnable := make(map[int]float64)
+	if trans.VoidedByTransId != 0 {
+		return returnable
+	}
+	for _, item := range trans.TransItems {
+		switch item.TransType {
+		case TRANS_TYPE_SUB_CURRENT_BRANCH_SALE, TRANS_TYPE_ADD_PURCHASED:
+			returnable[item.ItemId] += item.Quantity
+		}
+	}
+	for _, ret := range linked {
+		if ret.IsVoid || ret.VoidedByTransId != 0 {
+			continue
+		}
+		for _, item := range ret.TransItems {
+			if _, ok := ReturnedFromType(item.TransType); ok {
+				returnable[item.ItemId] -= item.Quantity
+			}
+		}
+	}
+	return returnable
+}
+
+/**
+ * Returns the price a unit of the item is valued at in a transaction of the
+ * type, sales and customer returns are at the selling price, the rest at cost.
+ */
+func DefaultUnitPrice(trans_type int, price *ShItemPrice) float64 {
+	switch trans_type {
+	case TRANS_TYPE_SUB_CURRENT_BRANCH_SALE, TRANS_TYPE_ADD_CUSTOMER_RETURN:
+		return price.SellingPrice
+	}
+	return price.CostPrice
+}
+
+/**
+ * Returns the amount of the line after the discount.
+ */
+func LineTotal(item *ShTransactionItem) float64 {
+	return item.Quantity*item.UnitPrice - item.Discount
+}
+
+/**
+ * Narrows down the transactions returned by GetShTransactionHistory.
+ * The zero value of each field means don't filter on it.
+ */
+type ShTransactionFilter struct {
+	CompanyId int
+
+	BranchId  int
+	UserId    int
+	TransType int // matches if any of the items has this type
+
+	// inclusive bounds on the transaction's date
+	StartDate int64
+	EndDate   int64
+
+	// only transactions with an id less than this are returned, used
+	// to continue from the last transaction of the previous page
+	BeforeTransId int64
+}
+
+func _nullTransId(id int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: id, Valid: id != 0}
+}
+
+func _nullClientUUID(uid string) sql.NullString {
+	return sql.NullString{String: uid, Valid: uid != ""}
+}
+
 func (s *shStore) CreateShTransactionInTx(tnx *sql.Tx, trans *ShTransaction) (*ShTransaction, error) {
 	err := tnx.QueryRow(
 		fmt.Sprintf("insert into %s "+
-			"(company_id, user_id, branch_id, t_date, trans_note, client_uuid) values "+
-			"($1, $2, $3, $4, $5, $6) RETURNING transaction_id",
+			"(company_id, user_id, branch_id, t_date, trans_note, client_uuid, "+
+			"linked_trans_id, is_void) values "+
+			"($1, $2, $3, $4, $5, $6, $7, $8) RETURNING transaction_id",
 			TABLE_TRANSACTION),
 		trans.CompanyId, trans.UserId, trans.BranchId,
-		trans.Date, trans.TransNote, trans.ClientUUID).
+		trans.Date, trans.TransNote, _nullClientUUID(trans.ClientUUID),
+		_nullTransId(trans.LinkedTransId), trans.IsVoid).
 		Scan(&trans.TransactionId)
 	if err != nil {
 		return nil, err
@@ -63,10 +215,15 @@ func (s *shStore) CreateShTransactionInTx(tnx *sql.Tx, trans *ShTransaction) (*S
 }
 
 func (s *shStore) AddShTransactionItemInTx(tnx *sql.Tx, trans *ShTransaction, elem *ShTransactionItem) (*ShTransactionItem, error) {
+	if elem.UnitId == TRANS_UNIT_BASE {
+		elem.UnitQuantity = elem.Quantity
+	}
 	_, err := tnx.Exec(fmt.Sprintf("insert into %s "+
-		"(company_id, transaction_id, trans_type, item_id, other_branch_id, quantity, item_note) values "+
-		"($1, $2, $3, $4, $5, $6, $7)", TABLE_TRANSACTION_ITEM),
-		trans.CompanyId, trans.TransactionId, elem.TransType, elem.ItemId, elem.OtherBranchId, elem.Quantity, elem.ItemNote)
+		"(company_id, transaction_id, trans_type, item_id, other_branch_id, quantity, item_note, "+
+		"unit_price, discount, line_total, unit_id, unit_quantity, unit_name) values "+
+		"($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)", TABLE_TRANSACTION_ITEM),
+		trans.CompanyId, trans.TransactionId, elem.TransType, elem.ItemId, elem.OtherBranchId, elem.Quantity, elem.ItemNote,
+		elem.UnitPrice, elem.Discount, elem.LineTotal, elem.UnitId, elem.UnitQuantity, elem.UnitName)
 	if err != nil {
 		return nil, err
 	}
@@ -76,7 +233,7 @@ func (s *shStore) AddShTransactionItemInTx(tnx *sql.Tx, trans *ShTransaction, el
 
 func (s *shStore) GetShTransactionById(company_id int, trans_id int64, fetch_items bool) (*ShTransaction, error) {
 	msg := fmt.Sprintf("company:%d, no transaction with id %d", company_id, trans_id)
-	transaction, err := _queryShTransactions(s, fetch_items, msg,
+	transaction, err := _queryShTransactions(s, fetch_items, msg, "",
 		"where company_id = $1 AND transaction_id = $2", company_id, trans_id)
 	if err != nil {
 		return nil, err
@@ -84,9 +241,48 @@ func (s *shStore) GetShTransactionById(company_id int, trans_id int64, fetch_ite
 	return transaction[0], nil
 }
 
-func (s *shStore) GetShTransactionByUUIDInTx(tnx *sql.Tx, uid string) (*ShTransaction, error) {
-	msg := fmt.Sprintf("no transaction with that uuid:%s", uid)
-	transaction, err := _queryShTransactionsInTx(tnx, msg, "where client_uuid = $1", uid)
+func (s *shStore) GetShTransactionByIdInTx(tnx *sql.Tx, company_id int, trans_id int64, fetch_items bool) (*ShTransaction, error) {
+	msg := fmt.Sprintf("company:%d, no transaction with id %d", company_id, trans_id)
+	transaction, err := _queryShTransactionsInTx(tnx, msg,
+		"where company_id = $1 AND transaction_id = $2", company_id, trans_id)
+	if err != nil {
+		return nil, err
+	}
+	if fetch_items {
+		items, err := _queryShTransactionItemsInTx(tnx, msg, "where transaction_id = $1", trans_id)
+		if err != nil && err != ErrNoData {
+			return nil, err
+		}
+		transaction[0].TransItems = items
+	}
+	return transaction[0], nil
+}
+
+/**
+ * Marks the transaction as voided by the compensating transaction. It fails
+ * with ErrAlreadyVoided if it has been voided before.
+ * Transactions don't have revisions, so clients that already synced it only
+ * learn about the void from the compensating transaction's linked_trans_id.
+ */
+func (s *shStore) SetShTransactionVoidedInTx(tnx *sql.Tx, company_id int, trans_id int64, voided_by_trans_id int64) error {
+	result, err := tnx.Exec(fmt.Sprintf("update %s set voided_by_trans_id = $1 "+
+		"where company_id = $2 AND transaction_id = $3 AND voided_by_trans_id is null",
+		TABLE_TRANSACTION),
+		voided_by_trans_id, company_id, trans_id)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrAlreadyVoided
+	}
+	return nil
+}
+
+func (s *shStore) GetShTransactionByUUIDInTx(tnx *sql.Tx, company_id int, uid string) (*ShTransaction, error) {
+	msg := fmt.Sprintf("no transaction with that uuid:%s in company:%d", uid, company_id)
+	transaction, err := _queryShTransactionsInTx(tnx, msg, "where company_id = $1 AND client_uuid = $2", company_id, uid)
 	if err != nil {
 		return nil, err
 	}
@@ -94,26 +290,183 @@ func (s *shStore) GetShTransactionByUUIDInTx(tnx *sql.Tx, uid string) (*ShTransa
 }
 
 func (s *shStore) GetShTransactionSinceTransId(company_id int, prev_id int64) (trans []*ShTransaction, err error) {
+	trans, _, err = s.GetShTransactionPageSinceTransId(company_id, prev_id, REV_NO_PAGE_LIMIT)
+	return trans, err
+}
+
+func (s *shStore) GetCompanyTransactionsInTx(tnx *sql.Tx, company_id int) ([]*ShTransaction, error) {
+	msg := fmt.Sprintf("company:%d, transactions", company_id)
+	transactions, err := _queryShTransactionsInTx(tnx, msg, "where company_id = $1", company_id)
+	if err == ErrNoData {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	// the items are read in a single query, a transaction can't run
+	// another query while the rows of the first are still open
+	items, err := _queryShTransactionItemsInTx(tnx, msg,
+		fmt.Sprintf("where transaction_id in "+
+			"(select transaction_id from %s where company_id = $1) "+
+			"ORDER BY transaction_id asc", TABLE_TRANSACTION), company_id)
+	if err != nil && err != ErrNoData {
+		return nil, err
+	}
+
+	_groupShTransactionItems(transactions, items)
+	return transactions, nil
+}
+
+/**
+ * Returns the transactions dated in (after, until] with their items, by date.
+ */
+func (s *shStore) GetCompanyTransactionsBetween(company_id int, after, until int64) ([]*ShTransaction, error) {
+	msg := fmt.Sprintf("company:%d, transactions between %d and %d", company_id, after, until)
+	where_stmt := "where company_id = $1 AND t_date > $2 AND t_date <= $3"
+	transactions, err := _queryShTransactions(s, false, msg,
+		" ORDER BY t_date asc, transaction_id asc", where_stmt, company_id, after, until)
+	if err == ErrNoData {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	items, err := _queryShTransactionItems(s, msg,
+		fmt.Sprintf("where transaction_id in (select transaction_id from %s %s) "+
+			"ORDER BY transaction_id asc", TABLE_TRANSACTION, where_stmt), company_id, after, until)
+	if err != nil && err != ErrNoData {
+		return nil, err
+	}
+	_groupShTransactionItems(transactions, items)
+	return transactions, nil
+}
+
+/**
+ * Returns the transactions linked to the transaction with their items, e.g: its returns and void.
+ */
+func (s *shStore) GetLinkedShTransactionsInTx(tnx *sql.Tx, company_id int, trans_id int64) ([]*ShTransaction, error) {
+	msg := fmt.Sprintf("company:%d, transactions linked to %d", company_id, trans_id)
+	transactions, err := _queryShTransactionsInTx(tnx, msg,
+		"where company_id = $1 AND linked_trans_id = $2", company_id, trans_id)
+	if err == ErrNoData {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	items, err := _queryShTransactionItemsInTx(tnx, msg,
+		fmt.Sprintf("where transaction_id in "+
+			"(select transaction_id from %s where company_id = $1 AND linked_trans_id = $2) "+
+			"ORDER BY transaction_id asc", TABLE_TRANSACTION), company_id, trans_id)
+	if err != nil && err != ErrNoData {
+		return nil, err
+	}
+	_groupShTransactionItems(transactions, items)
+	return transactions, nil
+}
+
+func _groupShTransactionItems(transactions []*ShTransaction, items []*ShTransactionItem) {
+	by_id := make(map[int64]*ShTransaction, len(transactions))
+	for _, trans := range transactions {
+		by_id[trans.TransactionId] = trans
+	}
+	for _, item := range items {
+		if trans, ok := by_id[item.TransactionId]; ok {
+			trans.TransItems = append(trans.TransItems, item)
+		}
+	}
+}
+
+func (s *shStore) GetShTransactionPageSinceTransId(company_id int, prev_id int64, page_size int) (trans []*ShTransaction, has_more bool, err error) {
 	msg := fmt.Sprintf("no transactions after id:%d", prev_id)
-	transaction, err := _queryShTransactions(s, true, msg,
+	sort_by := " ORDER BY transaction_id asc"
+	if page_size > 0 {
+		// fetch one more so we know if there is something after the page
+		sort_by += fmt.Sprintf(" LIMIT %d", page_size+1)
+	}
+	transaction, err := _queryShTransactions(s, true, msg, sort_by,
 		"where company_id = $1 AND transaction_id > $2", company_id, prev_id)
 	if err != nil {
 		if err != ErrNoData {
-			return nil, err
+			return nil, false, err
 		} else {
 			// we have an empty array, that is totally valid
-			return trans, nil
+			return trans, false, nil
 		}
 	}
-	return transaction, nil
+	if page_size > 0 && len(transaction) > page_size {
+		return transaction[:page_size], true, nil
+	}
+	return transaction, false, nil
 }
 
-func _queryShTransactions(s *shStore, fetch_items bool, err_msg string, where_stmt string, args ...interface{}) ([]*ShTransaction, error) {
+func (s *shStore) GetShTransactionHistory(filter *ShTransactionFilter, page_size int) (trans []*ShTransaction, has_more bool, err error) {
+	where_stmt := "where company_id = $1"
+	args := []interface{}{filter.CompanyId}
+
+	add_condition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		where_stmt += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	if filter.BranchId != 0 {
+		add_condition("branch_id = $%d", filter.BranchId)
+	}
+	if filter.UserId != 0 {
+		add_condition("user_id = $%d", filter.UserId)
+	}
+	if filter.StartDate != 0 {
+		add_condition("t_date >= $%d", filter.StartDate)
+	}
+	if filter.EndDate != 0 {
+		add_condition("t_date <= $%d", filter.EndDate)
+	}
+	if filter.BeforeTransId != 0 {
+		add_condition("transaction_id < $%d", filter.BeforeTransId)
+	}
+	if filter.TransType != 0 {
+		add_condition(
+			fmt.Sprintf("EXISTS (select 1 from %s i "+
+				" where i.transaction_id = %s.transaction_id AND i.trans_type = $%%d)",
+				TABLE_TRANSACTION_ITEM, TABLE_TRANSACTION),
+			filter.TransType)
+	}
+
+	// history is browsed from the newest backwards
+	sort_by := " ORDER BY transaction_id desc"
+	if page_size > 0 {
+		// fetch one more so we know if there is something after the page
+		sort_by += fmt.Sprintf(" LIMIT %d", page_size+1)
+	}
+
+	msg := fmt.Sprintf("company:%d, transaction history", filter.CompanyId)
+	transaction, err := _queryShTransactions(s, true, msg, sort_by, where_stmt, args...)
+	if err != nil {
+		if err != ErrNoData {
+			return nil, false, err
+		} else {
+			return trans, false, nil
+		}
+	}
+	if page_size > 0 && len(transaction) > page_size {
+		return transaction[:page_size], true, nil
+	}
+	return transaction, false, nil
+}
+
+/**
+ * @args sort_by	the ORDER BY(and optional LIMIT) clause, defaults to
+ * 			ascending transaction_id if empty.
+ */
+func _queryShTransactions(s *shStore, fetch_items bool, err_msg string, sort_by string, where_stmt string, args ...interface{}) ([]*ShTransaction, error) {
 	var result []*ShTransaction
 
 	query := fmt.Sprintf("select transaction_id, company_id, "+
-		"branch_id, user_id, t_date, trans_note, client_uuid from %s", TABLE_TRANSACTION)
-	sort_by := " ORDER BY transaction_id asc"
+		"branch_id, user_id, t_date, trans_note, client_uuid, "+
+		"linked_trans_id, is_void, voided_by_trans_id from %s", TABLE_TRANSACTION)
+	if len(sort_by) == 0 {
+		sort_by = " ORDER BY transaction_id asc"
+	}
 
 	var rows *sql.Rows
 	var err error
@@ -130,6 +483,8 @@ func _queryShTransactions(s *shStore, fetch_items bool, err_msg string, where_st
 
 	for rows.Next() {
 		t := new(ShTransaction)
+		var client_uuid sql.NullString
+		var linked_trans_id, voided_by_trans_id sql.NullInt64
 		err := rows.Scan(
 			&t.TransactionId,
 			&t.CompanyId,
@@ -137,7 +492,10 @@ func _queryShTransactions(s *shStore, fetch_items bool, err_msg string, where_st
 			&t.UserId,
 			&t.Date,
 			&t.TransNote,
-			&t.ClientUUID,
+			&client_uuid,
+			&linked_trans_id,
+			&t.IsVoid,
+			&voided_by_trans_id,
 		)
 		if err != nil {
 			if err == sql.ErrNoRows {
@@ -145,6 +503,9 @@ func _queryShTransactions(s *shStore, fetch_items bool, err_msg string, where_st
 			}
 			return nil, fmt.Errorf("%s %v", err_msg, err.Error())
 		}
+		t.ClientUUID = client_uuid.String
+		t.LinkedTransId = linked_trans_id.Int64
+		t.VoidedByTransId = voided_by_trans_id.Int64
 
 		var items []*ShTransactionItem
 		if fetch_items {
@@ -171,7 +532,8 @@ func _queryShTransactionsInTx(tnx *sql.Tx, err_msg string, where_stmt string, ar
 	var result []*ShTransaction
 
 	query := fmt.Sprintf("select transaction_id, company_id, "+
-		"branch_id, user_id, t_date, trans_note, client_uuid from %s", TABLE_TRANSACTION)
+		"branch_id, user_id, t_date, trans_note, client_uuid, "+
+		"linked_trans_id, is_void, voided_by_trans_id from %s", TABLE_TRANSACTION)
 	sort_by := " ORDER BY transaction_id asc"
 
 	var rows *sql.Rows
@@ -189,6 +551,8 @@ func _queryShTransactionsInTx(tnx *sql.Tx, err_msg string, where_stmt string, ar
 
 	for rows.Next() {
 		t := new(ShTransaction)
+		var client_uuid sql.NullString
+		var linked_trans_id, voided_by_trans_id sql.NullInt64
 		err := rows.Scan(
 			&t.TransactionId,
 			&t.CompanyId,
@@ -196,7 +560,10 @@ func _queryShTransactionsInTx(tnx *sql.Tx, err_msg string, where_stmt string, ar
 			&t.UserId,
 			&t.Date,
 			&t.TransNote,
-			&t.ClientUUID,
+			&client_uuid,
+			&linked_trans_id,
+			&t.IsVoid,
+			&voided_by_trans_id,
 		)
 		if err != nil {
 			if err == sql.ErrNoRows {
@@ -204,6 +571,9 @@ func _queryShTransactionsInTx(tnx *sql.Tx, err_msg string, where_stmt string, ar
 			}
 			return nil, fmt.Errorf("%s %v", err_msg, err.Error())
 		}
+		t.ClientUUID = client_uuid.String
+		t.LinkedTransId = linked_trans_id.Int64
+		t.VoidedByTransId = voided_by_trans_id.Int64
 		result = append(result, t)
 	}
 
@@ -217,7 +587,9 @@ func _queryShTransactionItems(s *shStore, err_msg string, where_stmt string, arg
 	var result []*ShTransactionItem
 
 	query := fmt.Sprintf("select company_id, transaction_id, trans_type, item_id, "+
-		"other_branch_id, quantity, item_note from %s", TABLE_TRANSACTION_ITEM)
+		"other_branch_id, quantity, item_note, unit_price, discount, line_total, "+
+		"unit_id, unit_quantity, unit_name "+
+		"from %s", TABLE_TRANSACTION_ITEM)
 
 	var rows *sql.Rows
 	var err error
@@ -234,6 +606,71 @@ func _queryShTransactionItems(s *shStore, err_msg string, where_stmt string, arg
 
 	for rows.Next() {
 		i := new(ShTransactionItem)
+		var unit_quantity sql.NullFloat64
+		var unit_name sql.NullString
+		err := rows.Scan(
+			&i.CompanyId,
+			&i.TransactionId,
+			&i.TransType,
+			&i.ItemId,
+			&i.OtherBranchId,
+			&i.Quantity,
+			&i.ItemNote,
+			&i.UnitPrice,
+			&i.Discount,
+			&i.LineTotal,
+			&i.UnitId,
+			&unit_quantity,
+			&unit_name,
+		)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, ErrNoData
+			}
+			return nil, fmt.Errorf("%s %v", err_msg, err.Error())
+		}
+		i.UnitName = unit_name.String
+		if unit_quantity.Valid {
+			i.UnitQuantity = unit_quantity.Float64
+		} else {
+			// it was posted before units were kept, so it is in the base unit
+			i.UnitQuantity = i.Quantity
+		}
+
+		result = append(result, i)
+	}
+
+	if len(result) == 0 {
+		return nil, ErrNoData
+	}
+	return result, nil
+}
+
+func _queryShTransactionItemsInTx(tnx *sql.Tx, err_msg string, where_stmt string, args ...interface{}) ([]*ShTransactionItem, error) {
+	var result []*ShTransactionItem
+
+	query := fmt.Sprintf("select company_id, transaction_id, trans_type, item_id, "+
+		"other_branch_id, quantity, item_note, unit_price, discount, line_total, "+
+		"unit_id, unit_quantity, unit_name "+
+		"from %s", TABLE_TRANSACTION_ITEM)
+
+	var rows *sql.Rows
+	var err error
+	if len(where_stmt) > 0 {
+		rows, err = tnx.Query(query+" "+where_stmt, args...)
+	} else {
+		rows, err = tnx.Query(query)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s %v", err_msg, err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		i := new(ShTransactionItem)
+		var unit_quantity sql.NullFloat64
+		var unit_name sql.NullString
 		err := rows.Scan(
 			&i.CompanyId,
 			&i.TransactionId,
@@ -242,6 +679,12 @@ func _queryShTransactionItems(s *shStore, err_msg string, where_stmt string, arg
 			&i.OtherBranchId,
 			&i.Quantity,
 			&i.ItemNote,
+			&i.UnitPrice,
+			&i.Discount,
+			&i.LineTotal,
+			&i.UnitId,
+			&unit_quantity,
+			&unit_name,
 		)
 		if err != nil {
 			if err == sql.ErrNoRows {
@@ -249,6 +692,13 @@ func _queryShTransactionItems(s *shStore, err_msg string, where_stmt string, arg
 			}
 			return nil, fmt.Errorf("%s %v", err_msg, err.Error())
 		}
+		i.UnitName = unit_name.String
+		if unit_quantity.Valid {
+			i.UnitQuantity = unit_quantity.Float64
+		} else {
+			// it was posted before units were kept, so it is in the base unit
+			i.UnitQuantity = i.Quantity
+		}
 
 		result = append(result, i)
 	}
@@ -3,13 +3,10 @@ package models
 import (
 	"database/sql"
 	"fmt"
+	"sort"
+	"strings"
 )
 
-type BranchItemPair struct {
-	BranchId int
-	ItemId   int
-}
-
 // Begin: SimpleBranchItemStore
 type SimpleBranchItemStore struct {
 	items      map[BranchItemPair]*ShBranchItem
@@ -50,7 +47,7 @@ func (m *SimpleBranchItemStore) GetBranchItemInTx(tnx *sql.Tx, branch_id, item_i
 		}, nil
 	}
 
-	return nil, fmt.Errorf("item doesn't exist")
+	return nil, ErrNoData
 }
 
 func (m *SimpleBranchItemStore) UpdateBranchItemInTx(tnx *sql.Tx, item *ShBranchItem) (*ShBranchItem, error) {
@@ -58,6 +55,22 @@ func (m *SimpleBranchItemStore) UpdateBranchItemInTx(tnx *sql.Tx, item *ShBranch
 	return item, nil
 }
 
+func (m *SimpleBranchItemStore) LockBranchItemInTx(tnx *sql.Tx, branch_id, item_id int) error {
+	_, err := m.GetBranchItemInTx(tnx, branch_id, item_id)
+	return err
+}
+
+func (m *SimpleBranchItemStore) UpdateBranchItemQuantityInTx(tnx *sql.Tx, branch_id, item_id int, quantity float64) error {
+	item, err := m.GetBranchItemInTx(tnx, branch_id, item_id)
+	if err != nil {
+		return err
+	}
+	updated := *item
+	updated.Quantity = quantity
+	m.items[BranchItemPair{branch_id, item_id}] = &updated
+	return nil
+}
+
 func (m *SimpleBranchItemStore) GetItemsInBranch(int) ([]*ShBranchItem, error) {
 	// TODO: not yet implemented
 	return nil, nil
@@ -66,9 +79,154 @@ func (m *SimpleBranchItemStore) GetItemsInAllCompanyBranches(int) ([]*ShBranchIt
 	return nil, nil
 }
 
-// Begin: SimpleBranchItemStore
+func (m *SimpleBranchItemStore) GetCompanyBranchItemsInTx(tnx *sql.Tx, company_id int) ([]*ShBranchItem, error) {
+	var items []*ShBranchItem
+	for _, item := range m.items {
+		if item.CompanyId == company_id {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
 
-// End: SimpleTransactionStore
+func (m *SimpleBranchItemStore) GetBranchReorderReport(company_id, branch_id int) ([]*ShReorderItem, error) {
+	return nil, fmt.Errorf("GetBranchReorderReport, Not yet implemented ")
+}
+
+func (m *SimpleBranchItemStore) GetCompanyReorderReport(company_id int) ([]*ShReorderItem, error) {
+	return nil, fmt.Errorf("GetCompanyReorderReport, Not yet implemented ")
+}
+
+// End: SimpleBranchItemStore
+
+// Begin: SimpleStockLedgerStore
+type SimpleStockLedgerStore struct {
+	Entries []*ShStockLedgerEntry
+
+	// in the order they were taken
+	CostSnapshots []*SimpleCostSnapshot
+}
+
+type SimpleCostSnapshot struct {
+	CompanyId     int
+	CostingMethod int
+	SnapshotDate  int64
+	Layers        []*ShCostLayer
+}
+
+func NewSimpleStockLedgerStore(entries []*ShStockLedgerEntry) *SimpleStockLedgerStore {
+	return &SimpleStockLedgerStore{Entries: entries}
+}
+
+func (s *SimpleStockLedgerStore) AddStockLedgerEntriesInTx(tnx *sql.Tx, entries []*ShStockLedgerEntry) error {
+	s.Entries = append(s.Entries, entries...)
+
+	// like the db, the snapshots that don't include the entries are removed
+	var kept []*SimpleCostSnapshot
+	for _, snapshot := range s.CostSnapshots {
+		valid := true
+		for _, entry := range entries {
+			if entry.CompanyId == snapshot.CompanyId && entry.Date <= snapshot.SnapshotDate {
+				valid = false
+			}
+		}
+		if valid {
+			kept = append(kept, snapshot)
+		}
+	}
+	s.CostSnapshots = kept
+	return nil
+}
+
+func (s *SimpleStockLedgerStore) GetStockLedgerQuantityInTx(tnx *sql.Tx, company_id, branch_id, item_id int) (float64, error) {
+	quantity := float64(0)
+	for _, entry := range s.Entries {
+		if entry.CompanyId == company_id && entry.Account == LEDGER_ACCOUNT_BRANCH &&
+			entry.BranchId == branch_id && entry.ItemId == item_id {
+			quantity += entry.Quantity
+		}
+	}
+	return quantity, nil
+}
+
+func (s *SimpleStockLedgerStore) GetStockLedgerQuantitiesInTx(tnx *sql.Tx, company_id int) (map[BranchItemPair]float64, error) {
+	quantities := make(map[BranchItemPair]float64)
+	for _, entry := range s.Entries {
+		if entry.CompanyId == company_id && entry.Account == LEDGER_ACCOUNT_BRANCH {
+			quantities[BranchItemPair{entry.BranchId, entry.ItemId}] += entry.Quantity
+		}
+	}
+	return quantities, nil
+}
+
+func (s *SimpleStockLedgerStore) GetStockAsOf(company_id, branch_id int, as_of int64) ([]*ShStockQuantity, error) {
+	quantities := make(map[BranchItemPair]float64)
+	for _, entry := range s.Entries {
+		if entry.CompanyId == company_id && entry.Account == LEDGER_ACCOUNT_BRANCH &&
+			entry.Date <= as_of && (branch_id == 0 || entry.BranchId == branch_id) {
+			quantities[BranchItemPair{entry.BranchId, entry.ItemId}] += entry.Quantity
+		}
+	}
+	var result []*ShStockQuantity
+	for pair, quantity := range quantities {
+		if quantity != 0 {
+			result = append(result, &ShStockQuantity{pair.BranchId, pair.ItemId, quantity})
+		}
+	}
+	return result, nil
+}
+
+func (s *SimpleStockLedgerStore) CreateStockSnapshotInTx(tnx *sql.Tx, company_id int, snapshot_date int64) error {
+	// there is nothing to speed-up
+	return nil
+}
+
+func (s *SimpleStockLedgerStore) GetStockLedgerCorrections(company_id int, after, until int64) ([]*ShStockLedgerEntry, error) {
+	var corrections []*ShStockLedgerEntry
+	for _, entry := range s.Entries {
+		if entry.CompanyId == company_id && entry.TransactionId == 0 &&
+			entry.Date > after && entry.Date <= until {
+			corrections = append(corrections, entry)
+		}
+	}
+	sort.Stable(_ledgerEntriesByDate(corrections))
+	return corrections, nil
+}
+
+func (s *SimpleStockLedgerStore) GetStockCostSnapshot(company_id, costing_method int, before int64) (int64, []*ShCostLayer, error) {
+	var latest *SimpleCostSnapshot
+	for _, snapshot := range s.CostSnapshots {
+		if snapshot.CompanyId == company_id && snapshot.CostingMethod == costing_method &&
+			snapshot.SnapshotDate <= before &&
+			(latest == nil || snapshot.SnapshotDate >= latest.SnapshotDate) {
+			latest = snapshot
+		}
+	}
+	if latest == nil {
+		return 0, nil, ErrNoData
+	}
+	return latest.SnapshotDate, latest.Layers, nil
+}
+
+func (s *SimpleStockLedgerStore) CreateStockCostSnapshotInTx(tnx *sql.Tx, company_id, costing_method int, snapshot_date int64, layers []*ShCostLayer) error {
+	s.CostSnapshots = append(s.CostSnapshots, &SimpleCostSnapshot{
+		CompanyId:     company_id,
+		CostingMethod: costing_method,
+		SnapshotDate:  snapshot_date,
+		Layers:        layers,
+	})
+	return nil
+}
+
+type _ledgerEntriesByDate []*ShStockLedgerEntry
+
+func (e _ledgerEntriesByDate) Len() int           { return len(e) }
+func (e _ledgerEntriesByDate) Swap(i, j int)      { e[i], e[j] = e[j], e[i] }
+func (e _ledgerEntriesByDate) Less(i, j int) bool { return e[i].Date < e[j].Date }
+
+// End: SimpleStockLedgerStore
+
+// Begin: SimpleTransactionStore
 type SimpleTransactionStore struct {
 	Transactions map[int64]*ShTransaction
 	TransItems   map[int64]map[*ShTransactionItem]bool
@@ -82,7 +240,7 @@ func NewSimpleTransactionStore() *SimpleTransactionStore {
 }
 
 func (s *SimpleTransactionStore) CreateShTransactionInTx(tnx *sql.Tx, trans *ShTransaction) (*ShTransaction, error) {
-	trans.TransactionId = int64(len(s.Transactions))
+	trans.TransactionId = int64(len(s.Transactions) + 1)
 	s.Transactions[trans.TransactionId] = trans
 	return trans, nil
 }
@@ -111,14 +269,88 @@ func (s *SimpleTransactionStore) GetShTransactionById(company_id int, id int64,
 	return trans, nil
 }
 
+func (s *SimpleTransactionStore) GetShTransactionByIdInTx(tnx *sql.Tx, company_id int, id int64, fetch_items bool) (*ShTransaction, error) {
+	if trans, ok := s.Transactions[id]; ok && trans.CompanyId == company_id {
+		return trans, nil
+	}
+	return nil, ErrNoData
+}
+
+func (s *SimpleTransactionStore) SetShTransactionVoidedInTx(tnx *sql.Tx, company_id int, id int64, voided_by_trans_id int64) error {
+	trans, err := s.GetShTransactionByIdInTx(tnx, company_id, id, false)
+	if err != nil {
+		return err
+	}
+	if trans.VoidedByTransId != 0 {
+		return ErrAlreadyVoided
+	}
+	trans.VoidedByTransId = voided_by_trans_id
+	return nil
+}
+
+func (s *SimpleTransactionStore) GetShTransactionByUUIDInTx(tnx *sql.Tx, company_id int, uid string) (*ShTransaction, error) {
+	for _, trans := range s.Transactions {
+		if trans.CompanyId == company_id && trans.ClientUUID == uid {
+			return trans, nil
+		}
+	}
+	return nil, ErrNoData
+}
+
 func (s *SimpleTransactionStore) GetShTransactionSinceTransId(company_id int, trans_id int64) ([]*ShTransaction, error) {
 	var trans []*ShTransaction
-	for _, t := range s.Transactions {
-		trans = append(trans, t)
+	// in the order they were created, like the db
+	for id := int64(1); id <= int64(len(s.Transactions)); id++ {
+		if t, ok := s.Transactions[id]; ok && id > trans_id {
+			trans = append(trans, t)
+		}
 	}
 	return trans, nil
 }
 
+func (s *SimpleTransactionStore) GetCompanyTransactionsInTx(tnx *sql.Tx, company_id int) ([]*ShTransaction, error) {
+	return s.GetShTransactionSinceTransId(company_id, 0)
+}
+
+func (s *SimpleTransactionStore) GetLinkedShTransactionsInTx(tnx *sql.Tx, company_id int, trans_id int64) ([]*ShTransaction, error) {
+	var linked []*ShTransaction
+	for id := int64(1); id <= int64(len(s.Transactions)); id++ {
+		if t, ok := s.Transactions[id]; ok && t.CompanyId == company_id && t.LinkedTransId == trans_id {
+			linked = append(linked, t)
+		}
+	}
+	return linked, nil
+}
+
+func (s *SimpleTransactionStore) GetCompanyTransactionsBetween(company_id int, after, until int64) ([]*ShTransaction, error) {
+	var trans []*ShTransaction
+	for id := int64(1); id <= int64(len(s.Transactions)); id++ {
+		if t, ok := s.Transactions[id]; ok && t.CompanyId == company_id &&
+			t.Date > after && t.Date <= until {
+			trans = append(trans, t)
+		}
+	}
+	// they are already by id
+	sort.Stable(_transactionsByDate(trans))
+	return trans, nil
+}
+
+type _transactionsByDate []*ShTransaction
+
+func (t _transactionsByDate) Len() int           { return len(t) }
+func (t _transactionsByDate) Swap(i, j int)      { t[i], t[j] = t[j], t[i] }
+func (t _transactionsByDate) Less(i, j int) bool { return t[i].Date < t[j].Date }
+
+func (s *SimpleTransactionStore) GetShTransactionPageSinceTransId(company_id int, trans_id int64, page_size int) ([]*ShTransaction, bool, error) {
+	trans, err := s.GetShTransactionSinceTransId(company_id, trans_id)
+	return trans, false, err
+}
+
+func (s *SimpleTransactionStore) GetShTransactionHistory(filter *ShTransactionFilter, page_size int) ([]*ShTransaction, bool, error) {
+	trans, err := s.GetShTransactionSinceTransId(filter.CompanyId, 0)
+	return trans, false, err
+}
+
 // End: SimpleTransactionStore
 
 // Begin: SimpleRevisionStore
@@ -132,8 +364,19 @@ func NewSimpleRevisionStore(revs []*ShEntityRevision) *SimpleRevisionStore {
 	return s
 }
 
-func (s *SimpleRevisionStore) AddEntityRevisionInTx(*sql.Tx, *ShEntityRevision) (*ShEntityRevision, error) {
-	return nil, nil
+func (s *SimpleRevisionStore) AddEntityRevisionInTx(tnx *sql.Tx, rev *ShEntityRevision) (*ShEntityRevision, error) {
+	max_rev := 0
+	for _, prev := range s.Revisions {
+		if prev.CompanyId == rev.CompanyId && prev.EntityType == rev.EntityType &&
+			prev.RevisionNumber > max_rev {
+			max_rev = prev.RevisionNumber
+		}
+	}
+	added := &ShEntityRevision{}
+	*added = *rev
+	added.RevisionNumber = max_rev + 1
+	s.Revisions = append(s.Revisions, added)
+	return added, nil
 }
 
 func (s *SimpleRevisionStore) GetRevisionsSince(start_from *ShEntityRevision) (latest_rev int, since []*ShEntityRevision, err error) {
@@ -148,6 +391,11 @@ func (s *SimpleRevisionStore) GetRevisionsSince(start_from *ShEntityRevision) (l
 	return max_rev, s.Revisions, nil
 }
 
+func (s *SimpleRevisionStore) GetRevisionPageSince(start_from *ShEntityRevision, page_size int) (latest_rev int, since []*ShEntityRevision, has_more bool, err error) {
+	latest_rev, since, err = s.GetRevisionsSince(start_from)
+	return latest_rev, since, false, err
+}
+
 // End: SimpleRevisionStore
 
 // Begin: SimpleItemStore
@@ -178,7 +426,11 @@ func (s *SimpleItemStore) CreateItemInTx(tnx *sql.Tx, item *ShItem) (*ShItem, er
 
 func (s *SimpleItemStore) UpdateItemInTx(tnx *sql.Tx, item *ShItem) (*ShItem, error) {
 	if prev_item, ok := s.Items[item.ItemId]; ok {
+		if prev_item.Version != item.Version {
+			return nil, ErrVersionConflict
+		}
 		*prev_item = *item
+		prev_item.Version++
 		return prev_item, nil
 	}
 	return nil, fmt.Errorf("UpdateItemInTx, Item %d doens't exist", item.ItemId)
@@ -195,6 +447,80 @@ func (s *SimpleItemStore) GetItemByIdInTx(tnx *sql.Tx, id int) (*ShItem, error)
 	return nil, fmt.Errorf("GetItemByIdInTx, Item %d doens't exist", id)
 }
 
+func (s *SimpleItemStore) GetItemByUUIDInTx(tnx *sql.Tx, company_id int, uid string) (*ShItem, error) {
+	for _, item := range s.Items {
+		if item.CompanyId == company_id && item.ClientUUID == uid {
+			return item, nil
+		}
+	}
+	return nil, ErrNoData
+}
+
+func (s *SimpleItemStore) GetItemByBarCode(company_id int, bar_code string) (*ShItem, error) {
+	for _, item := range s.Items {
+		if item.CompanyId == company_id && item.BarCode == bar_code {
+			return item, nil
+		}
+	}
+	return nil, ErrNoData
+}
+
+// sorts the exact code or bar code matches of query first, then by name
+type _itemsBySearchRank struct {
+	items []*ShItem
+	query string
+}
+
+func (s _itemsBySearchRank) is_exact(i int) bool {
+	return len(s.query) > 0 && (strings.ToLower(s.items[i].ItemCode) == s.query ||
+		strings.ToLower(s.items[i].BarCode) == s.query)
+}
+
+func (s _itemsBySearchRank) Len() int      { return len(s.items) }
+func (s _itemsBySearchRank) Swap(i, j int) { s.items[i], s.items[j] = s.items[j], s.items[i] }
+func (s _itemsBySearchRank) Less(i, j int) bool {
+	if s.is_exact(i) != s.is_exact(j) {
+		return s.is_exact(i)
+	}
+	if s.items[i].Name != s.items[j].Name {
+		return s.items[i].Name < s.items[j].Name
+	}
+	return s.items[i].ItemId > s.items[j].ItemId
+}
+
+// Only does substring matches, it doesn't know about branch items.
+func (s *SimpleItemStore) SearchItems(search *ShItemSearch, page_size int) ([]*ShItem, bool, error) {
+	if search.BranchId != 0 {
+		return nil, false, fmt.Errorf("SearchItems by branch, Not yet implemented ")
+	}
+
+	query := strings.ToLower(strings.TrimSpace(search.Query))
+
+	var items []*ShItem
+	for _, item := range s.Items {
+		if item.CompanyId != search.CompanyId ||
+			(!search.IncludeHidden && item.StatusFlag != STATUS_VISIBLE) ||
+			(search.CategoryId != 0 && item.CategoryId != search.CategoryId) {
+			continue
+		}
+		text := strings.ToLower(strings.Join(
+			[]string{item.Name, item.ItemCode, item.PartNumber, item.BarCode}, " "))
+		if strings.Contains(text, query) {
+			items = append(items, item)
+		}
+	}
+	sort.Sort(_itemsBySearchRank{items, query})
+
+	if search.Offset >= len(items) {
+		return nil, false, nil
+	}
+	items = items[search.Offset:]
+	if page_size > 0 && len(items) > page_size {
+		return items[:page_size], true, nil
+	}
+	return items, false, nil
+}
+
 func (s *SimpleItemStore) GetAllCompanyItems(int) ([]*ShItem, error) {
 	return nil, fmt.Errorf("GetAllCompanyItems, Not yet implemented ")
 }
@@ -226,12 +552,25 @@ func (s *SimpleBranchStore) CreateBranchInTx(tnx *sql.Tx, branch *ShBranch) (*Sh
 
 func (s *SimpleBranchStore) UpdateBranchInTx(tnx *sql.Tx, branch *ShBranch) (*ShBranch, error) {
 	if prev_item, ok := s.Branches[branch.BranchId]; ok {
+		if prev_item.Version != branch.Version {
+			return nil, ErrVersionConflict
+		}
 		*prev_item = *branch
+		prev_item.Version++
 		return prev_item, nil
 	}
 	return nil, fmt.Errorf("UpdateBranchInTx, Branch %d doens't exist", branch.BranchId)
 }
 
+func (s *SimpleBranchStore) GetBranchByUUIDInTx(tnx *sql.Tx, company_id int, uid string) (*ShBranch, error) {
+	for _, branch := range s.Branches {
+		if branch.CompanyId == company_id && branch.ClientUUID == uid {
+			return branch, nil
+		}
+	}
+	return nil, ErrNoData
+}
+
 func (s *SimpleBranchStore) GetBranchById(id int) (*ShBranch, error) {
 	return s.GetBranchByIdInTx(nil, id)
 }
@@ -246,3 +585,332 @@ func (s *SimpleBranchStore) GetBranchByIdInTx(tnx *sql.Tx, id int) (*ShBranch, e
 func (s *SimpleBranchStore) ListCompanyBranches(int) ([]*ShBranch, error) {
 	return nil, fmt.Errorf("ListCompanyBranches, Not yet implemented ")
 }
+
+// End: SimpleBranchStore
+
+// Begin: SimpleCategoryStore
+type SimpleCategoryStore struct {
+	Categories map[int]*ShCategory
+
+	// if set, it is used for the items of the categories
+	ItemStore *SimpleItemStore
+}
+
+func NewSimpleCategoryStore(item_store *SimpleItemStore) *SimpleCategoryStore {
+	s := &SimpleCategoryStore{ItemStore: item_store}
+	s.Categories = make(map[int]*ShCategory)
+	s.Categories[SERVER_ROOT_CATEGORY_ID] = &ShCategory{
+		CategoryId: SERVER_ROOT_CATEGORY_ID, StatusFlag: STATUS_VISIBLE, Version: 1}
+	return s
+}
+
+func (s *SimpleCategoryStore) CreateCategoryInTx(tnx *sql.Tx, category *ShCategory) (*ShCategory, error) {
+	created := &ShCategory{}
+	*created = *category
+	created.CategoryId = len(s.Categories) + 1
+	for s.Categories[created.CategoryId] != nil {
+		created.CategoryId++
+	}
+	created.Version = 1
+	s.Categories[created.CategoryId] = created
+	return created, nil
+}
+
+func (s *SimpleCategoryStore) GetCategoryById(id int) (*ShCategory, error) {
+	return s.GetCategoryByIdInTx(nil, id)
+}
+
+func (s *SimpleCategoryStore) GetCategoryByIdInTx(tnx *sql.Tx, id int) (*ShCategory, error) {
+	if category, ok := s.Categories[id]; ok {
+		return category, nil
+	}
+	return nil, ErrNoData
+}
+
+func (s *SimpleCategoryStore) GetCategoryByUUIDInTx(tnx *sql.Tx, company_id int, uid string) (*ShCategory, error) {
+	for _, category := range s.Categories {
+		if category.CompanyId == company_id && category.ClientUUID == uid {
+			return category, nil
+		}
+	}
+	return nil, ErrNoData
+}
+
+func (s *SimpleCategoryStore) UpdateCategoryInTx(tnx *sql.Tx, category *ShCategory) (*ShCategory, error) {
+	if prev_category, ok := s.Categories[category.CategoryId]; ok {
+		if prev_category.Version != category.Version {
+			return nil, ErrVersionConflict
+		}
+		*prev_category = *category
+		prev_category.Version++
+		return prev_category, nil
+	}
+	return nil, fmt.Errorf("UpdateCategoryInTx, Category %d doens't exist", category.CategoryId)
+}
+
+func (s *SimpleCategoryStore) DeleteCategoryInTx(tnx *sql.Tx, category_id int) error {
+	delete(s.Categories, category_id)
+	return nil
+}
+
+func (s *SimpleCategoryStore) IsCategoryAncestorInTx(tnx *sql.Tx, ancestor_id, category_id int) (bool, error) {
+	visited := make(map[int]bool)
+	for id := category_id; !visited[id]; {
+		if id == ancestor_id {
+			return true, nil
+		}
+		visited[id] = true
+		category, ok := s.Categories[id]
+		if !ok {
+			break
+		}
+		id = category.ParentId
+	}
+	return false, nil
+}
+
+func (s *SimpleCategoryStore) _items() map[int]*ShItem {
+	if s.ItemStore == nil {
+		return nil
+	}
+	return s.ItemStore.Items
+}
+
+func (s *SimpleCategoryStore) IsCategoryEmptyInTx(tnx *sql.Tx, category_id int) (bool, error) {
+	for id, category := range s.Categories {
+		if id != category_id && category.ParentId == category_id {
+			return false, nil
+		}
+	}
+	for _, item := range s._items() {
+		if item.CategoryId == category_id {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (s *SimpleCategoryStore) MoveCategoryChildrenInTx(tnx *sql.Tx, category_id, new_parent_id int) (moved_categories []int, moved_items []int, err error) {
+	for id, category := range s.Categories {
+		if id != category_id && category.ParentId == category_id {
+			category.ParentId = new_parent_id
+			category.Version++
+			moved_categories = append(moved_categories, id)
+		}
+	}
+	for _, item := range s._items() {
+		if item.CategoryId == category_id {
+			item.CategoryId = new_parent_id
+			item.Version++
+			moved_items = append(moved_items, item.ItemId)
+		}
+	}
+	return moved_categories, moved_items, nil
+}
+
+func (s *SimpleCategoryStore) DeleteCategorySubtreeInTx(tnx *sql.Tx, category_id, items_parent_id int) (deleted_categories []int, moved_items []int, err error) {
+	in_subtree := map[int]bool{category_id: true}
+	for changed := true; changed; {
+		changed = false
+		for id, category := range s.Categories {
+			if !in_subtree[id] && in_subtree[category.ParentId] {
+				in_subtree[id] = true
+				changed = true
+			}
+		}
+	}
+
+	for _, item := range s._items() {
+		if in_subtree[item.CategoryId] {
+			item.CategoryId = items_parent_id
+			item.StatusFlag = STATUS_IN_VISIBLE
+			item.Version++
+			moved_items = append(moved_items, item.ItemId)
+		}
+	}
+	for id := range in_subtree {
+		if _, ok := s.Categories[id]; ok {
+			delete(s.Categories, id)
+			deleted_categories = append(deleted_categories, id)
+		}
+	}
+	return deleted_categories, moved_items, nil
+}
+
+// End: SimpleCategoryStore
+
+// Begin: SimpleTransferStore
+type SimpleTransferStore struct {
+	Transfers map[int]*ShTransfer
+}
+
+func NewSimpleTransferStore() *SimpleTransferStore {
+	s := &SimpleTransferStore{}
+	s.Transfers = make(map[int]*ShTransfer)
+	return s
+}
+
+func (s *SimpleTransferStore) CreateTransferInTx(tnx *sql.Tx, transfer *ShTransfer) (*ShTransfer, error) {
+	transfer.TransferId = len(s.Transfers) + 1
+	for _, item := range transfer.Items {
+		item.TransferId = transfer.TransferId
+	}
+	s.Transfers[transfer.TransferId] = transfer
+	return transfer, nil
+}
+
+func (s *SimpleTransferStore) ReceiveTransferInTx(tnx *sql.Tx, transfer *ShTransfer) (*ShTransfer, error) {
+	prev, err := s.GetTransferInTx(tnx, transfer.CompanyId, transfer.TransferId)
+	if err != nil {
+		return nil, err
+	}
+	if prev.Status != TRANSFER_STATUS_SENT {
+		return nil, ErrTransferReceived
+	}
+	*prev = *transfer
+	prev.Status = TRANSFER_STATUS_RECEIVED
+	return prev, nil
+}
+
+func (s *SimpleTransferStore) GetTransferInTx(tnx *sql.Tx, company_id, transfer_id int) (*ShTransfer, error) {
+	if transfer, ok := s.Transfers[transfer_id]; ok && transfer.CompanyId == company_id {
+		return transfer, nil
+	}
+	return nil, ErrNoData
+}
+
+func (s *SimpleTransferStore) GetTransferByUUIDInTx(tnx *sql.Tx, company_id int, uid string) (*ShTransfer, error) {
+	for _, transfer := range s.Transfers {
+		if transfer.CompanyId == company_id && transfer.ClientUUID == uid {
+			return transfer, nil
+		}
+	}
+	return nil, ErrNoData
+}
+
+func (s *SimpleTransferStore) GetPendingTransfers(company_id int) ([]*ShTransfer, error) {
+	var transfers []*ShTransfer
+	for id := 1; id <= len(s.Transfers); id++ {
+		if transfer := s.Transfers[id]; transfer.CompanyId == company_id &&
+			transfer.Status == TRANSFER_STATUS_SENT {
+			transfers = append(transfers, transfer)
+		}
+	}
+	return transfers, nil
+}
+
+// End: SimpleTransferStore
+
+// Begin: SimpleStockTakeStore
+type SimpleStockTakeStore struct {
+	StockTakes map[int]*ShStockTake
+}
+
+func NewSimpleStockTakeStore() *SimpleStockTakeStore {
+	s := &SimpleStockTakeStore{}
+	s.StockTakes = make(map[int]*ShStockTake)
+	return s
+}
+
+func (s *SimpleStockTakeStore) CreateStockTakeInTx(tnx *sql.Tx, take *ShStockTake) (*ShStockTake, error) {
+	take.StockTakeId = len(s.StockTakes) + 1
+	s.StockTakes[take.StockTakeId] = take
+	return take, nil
+}
+
+func (s *SimpleStockTakeStore) UpdateStockTakeInTx(tnx *sql.Tx, take *ShStockTake) (*ShStockTake, error) {
+	prev, ok := s.StockTakes[take.StockTakeId]
+	if !ok || prev.Status != STOCK_TAKE_OPEN {
+		return nil, ErrStockTakeApproved
+	}
+	prev.Status = take.Status
+	prev.ApprovedBy = take.ApprovedBy
+	prev.ApprovedDate = take.ApprovedDate
+	prev.TransactionId = take.TransactionId
+	return take, nil
+}
+
+func (s *SimpleStockTakeStore) SetStockTakeCountInTx(tnx *sql.Tx, item *ShStockTakeItem) error {
+	take := s.StockTakes[item.StockTakeId]
+	for i, prev := range take.Items {
+		if prev.ItemId == item.ItemId {
+			take.Items[i] = item
+			return nil
+		}
+	}
+	take.Items = append(take.Items, item)
+	return nil
+}
+
+/**
+ * Returns a copy, so changes made before it is updated aren't seen by other readers.
+ */
+func (s *SimpleStockTakeStore) GetStockTakeInTx(tnx *sql.Tx, company_id, stock_take_id int) (*ShStockTake, error) {
+	take, ok := s.StockTakes[stock_take_id]
+	if !ok || take.CompanyId != company_id {
+		return nil, ErrNoData
+	}
+	result := *take
+	result.Items = nil
+	for _, item := range take.Items {
+		i := *item
+		result.Items = append(result.Items, &i)
+	}
+	return &result, nil
+}
+
+func (s *SimpleStockTakeStore) GetOpenStockTakeInTx(tnx *sql.Tx, company_id, branch_id int) (*ShStockTake, error) {
+	for _, take := range s.StockTakes {
+		if take.CompanyId == company_id && take.BranchId == branch_id &&
+			take.Status == STOCK_TAKE_OPEN {
+			return s.GetStockTakeInTx(tnx, company_id, take.StockTakeId)
+		}
+	}
+	return nil, ErrNoData
+}
+
+// End: SimpleStockTakeStore
+// Begin: SimpleItemPriceStore
+type SimpleItemPriceStore struct {
+	// in the order they were added
+	Prices []*ShItemPrice
+}
+
+func NewSimpleItemPriceStore(prices []*ShItemPrice) *SimpleItemPriceStore {
+	return &SimpleItemPriceStore{Prices: prices}
+}
+
+func (s *SimpleItemPriceStore) AddItemPriceInTx(tnx *sql.Tx, price *ShItemPrice) error {
+	s.Prices = append(s.Prices, price)
+	return nil
+}
+
+func (s *SimpleItemPriceStore) GetItemPriceHistory(company_id, item_id int) ([]*ShItemPrice, error) {
+	var history []*ShItemPrice
+	for i := len(s.Prices) - 1; i >= 0; i-- {
+		if price := s.Prices[i]; price.CompanyId == company_id && price.ItemId == item_id {
+			history = append(history, price)
+		}
+	}
+	return history, nil
+}
+
+func (s *SimpleItemPriceStore) GetItemCostPrices(company_id int) (map[int]float64, error) {
+	prices := make(map[int]float64)
+	for _, price := range s.Prices {
+		if price.CompanyId == company_id && price.BranchId == 0 {
+			prices[price.ItemId] = price.CostPrice
+		}
+	}
+	return prices, nil
+}
+
+func (s *SimpleItemPriceStore) GetItemPriceAsOfInTx(tnx *sql.Tx, company_id, branch_id, item_id int, date int64) (*ShItemPrice, error) {
+	history, _ := s.GetItemPriceHistory(company_id, item_id)
+	if price := EffectivePrice(history, branch_id, date); price != nil {
+		return price, nil
+	}
+	return nil, ErrNoData
+}
+
+// End: SimpleItemPriceStore
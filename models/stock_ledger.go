@@ -0,0 +1,189 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+/**
+ * Every stock movement is recorded in the ledger as a pair of balanced
+ * entries, stock leaves one account and enters another. The quantity of an
+ * item in a branch is the sum of the entries of that branch's account, so
+ * the quantity in {@table s_branch_item} is only a cache of that sum.
+ */
+const (
+	// stock held by a branch, the entry's BranchId tells which
+	LEDGER_ACCOUNT_BRANCH = 1
+
+	// stock outside the company, where purchases come from and sales go to
+	LEDGER_ACCOUNT_EXTERNAL = 2
+
+	// stock written-off or found, e.g: damage, loss and count corrections
+	LEDGER_ACCOUNT_ADJUSTMENT = 3
+
+	// stock sent by a branch that isn't yet received, see ShTransfer
+	LEDGER_ACCOUNT_IN_TRANSIT = 4
+)
+
+type ShStockLedgerEntry struct {
+	CompanyId int
+	// 0 for corrections, they aren't part of any transaction
+	TransactionId int64
+	ItemId        int
+
+	Account int
+	// only set for LEDGER_ACCOUNT_BRANCH entries
+	BranchId int
+
+	// positive if the stock entered the account, negative if it left
+	Quantity float64
+	Date     int64
+}
+
+func _ledgerMove(trans *ShTransaction, trans_item *ShTransactionItem,
+	from_account, from_branch, to_account, to_branch int) []*ShStockLedgerEntry {
+
+	entry := func(account, branch_id int, quantity float64) *ShStockLedgerEntry {
+		return &ShStockLedgerEntry{
+			CompanyId:     trans.CompanyId,
+			TransactionId: trans.TransactionId,
+			ItemId:        trans_item.ItemId,
+			Account:       account,
+			BranchId:      branch_id,
+			Quantity:      quantity,
+			Date:          trans.Date,
+		}
+	}
+	return []*ShStockLedgerEntry{
+		entry(from_account, from_branch, -trans_item.Quantity),
+		entry(to_account, to_branch, trans_item.Quantity),
+	}
+}
+
+/**
+ * Returns the ledger entries of the transaction's items. Items of an unknown
+ * type don't move stock, so they don't have entries.
+ */
+func StockLedgerEntries(trans *ShTransaction) []*ShStockLedgerEntry {
+	var entries []*ShStockLedgerEntry
+
+	branch_id := trans.BranchId
+	for _, trans_item := range trans.TransItems {
+		switch trans_item.TransType {
+		case TRANS_TYPE_ADD_PURCHASED, TRANS_TYPE_ADD_CUSTOMER_RETURN:
+			entries = append(entries, _ledgerMove(trans, trans_item,
+				LEDGER_ACCOUNT_EXTERNAL, 0, LEDGER_ACCOUNT_BRANCH, branch_id)...)
+		case TRANS_TYPE_SUB_CURRENT_BRANCH_SALE, TRANS_TYPE_SUB_RETURN_TO_SUPPLIER:
+			entries = append(entries, _ledgerMove(trans, trans_item,
+				LEDGER_ACCOUNT_BRANCH, branch_id, LEDGER_ACCOUNT_EXTERNAL, 0)...)
+		case TRANS_TYPE_ADD_TRANSFER_FROM_OTHER:
+			entries = append(entries, _ledgerMove(trans, trans_item,
+				LEDGER_ACCOUNT_BRANCH, trans_item.OtherBranchId, LEDGER_ACCOUNT_BRANCH, branch_id)...)
+		case TRANS_TYPE_SUB_TRANSFER_TO_OTHER:
+			entries = append(entries, _ledgerMove(trans, trans_item,
+				LEDGER_ACCOUNT_BRANCH, branch_id, LEDGER_ACCOUNT_BRANCH, trans_item.OtherBranchId)...)
+		case TRANS_TYPE_ADD_FOUND, TRANS_TYPE_ADD_COUNT_CORRECTION:
+			entries = append(entries, _ledgerMove(trans, trans_item,
+				LEDGER_ACCOUNT_ADJUSTMENT, 0, LEDGER_ACCOUNT_BRANCH, branch_id)...)
+		case TRANS_TYPE_SUB_DAMAGE, TRANS_TYPE_SUB_LOSS, TRANS_TYPE_SUB_COUNT_CORRECTION:
+			entries = append(entries, _ledgerMove(trans, trans_item,
+				LEDGER_ACCOUNT_BRANCH, branch_id, LEDGER_ACCOUNT_ADJUSTMENT, 0)...)
+		case TRANS_TYPE_SUB_TRANSFER_SENT:
+			entries = append(entries, _ledgerMove(trans, trans_item,
+				LEDGER_ACCOUNT_BRANCH, branch_id, LEDGER_ACCOUNT_IN_TRANSIT, 0)...)
+		case TRANS_TYPE_ADD_TRANSFER_RECEIVED:
+			entries = append(entries, _ledgerMove(trans, trans_item,
+				LEDGER_ACCOUNT_IN_TRANSIT, 0, LEDGER_ACCOUNT_BRANCH, branch_id)...)
+		case TRANS_TYPE_SUB_TRANSIT_DISCREPANCY:
+			entries = append(entries, _ledgerMove(trans, trans_item,
+				LEDGER_ACCOUNT_IN_TRANSIT, 0, LEDGER_ACCOUNT_ADJUSTMENT, 0)...)
+		}
+	}
+	return entries
+}
+
+func _nullLedgerBranch(entry *ShStockLedgerEntry) sql.NullInt64 {
+	if entry.Account != LEDGER_ACCOUNT_BRANCH {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(entry.BranchId), Valid: true}
+}
+
+func _nullLedgerTransaction(entry *ShStockLedgerEntry) sql.NullInt64 {
+	return sql.NullInt64{Int64: entry.TransactionId, Valid: entry.TransactionId != 0}
+}
+
+func (s *shStore) AddStockLedgerEntriesInTx(tnx *sql.Tx, entries []*ShStockLedgerEntry) error {
+	for _, entry := range entries {
+		_, err := tnx.Exec(fmt.Sprintf("insert into %s "+
+			"(company_id, transaction_id, item_id, account, branch_id, quantity, t_date) values "+
+			"($1, $2, $3, $4, $5, $6, $7)", TABLE_STOCK_LEDGER),
+			entry.CompanyId, _nullLedgerTransaction(entry), entry.ItemId, entry.Account,
+			_nullLedgerBranch(entry), entry.Quantity, entry.Date)
+		if err != nil {
+			return err
+		}
+	}
+	return _invalidateStockSnapshotsInTx(tnx, entries)
+}
+
+func (s *shStore) GetStockLedgerQuantityInTx(tnx *sql.Tx, company_id, branch_id, item_id int) (float64, error) {
+	var quantity float64
+	err := tnx.QueryRow(fmt.Sprintf("select coalesce(sum(quantity), 0) from %s "+
+		"where company_id = $1 and account = $2 and branch_id = $3 and item_id = $4",
+		TABLE_STOCK_LEDGER),
+		company_id, LEDGER_ACCOUNT_BRANCH, branch_id, item_id).Scan(&quantity)
+	if err != nil {
+		return 0, err
+	}
+	return quantity, nil
+}
+
+/**
+ * Returns the entries that aren't part of a transaction dated in (after, until], by date.
+ */
+func (s *shStore) GetStockLedgerCorrections(company_id int, after, until int64) ([]*ShStockLedgerEntry, error) {
+	rows, err := s.Query(fmt.Sprintf("select company_id, item_id, account, branch_id, quantity, t_date "+
+		"from %s where company_id = $1 and transaction_id is null and t_date > $2 and t_date <= $3 "+
+		"ORDER BY t_date asc, entry_id asc", TABLE_STOCK_LEDGER),
+		company_id, after, until)
+	if err != nil {
+		return nil, fmt.Errorf("company:%d, ledger corrections %v", company_id, err)
+	}
+	defer rows.Close()
+
+	var entries []*ShStockLedgerEntry
+	for rows.Next() {
+		e := new(ShStockLedgerEntry)
+		var branch_id sql.NullInt64
+		if err := rows.Scan(&e.CompanyId, &e.ItemId, &e.Account, &branch_id,
+			&e.Quantity, &e.Date); err != nil {
+			return nil, err
+		}
+		e.BranchId = int(branch_id.Int64)
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+func (s *shStore) GetStockLedgerQuantitiesInTx(tnx *sql.Tx, company_id int) (map[BranchItemPair]float64, error) {
+	rows, err := tnx.Query(fmt.Sprintf("select branch_id, item_id, sum(quantity) from %s "+
+		"where company_id = $1 and account = $2 group by branch_id, item_id",
+		TABLE_STOCK_LEDGER),
+		company_id, LEDGER_ACCOUNT_BRANCH)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	quantities := make(map[BranchItemPair]float64)
+	for rows.Next() {
+		var pair BranchItemPair
+		var quantity float64
+		if err := rows.Scan(&pair.BranchId, &pair.ItemId, &quantity); err != nil {
+			return nil, err
+		}
+		quantities[pair] = quantity
+	}
+	return quantities, rows.Err()
+}
@@ -0,0 +1,99 @@
+package models
+
+import "testing"
+
+func TestStockLedgerEntriesAreBalanced(t *testing.T) {
+	trans := &ShTransaction{
+		CompanyId:     1,
+		TransactionId: 20,
+		BranchId:      3,
+		Date:          1000,
+		TransItems: []*ShTransactionItem{
+			{TransType: TRANS_TYPE_ADD_PURCHASED, ItemId: 7, Quantity: 10},
+			{TransType: TRANS_TYPE_SUB_CURRENT_BRANCH_SALE, ItemId: 7, Quantity: 2},
+			{TransType: TRANS_TYPE_ADD_TRANSFER_FROM_OTHER, ItemId: 8, OtherBranchId: 4, Quantity: 5},
+			{TransType: TRANS_TYPE_SUB_TRANSFER_TO_OTHER, ItemId: 8, OtherBranchId: 4, Quantity: 1},
+			{TransType: TRANS_TYPE_ADD_CUSTOMER_RETURN, ItemId: 7, Quantity: 1},
+			{TransType: TRANS_TYPE_SUB_RETURN_TO_SUPPLIER, ItemId: 8, Quantity: 2},
+		},
+	}
+
+	entries := StockLedgerEntries(trans)
+	if len(entries) != 2*len(trans.TransItems) {
+		t.Fatalf("expected %d entries, got %d", 2*len(trans.TransItems), len(entries))
+	}
+
+	total := float64(0)
+	branch_qty := make(map[BranchItemPair]float64)
+	external_qty := float64(0)
+	for _, entry := range entries {
+		if entry.TransactionId != trans.TransactionId || entry.Date != trans.Date {
+			t.Errorf("entry isn't linked to the transaction: %+v", entry)
+		}
+		total += entry.Quantity
+		switch entry.Account {
+		case LEDGER_ACCOUNT_BRANCH:
+			branch_qty[BranchItemPair{entry.BranchId, entry.ItemId}] += entry.Quantity
+		case LEDGER_ACCOUNT_EXTERNAL:
+			external_qty += entry.Quantity
+		}
+	}
+
+	if total != 0 {
+		t.Errorf("expected the entries to sum to 0, got %v", total)
+	}
+	expected := map[BranchItemPair]float64{
+		{3, 7}: 9,
+		{3, 8}: 2,
+		{4, 8}: -4,
+	}
+	for pair, qty := range expected {
+		if branch_qty[pair] != qty {
+			t.Errorf("branch:%d item:%d, expected %v got %v",
+				pair.BranchId, pair.ItemId, qty, branch_qty[pair])
+		}
+	}
+	if external_qty != -7 {
+		t.Errorf("expected -7 to have left the external account, got %v", external_qty)
+	}
+}
+
+func TestUnknownTransTypeHasNoEntries(t *testing.T) {
+	trans := &ShTransaction{
+		TransItems: []*ShTransactionItem{
+			{TransType: 999, ItemId: 7, Quantity: 10},
+		},
+	}
+	if entries := StockLedgerEntries(trans); len(entries) != 0 {
+		t.Errorf("expected no entries, got %d", len(entries))
+	}
+}
+
+func TestBranchItemQuantityIsLockedAndWrittenAlone(t *testing.T) {
+	db, store, teardown := t_fake_store(t)
+	defer teardown()
+
+	db.expect("for update").returns("quantity", t_row(4.0))
+	db.expect("update " + TABLE_BRANCH_ITEM + " set quantity = $1 where").affects(1)
+	// the item isn't in the branch
+	db.expect("for update").returns("quantity")
+
+	tnx, err := store.Begin()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer tnx.Rollback()
+
+	if err = store.LockBranchItemInTx(tnx, 3, 7); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if err = store.UpdateBranchItemQuantityInTx(tnx, 3, 7, 6); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if args := db.args[1]; len(args) != 3 || args[0] != 6.0 {
+		t.Errorf("expected only the quantity to be written, got %v", args)
+	}
+	if err = store.LockBranchItemInTx(tnx, 3, 8); err != ErrNoData {
+		t.Errorf("expected ErrNoData, got %v", err)
+	}
+}
@@ -0,0 +1,264 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+)
+
+/**
+ * Rebuilding the quantities at a date means summing the ledger up to that
+ * date, which gets slow for companies with a long history. So snapshots of
+ * the quantities are periodically taken, and only the entries after the
+ * latest snapshot before the date need to be summed.
+ *
+ * Transactions can be posted with a date in the past, so adding ledger
+ * entries removes the snapshots taken on or after the entries' date.
+ */
+
+type ShStockQuantity struct {
+	BranchId int
+	ItemId   int
+	Quantity float64
+}
+
+func _scanStockQuantities(rows *sql.Rows) ([]*ShStockQuantity, error) {
+	defer rows.Close()
+
+	var result []*ShStockQuantity
+	for rows.Next() {
+		q := new(ShStockQuantity)
+		if err := rows.Scan(&q.BranchId, &q.ItemId, &q.Quantity); err != nil {
+			return nil, err
+		}
+		result = append(result, q)
+	}
+	return result, rows.Err()
+}
+
+/**
+ * Adds up the quantities of each (branch, item), the ones that end up with
+ * nothing are left out. The result is sorted by branch then item.
+ */
+func _sumStockQuantities(quantities ...[]*ShStockQuantity) []*ShStockQuantity {
+	sums := make(map[BranchItemPair]float64)
+	for _, list := range quantities {
+		for _, q := range list {
+			sums[BranchItemPair{q.BranchId, q.ItemId}] += q.Quantity
+		}
+	}
+
+	var result []*ShStockQuantity
+	for pair, quantity := range sums {
+		if _quantityDrifted(quantity, 0) {
+			result = append(result, &ShStockQuantity{
+				BranchId: pair.BranchId, ItemId: pair.ItemId, Quantity: quantity})
+		}
+	}
+	sort.Sort(_stockQuantitiesByBranch(result))
+	return result
+}
+
+type _stockQuantitiesByBranch []*ShStockQuantity
+
+func (q _stockQuantitiesByBranch) Len() int      { return len(q) }
+func (q _stockQuantitiesByBranch) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q _stockQuantitiesByBranch) Less(i, j int) bool {
+	if q[i].BranchId != q[j].BranchId {
+		return q[i].BranchId < q[j].BranchId
+	}
+	return q[i].ItemId < q[j].ItemId
+}
+
+/**
+ * The quantities of the latest snapshot on or before as_of, with the
+ * ledger entries after the snapshot up to as_of added to them.
+ */
+func _queryStockAsOf(query_fn func(string, ...interface{}) (*sql.Rows, error),
+	company_id, branch_id int, as_of int64) ([]*ShStockQuantity, error) {
+
+	err_msg := fmt.Sprintf("company:%d, stock as of:%d", company_id, as_of)
+
+	rows, err := query_fn(fmt.Sprintf("select max(snapshot_date) from %s "+
+		"where company_id = $1 and snapshot_date <= $2", TABLE_STOCK_SNAPSHOT),
+		company_id, as_of)
+	if err != nil {
+		return nil, fmt.Errorf("%s %v", err_msg, err)
+	}
+	var snapshot_date sql.NullInt64
+	if rows.Next() {
+		err = rows.Scan(&snapshot_date)
+	}
+	rows.Close()
+	if err != nil {
+		return nil, fmt.Errorf("%s %v", err_msg, err)
+	}
+
+	var snapshot []*ShStockQuantity
+	ledger_query := fmt.Sprintf("select branch_id, item_id, sum(quantity) from %s "+
+		"where company_id = $1 and account = $2 and ($3 = 0 or branch_id = $3) and t_date <= $4 ",
+		TABLE_STOCK_LEDGER)
+	ledger_args := []interface{}{company_id, LEDGER_ACCOUNT_BRANCH, branch_id, as_of}
+
+	if snapshot_date.Valid {
+		rows, err = query_fn(fmt.Sprintf("select branch_id, item_id, quantity from %s "+
+			"where company_id = $1 and snapshot_date = $2 and ($3 = 0 or branch_id = $3)",
+			TABLE_STOCK_SNAPSHOT), company_id, snapshot_date.Int64, branch_id)
+		if err != nil {
+			return nil, fmt.Errorf("%s %v", err_msg, err)
+		}
+		if snapshot, err = _scanStockQuantities(rows); err != nil {
+			return nil, fmt.Errorf("%s %v", err_msg, err)
+		}
+
+		// the snapshot already has the entries up to its date
+		ledger_query += "and t_date > $5 "
+		ledger_args = append(ledger_args, snapshot_date.Int64)
+	}
+
+	rows, err = query_fn(ledger_query+"group by branch_id, item_id", ledger_args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s %v", err_msg, err)
+	}
+	ledger, err := _scanStockQuantities(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s %v", err_msg, err)
+	}
+
+	return _sumStockQuantities(snapshot, ledger), nil
+}
+
+/**
+ * Returns the quantity of the items in the branch at the date, items
+ * that had nothing are left out.
+ * @args branch_id	0 returns the quantity in every branch
+ */
+func (s *shStore) GetStockAsOf(company_id, branch_id int, as_of int64) ([]*ShStockQuantity, error) {
+	return _queryStockAsOf(s.Query, company_id, branch_id, as_of)
+}
+
+/**
+ * Takes a snapshot of the quantities at the date, replacing the snapshot
+ * already taken at that date.
+ */
+func (s *shStore) CreateStockSnapshotInTx(tnx *sql.Tx, company_id int, snapshot_date int64) error {
+	quantities, err := _queryStockAsOf(tnx.Query, company_id, 0, snapshot_date)
+	if err != nil {
+		return err
+	}
+
+	_, err = tnx.Exec(fmt.Sprintf("delete from %s "+
+		"where company_id = $1 and snapshot_date = $2", TABLE_STOCK_SNAPSHOT),
+		company_id, snapshot_date)
+	if err != nil {
+		return err
+	}
+
+	for _, q := range quantities {
+		_, err = tnx.Exec(fmt.Sprintf("insert into %s "+
+			"(company_id, snapshot_date, branch_id, item_id, quantity) values "+
+			"($1, $2, $3, $4, $5)", TABLE_STOCK_SNAPSHOT),
+			company_id, snapshot_date, q.BranchId, q.ItemId, q.Quantity)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/**
+ * Returns the cost layers of the latest snapshot on or before the date, ErrNoData if there isn't one.
+ * @args costing_method	snapshots taken with another method can't be used
+ */
+func (s *shStore) GetStockCostSnapshot(company_id, costing_method int, before int64) (int64, []*ShCostLayer, error) {
+	err_msg := fmt.Sprintf("company:%d, cost snapshot before:%d", company_id, before)
+
+	var snapshot_date sql.NullInt64
+	err := s.QueryRow(fmt.Sprintf("select max(snapshot_date) from %s "+
+		"where company_id = $1 and costing_method = $2 and snapshot_date <= $3", TABLE_COST_SNAPSHOT),
+		company_id, costing_method, before).Scan(&snapshot_date)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%s %v", err_msg, err)
+	}
+	if !snapshot_date.Valid {
+		return 0, nil, ErrNoData
+	}
+
+	rows, err := s.Query(fmt.Sprintf("select account, branch_id, item_id, "+
+		"quantity, unit_cost, last_cost, deficit from %s "+
+		"where company_id = $1 and costing_method = $2 and snapshot_date = $3 "+
+		"ORDER BY account, branch_id, item_id, layer", TABLE_COST_SNAPSHOT),
+		company_id, costing_method, snapshot_date.Int64)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%s %v", err_msg, err)
+	}
+	defer rows.Close()
+
+	var layers []*ShCostLayer
+	for rows.Next() {
+		l := new(ShCostLayer)
+		var branch_id sql.NullInt64
+		if err := rows.Scan(&l.Account, &branch_id, &l.ItemId,
+			&l.Quantity, &l.UnitCost, &l.LastCost, &l.Deficit); err != nil {
+			return 0, nil, fmt.Errorf("%s %v", err_msg, err)
+		}
+		l.BranchId = int(branch_id.Int64)
+		layers = append(layers, l)
+	}
+	return snapshot_date.Int64, layers, rows.Err()
+}
+
+/**
+ * Stores the cost layers at the date, replacing the snapshot already taken
+ * at that date with the method.
+ */
+func (s *shStore) CreateStockCostSnapshotInTx(tnx *sql.Tx, company_id, costing_method int, snapshot_date int64, layers []*ShCostLayer) error {
+	_, err := tnx.Exec(fmt.Sprintf("delete from %s "+
+		"where company_id = $1 and costing_method = $2 and snapshot_date = $3", TABLE_COST_SNAPSHOT),
+		company_id, costing_method, snapshot_date)
+	if err != nil {
+		return err
+	}
+
+	for i, l := range layers {
+		var branch_id sql.NullInt64
+		if l.BranchId != 0 {
+			branch_id = sql.NullInt64{Int64: int64(l.BranchId), Valid: true}
+		}
+		_, err = tnx.Exec(fmt.Sprintf("insert into %s "+
+			"(company_id, snapshot_date, costing_method, account, branch_id, item_id, "+
+			"layer, quantity, unit_cost, last_cost, deficit) values "+
+			"($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)", TABLE_COST_SNAPSHOT),
+			company_id, snapshot_date, costing_method, l.Account, branch_id, l.ItemId,
+			i, l.Quantity, l.UnitCost, l.LastCost, l.Deficit)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/**
+ * Removes the snapshots that don't include the entries.
+ */
+func _invalidateStockSnapshotsInTx(tnx *sql.Tx, entries []*ShStockLedgerEntry) error {
+	// company_id -> the earliest date of its entries
+	earliest := make(map[int]int64)
+	for _, entry := range entries {
+		if date, ok := earliest[entry.CompanyId]; !ok || entry.Date < date {
+			earliest[entry.CompanyId] = entry.Date
+		}
+	}
+
+	for company_id, date := range earliest {
+		for _, table := range []string{TABLE_STOCK_SNAPSHOT, TABLE_COST_SNAPSHOT} {
+			_, err := tnx.Exec(fmt.Sprintf("delete from %s "+
+				"where company_id = $1 and snapshot_date >= $2", table),
+				company_id, date)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
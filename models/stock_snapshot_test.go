@@ -0,0 +1,87 @@
+package models
+
+import "testing"
+
+const t_snapshot_company_id = 5
+
+func TestStockAsOfAddsLedgerAfterSnapshot(t *testing.T) {
+	db, store, teardown := t_fake_store(t)
+	defer teardown()
+
+	db.expect("select max(snapshot_date)").returns("max", t_row(int64(100)))
+	db.expect("from "+TABLE_STOCK_SNAPSHOT+" where company_id = $1 and snapshot_date = $2").
+		returns("branch_id, item_id, quantity",
+			t_row(int64(1), int64(11), 5.0), t_row(int64(1), int64(12), 2.0))
+	db.expect("and t_date > $5 group by").
+		returns("branch_id, item_id, sum",
+			// everything in branch 1 was sold after the snapshot
+			t_row(int64(1), int64(11), -5.0), t_row(int64(2), int64(11), 3.0))
+
+	quantities, err := store.GetStockAsOf(t_snapshot_company_id, 0, 250)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(quantities) != 2 ||
+		*quantities[0] != (ShStockQuantity{BranchId: 1, ItemId: 12, Quantity: 2}) ||
+		*quantities[1] != (ShStockQuantity{BranchId: 2, ItemId: 11, Quantity: 3}) {
+		t.Errorf("expected item 12 in branch 1 and item 11 in branch 2, got %v", quantities)
+	}
+
+	// the ledger is only read after the snapshot, up to the date
+	if args := db.args[2]; len(args) != 5 || args[3] != int64(250) || args[4] != int64(100) {
+		t.Errorf("expected the ledger between the snapshot and the date, got %v", args)
+	}
+}
+
+func TestStockAsOfWithoutSnapshot(t *testing.T) {
+	db, store, teardown := t_fake_store(t)
+	defer teardown()
+
+	db.expect("select max(snapshot_date)").returns("max", t_row(nil))
+	db.expect("t_date <= $4 group by").
+		returns("branch_id, item_id, sum", t_row(int64(2), int64(11), 4.0))
+
+	quantities, err := store.GetStockAsOf(t_snapshot_company_id, 2, 250)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(quantities) != 1 || quantities[0].Quantity != 4 {
+		t.Errorf("expected the whole ledger of branch 2, got %v", quantities)
+	}
+	if args := db.args[1]; len(args) != 4 || args[2] != int64(2) {
+		t.Errorf("expected the ledger of branch 2 from the start, got %v", args)
+	}
+}
+
+func TestOlderEntriesInvalidateSnapshots(t *testing.T) {
+	db, store, teardown := t_fake_store(t)
+	defer teardown()
+
+	// a transaction posted with an older date than the snapshots
+	entries := []*ShStockLedgerEntry{
+		{CompanyId: t_snapshot_company_id, ItemId: 11, Account: LEDGER_ACCOUNT_EXTERNAL, Quantity: -2, Date: 300},
+		{CompanyId: t_snapshot_company_id, ItemId: 11, Account: LEDGER_ACCOUNT_BRANCH, BranchId: 1, Quantity: 2, Date: 200},
+	}
+	for range entries {
+		db.expect("insert into " + TABLE_STOCK_LEDGER)
+	}
+	db.expect("delete from " + TABLE_STOCK_SNAPSHOT + " where company_id = $1 and snapshot_date >= $2")
+	db.expect("delete from " + TABLE_COST_SNAPSHOT + " where company_id = $1 and snapshot_date >= $2")
+
+	tnx, err := store.Begin()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer tnx.Rollback()
+
+	if err = store.AddStockLedgerEntriesInTx(tnx, entries); err != nil {
+		t.Fatalf("%v", err)
+	}
+	// every snapshot from the earliest entry on is missing it
+	if args := db.args[2]; args[0] != int64(t_snapshot_company_id) || args[1] != int64(200) {
+		t.Errorf("expected the snapshots from 200 to be removed, got %v", args)
+	}
+	if args := db.args[3]; args[1] != int64(200) {
+		t.Errorf("expected the cost snapshots from 200 to be removed, got %v", args)
+	}
+}
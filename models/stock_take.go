@@ -0,0 +1,216 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+/**
+ * A stock take is a count of the items in a branch. Employees submit the
+ * quantities they counted while it is open, when a manager approves it the
+ * difference between the counted and the recorded quantities is posted as
+ * count correction transactions.
+ */
+type ShStockTake struct {
+	StockTakeId int
+	CompanyId   int
+	BranchId    int
+
+	Status int
+
+	CreatedBy   int
+	CreatedDate int64
+
+	// set once it is approved
+	ApprovedBy    int
+	ApprovedDate  int64
+	TransactionId int64 // the posted corrections, 0 if there wasn't any
+
+	Items []*ShStockTakeItem
+}
+
+type ShStockTakeItem struct {
+	StockTakeId     int
+	ItemId          int
+	CountedQuantity float64
+	CountedBy       int
+
+	// the quantity in the branch when the item was counted, the
+	// correction is relative to it so sales made after the count aren't undone
+	RecordedQuantity float64
+}
+
+const (
+	STOCK_TAKE_OPEN     = 1
+	STOCK_TAKE_APPROVED = 2
+)
+
+/**
+ * Returns the transaction items that bring the recorded quantities to the
+ * counted ones. Items that were counted right don't have one.
+ * @args recorded	item_id -> the quantity in the branch
+ */
+func StockTakeCorrections(take *ShStockTake, recorded map[int]float64) []*ShTransactionItem {
+	var corrections []*ShTransactionItem
+	for _, item := range take.Items {
+		diff := item.CountedQuantity - recorded[item.ItemId]
+		if !_quantityDrifted(diff, 0) {
+			continue
+		}
+
+		correction := &ShTransactionItem{
+			CompanyId: take.CompanyId,
+			ItemId:    item.ItemId,
+			ItemNote:  fmt.Sprintf("stock take %d", take.StockTakeId),
+		}
+		if diff > 0 {
+			correction.TransType = TRANS_TYPE_ADD_COUNT_CORRECTION
+			correction.Quantity = diff
+		} else {
+			correction.TransType = TRANS_TYPE_SUB_COUNT_CORRECTION
+			correction.Quantity = -diff
+		}
+		corrections = append(corrections, correction)
+	}
+	return corrections
+}
+
+func (s *shStore) CreateStockTakeInTx(tnx *sql.Tx, take *ShStockTake) (*ShStockTake, error) {
+	err := tnx.QueryRow(fmt.Sprintf("insert into %s "+
+		"(company_id, branch_id, status, created_by, created_date) values "+
+		"($1, $2, $3, $4, $5) returning stock_take_id", TABLE_STOCK_TAKE),
+		take.CompanyId, take.BranchId, take.Status, take.CreatedBy, take.CreatedDate).
+		Scan(&take.StockTakeId)
+	if err != nil {
+		return nil, err
+	}
+	return take, nil
+}
+
+/**
+ * Approves the stock take. It fails with ErrStockTakeApproved if it isn't open,
+ * so concurrent approvals can't post the corrections twice.
+ */
+func (s *shStore) UpdateStockTakeInTx(tnx *sql.Tx, take *ShStockTake) (*ShStockTake, error) {
+	result, err := tnx.Exec(fmt.Sprintf("update %s set "+
+		"status = $1, approved_by = $2, approved_date = $3, transaction_id = $4 "+
+		"where stock_take_id = $5 and status = $6", TABLE_STOCK_TAKE),
+		take.Status, take.ApprovedBy, take.ApprovedDate, _nullTransId(take.TransactionId),
+		take.StockTakeId, STOCK_TAKE_OPEN)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		return nil, ErrStockTakeApproved
+	}
+	return take, nil
+}
+
+/**
+ * Records the counted quantity of the item along with the quantity recorded at
+ * the time, counting it again replaces both.
+ */
+func (s *shStore) SetStockTakeCountInTx(tnx *sql.Tx, item *ShStockTakeItem) error {
+	result, err := tnx.Exec(fmt.Sprintf("update %s set "+
+		"counted_quantity = $1, counted_by = $2, recorded_quantity = $3 "+
+		"where stock_take_id = $4 and item_id = $5", TABLE_STOCK_TAKE_ITEM),
+		item.CountedQuantity, item.CountedBy, item.RecordedQuantity, item.StockTakeId, item.ItemId)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n > 0 {
+		return nil
+	}
+
+	// it is the first count of the item
+	_, err = tnx.Exec(fmt.Sprintf("insert into %s "+
+		"(stock_take_id, item_id, counted_quantity, counted_by, recorded_quantity) "+
+		"values ($1, $2, $3, $4, $5)", TABLE_STOCK_TAKE_ITEM),
+		item.StockTakeId, item.ItemId, item.CountedQuantity, item.CountedBy, item.RecordedQuantity)
+	return err
+}
+
+func (s *shStore) GetStockTakeInTx(tnx *sql.Tx, company_id, stock_take_id int) (*ShStockTake, error) {
+	msg := fmt.Sprintf("company:%d, no stock take %d", company_id, stock_take_id)
+	takes, err := _queryStockTakesInTx(tnx, msg,
+		"where company_id = $1 and stock_take_id = $2", company_id, stock_take_id)
+	if err != nil {
+		return nil, err
+	}
+	return takes[0], nil
+}
+
+func (s *shStore) GetOpenStockTakeInTx(tnx *sql.Tx, company_id, branch_id int) (*ShStockTake, error) {
+	msg := fmt.Sprintf("company:%d, no open stock take in branch %d", company_id, branch_id)
+	takes, err := _queryStockTakesInTx(tnx, msg,
+		"where company_id = $1 and branch_id = $2 and status = $3",
+		company_id, branch_id, STOCK_TAKE_OPEN)
+	if err != nil {
+		return nil, err
+	}
+	return takes[0], nil
+}
+
+func _queryStockTakesInTx(tnx *sql.Tx, err_msg string, where_stmt string, args ...interface{}) ([]*ShStockTake, error) {
+	rows, err := tnx.Query(fmt.Sprintf("select stock_take_id, company_id, branch_id, status, "+
+		"created_by, created_date, approved_by, approved_date, transaction_id from %s ",
+		TABLE_STOCK_TAKE)+where_stmt+" ORDER BY stock_take_id asc", args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s %v", err_msg, err)
+	}
+
+	var result []*ShStockTake
+	for rows.Next() {
+		t := new(ShStockTake)
+		var approved_by, approved_date, transaction_id sql.NullInt64
+		if err := rows.Scan(&t.StockTakeId, &t.CompanyId, &t.BranchId, &t.Status,
+			&t.CreatedBy, &t.CreatedDate, &approved_by, &approved_date, &transaction_id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("%s %v", err_msg, err)
+		}
+		t.ApprovedBy = int(approved_by.Int64)
+		t.ApprovedDate = approved_date.Int64
+		t.TransactionId = transaction_id.Int64
+		result = append(result, t)
+	}
+	// we don't defer the rows.Close b/c the items are queried on the same connection
+	rows.Close()
+
+	if len(result) == 0 {
+		return nil, ErrNoData
+	}
+
+	for _, t := range result {
+		if t.Items, err = _queryStockTakeItemsInTx(tnx, err_msg, t.StockTakeId); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func _queryStockTakeItemsInTx(tnx *sql.Tx, err_msg string, stock_take_id int) ([]*ShStockTakeItem, error) {
+	rows, err := tnx.Query(fmt.Sprintf("select stock_take_id, item_id, counted_quantity, counted_by, recorded_quantity "+
+		"from %s where stock_take_id = $1 ORDER BY item_id asc", TABLE_STOCK_TAKE_ITEM),
+		stock_take_id)
+	if err != nil {
+		return nil, fmt.Errorf("%s %v", err_msg, err)
+	}
+	defer rows.Close()
+
+	var result []*ShStockTakeItem
+	for rows.Next() {
+		i := new(ShStockTakeItem)
+		var recorded sql.NullFloat64
+		if err := rows.Scan(&i.StockTakeId, &i.ItemId, &i.CountedQuantity, &i.CountedBy,
+			&recorded); err != nil {
+			return nil, fmt.Errorf("%s %v", err_msg, err)
+		}
+		i.RecordedQuantity = recorded.Float64
+		result = append(result, i)
+	}
+	return result, rows.Err()
+}
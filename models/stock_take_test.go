@@ -0,0 +1,79 @@
+package models
+
+import "testing"
+
+func TestStockTakeCorrections(t *testing.T) {
+	take := &ShStockTake{
+		StockTakeId: 3,
+		CompanyId:   1,
+		BranchId:    2,
+		Items: []*ShStockTakeItem{
+			// counted right
+			{ItemId: 5, CountedQuantity: 10},
+			// more than recorded
+			{ItemId: 6, CountedQuantity: 7},
+			// less than recorded
+			{ItemId: 7, CountedQuantity: 1},
+			// never recorded in the branch
+			{ItemId: 8, CountedQuantity: 2},
+		},
+	}
+	recorded := map[int]float64{5: 10, 6: 4, 7: 3.5}
+
+	corrections := StockTakeCorrections(take, recorded)
+	if len(corrections) != 3 {
+		t.Fatalf("expected 3 corrections, got %d", len(corrections))
+	}
+
+	expected := []struct {
+		item_id    int
+		trans_type int
+		quantity   float64
+	}{
+		{6, TRANS_TYPE_ADD_COUNT_CORRECTION, 3},
+		{7, TRANS_TYPE_SUB_COUNT_CORRECTION, 2.5},
+		{8, TRANS_TYPE_ADD_COUNT_CORRECTION, 2},
+	}
+	for i, e := range expected {
+		c := corrections[i]
+		if c.ItemId != e.item_id || c.TransType != e.trans_type || c.Quantity != e.quantity {
+			t.Errorf("wrong correction of item %d %+v", e.item_id, c)
+		}
+	}
+
+	// applying them brings the branch to the counted quantities
+	trans := &ShTransaction{CompanyId: 1, BranchId: 2, TransItems: corrections}
+	quantities := ReplayStockQuantities([]*ShTransaction{trans})
+	for _, item := range take.Items {
+		pair := BranchItemPair{BranchId: 2, ItemId: item.ItemId}
+		if got := recorded[item.ItemId] + quantities[pair]; got != item.CountedQuantity {
+			t.Errorf("item %d ends with %v, counted %v", item.ItemId, got, item.CountedQuantity)
+		}
+	}
+}
+
+func TestApprovingStockTakeTwiceFails(t *testing.T) {
+	db, store, teardown := t_fake_store(t)
+	defer teardown()
+
+	db.expect("update " + TABLE_STOCK_TAKE + " set").affects(1)
+	// a concurrent approval committed first
+	db.expect("update " + TABLE_STOCK_TAKE + " set").affects(0)
+
+	tnx, err := store.Begin()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer tnx.Rollback()
+
+	take := &ShStockTake{StockTakeId: 3, CompanyId: 1, Status: STOCK_TAKE_APPROVED}
+	if _, err = store.UpdateStockTakeInTx(tnx, take); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if args := db.args[0]; args[5] != int64(STOCK_TAKE_OPEN) {
+		t.Errorf("expected it to only update an open stock take, got %v", args)
+	}
+	if _, err = store.UpdateStockTakeInTx(tnx, take); err != ErrStockTakeApproved {
+		t.Errorf("expected ErrStockTakeApproved, got %v", err)
+	}
+}
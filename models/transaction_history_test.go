@@ -0,0 +1,32 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestTransactionHistoryFilter(t *testing.T) {
+	db, store, teardown := t_fake_store(t)
+	defer teardown()
+
+	db.expect("where company_id = $1 AND branch_id = $2 AND transaction_id < $3 "+
+		"ORDER BY transaction_id desc LIMIT 2").
+		returns(t_trans_columns, t_trans_row(39), t_trans_row(35))
+	db.expect("where transaction_id = $1").returns(t_trans_item_columns, t_trans_item_row(39))
+	db.expect("where transaction_id = $1").returns(t_trans_item_columns, t_trans_item_row(35))
+
+	trans, has_more, err := store.GetShTransactionHistory(&ShTransactionFilter{
+		CompanyId:     t_rev_company_id,
+		BranchId:      2,
+		BeforeTransId: 40,
+	}, 1)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if !has_more || len(trans) != 1 || trans[0].TransactionId != 39 {
+		t.Errorf("expected transaction 39 with more after it, got %d, has_more:%v", len(trans), has_more)
+	}
+	args := db.args[0]
+	if len(args) != 3 || args[0] != int64(t_rev_company_id) || args[1] != int64(2) || args[2] != int64(40) {
+		t.Errorf("wrong filter args %v", args)
+	}
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"database/sql/driver"
+	"testing"
+)
+
+const t_trans_columns = "transaction_id, company_id, branch_id, user_id, t_date, trans_note, " +
+	"client_uuid, linked_trans_id, is_void, voided_by_trans_id"
+
+const t_trans_item_columns = "company_id, transaction_id, trans_type, item_id, other_branch_id, " +
+	"quantity, item_note, unit_price, discount, line_total, unit_id, unit_quantity, unit_name"
+
+func t_trans_row(trans_id int64) []driver.Value {
+	return []driver.Value{trans_id, int64(t_rev_company_id), int64(1), int64(1), int64(100),
+		"", nil, nil, false, nil}
+}
+
+func t_trans_item_row(trans_id int64) []driver.Value {
+	return []driver.Value{int64(t_rev_company_id), trans_id, int64(TRANS_TYPE_ADD_PURCHASED),
+		int64(4), int64(0), 2.0, "", 0.0, 0.0, 0.0, int64(0), nil, nil}
+}
+
+func TestTransactionPageHasMore(t *testing.T) {
+	db, store, teardown := t_fake_store(t)
+	defer teardown()
+
+	db.expect("LIMIT 3").returns(t_trans_columns,
+		t_trans_row(21), t_trans_row(22), t_trans_row(25))
+	db.expect("where transaction_id = $1").returns(t_trans_item_columns, t_trans_item_row(21))
+	db.expect("where transaction_id = $1").returns(t_trans_item_columns, t_trans_item_row(22))
+	db.expect("where transaction_id = $1").returns(t_trans_item_columns, t_trans_item_row(25))
+
+	trans, has_more, err := store.GetShTransactionPageSinceTransId(t_rev_company_id, 20, 2)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if !has_more || len(trans) != 2 || trans[1].TransactionId != 22 {
+		t.Errorf("expected transactions 21 and 22 with more after them, got %d, has_more:%v",
+			len(trans), has_more)
+	}
+	// posted before units were kept, so it is in the base unit
+	if trans[0].TransItems[0].UnitQuantity != 2 {
+		t.Errorf("expected the unit quantity to default to the quantity, got %v",
+			trans[0].TransItems[0].UnitQuantity)
+	}
+}
+
+func TestTransactionPageEmpty(t *testing.T) {
+	db, store, teardown := t_fake_store(t)
+	defer teardown()
+
+	db.expect("LIMIT 3").returns(t_trans_columns)
+
+	trans, has_more, err := store.GetShTransactionPageSinceTransId(t_rev_company_id, 20, 2)
+	if err != nil || has_more || len(trans) != 0 {
+		t.Errorf("expected an empty last page, got %d, has_more:%v, err:%v", len(trans), has_more, err)
+	}
+}
+
+func TestCompanyTransactionsInTx(t *testing.T) {
+	db, store, teardown := t_fake_store(t)
+	defer teardown()
+
+	db.expect("set transaction isolation level repeatable read")
+	db.expect("where company_id = $1").returns(t_trans_columns, t_trans_row(21), t_trans_row(22))
+	// every item in one query, after the transactions' rows are closed
+	db.expect("where transaction_id in (select transaction_id").returns(t_trans_item_columns,
+		t_trans_item_row(21), t_trans_item_row(22), t_trans_item_row(22))
+
+	tnx, err := store.BeginRepeatableRead()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer tnx.Rollback()
+
+	transactions, err := store.GetCompanyTransactionsInTx(tnx, t_rev_company_id)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(transactions) != 2 || len(transactions[0].TransItems) != 1 ||
+		len(transactions[1].TransItems) != 2 {
+		t.Errorf("expected the items grouped under their transactions, got %v", transactions)
+	}
+}
@@ -0,0 +1,254 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+/**
+ * A transfer moves items between branches in two steps. Sending it takes the
+ * items out of the sending branch into the in-transit account, receiving it
+ * moves what arrived into the receiving branch. If what arrived differs from
+ * what was sent, the difference is written-off(or in) to the adjustment account.
+ */
+type ShTransfer struct {
+	TransferId   int
+	CompanyId    int
+	ClientUUID   string
+	FromBranchId int
+	ToBranchId   int
+
+	Status       int
+	TransferNote string
+
+	SentBy      int
+	SentDate    int64
+	SendTransId int64
+
+	// set once it is received
+	ReceivedBy     int
+	ReceivedDate   int64
+	ReceiveTransId int64
+
+	Items []*ShTransferItem
+}
+
+type ShTransferItem struct {
+	TransferId   int
+	ItemId       int
+	SentQuantity float64
+	// only valid once the transfer is received
+	ReceivedQuantity float64
+}
+
+const (
+	TRANSFER_STATUS_SENT     = 1
+	TRANSFER_STATUS_RECEIVED = 2
+)
+
+/**
+ * Returns the transaction items that send the transfer.
+ */
+func TransferSendItems(transfer *ShTransfer) []*ShTransactionItem {
+	var items []*ShTransactionItem
+	for _, item := range transfer.Items {
+		items = append(items, &ShTransactionItem{
+			CompanyId:     transfer.CompanyId,
+			TransType:     TRANS_TYPE_SUB_TRANSFER_SENT,
+			ItemId:        item.ItemId,
+			OtherBranchId: transfer.ToBranchId,
+			Quantity:      item.SentQuantity,
+		})
+	}
+	return items
+}
+
+/**
+ * Returns the transaction items that receive the transfer, the received
+ * quantities should be set. Items that didn't arrive as sent also have a
+ * discrepancy, it is negative if more arrived than was sent.
+ */
+func TransferReceiveItems(transfer *ShTransfer) []*ShTransactionItem {
+	var items []*ShTransactionItem
+	for _, item := range transfer.Items {
+		if item.ReceivedQuantity != 0 {
+			items = append(items, &ShTransactionItem{
+				CompanyId:     transfer.CompanyId,
+				TransType:     TRANS_TYPE_ADD_TRANSFER_RECEIVED,
+				ItemId:        item.ItemId,
+				OtherBranchId: transfer.FromBranchId,
+				Quantity:      item.ReceivedQuantity,
+			})
+		}
+		if diff := item.SentQuantity - item.ReceivedQuantity; _quantityDrifted(diff, 0) {
+			items = append(items, &ShTransactionItem{
+				CompanyId:     transfer.CompanyId,
+				TransType:     TRANS_TYPE_SUB_TRANSIT_DISCREPANCY,
+				ItemId:        item.ItemId,
+				OtherBranchId: transfer.FromBranchId,
+				Quantity:      diff,
+			})
+		}
+	}
+	return items
+}
+
+func (s *shStore) CreateTransferInTx(tnx *sql.Tx, transfer *ShTransfer) (*ShTransfer, error) {
+	err := tnx.QueryRow(fmt.Sprintf("insert into %s "+
+		"(company_id, client_uuid, from_branch_id, to_branch_id, status, transfer_note, "+
+		"sent_by, sent_date, send_trans_id) values "+
+		"($1, $2, $3, $4, $5, $6, $7, $8, $9) returning transfer_id", TABLE_TRANSFER),
+		transfer.CompanyId, _nullClientUUID(transfer.ClientUUID),
+		transfer.FromBranchId, transfer.ToBranchId, transfer.Status, transfer.TransferNote,
+		transfer.SentBy, transfer.SentDate, _nullTransId(transfer.SendTransId)).
+		Scan(&transfer.TransferId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range transfer.Items {
+		item.TransferId = transfer.TransferId
+		_, err = tnx.Exec(fmt.Sprintf("insert into %s "+
+			"(transfer_id, item_id, sent_quantity) values ($1, $2, $3)", TABLE_TRANSFER_ITEM),
+			item.TransferId, item.ItemId, item.SentQuantity)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return transfer, nil
+}
+
+/**
+ * Records the receipt of the transfer, along with the received quantity of its
+ * items. It fails with ErrTransferReceived if it has been received before.
+ */
+func (s *shStore) ReceiveTransferInTx(tnx *sql.Tx, transfer *ShTransfer) (*ShTransfer, error) {
+	result, err := tnx.Exec(fmt.Sprintf("update %s set "+
+		"status = $1, received_by = $2, received_date = $3, receive_trans_id = $4 "+
+		"where company_id = $5 and transfer_id = $6 and status = $7", TABLE_TRANSFER),
+		TRANSFER_STATUS_RECEIVED, transfer.ReceivedBy, transfer.ReceivedDate,
+		_nullTransId(transfer.ReceiveTransId),
+		transfer.CompanyId, transfer.TransferId, TRANSFER_STATUS_SENT)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		return nil, ErrTransferReceived
+	}
+	transfer.Status = TRANSFER_STATUS_RECEIVED
+
+	for _, item := range transfer.Items {
+		_, err = tnx.Exec(fmt.Sprintf("update %s set received_quantity = $1 "+
+			"where transfer_id = $2 and item_id = $3", TABLE_TRANSFER_ITEM),
+			item.ReceivedQuantity, transfer.TransferId, item.ItemId)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return transfer, nil
+}
+
+func (s *shStore) GetTransferInTx(tnx *sql.Tx, company_id, transfer_id int) (*ShTransfer, error) {
+	msg := fmt.Sprintf("company:%d, no transfer %d", company_id, transfer_id)
+	transfers, err := _queryTransfers(tnx.Query, msg,
+		"where company_id = $1 and transfer_id = $2", company_id, transfer_id)
+	if err != nil {
+		return nil, err
+	}
+	return transfers[0], nil
+}
+
+func (s *shStore) GetTransferByUUIDInTx(tnx *sql.Tx, company_id int, uid string) (*ShTransfer, error) {
+	msg := fmt.Sprintf("company:%d, no transfer with uuid:%s", company_id, uid)
+	transfers, err := _queryTransfers(tnx.Query, msg,
+		"where company_id = $1 and client_uuid = $2", company_id, uid)
+	if err != nil {
+		return nil, err
+	}
+	return transfers[0], nil
+}
+
+/**
+ * Returns the transfers that are sent but not yet received.
+ */
+func (s *shStore) GetPendingTransfers(company_id int) ([]*ShTransfer, error) {
+	msg := fmt.Sprintf("company:%d, no pending transfers", company_id)
+	transfers, err := _queryTransfers(s.Query, msg,
+		"where company_id = $1 and status = $2", company_id, TRANSFER_STATUS_SENT)
+	if err == ErrNoData {
+		return nil, nil
+	}
+	return transfers, err
+}
+
+func _queryTransfers(query_fn func(string, ...interface{}) (*sql.Rows, error),
+	err_msg string, where_stmt string, args ...interface{}) ([]*ShTransfer, error) {
+
+	rows, err := query_fn(fmt.Sprintf("select transfer_id, company_id, client_uuid, "+
+		"from_branch_id, to_branch_id, status, transfer_note, "+
+		"sent_by, sent_date, send_trans_id, "+
+		"received_by, received_date, receive_trans_id from %s ",
+		TABLE_TRANSFER)+where_stmt+" ORDER BY transfer_id asc", args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s %v", err_msg, err)
+	}
+
+	var result []*ShTransfer
+	transfers := make(map[int]*ShTransfer)
+	for rows.Next() {
+		t := new(ShTransfer)
+		var client_uuid sql.NullString
+		var send_trans_id, received_by, received_date, receive_trans_id sql.NullInt64
+		if err := rows.Scan(&t.TransferId, &t.CompanyId, &client_uuid,
+			&t.FromBranchId, &t.ToBranchId, &t.Status, &t.TransferNote,
+			&t.SentBy, &t.SentDate, &send_trans_id,
+			&received_by, &received_date, &receive_trans_id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("%s %v", err_msg, err)
+		}
+		t.ClientUUID = client_uuid.String
+		t.SendTransId = send_trans_id.Int64
+		t.ReceivedBy = int(received_by.Int64)
+		t.ReceivedDate = received_date.Int64
+		t.ReceiveTransId = receive_trans_id.Int64
+		result = append(result, t)
+		transfers[t.TransferId] = t
+	}
+	// we don't defer the rows.Close b/c the items are queried on the same connection
+	rows.Close()
+
+	if len(result) == 0 {
+		return nil, ErrNoData
+	}
+
+	var transfer_ids []interface{}
+	var place_holders string
+	for i, t := range result {
+		transfer_ids = append(transfer_ids, t.TransferId)
+		if i > 0 {
+			place_holders += ", "
+		}
+		place_holders += fmt.Sprintf("$%d", i+1)
+	}
+
+	rows, err = query_fn(fmt.Sprintf("select transfer_id, item_id, sent_quantity, received_quantity "+
+		"from %s where transfer_id in (%s) ORDER BY item_id asc", TABLE_TRANSFER_ITEM, place_holders),
+		transfer_ids...)
+	if err != nil {
+		return nil, fmt.Errorf("%s %v", err_msg, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		i := new(ShTransferItem)
+		var received sql.NullFloat64
+		if err := rows.Scan(&i.TransferId, &i.ItemId, &i.SentQuantity, &received); err != nil {
+			return nil, fmt.Errorf("%s %v", err_msg, err)
+		}
+		i.ReceivedQuantity = received.Float64
+		transfers[i.TransferId].Items = append(transfers[i.TransferId].Items, i)
+	}
+	return result, rows.Err()
+}
@@ -39,6 +39,36 @@ It has these top-level messages:
 	Transaction
 	TransactionRequest
 	TransactionResponse
+	TransactionHistoryRequest
+	TransactionHistoryResponse
+	SalesReportRequest
+	SalesReportResponse
+	VoidTransactionRequest
+	VoidTransactionResponse
+	Transfer
+	SendTransferRequest
+	ReceiveTransferRequest
+	SubscribeRequest
+	ChangeNotification
+	ReorderReportRequest
+	ReorderReportResponse
+	ReconcileStockRequest
+	ReconcileStockResponse
+	StockAsOfRequest
+	StockAsOfResponse
+	ItemMovementsRequest
+	ItemMovementsResponse
+	InventoryValuationRequest
+	InventoryValuationResponse
+	StockTake
+	SubmitStockCountRequest
+	ApproveStockTakeRequest
+	ApproveStockTakeResponse
+	BarcodeLookupRequest
+	ItemPriceHistoryRequest
+	ItemPriceHistoryResponse
+	SearchItemsRequest
+	SearchItemsResponse
 */
 package sheketproto
 
@@ -86,6 +116,36 @@ func (x EntityRequest_Action) String() string {
 }
 func (EntityRequest_Action) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{25, 0} }
 
+// What happens to the sub-categories and items of a deleted category.
+type EntityRequest_DeletePolicy int32
+
+const (
+	// they are moved to the deleted category's parent
+	EntityRequest_MOVE_TO_PARENT EntityRequest_DeletePolicy = 0
+	// the sub-categories are deleted too, the items are hidden and moved to the parent
+	EntityRequest_DELETE_SUBTREE EntityRequest_DeletePolicy = 1
+	// the category is only deleted if it doesn't have any
+	EntityRequest_REFUSE_IF_NOT_EMPTY EntityRequest_DeletePolicy = 2
+)
+
+var EntityRequest_DeletePolicy_name = map[int32]string{
+	0: "MOVE_TO_PARENT",
+	1: "DELETE_SUBTREE",
+	2: "REFUSE_IF_NOT_EMPTY",
+}
+var EntityRequest_DeletePolicy_value = map[string]int32{
+	"MOVE_TO_PARENT":      0,
+	"DELETE_SUBTREE":      1,
+	"REFUSE_IF_NOT_EMPTY": 2,
+}
+
+func (x EntityRequest_DeletePolicy) String() string {
+	return proto.EnumName(EntityRequest_DeletePolicy_name, int32(x))
+}
+func (EntityRequest_DeletePolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{25, 1}
+}
+
 type EntityResponse_SyncState int32
 
 const (
@@ -109,6 +169,157 @@ func (x EntityResponse_SyncState) String() string {
 }
 func (EntityResponse_SyncState) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{26, 0} }
 
+// How the server handled an update made on a stale copy of an entity.
+type EntityResponse_ConflictResolution int32
+
+const (
+	// the update wasn't applied, the client should re-apply it on the server's copy
+	EntityResponse_REJECTED EntityResponse_ConflictResolution = 0
+	// the update was applied over a newer edit(last writer wins)
+	EntityResponse_OVERWRITTEN EntityResponse_ConflictResolution = 1
+)
+
+var EntityResponse_ConflictResolution_name = map[int32]string{
+	0: "REJECTED",
+	1: "OVERWRITTEN",
+}
+var EntityResponse_ConflictResolution_value = map[string]int32{
+	"REJECTED":    0,
+	"OVERWRITTEN": 1,
+}
+
+func (x EntityResponse_ConflictResolution) String() string {
+	return proto.EnumName(EntityResponse_ConflictResolution_name, int32(x))
+}
+func (EntityResponse_ConflictResolution) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{26, 1}
+}
+
+// Why an entity couldn't be created/updated.
+type EntityResponse_RejectReason int32
+
+const (
+	// another item in the company has the same bar code
+	EntityResponse_DUPLICATE_BAR_CODE EntityResponse_RejectReason = 0
+	// another item in the company has the same code(only if the server requires unique codes)
+	EntityResponse_DUPLICATE_ITEM_CODE EntityResponse_RejectReason = 1
+	// the category's new parent is the category itself or one of its sub-categories.
+	// Also for new categories whose parents form a cycle, or that are under one.
+	EntityResponse_CATEGORY_CYCLE EntityResponse_RejectReason = 2
+	// the category has sub-categories or items and the delete policy was REFUSE_IF_NOT_EMPTY
+	EntityResponse_CATEGORY_NOT_EMPTY EntityResponse_RejectReason = 3
+)
+
+var EntityResponse_RejectReason_name = map[int32]string{
+	0: "DUPLICATE_BAR_CODE",
+	1: "DUPLICATE_ITEM_CODE",
+	2: "CATEGORY_CYCLE",
+	3: "CATEGORY_NOT_EMPTY",
+}
+var EntityResponse_RejectReason_value = map[string]int32{
+	"DUPLICATE_BAR_CODE":  0,
+	"DUPLICATE_ITEM_CODE": 1,
+	"CATEGORY_CYCLE":      2,
+	"CATEGORY_NOT_EMPTY":  3,
+}
+
+func (x EntityResponse_RejectReason) String() string {
+	return proto.EnumName(EntityResponse_RejectReason_name, int32(x))
+}
+func (EntityResponse_RejectReason) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{26, 2}
+}
+
+type SalesReportRequest_GroupBy int32
+
+const (
+	SalesReportRequest_UNKNOWN  SalesReportRequest_GroupBy = 0
+	SalesReportRequest_ITEM     SalesReportRequest_GroupBy = 1
+	SalesReportRequest_CATEGORY SalesReportRequest_GroupBy = 2
+	SalesReportRequest_BRANCH   SalesReportRequest_GroupBy = 3
+	SalesReportRequest_USER     SalesReportRequest_GroupBy = 4
+	SalesReportRequest_DAY      SalesReportRequest_GroupBy = 5
+	// weeks start on monday
+	SalesReportRequest_WEEK  SalesReportRequest_GroupBy = 6
+	SalesReportRequest_MONTH SalesReportRequest_GroupBy = 7
+)
+
+var SalesReportRequest_GroupBy_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "ITEM",
+	2: "CATEGORY",
+	3: "BRANCH",
+	4: "USER",
+	5: "DAY",
+	6: "WEEK",
+	7: "MONTH",
+}
+var SalesReportRequest_GroupBy_value = map[string]int32{
+	"UNKNOWN":  0,
+	"ITEM":     1,
+	"CATEGORY": 2,
+	"BRANCH":   3,
+	"USER":     4,
+	"DAY":      5,
+	"WEEK":     6,
+	"MONTH":    7,
+}
+
+func (x SalesReportRequest_GroupBy) String() string {
+	return proto.EnumName(SalesReportRequest_GroupBy_name, int32(x))
+}
+func (SalesReportRequest_GroupBy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{32, 0}
+}
+
+type Transfer_Status int32
+
+const (
+	Transfer_UNKNOWN  Transfer_Status = 0
+	Transfer_SENT     Transfer_Status = 1
+	Transfer_RECEIVED Transfer_Status = 2
+)
+
+var Transfer_Status_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "SENT",
+	2: "RECEIVED",
+}
+var Transfer_Status_value = map[string]int32{
+	"UNKNOWN":  0,
+	"SENT":     1,
+	"RECEIVED": 2,
+}
+
+func (x Transfer_Status) String() string {
+	return proto.EnumName(Transfer_Status_name, int32(x))
+}
+func (Transfer_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{36, 0} }
+
+type StockTake_Status int32
+
+const (
+	StockTake_UNKNOWN  StockTake_Status = 0
+	StockTake_OPEN     StockTake_Status = 1
+	StockTake_APPROVED StockTake_Status = 2
+)
+
+var StockTake_Status_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "OPEN",
+	2: "APPROVED",
+}
+var StockTake_Status_value = map[string]int32{
+	"UNKNOWN":  0,
+	"OPEN":     1,
+	"APPROVED": 2,
+}
+
+func (x StockTake_Status) String() string {
+	return proto.EnumName(StockTake_Status_name, int32(x))
+}
+func (StockTake_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{51, 0} }
+
 // *
 // Common Messages
 type EmptyRequest struct {
@@ -235,6 +446,8 @@ type Company struct {
 	SignedLicense string `protobuf:"bytes,4,opt,name=signed_license,json=signedLicense" json:"signed_license,omitempty"`
 	// being able to generate payment_id at the server end gives us flexibilty
 	PaymentId string `protobuf:"bytes,5,opt,name=payment_id,json=paymentId" json:"payment_id,omitempty"`
+	// how the stock is valued, 1 for FIFO, 2 for weighted-average
+	CostingMethod int32 `protobuf:"varint,6,opt,name=costing_method,json=costingMethod" json:"costing_method,omitempty"`
 }
 
 func (m *Company) Reset()                    { *m = Company{} }
@@ -365,6 +578,8 @@ func (*VerifyPaymentResponse) Descriptor() ([]byte, []int) { return fileDescript
 type EditCompanyRequest struct {
 	CompanyAuth *CompanyAuth `protobuf:"bytes,1,opt,name=companyAuth" json:"companyAuth,omitempty"`
 	NewName     string       `protobuf:"bytes,2,opt,name=new_name,json=newName" json:"new_name,omitempty"`
+	// 0 leaves it as it is, only managers can change it. See Company.costing_method
+	CostingMethod int32 `protobuf:"varint,3,opt,name=costing_method,json=costingMethod" json:"costing_method,omitempty"`
 }
 
 func (m *EditCompanyRequest) Reset()                    { *m = EditCompanyRequest{} }
@@ -390,6 +605,21 @@ type Item struct {
 	HasDerivedUnit    bool    `protobuf:"varint,8,opt,name=has_derived_unit,json=hasDerivedUnit" json:"has_derived_unit,omitempty"`
 	DerivedName       string  `protobuf:"bytes,9,opt,name=derived_name,json=derivedName" json:"derived_name,omitempty"`
 	DerivedFactor     float64 `protobuf:"fixed64,10,opt,name=derived_factor,json=derivedFactor" json:"derived_factor,omitempty"`
+	// The version of the item the client edited, the server increments it on
+	// every update. 0 means the client doesn't track versions(no conflict check).
+	Version      int32   `protobuf:"varint,11,opt,name=version" json:"version,omitempty"`
+	ReorderLevel float64 `protobuf:"fixed64,12,opt,name=reorder_level,json=reorderLevel" json:"reorder_level,omitempty"`
+	ModelYear    string  `protobuf:"bytes,13,opt,name=model_year,json=modelYear" json:"model_year,omitempty"`
+	PartNumber   string  `protobuf:"bytes,14,opt,name=part_number,json=partNumber" json:"part_number,omitempty"`
+	BarCode      string  `protobuf:"bytes,15,opt,name=bar_code,json=barCode" json:"bar_code,omitempty"`
+	HasBarCode   bool    `protobuf:"varint,16,opt,name=has_bar_code,json=hasBarCode" json:"has_bar_code,omitempty"`
+	// only managers see(and can set) the cost price, it is 0 for others
+	CostPrice float64 `protobuf:"fixed64,17,opt,name=cost_price,json=costPrice" json:"cost_price,omitempty"`
+	// it can be overridden per branch, see BranchItem.selling_price
+	SellingPrice float64      `protobuf:"fixed64,18,opt,name=selling_price,json=sellingPrice" json:"selling_price,omitempty"`
+	Units        []*Item_Unit `protobuf:"bytes,19,rep,name=units" json:"units,omitempty"`
+	// if not set, the units are left as they are on the server
+	HasUnits bool `protobuf:"varint,20,opt,name=has_units,json=hasUnits" json:"has_units,omitempty"`
 }
 
 func (m *Item) Reset()                    { *m = Item{} }
@@ -397,12 +627,35 @@ func (m *Item) String() string            { return proto.CompactTextString(m) }
 func (*Item) ProtoMessage()               {}
 func (*Item) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
+func (m *Item) GetUnits() []*Item_Unit {
+	if m != nil {
+		return m.Units
+	}
+	return nil
+}
+
+// Units other than derived_name the item is counted in, e.g: a box and a carton.
+type Item_Unit struct {
+	// 0 for units the client is adding, the server assigns it
+	UnitId int32  `protobuf:"varint,1,opt,name=unit_id,json=unitId" json:"unit_id,omitempty"`
+	Name   string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// the number of unit_of_measurement in one of this unit
+	Factor float64 `protobuf:"fixed64,3,opt,name=factor" json:"factor,omitempty"`
+}
+
+func (m *Item_Unit) Reset()                    { *m = Item_Unit{} }
+func (m *Item_Unit) String() string            { return proto.CompactTextString(m) }
+func (*Item_Unit) ProtoMessage()               {}
+func (*Item_Unit) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19, 0} }
+
 type Category struct {
 	CategoryId int32  `protobuf:"zigzag32,1,opt,name=category_id,json=categoryId" json:"category_id,omitempty"`
 	Name       string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
 	ParentId   int32  `protobuf:"zigzag32,3,opt,name=parent_id,json=parentId" json:"parent_id,omitempty"`
 	UUID       string `protobuf:"bytes,4,opt,name=UUID,json=uUID" json:"UUID,omitempty"`
 	StatusFlag int32  `protobuf:"varint,5,opt,name=status_flag,json=statusFlag" json:"status_flag,omitempty"`
+	// See Item.version
+	Version int32 `protobuf:"varint,6,opt,name=version" json:"version,omitempty"`
 }
 
 func (m *Category) Reset()                    { *m = Category{} }
@@ -415,6 +668,8 @@ type Branch struct {
 	Name       string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
 	UUID       string `protobuf:"bytes,3,opt,name=UUID,json=uUID" json:"UUID,omitempty"`
 	StatusFlag int32  `protobuf:"varint,4,opt,name=status_flag,json=statusFlag" json:"status_flag,omitempty"`
+	// See Item.version
+	Version int32 `protobuf:"varint,5,opt,name=version" json:"version,omitempty"`
 }
 
 func (m *Branch) Reset()                    { *m = Branch{} }
@@ -438,6 +693,14 @@ type BranchItem struct {
 	ItemId        int32   `protobuf:"zigzag32,2,opt,name=item_id,json=itemId" json:"item_id,omitempty"`
 	Quantity      float64 `protobuf:"fixed64,3,opt,name=quantity" json:"quantity,omitempty"`
 	ShelfLocation string  `protobuf:"bytes,4,opt,name=shelf_location,json=shelfLocation" json:"shelf_location,omitempty"`
+	// If has_reorder_level is set, reorder_level overrides
+	// the item's reorder level in this branch.
+	ReorderLevel    float64 `protobuf:"fixed64,5,opt,name=reorder_level,json=reorderLevel" json:"reorder_level,omitempty"`
+	HasReorderLevel bool    `protobuf:"varint,6,opt,name=has_reorder_level,json=hasReorderLevel" json:"has_reorder_level,omitempty"`
+	// If has_selling_price is set, selling_price overrides
+	// the item's selling price in this branch.
+	SellingPrice    float64 `protobuf:"fixed64,7,opt,name=selling_price,json=sellingPrice" json:"selling_price,omitempty"`
+	HasSellingPrice bool    `protobuf:"varint,8,opt,name=has_selling_price,json=hasSellingPrice" json:"has_selling_price,omitempty"`
 }
 
 func (m *BranchItem) Reset()                    { *m = BranchItem{} }
@@ -469,6 +732,11 @@ type EntityRequest struct {
 	OldBranchItemRev     int32                                  `protobuf:"varint,24,opt,name=old_branch_item_rev,json=oldBranchItemRev" json:"old_branch_item_rev,omitempty"`
 	OldMemberRev         int32                                  `protobuf:"varint,25,opt,name=old_member_rev,json=oldMemberRev" json:"old_member_rev,omitempty"`
 	OldBranchCategoryRev int32                                  `protobuf:"varint,26,opt,name=old_branch_category_rev,json=oldBranchCategoryRev" json:"old_branch_category_rev,omitempty"`
+	// The maximum number of changes to return for each entity type, 0 means no limit.
+	// If there are more changes than fit in a page, the response's has_more is set
+	// and the new_*_rev values point to the last change sent. So just send
+	// those revs back to get the next page.
+	PageSize int32 `protobuf:"varint,27,opt,name=page_size,json=pageSize" json:"page_size,omitempty"`
 }
 
 func (m *EntityRequest) Reset()                    { *m = EntityRequest{} }
@@ -528,6 +796,11 @@ func (m *EntityRequest) GetCompanyAuth() *CompanyAuth {
 type EntityRequest_RequestItem struct {
 	Item   *Item                `protobuf:"bytes,1,opt,name=item" json:"item,omitempty"`
 	Action EntityRequest_Action `protobuf:"varint,2,opt,name=action,enum=sheketproto.EntityRequest_Action" json:"action,omitempty"`
+	// The names of the Item fields(as written in this file, e.g: "derived_name")
+	// the client edited, only those are changed on UPDATE. Unknown names are ignored.
+	// If empty, only the fields up to derived_factor(and the units if has_units is set)
+	// are changed, the fields added after those have to be named to be changed.
+	FieldMask []string `protobuf:"bytes,3,rep,name=field_mask,json=fieldMask" json:"field_mask,omitempty"`
 }
 
 func (m *EntityRequest_RequestItem) Reset()                    { *m = EntityRequest_RequestItem{} }
@@ -545,6 +818,8 @@ func (m *EntityRequest_RequestItem) GetItem() *Item {
 type EntityRequest_RequestCategory struct {
 	Category *Category            `protobuf:"bytes,1,opt,name=category" json:"category,omitempty"`
 	Action   EntityRequest_Action `protobuf:"varint,2,opt,name=action,enum=sheketproto.EntityRequest_Action" json:"action,omitempty"`
+	// only used on DELETE
+	DeletePolicy EntityRequest_DeletePolicy `protobuf:"varint,3,opt,name=delete_policy,json=deletePolicy,enum=sheketproto.EntityRequest_DeletePolicy" json:"delete_policy,omitempty"`
 }
 
 func (m *EntityRequest_RequestCategory) Reset()         { *m = EntityRequest_RequestCategory{} }
@@ -600,6 +875,10 @@ func (m *EntityRequest_RequestEmployee) GetEmployee() *Employee {
 type EntityRequest_RequestBranchItem struct {
 	Action     EntityRequest_Action `protobuf:"varint,1,opt,name=action,enum=sheketproto.EntityRequest_Action" json:"action,omitempty"`
 	BranchItem *BranchItem          `protobuf:"bytes,2,opt,name=branchItem" json:"branchItem,omitempty"`
+	// The names of the BranchItem fields the client edited, see RequestItem.field_mask.
+	// If empty, the shelf_location is changed, and the reorder level only if
+	// has_reorder_level is set. So an override can only be removed by naming it.
+	FieldMask []string `protobuf:"bytes,3,rep,name=field_mask,json=fieldMask" json:"field_mask,omitempty"`
 }
 
 func (m *EntityRequest_RequestBranchItem) Reset()         { *m = EntityRequest_RequestBranchItem{} }
@@ -644,12 +923,19 @@ type EntityResponse struct {
 	Branches             []*EntityResponse_SyncBranch         `protobuf:"bytes,6,rep,name=branches" json:"branches,omitempty"`
 	Employees            []*EntityResponse_SyncEmployee       `protobuf:"bytes,7,rep,name=employees" json:"employees,omitempty"`
 	BranchCategories     []*EntityResponse_SyncBranchCategory `protobuf:"bytes,8,rep,name=branchCategories" json:"branchCategories,omitempty"`
+	ItemConflicts        []*EntityResponse_ItemConflict       `protobuf:"bytes,9,rep,name=item_conflicts,json=itemConflicts" json:"item_conflicts,omitempty"`
+	CategoryConflicts    []*EntityResponse_CategoryConflict   `protobuf:"bytes,10,rep,name=category_conflicts,json=categoryConflicts" json:"category_conflicts,omitempty"`
+	BranchConflicts      []*EntityResponse_BranchConflict     `protobuf:"bytes,11,rep,name=branch_conflicts,json=branchConflicts" json:"branch_conflicts,omitempty"`
+	RejectedItems        []*EntityResponse_RejectedItem       `protobuf:"bytes,12,rep,name=rejected_items,json=rejectedItems" json:"rejected_items,omitempty"`
+	RejectedCategories   []*EntityResponse_RejectedCategory   `protobuf:"bytes,13,rep,name=rejected_categories,json=rejectedCategories" json:"rejected_categories,omitempty"`
 	NewCategoryRev       int32                                `protobuf:"varint,20,opt,name=new_category_rev,json=newCategoryRev" json:"new_category_rev,omitempty"`
 	NewItemRev           int32                                `protobuf:"varint,21,opt,name=new_item_rev,json=newItemRev" json:"new_item_rev,omitempty"`
 	NewBranchRev         int32                                `protobuf:"varint,22,opt,name=new_branch_rev,json=newBranchRev" json:"new_branch_rev,omitempty"`
 	NewBranchItemRev     int32                                `protobuf:"varint,23,opt,name=new_branch_item_rev,json=newBranchItemRev" json:"new_branch_item_rev,omitempty"`
 	NewMemberRev         int32                                `protobuf:"varint,24,opt,name=new_member_rev,json=newMemberRev" json:"new_member_rev,omitempty"`
 	NewBranchCategoryRev int32                                `protobuf:"varint,25,opt,name=new_branch_category_rev,json=newBranchCategoryRev" json:"new_branch_category_rev,omitempty"`
+	// true if any of the entities didn't fit in the requested page_size
+	HasMore bool `protobuf:"varint,26,opt,name=has_more,json=hasMore" json:"has_more,omitempty"`
 }
 
 func (m *EntityResponse) Reset()                    { *m = EntityResponse{} }
@@ -713,6 +999,41 @@ func (m *EntityResponse) GetBranchCategories() []*EntityResponse_SyncBranchCateg
 	return nil
 }
 
+func (m *EntityResponse) GetItemConflicts() []*EntityResponse_ItemConflict {
+	if m != nil {
+		return m.ItemConflicts
+	}
+	return nil
+}
+
+func (m *EntityResponse) GetCategoryConflicts() []*EntityResponse_CategoryConflict {
+	if m != nil {
+		return m.CategoryConflicts
+	}
+	return nil
+}
+
+func (m *EntityResponse) GetBranchConflicts() []*EntityResponse_BranchConflict {
+	if m != nil {
+		return m.BranchConflicts
+	}
+	return nil
+}
+
+func (m *EntityResponse) GetRejectedItems() []*EntityResponse_RejectedItem {
+	if m != nil {
+		return m.RejectedItems
+	}
+	return nil
+}
+
+func (m *EntityResponse) GetRejectedCategories() []*EntityResponse_RejectedCategory {
+	if m != nil {
+		return m.RejectedCategories
+	}
+	return nil
+}
+
 type EntityResponse_SyncItem struct {
 	Item  *Item                    `protobuf:"bytes,1,opt,name=item" json:"item,omitempty"`
 	State EntityResponse_SyncState `protobuf:"varint,2,opt,name=state,enum=sheketproto.EntityResponse_SyncState" json:"state,omitempty"`
@@ -800,6 +1121,88 @@ func (m *EntityResponse_SyncBranchCategory) GetBranchCategory() *BranchCategory
 	return nil
 }
 
+// The server's copy of the entity at the time of the conflict. If the update
+// was REJECTED, it is the current copy. If it was OVERWRITTEN, it is the edit that got replaced.
+type EntityResponse_ItemConflict struct {
+	Item       *Item                             `protobuf:"bytes,1,opt,name=item" json:"item,omitempty"`
+	Resolution EntityResponse_ConflictResolution `protobuf:"varint,2,opt,name=resolution,enum=sheketproto.EntityResponse_ConflictResolution" json:"resolution,omitempty"`
+}
+
+func (m *EntityResponse_ItemConflict) Reset()                    { *m = EntityResponse_ItemConflict{} }
+func (m *EntityResponse_ItemConflict) String() string            { return proto.CompactTextString(m) }
+func (*EntityResponse_ItemConflict) ProtoMessage()               {}
+func (*EntityResponse_ItemConflict) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26, 5} }
+
+func (m *EntityResponse_ItemConflict) GetItem() *Item {
+	if m != nil {
+		return m.Item
+	}
+	return nil
+}
+
+type EntityResponse_CategoryConflict struct {
+	Category   *Category                         `protobuf:"bytes,1,opt,name=category" json:"category,omitempty"`
+	Resolution EntityResponse_ConflictResolution `protobuf:"varint,2,opt,name=resolution,enum=sheketproto.EntityResponse_ConflictResolution" json:"resolution,omitempty"`
+}
+
+func (m *EntityResponse_CategoryConflict) Reset()         { *m = EntityResponse_CategoryConflict{} }
+func (m *EntityResponse_CategoryConflict) String() string { return proto.CompactTextString(m) }
+func (*EntityResponse_CategoryConflict) ProtoMessage()    {}
+func (*EntityResponse_CategoryConflict) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{26, 6}
+}
+
+func (m *EntityResponse_CategoryConflict) GetCategory() *Category {
+	if m != nil {
+		return m.Category
+	}
+	return nil
+}
+
+type EntityResponse_BranchConflict struct {
+	Branch     *Branch                           `protobuf:"bytes,1,opt,name=branch" json:"branch,omitempty"`
+	Resolution EntityResponse_ConflictResolution `protobuf:"varint,2,opt,name=resolution,enum=sheketproto.EntityResponse_ConflictResolution" json:"resolution,omitempty"`
+}
+
+func (m *EntityResponse_BranchConflict) Reset()         { *m = EntityResponse_BranchConflict{} }
+func (m *EntityResponse_BranchConflict) String() string { return proto.CompactTextString(m) }
+func (*EntityResponse_BranchConflict) ProtoMessage()    {}
+func (*EntityResponse_BranchConflict) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{26, 7}
+}
+
+func (m *EntityResponse_BranchConflict) GetBranch() *Branch {
+	if m != nil {
+		return m.Branch
+	}
+	return nil
+}
+
+type EntityResponse_RejectedItem struct {
+	// the id the client sent, it is -ve if the item was being created
+	ItemId int32                       `protobuf:"zigzag32,1,opt,name=item_id,json=itemId" json:"item_id,omitempty"`
+	UUID   string                      `protobuf:"bytes,2,opt,name=UUID,json=uUID" json:"UUID,omitempty"`
+	Reason EntityResponse_RejectReason `protobuf:"varint,3,opt,name=reason,enum=sheketproto.EntityResponse_RejectReason" json:"reason,omitempty"`
+}
+
+func (m *EntityResponse_RejectedItem) Reset()                    { *m = EntityResponse_RejectedItem{} }
+func (m *EntityResponse_RejectedItem) String() string            { return proto.CompactTextString(m) }
+func (*EntityResponse_RejectedItem) ProtoMessage()               {}
+func (*EntityResponse_RejectedItem) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26, 8} }
+
+type EntityResponse_RejectedCategory struct {
+	CategoryId int32                       `protobuf:"zigzag32,1,opt,name=category_id,json=categoryId" json:"category_id,omitempty"`
+	UUID       string                      `protobuf:"bytes,2,opt,name=UUID,json=uUID" json:"UUID,omitempty"`
+	Reason     EntityResponse_RejectReason `protobuf:"varint,3,opt,name=reason,enum=sheketproto.EntityResponse_RejectReason" json:"reason,omitempty"`
+}
+
+func (m *EntityResponse_RejectedCategory) Reset()         { *m = EntityResponse_RejectedCategory{} }
+func (m *EntityResponse_RejectedCategory) String() string { return proto.CompactTextString(m) }
+func (*EntityResponse_RejectedCategory) ProtoMessage()    {}
+func (*EntityResponse_RejectedCategory) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{26, 9}
+}
+
 type EntityResponse_UpdatedId struct {
 	// old_id is signed because it can be -ve
 	OldId int32 `protobuf:"zigzag32,1,opt,name=old_id,json=oldId" json:"old_id,omitempty"`
@@ -810,7 +1213,7 @@ type EntityResponse_UpdatedId struct {
 func (m *EntityResponse_UpdatedId) Reset()                    { *m = EntityResponse_UpdatedId{} }
 func (m *EntityResponse_UpdatedId) String() string            { return proto.CompactTextString(m) }
 func (*EntityResponse_UpdatedId) ProtoMessage()               {}
-func (*EntityResponse_UpdatedId) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26, 5} }
+func (*EntityResponse_UpdatedId) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26, 10} }
 
 type Transaction struct {
 	TransactionItems []*Transaction_TransItem `protobuf:"bytes,1,rep,name=transactionItems" json:"transactionItems,omitempty"`
@@ -819,6 +1222,19 @@ type Transaction struct {
 	DateTime         int64                    `protobuf:"varint,4,opt,name=date_time,json=dateTime" json:"date_time,omitempty"`
 	TransNote        string                   `protobuf:"bytes,5,opt,name=trans_note,json=transNote" json:"trans_note,omitempty"`
 	UUID             string                   `protobuf:"bytes,6,opt,name=UUID,json=uUID" json:"UUID,omitempty"`
+	// The transaction this one is linked to, e.g: the one it voids or the
+	// sale(or purchase) it returns. Clients can set it on returns, a negative
+	// id links to a transaction posted earlier in the same request.
+	LinkedTransId int64 `protobuf:"varint,7,opt,name=linked_trans_id,json=linkedTransId" json:"linked_trans_id,omitempty"`
+	// This is the compensating transaction of a void, its quantities are
+	// the voided transaction's negated. The trans_note is the reason.
+	// The voided transaction is its linked_trans_id.
+	IsVoid bool `protobuf:"varint,8,opt,name=is_void,json=isVoid" json:"is_void,omitempty"`
+	// The compensating transaction if this was voided, 0 otherwise.
+	// Transactions are only synced once, so it is set on the voided transaction
+	// if it is fetched after the void. A client that already has the voided
+	// transaction should mark it from the void's linked_trans_id instead.
+	VoidedByTransId int64 `protobuf:"varint,9,opt,name=voided_by_trans_id,json=voidedByTransId" json:"voided_by_trans_id,omitempty"`
 }
 
 func (m *Transaction) Reset()                    { *m = Transaction{} }
@@ -834,11 +1250,29 @@ func (m *Transaction) GetTransactionItems() []*Transaction_TransItem {
 }
 
 type Transaction_TransItem struct {
-	ItemId        int32   `protobuf:"varint,2,opt,name=item_id,json=itemId" json:"item_id,omitempty"`
+	ItemId int32 `protobuf:"varint,2,opt,name=item_id,json=itemId" json:"item_id,omitempty"`
+	// the count corrections(5, 15) and transfer types(7, 17, 18) are only
+	// posted by the server, posting them is an INVALID_ARGUMENT
 	TransType     int32   `protobuf:"varint,1,opt,name=trans_type,json=transType" json:"trans_type,omitempty"`
 	Quantity      float64 `protobuf:"fixed64,3,opt,name=quantity" json:"quantity,omitempty"`
 	OtherBranchId int32   `protobuf:"varint,4,opt,name=other_branch_id,json=otherBranchId" json:"other_branch_id,omitempty"`
 	ItemNote      string  `protobuf:"bytes,5,opt,name=item_note,json=itemNote" json:"item_note,omitempty"`
+	// if the client doesn't set the price, the item's price at
+	// the transaction's date is used
+	HasPrice  bool    `protobuf:"varint,6,opt,name=has_price,json=hasPrice" json:"has_price,omitempty"`
+	UnitPrice float64 `protobuf:"fixed64,7,opt,name=unit_price,json=unitPrice" json:"unit_price,omitempty"`
+	// taken off the whole line, not each unit
+	Discount float64 `protobuf:"fixed64,8,opt,name=discount" json:"discount,omitempty"`
+	// computed by the server, quantity * unit_price - discount
+	LineTotal float64 `protobuf:"fixed64,9,opt,name=line_total,json=lineTotal" json:"line_total,omitempty"`
+	// The unit the quantity was entered in, 0 for the item's unit_of_measurement,
+	// -1 for its derived_name, otherwise the unit_id of one of Item.units.
+	// If it isn't 0, the server computes quantity from unit_quantity, so quantity
+	// is always in the item's unit_of_measurement. unit_price is also per unit_of_measurement.
+	UnitId       int32   `protobuf:"zigzag32,10,opt,name=unit_id,json=unitId" json:"unit_id,omitempty"`
+	UnitQuantity float64 `protobuf:"fixed64,11,opt,name=unit_quantity,json=unitQuantity" json:"unit_quantity,omitempty"`
+	// set by the server, the name of the unit when it was posted
+	UnitName string `protobuf:"bytes,12,opt,name=unit_name,json=unitName" json:"unit_name,omitempty"`
 }
 
 func (m *Transaction_TransItem) Reset()                    { *m = Transaction_TransItem{} }
@@ -851,6 +1285,9 @@ type TransactionRequest struct {
 	CompanyAuth      *CompanyAuth   `protobuf:"bytes,2,opt,name=companyAuth" json:"companyAuth,omitempty"`
 	OldBranchItemRev int64          `protobuf:"varint,3,opt,name=old_branch_item_rev,json=oldBranchItemRev" json:"old_branch_item_rev,omitempty"`
 	OldTransRev      int64          `protobuf:"varint,4,opt,name=old_trans_rev,json=oldTransRev" json:"old_trans_rev,omitempty"`
+	// The maximum number of transactions(and branch items) to return, 0 means no limit.
+	// See EntityRequest.page_size
+	PageSize int32 `protobuf:"varint,5,opt,name=page_size,json=pageSize" json:"page_size,omitempty"`
 }
 
 func (m *TransactionRequest) Reset()                    { *m = TransactionRequest{} }
@@ -878,6 +1315,10 @@ type TransactionResponse struct {
 	UpdatedTransactionIds []*TransactionResponse_UpdatedTransId  `protobuf:"bytes,3,rep,name=updated_transaction_ids,json=updatedTransactionIds" json:"updated_transaction_ids,omitempty"`
 	NewBranchItemRev      int64                                  `protobuf:"varint,4,opt,name=new_branch_item_rev,json=newBranchItemRev" json:"new_branch_item_rev,omitempty"`
 	NewTransRev           int64                                  `protobuf:"varint,5,opt,name=new_trans_rev,json=newTransRev" json:"new_trans_rev,omitempty"`
+	HasMore               bool                                   `protobuf:"varint,6,opt,name=has_more,json=hasMore" json:"has_more,omitempty"`
+	// The transfers that are sent but not yet received, to or from the branches
+	// the user can access. It is the full list, not only what changed since the last sync.
+	PendingTransfers []*Transfer `protobuf:"bytes,7,rep,name=pending_transfers,json=pendingTransfers" json:"pending_transfers,omitempty"`
 }
 
 func (m *TransactionResponse) Reset()                    { *m = TransactionResponse{} }
@@ -906,6 +1347,13 @@ func (m *TransactionResponse) GetUpdatedTransactionIds() []*TransactionResponse_
 	return nil
 }
 
+func (m *TransactionResponse) GetPendingTransfers() []*Transfer {
+	if m != nil {
+		return m.PendingTransfers
+	}
+	return nil
+}
+
 type TransactionResponse_SyncTransaction struct {
 	Transaction *Transaction `protobuf:"bytes,1,opt,name=transaction" json:"transaction,omitempty"`
 	UserId      int32        `protobuf:"varint,2,opt,name=user_id,json=userId" json:"user_id,omitempty"`
@@ -956,125 +1404,1026 @@ func (*TransactionResponse_UpdatedTransId) Descriptor() ([]byte, []int) {
 	return fileDescriptor0, []int{29, 2}
 }
 
-func init() {
-	proto.RegisterType((*EmptyRequest)(nil), "sheketproto.EmptyRequest")
-	proto.RegisterType((*EmptyResponse)(nil), "sheketproto.EmptyResponse")
-	proto.RegisterType((*SheketAuth)(nil), "sheketproto.SheketAuth")
-	proto.RegisterType((*CompanyID)(nil), "sheketproto.CompanyID")
-	proto.RegisterType((*CompanyAuth)(nil), "sheketproto.CompanyAuth")
-	proto.RegisterType((*SingupRequest)(nil), "sheketproto.SingupRequest")
-	proto.RegisterType((*SignupResponse)(nil), "sheketproto.SignupResponse")
-	proto.RegisterType((*SyncCompanyRequest)(nil), "sheketproto.SyncCompanyRequest")
-	proto.RegisterType((*NewCompanyRequest)(nil), "sheketproto.NewCompanyRequest")
-	proto.RegisterType((*Company)(nil), "sheketproto.Company")
-	proto.RegisterType((*CompanyList)(nil), "sheketproto.CompanyList")
-	proto.RegisterType((*EditUserNameRequest)(nil), "sheketproto.EditUserNameRequest")
-	proto.RegisterType((*AddEmployeeRequest)(nil), "sheketproto.AddEmployeeRequest")
-	proto.RegisterType((*AddEmployeeResponse)(nil), "sheketproto.AddEmployeeResponse")
-	proto.RegisterType((*IssuePaymentRequest)(nil), "sheketproto.IssuePaymentRequest")
-	proto.RegisterType((*IssuePaymentResponse)(nil), "sheketproto.IssuePaymentResponse")
-	proto.RegisterType((*VerifyPaymentRequest)(nil), "sheketproto.VerifyPaymentRequest")
-	proto.RegisterType((*VerifyPaymentResponse)(nil), "sheketproto.VerifyPaymentResponse")
-	proto.RegisterType((*EditCompanyRequest)(nil), "sheketproto.EditCompanyRequest")
-	proto.RegisterType((*Item)(nil), "sheketproto.Item")
-	proto.RegisterType((*Category)(nil), "sheketproto.Category")
-	proto.RegisterType((*Branch)(nil), "sheketproto.Branch")
-	proto.RegisterType((*Employee)(nil), "sheketproto.Employee")
-	proto.RegisterType((*BranchItem)(nil), "sheketproto.BranchItem")
-	proto.RegisterType((*BranchCategory)(nil), "sheketproto.BranchCategory")
-	proto.RegisterType((*EntityRequest)(nil), "sheketproto.EntityRequest")
-	proto.RegisterType((*EntityRequest_RequestItem)(nil), "sheketproto.EntityRequest.RequestItem")
-	proto.RegisterType((*EntityRequest_RequestCategory)(nil), "sheketproto.EntityRequest.RequestCategory")
-	proto.RegisterType((*EntityRequest_RequestBranch)(nil), "sheketproto.EntityRequest.RequestBranch")
-	proto.RegisterType((*EntityRequest_RequestEmployee)(nil), "sheketproto.EntityRequest.RequestEmployee")
-	proto.RegisterType((*EntityRequest_RequestBranchItem)(nil), "sheketproto.EntityRequest.RequestBranchItem")
-	proto.RegisterType((*EntityRequest_RequestBranchCategory)(nil), "sheketproto.EntityRequest.RequestBranchCategory")
-	proto.RegisterType((*EntityResponse)(nil), "sheketproto.EntityResponse")
-	proto.RegisterType((*EntityResponse_SyncItem)(nil), "sheketproto.EntityResponse.SyncItem")
-	proto.RegisterType((*EntityResponse_SyncCategory)(nil), "sheketproto.EntityResponse.SyncCategory")
-	proto.RegisterType((*EntityResponse_SyncBranch)(nil), "sheketproto.EntityResponse.SyncBranch")
-	proto.RegisterType((*EntityResponse_SyncEmployee)(nil), "sheketproto.EntityResponse.SyncEmployee")
-	proto.RegisterType((*EntityResponse_SyncBranchCategory)(nil), "sheketproto.EntityResponse.SyncBranchCategory")
-	proto.RegisterType((*EntityResponse_UpdatedId)(nil), "sheketproto.EntityResponse.UpdatedId")
-	proto.RegisterType((*Transaction)(nil), "sheketproto.Transaction")
-	proto.RegisterType((*Transaction_TransItem)(nil), "sheketproto.Transaction.TransItem")
-	proto.RegisterType((*TransactionRequest)(nil), "sheketproto.TransactionRequest")
-	proto.RegisterType((*TransactionResponse)(nil), "sheketproto.TransactionResponse")
-	proto.RegisterType((*TransactionResponse_SyncTransaction)(nil), "sheketproto.TransactionResponse.SyncTransaction")
-	proto.RegisterType((*TransactionResponse_SyncBranchItem)(nil), "sheketproto.TransactionResponse.SyncBranchItem")
-	proto.RegisterType((*TransactionResponse_UpdatedTransId)(nil), "sheketproto.TransactionResponse.UpdatedTransId")
-	proto.RegisterEnum("sheketproto.EntityRequest_Action", EntityRequest_Action_name, EntityRequest_Action_value)
-	proto.RegisterEnum("sheketproto.EntityResponse_SyncState", EntityResponse_SyncState_name, EntityResponse_SyncState_value)
+// Lets managers browse old transactions without syncing the whole history.
+// Transactions are returned newest first.
+type TransactionHistoryRequest struct {
+	CompanyAuth *CompanyAuth `protobuf:"bytes,1,opt,name=companyAuth" json:"companyAuth,omitempty"`
+	// The filters, 0 means don't filter on that field.
+	BranchId  int32 `protobuf:"varint,2,opt,name=branch_id,json=branchId" json:"branch_id,omitempty"`
+	UserId    int32 `protobuf:"varint,3,opt,name=user_id,json=userId" json:"user_id,omitempty"`
+	TransType int32 `protobuf:"varint,4,opt,name=trans_type,json=transType" json:"trans_type,omitempty"`
+	// inclusive range on Transaction.date_time
+	StartDate int64 `protobuf:"varint,5,opt,name=start_date,json=startDate" json:"start_date,omitempty"`
+	EndDate   int64 `protobuf:"varint,6,opt,name=end_date,json=endDate" json:"end_date,omitempty"`
+	// The next_cursor of the previous response, 0 starts from the newest transaction.
+	Cursor int64 `protobuf:"varint,7,opt,name=cursor" json:"cursor,omitempty"`
+	// 0 uses the server's default page size.
+	PageSize int32 `protobuf:"varint,8,opt,name=page_size,json=pageSize" json:"page_size,omitempty"`
+}
+
+func (m *TransactionHistoryRequest) Reset()                    { *m = TransactionHistoryRequest{} }
+func (m *TransactionHistoryRequest) String() string            { return proto.CompactTextString(m) }
+func (*TransactionHistoryRequest) ProtoMessage()               {}
+func (*TransactionHistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *TransactionHistoryRequest) GetCompanyAuth() *CompanyAuth {
+	if m != nil {
+		return m.CompanyAuth
+	}
+	return nil
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+type TransactionHistoryResponse struct {
+	Transactions []*TransactionResponse_SyncTransaction `protobuf:"bytes,1,rep,name=transactions" json:"transactions,omitempty"`
+	// pass this as the cursor to fetch the next page
+	NextCursor int64 `protobuf:"varint,2,opt,name=next_cursor,json=nextCursor" json:"next_cursor,omitempty"`
+	HasMore    bool  `protobuf:"varint,3,opt,name=has_more,json=hasMore" json:"has_more,omitempty"`
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion3
+func (m *TransactionHistoryResponse) Reset()                    { *m = TransactionHistoryResponse{} }
+func (m *TransactionHistoryResponse) String() string            { return proto.CompactTextString(m) }
+func (*TransactionHistoryResponse) ProtoMessage()               {}
+func (*TransactionHistoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
 
-// Client API for SheketService service
+func (m *TransactionHistoryResponse) GetTransactions() []*TransactionResponse_SyncTransaction {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
 
-type SheketServiceClient interface {
-	UserSignup(ctx context.Context, in *SingupRequest, opts ...grpc.CallOption) (*SignupResponse, error)
-	SyncCompanies(ctx context.Context, in *SyncCompanyRequest, opts ...grpc.CallOption) (*CompanyList, error)
-	EditUserName(ctx context.Context, in *EditUserNameRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
-	CreateCompany(ctx context.Context, in *NewCompanyRequest, opts ...grpc.CallOption) (*Company, error)
-	EditCompany(ctx context.Context, in *EditCompanyRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
-	AddEmployee(ctx context.Context, in *AddEmployeeRequest, opts ...grpc.CallOption) (*AddEmployeeResponse, error)
-	SyncEntity(ctx context.Context, in *EntityRequest, opts ...grpc.CallOption) (*EntityResponse, error)
-	SyncTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
-	IssuePayment(ctx context.Context, in *IssuePaymentRequest, opts ...grpc.CallOption) (*IssuePaymentResponse, error)
-	VerifyPayment(ctx context.Context, in *VerifyPaymentRequest, opts ...grpc.CallOption) (*VerifyPaymentResponse, error)
+// Totals the sales on the server, customer returns are taken off the sales they return.
+type SalesReportRequest struct {
+	CompanyAuth *CompanyAuth               `protobuf:"bytes,1,opt,name=companyAuth" json:"companyAuth,omitempty"`
+	GroupBy     SalesReportRequest_GroupBy `protobuf:"varint,2,opt,name=group_by,json=groupBy,enum=sheketproto.SalesReportRequest_GroupBy" json:"group_by,omitempty"`
+	// The filters, 0 means don't filter on that field.
+	BranchId int32 `protobuf:"varint,3,opt,name=branch_id,json=branchId" json:"branch_id,omitempty"`
+	// inclusive range on Transaction.date_time
+	StartDate int64 `protobuf:"varint,4,opt,name=start_date,json=startDate" json:"start_date,omitempty"`
+	EndDate   int64 `protobuf:"varint,5,opt,name=end_date,json=endDate" json:"end_date,omitempty"`
+	// seconds east of UTC, the periods start at midnight in this offset
+	UtcOffset int64 `protobuf:"varint,6,opt,name=utc_offset,json=utcOffset" json:"utc_offset,omitempty"`
 }
 
-type sheketServiceClient struct {
-	cc *grpc.ClientConn
+func (m *SalesReportRequest) Reset()                    { *m = SalesReportRequest{} }
+func (m *SalesReportRequest) String() string            { return proto.CompactTextString(m) }
+func (*SalesReportRequest) ProtoMessage()               {}
+func (*SalesReportRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *SalesReportRequest) GetCompanyAuth() *CompanyAuth {
+	if m != nil {
+		return m.CompanyAuth
+	}
+	return nil
 }
 
-func NewSheketServiceClient(cc *grpc.ClientConn) SheketServiceClient {
-	return &sheketServiceClient{cc}
+type SalesReportResponse struct {
+	// ordered by the key, voided sales aren't counted
+	Totals []*SalesReportResponse_Total `protobuf:"bytes,1,rep,name=totals" json:"totals,omitempty"`
 }
 
-func (c *sheketServiceClient) UserSignup(ctx context.Context, in *SingupRequest, opts ...grpc.CallOption) (*SignupResponse, error) {
-	out := new(SignupResponse)
-	err := grpc.Invoke(ctx, "/sheketproto.SheketService/UserSignup", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *SalesReportResponse) Reset()                    { *m = SalesReportResponse{} }
+func (m *SalesReportResponse) String() string            { return proto.CompactTextString(m) }
+func (*SalesReportResponse) ProtoMessage()               {}
+func (*SalesReportResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *SalesReportResponse) GetTotals() []*SalesReportResponse_Total {
+	if m != nil {
+		return m.Totals
 	}
-	return out, nil
+	return nil
 }
 
-func (c *sheketServiceClient) SyncCompanies(ctx context.Context, in *SyncCompanyRequest, opts ...grpc.CallOption) (*CompanyList, error) {
-	out := new(CompanyList)
-	err := grpc.Invoke(ctx, "/sheketproto.SheketService/SyncCompanies", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type SalesReportResponse_Total struct {
+	// the id of the item, category, branch or user. For periods, the date it starts at
+	Key      int64   `protobuf:"varint,1,opt,name=key" json:"key,omitempty"`
+	Quantity float64 `protobuf:"fixed64,2,opt,name=quantity" json:"quantity,omitempty"`
+	// the total of the sales' line_total
+	Amount float64 `protobuf:"fixed64,3,opt,name=amount" json:"amount,omitempty"`
 }
 
-func (c *sheketServiceClient) EditUserName(ctx context.Context, in *EditUserNameRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
-	out := new(EmptyResponse)
-	err := grpc.Invoke(ctx, "/sheketproto.SheketService/EditUserName", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *SalesReportResponse_Total) Reset()                    { *m = SalesReportResponse_Total{} }
+func (m *SalesReportResponse_Total) String() string            { return proto.CompactTextString(m) }
+func (*SalesReportResponse_Total) ProtoMessage()               {}
+func (*SalesReportResponse_Total) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33, 0} }
+
+// Reverses the effects of a posted transaction by posting a compensating transaction.
+// Voiding an already voided transaction returns the existing compensating transaction.
+// A transaction with returns linked to it can only be voided after the returns are,
+// it fails with FAILED_PRECONDITION otherwise.
+type VoidTransactionRequest struct {
+	CompanyAuth *CompanyAuth `protobuf:"bytes,1,opt,name=companyAuth" json:"companyAuth,omitempty"`
+	TransId     int64        `protobuf:"varint,2,opt,name=trans_id,json=transId" json:"trans_id,omitempty"`
+	// required, it becomes the compensating transaction's note
+	Reason string `protobuf:"bytes,3,opt,name=reason" json:"reason,omitempty"`
 }
 
-func (c *sheketServiceClient) CreateCompany(ctx context.Context, in *NewCompanyRequest, opts ...grpc.CallOption) (*Company, error) {
-	out := new(Company)
-	err := grpc.Invoke(ctx, "/sheketproto.SheketService/CreateCompany", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *VoidTransactionRequest) Reset()                    { *m = VoidTransactionRequest{} }
+func (m *VoidTransactionRequest) String() string            { return proto.CompactTextString(m) }
+func (*VoidTransactionRequest) ProtoMessage()               {}
+func (*VoidTransactionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *VoidTransactionRequest) GetCompanyAuth() *CompanyAuth {
+	if m != nil {
+		return m.CompanyAuth
 	}
-	return out, nil
+	return nil
 }
 
-func (c *sheketServiceClient) EditCompany(ctx context.Context, in *EditCompanyRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+type VoidTransactionResponse struct {
+	// the compensating transaction, the branch items it changed are sent on the next SyncTransaction
+	VoidTransaction *TransactionResponse_SyncTransaction `protobuf:"bytes,1,opt,name=void_transaction,json=voidTransaction" json:"void_transaction,omitempty"`
+}
+
+func (m *VoidTransactionResponse) Reset()                    { *m = VoidTransactionResponse{} }
+func (m *VoidTransactionResponse) String() string            { return proto.CompactTextString(m) }
+func (*VoidTransactionResponse) ProtoMessage()               {}
+func (*VoidTransactionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *VoidTransactionResponse) GetVoidTransaction() *TransactionResponse_SyncTransaction {
+	if m != nil {
+		return m.VoidTransaction
+	}
+	return nil
+}
+
+// Moves items between branches in two steps. Sending takes the items out of the
+// sending branch, they are in-transit until the receiving branch receives them.
+type Transfer struct {
+	TransferId   int32           `protobuf:"varint,1,opt,name=transfer_id,json=transferId" json:"transfer_id,omitempty"`
+	FromBranchId int32           `protobuf:"varint,2,opt,name=from_branch_id,json=fromBranchId" json:"from_branch_id,omitempty"`
+	ToBranchId   int32           `protobuf:"varint,3,opt,name=to_branch_id,json=toBranchId" json:"to_branch_id,omitempty"`
+	Status       Transfer_Status `protobuf:"varint,4,opt,name=status,enum=sheketproto.Transfer_Status" json:"status,omitempty"`
+	Note         string          `protobuf:"bytes,5,opt,name=note" json:"note,omitempty"`
+	SentBy       int32           `protobuf:"varint,6,opt,name=sent_by,json=sentBy" json:"sent_by,omitempty"`
+	SentDate     int64           `protobuf:"varint,7,opt,name=sent_date,json=sentDate" json:"sent_date,omitempty"`
+	SendTransId  int64           `protobuf:"varint,8,opt,name=send_trans_id,json=sendTransId" json:"send_trans_id,omitempty"`
+	ReceivedBy   int32           `protobuf:"varint,9,opt,name=received_by,json=receivedBy" json:"received_by,omitempty"`
+	ReceivedDate int64           `protobuf:"varint,10,opt,name=received_date,json=receivedDate" json:"received_date,omitempty"`
+	// it has the received items and the discrepancies
+	ReceiveTransId int64            `protobuf:"varint,11,opt,name=receive_trans_id,json=receiveTransId" json:"receive_trans_id,omitempty"`
+	Items          []*Transfer_Item `protobuf:"bytes,12,rep,name=items" json:"items,omitempty"`
+}
+
+func (m *Transfer) Reset()                    { *m = Transfer{} }
+func (m *Transfer) String() string            { return proto.CompactTextString(m) }
+func (*Transfer) ProtoMessage()               {}
+func (*Transfer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *Transfer) GetItems() []*Transfer_Item {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+type Transfer_Item struct {
+	ItemId       int32   `protobuf:"varint,1,opt,name=item_id,json=itemId" json:"item_id,omitempty"`
+	SentQuantity float64 `protobuf:"fixed64,2,opt,name=sent_quantity,json=sentQuantity" json:"sent_quantity,omitempty"`
+	// only set once it is received
+	ReceivedQuantity float64 `protobuf:"fixed64,3,opt,name=received_quantity,json=receivedQuantity" json:"received_quantity,omitempty"`
+}
+
+func (m *Transfer_Item) Reset()                    { *m = Transfer_Item{} }
+func (m *Transfer_Item) String() string            { return proto.CompactTextString(m) }
+func (*Transfer_Item) ProtoMessage()               {}
+func (*Transfer_Item) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36, 0} }
+
+// Re-sending a transfer with the same UUID returns the one already sent.
+type SendTransferRequest struct {
+	CompanyAuth  *CompanyAuth                    `protobuf:"bytes,1,opt,name=companyAuth" json:"companyAuth,omitempty"`
+	UUID         string                          `protobuf:"bytes,2,opt,name=UUID,json=uUID" json:"UUID,omitempty"`
+	FromBranchId int32                           `protobuf:"varint,3,opt,name=from_branch_id,json=fromBranchId" json:"from_branch_id,omitempty"`
+	ToBranchId   int32                           `protobuf:"varint,4,opt,name=to_branch_id,json=toBranchId" json:"to_branch_id,omitempty"`
+	Note         string                          `protobuf:"bytes,5,opt,name=note" json:"note,omitempty"`
+	Items        []*SendTransferRequest_SentItem `protobuf:"bytes,6,rep,name=items" json:"items,omitempty"`
+}
+
+func (m *SendTransferRequest) Reset()                    { *m = SendTransferRequest{} }
+func (m *SendTransferRequest) String() string            { return proto.CompactTextString(m) }
+func (*SendTransferRequest) ProtoMessage()               {}
+func (*SendTransferRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *SendTransferRequest) GetCompanyAuth() *CompanyAuth {
+	if m != nil {
+		return m.CompanyAuth
+	}
+	return nil
+}
+
+func (m *SendTransferRequest) GetItems() []*SendTransferRequest_SentItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+type SendTransferRequest_SentItem struct {
+	ItemId   int32   `protobuf:"varint,1,opt,name=item_id,json=itemId" json:"item_id,omitempty"`
+	Quantity float64 `protobuf:"fixed64,2,opt,name=quantity" json:"quantity,omitempty"`
+}
+
+func (m *SendTransferRequest_SentItem) Reset()                    { *m = SendTransferRequest_SentItem{} }
+func (m *SendTransferRequest_SentItem) String() string            { return proto.CompactTextString(m) }
+func (*SendTransferRequest_SentItem) ProtoMessage()               {}
+func (*SendTransferRequest_SentItem) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37, 0} }
+
+type ReceiveTransferRequest struct {
+	CompanyAuth *CompanyAuth `protobuf:"bytes,1,opt,name=companyAuth" json:"companyAuth,omitempty"`
+	TransferId  int32        `protobuf:"varint,2,opt,name=transfer_id,json=transferId" json:"transfer_id,omitempty"`
+	// only list the items that didn't arrive as sent, the others are received as sent
+	Items []*ReceiveTransferRequest_ReceivedItem `protobuf:"bytes,3,rep,name=items" json:"items,omitempty"`
+}
+
+func (m *ReceiveTransferRequest) Reset()                    { *m = ReceiveTransferRequest{} }
+func (m *ReceiveTransferRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReceiveTransferRequest) ProtoMessage()               {}
+func (*ReceiveTransferRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *ReceiveTransferRequest) GetCompanyAuth() *CompanyAuth {
+	if m != nil {
+		return m.CompanyAuth
+	}
+	return nil
+}
+
+func (m *ReceiveTransferRequest) GetItems() []*ReceiveTransferRequest_ReceivedItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+type ReceiveTransferRequest_ReceivedItem struct {
+	ItemId   int32   `protobuf:"varint,1,opt,name=item_id,json=itemId" json:"item_id,omitempty"`
+	Quantity float64 `protobuf:"fixed64,2,opt,name=quantity" json:"quantity,omitempty"`
+}
+
+func (m *ReceiveTransferRequest_ReceivedItem) Reset()         { *m = ReceiveTransferRequest_ReceivedItem{} }
+func (m *ReceiveTransferRequest_ReceivedItem) String() string { return proto.CompactTextString(m) }
+func (*ReceiveTransferRequest_ReceivedItem) ProtoMessage()    {}
+func (*ReceiveTransferRequest_ReceivedItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{38, 0}
+}
+
+type SubscribeRequest struct {
+	CompanyAuth *CompanyAuth `protobuf:"bytes,1,opt,name=companyAuth" json:"companyAuth,omitempty"`
+}
+
+func (m *SubscribeRequest) Reset()                    { *m = SubscribeRequest{} }
+func (m *SubscribeRequest) String() string            { return proto.CompactTextString(m) }
+func (*SubscribeRequest) ProtoMessage()               {}
+func (*SubscribeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *SubscribeRequest) GetCompanyAuth() *CompanyAuth {
+	if m != nil {
+		return m.CompanyAuth
+	}
+	return nil
+}
+
+// Sent to the subscribed devices of a company when someone else
+// commits a change, the device should then sync to fetch it.
+type ChangeNotification struct {
+	// call SyncEntity
+	EntitiesChanged bool `protobuf:"varint,1,opt,name=entities_changed,json=entitiesChanged" json:"entities_changed,omitempty"`
+	// call SyncTransaction
+	TransactionsChanged bool `protobuf:"varint,2,opt,name=transactions_changed,json=transactionsChanged" json:"transactions_changed,omitempty"`
+}
+
+func (m *ChangeNotification) Reset()                    { *m = ChangeNotification{} }
+func (m *ChangeNotification) String() string            { return proto.CompactTextString(m) }
+func (*ChangeNotification) ProtoMessage()               {}
+func (*ChangeNotification) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+type ReorderReportRequest struct {
+	CompanyAuth *CompanyAuth `protobuf:"bytes,1,opt,name=companyAuth" json:"companyAuth,omitempty"`
+	// limits the report to a single branch, 0 reports on every branch
+	BranchId int32 `protobuf:"varint,2,opt,name=branch_id,json=branchId" json:"branch_id,omitempty"`
+	// compare the total quantity across all branches against the item's reorder level,
+	// branch_id and the branch overrides are ignored
+	CompanyWide bool `protobuf:"varint,3,opt,name=company_wide,json=companyWide" json:"company_wide,omitempty"`
+}
+
+func (m *ReorderReportRequest) Reset()                    { *m = ReorderReportRequest{} }
+func (m *ReorderReportRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReorderReportRequest) ProtoMessage()               {}
+func (*ReorderReportRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *ReorderReportRequest) GetCompanyAuth() *CompanyAuth {
+	if m != nil {
+		return m.CompanyAuth
+	}
+	return nil
+}
+
+type ReorderReportResponse struct {
+	Items []*ReorderReportResponse_ReorderItem `protobuf:"bytes,1,rep,name=items" json:"items,omitempty"`
+}
+
+func (m *ReorderReportResponse) Reset()                    { *m = ReorderReportResponse{} }
+func (m *ReorderReportResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReorderReportResponse) ProtoMessage()               {}
+func (*ReorderReportResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *ReorderReportResponse) GetItems() []*ReorderReportResponse_ReorderItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+type ReorderReportResponse_ReorderItem struct {
+	// 0 if the report is company wide
+	BranchId          int32   `protobuf:"varint,1,opt,name=branch_id,json=branchId" json:"branch_id,omitempty"`
+	ItemId            int32   `protobuf:"varint,2,opt,name=item_id,json=itemId" json:"item_id,omitempty"`
+	Quantity          float64 `protobuf:"fixed64,3,opt,name=quantity" json:"quantity,omitempty"`
+	ReorderLevel      float64 `protobuf:"fixed64,4,opt,name=reorder_level,json=reorderLevel" json:"reorder_level,omitempty"`
+	SuggestedQuantity float64 `protobuf:"fixed64,5,opt,name=suggested_quantity,json=suggestedQuantity" json:"suggested_quantity,omitempty"`
+}
+
+func (m *ReorderReportResponse_ReorderItem) Reset()         { *m = ReorderReportResponse_ReorderItem{} }
+func (m *ReorderReportResponse_ReorderItem) String() string { return proto.CompactTextString(m) }
+func (*ReorderReportResponse_ReorderItem) ProtoMessage()    {}
+func (*ReorderReportResponse_ReorderItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{42, 0}
+}
+
+type ReconcileStockRequest struct {
+	CompanyAuth *CompanyAuth `protobuf:"bytes,1,opt,name=companyAuth" json:"companyAuth,omitempty"`
+	// fix the drifted quantities, otherwise they are only reported
+	Repair bool `protobuf:"varint,2,opt,name=repair" json:"repair,omitempty"`
+}
+
+func (m *ReconcileStockRequest) Reset()                    { *m = ReconcileStockRequest{} }
+func (m *ReconcileStockRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReconcileStockRequest) ProtoMessage()               {}
+func (*ReconcileStockRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *ReconcileStockRequest) GetCompanyAuth() *CompanyAuth {
+	if m != nil {
+		return m.CompanyAuth
+	}
+	return nil
+}
+
+type ReconcileStockResponse struct {
+	Drifts []*ReconcileStockResponse_StockDrift `protobuf:"bytes,1,rep,name=drifts" json:"drifts,omitempty"`
+	// true if the drifts were fixed, the branch items now have the expected quantity
+	Repaired bool `protobuf:"varint,2,opt,name=repaired" json:"repaired,omitempty"`
+}
+
+func (m *ReconcileStockResponse) Reset()                    { *m = ReconcileStockResponse{} }
+func (m *ReconcileStockResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReconcileStockResponse) ProtoMessage()               {}
+func (*ReconcileStockResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+func (m *ReconcileStockResponse) GetDrifts() []*ReconcileStockResponse_StockDrift {
+	if m != nil {
+		return m.Drifts
+	}
+	return nil
+}
+
+type ReconcileStockResponse_StockDrift struct {
+	BranchId int32 `protobuf:"varint,1,opt,name=branch_id,json=branchId" json:"branch_id,omitempty"`
+	ItemId   int32 `protobuf:"varint,2,opt,name=item_id,json=itemId" json:"item_id,omitempty"`
+	// the quantity the branch item had
+	Quantity float64 `protobuf:"fixed64,3,opt,name=quantity" json:"quantity,omitempty"`
+	// the quantity in the stock ledger
+	LedgerQuantity float64 `protobuf:"fixed64,4,opt,name=ledger_quantity,json=ledgerQuantity" json:"ledger_quantity,omitempty"`
+	// the quantity from replaying the transaction history
+	ExpectedQuantity float64 `protobuf:"fixed64,5,opt,name=expected_quantity,json=expectedQuantity" json:"expected_quantity,omitempty"`
+}
+
+func (m *ReconcileStockResponse_StockDrift) Reset()         { *m = ReconcileStockResponse_StockDrift{} }
+func (m *ReconcileStockResponse_StockDrift) String() string { return proto.CompactTextString(m) }
+func (*ReconcileStockResponse_StockDrift) ProtoMessage()    {}
+func (*ReconcileStockResponse_StockDrift) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{44, 0}
+}
+
+// The quantity of the items at a date in the past, rebuilt from the transaction history.
+type StockAsOfRequest struct {
+	CompanyAuth *CompanyAuth `protobuf:"bytes,1,opt,name=companyAuth" json:"companyAuth,omitempty"`
+	// 0 returns the quantity in every branch the user can see
+	BranchId int32 `protobuf:"varint,2,opt,name=branch_id,json=branchId" json:"branch_id,omitempty"`
+	// in the same unit as Transaction.date_time, transactions on the date are included
+	AsOf int64 `protobuf:"varint,3,opt,name=as_of,json=asOf" json:"as_of,omitempty"`
+}
+
+func (m *StockAsOfRequest) Reset()                    { *m = StockAsOfRequest{} }
+func (m *StockAsOfRequest) String() string            { return proto.CompactTextString(m) }
+func (*StockAsOfRequest) ProtoMessage()               {}
+func (*StockAsOfRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+func (m *StockAsOfRequest) GetCompanyAuth() *CompanyAuth {
+	if m != nil {
+		return m.CompanyAuth
+	}
+	return nil
+}
+
+type StockAsOfResponse struct {
+	// items that had nothing at the date are left out
+	Quantities []*StockAsOfResponse_StockQuantity `protobuf:"bytes,1,rep,name=quantities" json:"quantities,omitempty"`
+}
+
+func (m *StockAsOfResponse) Reset()                    { *m = StockAsOfResponse{} }
+func (m *StockAsOfResponse) String() string            { return proto.CompactTextString(m) }
+func (*StockAsOfResponse) ProtoMessage()               {}
+func (*StockAsOfResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+func (m *StockAsOfResponse) GetQuantities() []*StockAsOfResponse_StockQuantity {
+	if m != nil {
+		return m.Quantities
+	}
+	return nil
+}
+
+type StockAsOfResponse_StockQuantity struct {
+	BranchId int32   `protobuf:"varint,1,opt,name=branch_id,json=branchId" json:"branch_id,omitempty"`
+	ItemId   int32   `protobuf:"varint,2,opt,name=item_id,json=itemId" json:"item_id,omitempty"`
+	Quantity float64 `protobuf:"fixed64,3,opt,name=quantity" json:"quantity,omitempty"`
+}
+
+func (m *StockAsOfResponse_StockQuantity) Reset()         { *m = StockAsOfResponse_StockQuantity{} }
+func (m *StockAsOfResponse_StockQuantity) String() string { return proto.CompactTextString(m) }
+func (*StockAsOfResponse_StockQuantity) ProtoMessage()    {}
+func (*StockAsOfResponse_StockQuantity) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{46, 0}
+}
+
+type ItemMovementsRequest struct {
+	CompanyAuth *CompanyAuth `protobuf:"bytes,1,opt,name=companyAuth" json:"companyAuth,omitempty"`
+	BranchId    int32        `protobuf:"varint,2,opt,name=branch_id,json=branchId" json:"branch_id,omitempty"`
+	ItemId      int32        `protobuf:"varint,3,opt,name=item_id,json=itemId" json:"item_id,omitempty"`
+	// inclusive range on the date, 0 means don't filter on it
+	StartDate int64 `protobuf:"varint,4,opt,name=start_date,json=startDate" json:"start_date,omitempty"`
+	EndDate   int64 `protobuf:"varint,5,opt,name=end_date,json=endDate" json:"end_date,omitempty"`
+}
+
+func (m *ItemMovementsRequest) Reset()                    { *m = ItemMovementsRequest{} }
+func (m *ItemMovementsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ItemMovementsRequest) ProtoMessage()               {}
+func (*ItemMovementsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+func (m *ItemMovementsRequest) GetCompanyAuth() *CompanyAuth {
+	if m != nil {
+		return m.CompanyAuth
+	}
+	return nil
+}
+
+type ItemMovementsResponse struct {
+	// ordered by date, those on the same date in the order they were posted
+	Movements []*ItemMovementsResponse_Movement `protobuf:"bytes,1,rep,name=movements" json:"movements,omitempty"`
+}
+
+func (m *ItemMovementsResponse) Reset()                    { *m = ItemMovementsResponse{} }
+func (m *ItemMovementsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ItemMovementsResponse) ProtoMessage()               {}
+func (*ItemMovementsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+
+func (m *ItemMovementsResponse) GetMovements() []*ItemMovementsResponse_Movement {
+	if m != nil {
+		return m.Movements
+	}
+	return nil
+}
+
+type ItemMovementsResponse_Movement struct {
+	// 0 for corrections of the stock that aren't part of a transaction
+	TransId   int64 `protobuf:"varint,1,opt,name=trans_id,json=transId" json:"trans_id,omitempty"`
+	Date      int64 `protobuf:"varint,2,opt,name=date" json:"date,omitempty"`
+	TransType int32 `protobuf:"varint,3,opt,name=trans_type,json=transType" json:"trans_type,omitempty"`
+	// the branch the item moved from or to, 0 if it didn't move between branches
+	OtherBranchId int32  `protobuf:"varint,4,opt,name=other_branch_id,json=otherBranchId" json:"other_branch_id,omitempty"`
+	UserId        int32  `protobuf:"varint,5,opt,name=user_id,json=userId" json:"user_id,omitempty"`
+	TransNote     string `protobuf:"bytes,6,opt,name=trans_note,json=transNote" json:"trans_note,omitempty"`
+	ItemNote      string `protobuf:"bytes,7,opt,name=item_note,json=itemNote" json:"item_note,omitempty"`
+	IsVoid        bool   `protobuf:"varint,8,opt,name=is_void,json=isVoid" json:"is_void,omitempty"`
+	// positive if it came in, negative if it left
+	Quantity float64 `protobuf:"fixed64,9,opt,name=quantity" json:"quantity,omitempty"`
+	// the quantity in the branch after this movement
+	Balance float64 `protobuf:"fixed64,10,opt,name=balance" json:"balance,omitempty"`
+}
+
+func (m *ItemMovementsResponse_Movement) Reset()         { *m = ItemMovementsResponse_Movement{} }
+func (m *ItemMovementsResponse_Movement) String() string { return proto.CompactTextString(m) }
+func (*ItemMovementsResponse_Movement) ProtoMessage()    {}
+func (*ItemMovementsResponse_Movement) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{48, 0}
+}
+
+type InventoryValuationRequest struct {
+	CompanyAuth *CompanyAuth `protobuf:"bytes,1,opt,name=companyAuth" json:"companyAuth,omitempty"`
+	// 0 values every branch
+	BranchId int32 `protobuf:"varint,2,opt,name=branch_id,json=branchId" json:"branch_id,omitempty"`
+	// the cost of the sales between these dates is included if sales_end_date is set
+	SalesStartDate int64 `protobuf:"varint,3,opt,name=sales_start_date,json=salesStartDate" json:"sales_start_date,omitempty"`
+	SalesEndDate   int64 `protobuf:"varint,4,opt,name=sales_end_date,json=salesEndDate" json:"sales_end_date,omitempty"`
+}
+
+func (m *InventoryValuationRequest) Reset()                    { *m = InventoryValuationRequest{} }
+func (m *InventoryValuationRequest) String() string            { return proto.CompactTextString(m) }
+func (*InventoryValuationRequest) ProtoMessage()               {}
+func (*InventoryValuationRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+
+func (m *InventoryValuationRequest) GetCompanyAuth() *CompanyAuth {
+	if m != nil {
+		return m.CompanyAuth
+	}
+	return nil
+}
+
+type InventoryValuationResponse struct {
+	// see Company.costing_method
+	CostingMethod int32                                   `protobuf:"varint,1,opt,name=costing_method,json=costingMethod" json:"costing_method,omitempty"`
+	Items         []*InventoryValuationResponse_ItemValue `protobuf:"bytes,2,rep,name=items" json:"items,omitempty"`
+	TotalValue    float64                                 `protobuf:"fixed64,3,opt,name=total_value,json=totalValue" json:"total_value,omitempty"`
+	// the stock sent but not yet received, it isn't in any branch
+	InTransitValue float64                                `protobuf:"fixed64,4,opt,name=in_transit_value,json=inTransitValue" json:"in_transit_value,omitempty"`
+	SaleCosts      []*InventoryValuationResponse_SaleCost `protobuf:"bytes,5,rep,name=sale_costs,json=saleCosts" json:"sale_costs,omitempty"`
+}
+
+func (m *InventoryValuationResponse) Reset()                    { *m = InventoryValuationResponse{} }
+func (m *InventoryValuationResponse) String() string            { return proto.CompactTextString(m) }
+func (*InventoryValuationResponse) ProtoMessage()               {}
+func (*InventoryValuationResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+
+func (m *InventoryValuationResponse) GetItems() []*InventoryValuationResponse_ItemValue {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *InventoryValuationResponse) GetSaleCosts() []*InventoryValuationResponse_SaleCost {
+	if m != nil {
+		return m.SaleCosts
+	}
+	return nil
+}
+
+type InventoryValuationResponse_ItemValue struct {
+	BranchId int32   `protobuf:"varint,1,opt,name=branch_id,json=branchId" json:"branch_id,omitempty"`
+	ItemId   int32   `protobuf:"varint,2,opt,name=item_id,json=itemId" json:"item_id,omitempty"`
+	Quantity float64 `protobuf:"fixed64,3,opt,name=quantity" json:"quantity,omitempty"`
+	Value    float64 `protobuf:"fixed64,4,opt,name=value" json:"value,omitempty"`
+}
+
+func (m *InventoryValuationResponse_ItemValue) Reset()         { *m = InventoryValuationResponse_ItemValue{} }
+func (m *InventoryValuationResponse_ItemValue) String() string { return proto.CompactTextString(m) }
+func (*InventoryValuationResponse_ItemValue) ProtoMessage()    {}
+func (*InventoryValuationResponse_ItemValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{50, 0}
+}
+
+// the cost of the items a sale sold, it is negative for customer returns
+type InventoryValuationResponse_SaleCost struct {
+	TransId int64   `protobuf:"varint,1,opt,name=trans_id,json=transId" json:"trans_id,omitempty"`
+	Cost    float64 `protobuf:"fixed64,2,opt,name=cost" json:"cost,omitempty"`
+}
+
+func (m *InventoryValuationResponse_SaleCost) Reset()         { *m = InventoryValuationResponse_SaleCost{} }
+func (m *InventoryValuationResponse_SaleCost) String() string { return proto.CompactTextString(m) }
+func (*InventoryValuationResponse_SaleCost) ProtoMessage()    {}
+func (*InventoryValuationResponse_SaleCost) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{50, 1}
+}
+
+// A count of the items in a branch, while it is open employees submit the
+// quantities they counted. Approving it posts the difference between the
+// counted and the recorded quantities as count correction transactions.
+type StockTake struct {
+	StockTakeId  int32             `protobuf:"varint,1,opt,name=stock_take_id,json=stockTakeId" json:"stock_take_id,omitempty"`
+	BranchId     int32             `protobuf:"varint,2,opt,name=branch_id,json=branchId" json:"branch_id,omitempty"`
+	Status       StockTake_Status  `protobuf:"varint,3,opt,name=status,enum=sheketproto.StockTake_Status" json:"status,omitempty"`
+	CreatedBy    int32             `protobuf:"varint,4,opt,name=created_by,json=createdBy" json:"created_by,omitempty"`
+	CreatedDate  int64             `protobuf:"varint,5,opt,name=created_date,json=createdDate" json:"created_date,omitempty"`
+	ApprovedBy   int32             `protobuf:"varint,6,opt,name=approved_by,json=approvedBy" json:"approved_by,omitempty"`
+	ApprovedDate int64             `protobuf:"varint,7,opt,name=approved_date,json=approvedDate" json:"approved_date,omitempty"`
+	Items        []*StockTake_Item `protobuf:"bytes,8,rep,name=items" json:"items,omitempty"`
+}
+
+func (m *StockTake) Reset()                    { *m = StockTake{} }
+func (m *StockTake) String() string            { return proto.CompactTextString(m) }
+func (*StockTake) ProtoMessage()               {}
+func (*StockTake) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+
+func (m *StockTake) GetItems() []*StockTake_Item {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+type StockTake_Item struct {
+	ItemId          int32   `protobuf:"varint,1,opt,name=item_id,json=itemId" json:"item_id,omitempty"`
+	CountedQuantity float64 `protobuf:"fixed64,2,opt,name=counted_quantity,json=countedQuantity" json:"counted_quantity,omitempty"`
+	// the quantity in the branch when the item was counted
+	RecordedQuantity float64 `protobuf:"fixed64,3,opt,name=recorded_quantity,json=recordedQuantity" json:"recorded_quantity,omitempty"`
+	CountedBy        int32   `protobuf:"varint,4,opt,name=counted_by,json=countedBy" json:"counted_by,omitempty"`
+}
+
+func (m *StockTake_Item) Reset()                    { *m = StockTake_Item{} }
+func (m *StockTake_Item) String() string            { return proto.CompactTextString(m) }
+func (*StockTake_Item) ProtoMessage()               {}
+func (*StockTake_Item) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51, 0} }
+
+// Opens a stock take in the branch if it doesn't have one, then records
+// the counts. Counting an item again replaces its previous count, an
+// empty list of items just returns the open stock take.
+type SubmitStockCountRequest struct {
+	CompanyAuth *CompanyAuth                           `protobuf:"bytes,1,opt,name=companyAuth" json:"companyAuth,omitempty"`
+	BranchId    int32                                  `protobuf:"varint,2,opt,name=branch_id,json=branchId" json:"branch_id,omitempty"`
+	Items       []*SubmitStockCountRequest_CountedItem `protobuf:"bytes,3,rep,name=items" json:"items,omitempty"`
+}
+
+func (m *SubmitStockCountRequest) Reset()                    { *m = SubmitStockCountRequest{} }
+func (m *SubmitStockCountRequest) String() string            { return proto.CompactTextString(m) }
+func (*SubmitStockCountRequest) ProtoMessage()               {}
+func (*SubmitStockCountRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+
+func (m *SubmitStockCountRequest) GetCompanyAuth() *CompanyAuth {
+	if m != nil {
+		return m.CompanyAuth
+	}
+	return nil
+}
+
+func (m *SubmitStockCountRequest) GetItems() []*SubmitStockCountRequest_CountedItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+type SubmitStockCountRequest_CountedItem struct {
+	ItemId          int32   `protobuf:"varint,1,opt,name=item_id,json=itemId" json:"item_id,omitempty"`
+	CountedQuantity float64 `protobuf:"fixed64,2,opt,name=counted_quantity,json=countedQuantity" json:"counted_quantity,omitempty"`
+}
+
+func (m *SubmitStockCountRequest_CountedItem) Reset()         { *m = SubmitStockCountRequest_CountedItem{} }
+func (m *SubmitStockCountRequest_CountedItem) String() string { return proto.CompactTextString(m) }
+func (*SubmitStockCountRequest_CountedItem) ProtoMessage()    {}
+func (*SubmitStockCountRequest_CountedItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{52, 0}
+}
+
+type ApproveStockTakeRequest struct {
+	CompanyAuth *CompanyAuth `protobuf:"bytes,1,opt,name=companyAuth" json:"companyAuth,omitempty"`
+	StockTakeId int32        `protobuf:"varint,2,opt,name=stock_take_id,json=stockTakeId" json:"stock_take_id,omitempty"`
+}
+
+func (m *ApproveStockTakeRequest) Reset()                    { *m = ApproveStockTakeRequest{} }
+func (m *ApproveStockTakeRequest) String() string            { return proto.CompactTextString(m) }
+func (*ApproveStockTakeRequest) ProtoMessage()               {}
+func (*ApproveStockTakeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+
+func (m *ApproveStockTakeRequest) GetCompanyAuth() *CompanyAuth {
+	if m != nil {
+		return m.CompanyAuth
+	}
+	return nil
+}
+
+type ApproveStockTakeResponse struct {
+	StockTake *StockTake `protobuf:"bytes,1,opt,name=stock_take,json=stockTake" json:"stock_take,omitempty"`
+	// not set if every item was counted right, the branch items
+	// it changed are sent on the next SyncTransaction
+	Corrections *TransactionResponse_SyncTransaction `protobuf:"bytes,2,opt,name=corrections" json:"corrections,omitempty"`
+}
+
+func (m *ApproveStockTakeResponse) Reset()                    { *m = ApproveStockTakeResponse{} }
+func (m *ApproveStockTakeResponse) String() string            { return proto.CompactTextString(m) }
+func (*ApproveStockTakeResponse) ProtoMessage()               {}
+func (*ApproveStockTakeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+
+func (m *ApproveStockTakeResponse) GetStockTake() *StockTake {
+	if m != nil {
+		return m.StockTake
+	}
+	return nil
+}
+
+func (m *ApproveStockTakeResponse) GetCorrections() *TransactionResponse_SyncTransaction {
+	if m != nil {
+		return m.Corrections
+	}
+	return nil
+}
+
+type BarcodeLookupRequest struct {
+	CompanyAuth *CompanyAuth `protobuf:"bytes,1,opt,name=companyAuth" json:"companyAuth,omitempty"`
+	BarCode     string       `protobuf:"bytes,2,opt,name=bar_code,json=barCode" json:"bar_code,omitempty"`
+}
+
+func (m *BarcodeLookupRequest) Reset()                    { *m = BarcodeLookupRequest{} }
+func (m *BarcodeLookupRequest) String() string            { return proto.CompactTextString(m) }
+func (*BarcodeLookupRequest) ProtoMessage()               {}
+func (*BarcodeLookupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+
+func (m *BarcodeLookupRequest) GetCompanyAuth() *CompanyAuth {
+	if m != nil {
+		return m.CompanyAuth
+	}
+	return nil
+}
+
+type ItemPriceHistoryRequest struct {
+	CompanyAuth *CompanyAuth `protobuf:"bytes,1,opt,name=companyAuth" json:"companyAuth,omitempty"`
+	ItemId      int32        `protobuf:"varint,2,opt,name=item_id,json=itemId" json:"item_id,omitempty"`
+}
+
+func (m *ItemPriceHistoryRequest) Reset()                    { *m = ItemPriceHistoryRequest{} }
+func (m *ItemPriceHistoryRequest) String() string            { return proto.CompactTextString(m) }
+func (*ItemPriceHistoryRequest) ProtoMessage()               {}
+func (*ItemPriceHistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+
+func (m *ItemPriceHistoryRequest) GetCompanyAuth() *CompanyAuth {
+	if m != nil {
+		return m.CompanyAuth
+	}
+	return nil
+}
+
+type ItemPriceHistoryResponse struct {
+	// the latest first
+	Prices []*ItemPriceHistoryResponse_ItemPrice `protobuf:"bytes,1,rep,name=prices" json:"prices,omitempty"`
+}
+
+func (m *ItemPriceHistoryResponse) Reset()                    { *m = ItemPriceHistoryResponse{} }
+func (m *ItemPriceHistoryResponse) String() string            { return proto.CompactTextString(m) }
+func (*ItemPriceHistoryResponse) ProtoMessage()               {}
+func (*ItemPriceHistoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+
+func (m *ItemPriceHistoryResponse) GetPrices() []*ItemPriceHistoryResponse_ItemPrice {
+	if m != nil {
+		return m.Prices
+	}
+	return nil
+}
+
+type ItemPriceHistoryResponse_ItemPrice struct {
+	// 0 for changes of the item's prices, otherwise of the branch's override
+	BranchId int32 `protobuf:"varint,1,opt,name=branch_id,json=branchId" json:"branch_id,omitempty"`
+	// only sent to managers
+	CostPrice    float64 `protobuf:"fixed64,2,opt,name=cost_price,json=costPrice" json:"cost_price,omitempty"`
+	SellingPrice float64 `protobuf:"fixed64,3,opt,name=selling_price,json=sellingPrice" json:"selling_price,omitempty"`
+	// for branches, not having it means the override was removed
+	HasSellingPrice bool  `protobuf:"varint,4,opt,name=has_selling_price,json=hasSellingPrice" json:"has_selling_price,omitempty"`
+	EffectiveDate   int64 `protobuf:"varint,5,opt,name=effective_date,json=effectiveDate" json:"effective_date,omitempty"`
+}
+
+func (m *ItemPriceHistoryResponse_ItemPrice) Reset()         { *m = ItemPriceHistoryResponse_ItemPrice{} }
+func (m *ItemPriceHistoryResponse_ItemPrice) String() string { return proto.CompactTextString(m) }
+func (*ItemPriceHistoryResponse_ItemPrice) ProtoMessage()    {}
+func (*ItemPriceHistoryResponse_ItemPrice) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{57, 0}
+}
+
+type SearchItemsRequest struct {
+	CompanyAuth *CompanyAuth `protobuf:"bytes,1,opt,name=companyAuth" json:"companyAuth,omitempty"`
+	// matched against the item's name, code, part number and bar code,
+	// it tolerates typos. If empty, the items are sorted by name.
+	Query string `protobuf:"bytes,2,opt,name=query" json:"query,omitempty"`
+	// The filters, 0 means don't filter on that field.
+	CategoryId int32 `protobuf:"zigzag32,3,opt,name=category_id,json=categoryId" json:"category_id,omitempty"`
+	// only the items that exist in the branch
+	BranchId      int32 `protobuf:"varint,4,opt,name=branch_id,json=branchId" json:"branch_id,omitempty"`
+	IncludeHidden bool  `protobuf:"varint,5,opt,name=include_hidden,json=includeHidden" json:"include_hidden,omitempty"`
+	// the number of items to skip, used for paging
+	Offset int32 `protobuf:"varint,6,opt,name=offset" json:"offset,omitempty"`
+	// 0 uses the server's default page size.
+	PageSize int32 `protobuf:"varint,7,opt,name=page_size,json=pageSize" json:"page_size,omitempty"`
+}
+
+func (m *SearchItemsRequest) Reset()                    { *m = SearchItemsRequest{} }
+func (m *SearchItemsRequest) String() string            { return proto.CompactTextString(m) }
+func (*SearchItemsRequest) ProtoMessage()               {}
+func (*SearchItemsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+
+func (m *SearchItemsRequest) GetCompanyAuth() *CompanyAuth {
+	if m != nil {
+		return m.CompanyAuth
+	}
+	return nil
+}
+
+type SearchItemsResponse struct {
+	// the most relevant first
+	Items   []*Item `protobuf:"bytes,1,rep,name=items" json:"items,omitempty"`
+	HasMore bool    `protobuf:"varint,2,opt,name=has_more,json=hasMore" json:"has_more,omitempty"`
+}
+
+func (m *SearchItemsResponse) Reset()                    { *m = SearchItemsResponse{} }
+func (m *SearchItemsResponse) String() string            { return proto.CompactTextString(m) }
+func (*SearchItemsResponse) ProtoMessage()               {}
+func (*SearchItemsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+
+func (m *SearchItemsResponse) GetItems() []*Item {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*EmptyRequest)(nil), "sheketproto.EmptyRequest")
+	proto.RegisterType((*EmptyResponse)(nil), "sheketproto.EmptyResponse")
+	proto.RegisterType((*SheketAuth)(nil), "sheketproto.SheketAuth")
+	proto.RegisterType((*CompanyID)(nil), "sheketproto.CompanyID")
+	proto.RegisterType((*CompanyAuth)(nil), "sheketproto.CompanyAuth")
+	proto.RegisterType((*SingupRequest)(nil), "sheketproto.SingupRequest")
+	proto.RegisterType((*SignupResponse)(nil), "sheketproto.SignupResponse")
+	proto.RegisterType((*SyncCompanyRequest)(nil), "sheketproto.SyncCompanyRequest")
+	proto.RegisterType((*NewCompanyRequest)(nil), "sheketproto.NewCompanyRequest")
+	proto.RegisterType((*Company)(nil), "sheketproto.Company")
+	proto.RegisterType((*CompanyList)(nil), "sheketproto.CompanyList")
+	proto.RegisterType((*EditUserNameRequest)(nil), "sheketproto.EditUserNameRequest")
+	proto.RegisterType((*AddEmployeeRequest)(nil), "sheketproto.AddEmployeeRequest")
+	proto.RegisterType((*AddEmployeeResponse)(nil), "sheketproto.AddEmployeeResponse")
+	proto.RegisterType((*IssuePaymentRequest)(nil), "sheketproto.IssuePaymentRequest")
+	proto.RegisterType((*IssuePaymentResponse)(nil), "sheketproto.IssuePaymentResponse")
+	proto.RegisterType((*VerifyPaymentRequest)(nil), "sheketproto.VerifyPaymentRequest")
+	proto.RegisterType((*VerifyPaymentResponse)(nil), "sheketproto.VerifyPaymentResponse")
+	proto.RegisterType((*EditCompanyRequest)(nil), "sheketproto.EditCompanyRequest")
+	proto.RegisterType((*Item)(nil), "sheketproto.Item")
+	proto.RegisterType((*Item_Unit)(nil), "sheketproto.Item.Unit")
+	proto.RegisterType((*Category)(nil), "sheketproto.Category")
+	proto.RegisterType((*Branch)(nil), "sheketproto.Branch")
+	proto.RegisterType((*Employee)(nil), "sheketproto.Employee")
+	proto.RegisterType((*BranchItem)(nil), "sheketproto.BranchItem")
+	proto.RegisterType((*BranchCategory)(nil), "sheketproto.BranchCategory")
+	proto.RegisterType((*EntityRequest)(nil), "sheketproto.EntityRequest")
+	proto.RegisterType((*EntityRequest_RequestItem)(nil), "sheketproto.EntityRequest.RequestItem")
+	proto.RegisterType((*EntityRequest_RequestCategory)(nil), "sheketproto.EntityRequest.RequestCategory")
+	proto.RegisterType((*EntityRequest_RequestBranch)(nil), "sheketproto.EntityRequest.RequestBranch")
+	proto.RegisterType((*EntityRequest_RequestEmployee)(nil), "sheketproto.EntityRequest.RequestEmployee")
+	proto.RegisterType((*EntityRequest_RequestBranchItem)(nil), "sheketproto.EntityRequest.RequestBranchItem")
+	proto.RegisterType((*EntityRequest_RequestBranchCategory)(nil), "sheketproto.EntityRequest.RequestBranchCategory")
+	proto.RegisterType((*EntityResponse)(nil), "sheketproto.EntityResponse")
+	proto.RegisterType((*EntityResponse_SyncItem)(nil), "sheketproto.EntityResponse.SyncItem")
+	proto.RegisterType((*EntityResponse_SyncCategory)(nil), "sheketproto.EntityResponse.SyncCategory")
+	proto.RegisterType((*EntityResponse_SyncBranch)(nil), "sheketproto.EntityResponse.SyncBranch")
+	proto.RegisterType((*EntityResponse_SyncEmployee)(nil), "sheketproto.EntityResponse.SyncEmployee")
+	proto.RegisterType((*EntityResponse_SyncBranchCategory)(nil), "sheketproto.EntityResponse.SyncBranchCategory")
+	proto.RegisterType((*EntityResponse_ItemConflict)(nil), "sheketproto.EntityResponse.ItemConflict")
+	proto.RegisterType((*EntityResponse_CategoryConflict)(nil), "sheketproto.EntityResponse.CategoryConflict")
+	proto.RegisterType((*EntityResponse_BranchConflict)(nil), "sheketproto.EntityResponse.BranchConflict")
+	proto.RegisterType((*EntityResponse_RejectedItem)(nil), "sheketproto.EntityResponse.RejectedItem")
+	proto.RegisterType((*EntityResponse_RejectedCategory)(nil), "sheketproto.EntityResponse.RejectedCategory")
+	proto.RegisterType((*EntityResponse_UpdatedId)(nil), "sheketproto.EntityResponse.UpdatedId")
+	proto.RegisterType((*Transaction)(nil), "sheketproto.Transaction")
+	proto.RegisterType((*Transaction_TransItem)(nil), "sheketproto.Transaction.TransItem")
+	proto.RegisterType((*TransactionRequest)(nil), "sheketproto.TransactionRequest")
+	proto.RegisterType((*TransactionResponse)(nil), "sheketproto.TransactionResponse")
+	proto.RegisterType((*TransactionResponse_SyncTransaction)(nil), "sheketproto.TransactionResponse.SyncTransaction")
+	proto.RegisterType((*TransactionResponse_SyncBranchItem)(nil), "sheketproto.TransactionResponse.SyncBranchItem")
+	proto.RegisterType((*TransactionResponse_UpdatedTransId)(nil), "sheketproto.TransactionResponse.UpdatedTransId")
+	proto.RegisterType((*TransactionHistoryRequest)(nil), "sheketproto.TransactionHistoryRequest")
+	proto.RegisterType((*TransactionHistoryResponse)(nil), "sheketproto.TransactionHistoryResponse")
+	proto.RegisterType((*SalesReportRequest)(nil), "sheketproto.SalesReportRequest")
+	proto.RegisterType((*SalesReportResponse)(nil), "sheketproto.SalesReportResponse")
+	proto.RegisterType((*SalesReportResponse_Total)(nil), "sheketproto.SalesReportResponse.Total")
+	proto.RegisterType((*VoidTransactionRequest)(nil), "sheketproto.VoidTransactionRequest")
+	proto.RegisterType((*VoidTransactionResponse)(nil), "sheketproto.VoidTransactionResponse")
+	proto.RegisterType((*Transfer)(nil), "sheketproto.Transfer")
+	proto.RegisterType((*Transfer_Item)(nil), "sheketproto.Transfer.Item")
+	proto.RegisterType((*SendTransferRequest)(nil), "sheketproto.SendTransferRequest")
+	proto.RegisterType((*SendTransferRequest_SentItem)(nil), "sheketproto.SendTransferRequest.SentItem")
+	proto.RegisterType((*ReceiveTransferRequest)(nil), "sheketproto.ReceiveTransferRequest")
+	proto.RegisterType((*ReceiveTransferRequest_ReceivedItem)(nil), "sheketproto.ReceiveTransferRequest.ReceivedItem")
+	proto.RegisterType((*SubscribeRequest)(nil), "sheketproto.SubscribeRequest")
+	proto.RegisterType((*ChangeNotification)(nil), "sheketproto.ChangeNotification")
+	proto.RegisterType((*ReorderReportRequest)(nil), "sheketproto.ReorderReportRequest")
+	proto.RegisterType((*ReorderReportResponse)(nil), "sheketproto.ReorderReportResponse")
+	proto.RegisterType((*ReorderReportResponse_ReorderItem)(nil), "sheketproto.ReorderReportResponse.ReorderItem")
+	proto.RegisterType((*ReconcileStockRequest)(nil), "sheketproto.ReconcileStockRequest")
+	proto.RegisterType((*ReconcileStockResponse)(nil), "sheketproto.ReconcileStockResponse")
+	proto.RegisterType((*ReconcileStockResponse_StockDrift)(nil), "sheketproto.ReconcileStockResponse.StockDrift")
+	proto.RegisterType((*StockAsOfRequest)(nil), "sheketproto.StockAsOfRequest")
+	proto.RegisterType((*StockAsOfResponse)(nil), "sheketproto.StockAsOfResponse")
+	proto.RegisterType((*StockAsOfResponse_StockQuantity)(nil), "sheketproto.StockAsOfResponse.StockQuantity")
+	proto.RegisterType((*ItemMovementsRequest)(nil), "sheketproto.ItemMovementsRequest")
+	proto.RegisterType((*ItemMovementsResponse)(nil), "sheketproto.ItemMovementsResponse")
+	proto.RegisterType((*ItemMovementsResponse_Movement)(nil), "sheketproto.ItemMovementsResponse.Movement")
+	proto.RegisterType((*InventoryValuationRequest)(nil), "sheketproto.InventoryValuationRequest")
+	proto.RegisterType((*InventoryValuationResponse)(nil), "sheketproto.InventoryValuationResponse")
+	proto.RegisterType((*InventoryValuationResponse_ItemValue)(nil), "sheketproto.InventoryValuationResponse.ItemValue")
+	proto.RegisterType((*InventoryValuationResponse_SaleCost)(nil), "sheketproto.InventoryValuationResponse.SaleCost")
+	proto.RegisterType((*StockTake)(nil), "sheketproto.StockTake")
+	proto.RegisterType((*StockTake_Item)(nil), "sheketproto.StockTake.Item")
+	proto.RegisterType((*SubmitStockCountRequest)(nil), "sheketproto.SubmitStockCountRequest")
+	proto.RegisterType((*SubmitStockCountRequest_CountedItem)(nil), "sheketproto.SubmitStockCountRequest.CountedItem")
+	proto.RegisterType((*ApproveStockTakeRequest)(nil), "sheketproto.ApproveStockTakeRequest")
+	proto.RegisterType((*ApproveStockTakeResponse)(nil), "sheketproto.ApproveStockTakeResponse")
+	proto.RegisterType((*BarcodeLookupRequest)(nil), "sheketproto.BarcodeLookupRequest")
+	proto.RegisterType((*ItemPriceHistoryRequest)(nil), "sheketproto.ItemPriceHistoryRequest")
+	proto.RegisterType((*ItemPriceHistoryResponse)(nil), "sheketproto.ItemPriceHistoryResponse")
+	proto.RegisterType((*ItemPriceHistoryResponse_ItemPrice)(nil), "sheketproto.ItemPriceHistoryResponse.ItemPrice")
+	proto.RegisterType((*SearchItemsRequest)(nil), "sheketproto.SearchItemsRequest")
+	proto.RegisterType((*SearchItemsResponse)(nil), "sheketproto.SearchItemsResponse")
+	proto.RegisterEnum("sheketproto.EntityRequest_Action", EntityRequest_Action_name, EntityRequest_Action_value)
+	proto.RegisterEnum("sheketproto.EntityRequest_DeletePolicy", EntityRequest_DeletePolicy_name, EntityRequest_DeletePolicy_value)
+	proto.RegisterEnum("sheketproto.EntityResponse_SyncState", EntityResponse_SyncState_name, EntityResponse_SyncState_value)
+	proto.RegisterEnum("sheketproto.EntityResponse_ConflictResolution", EntityResponse_ConflictResolution_name, EntityResponse_ConflictResolution_value)
+	proto.RegisterEnum("sheketproto.EntityResponse_RejectReason", EntityResponse_RejectReason_name, EntityResponse_RejectReason_value)
+	proto.RegisterEnum("sheketproto.SalesReportRequest_GroupBy", SalesReportRequest_GroupBy_name, SalesReportRequest_GroupBy_value)
+	proto.RegisterEnum("sheketproto.Transfer_Status", Transfer_Status_name, Transfer_Status_value)
+	proto.RegisterEnum("sheketproto.StockTake_Status", StockTake_Status_name, StockTake_Status_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion3
+
+// Client API for SheketService service
+
+type SheketServiceClient interface {
+	UserSignup(ctx context.Context, in *SingupRequest, opts ...grpc.CallOption) (*SignupResponse, error)
+	SyncCompanies(ctx context.Context, in *SyncCompanyRequest, opts ...grpc.CallOption) (*CompanyList, error)
+	EditUserName(ctx context.Context, in *EditUserNameRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	CreateCompany(ctx context.Context, in *NewCompanyRequest, opts ...grpc.CallOption) (*Company, error)
+	EditCompany(ctx context.Context, in *EditCompanyRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	AddEmployee(ctx context.Context, in *AddEmployeeRequest, opts ...grpc.CallOption) (*AddEmployeeResponse, error)
+	SyncEntity(ctx context.Context, in *EntityRequest, opts ...grpc.CallOption) (*EntityResponse, error)
+	SyncTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
+	GetTransactionHistory(ctx context.Context, in *TransactionHistoryRequest, opts ...grpc.CallOption) (*TransactionHistoryResponse, error)
+	// only managers can see the sales
+	GetSalesReport(ctx context.Context, in *SalesReportRequest, opts ...grpc.CallOption) (*SalesReportResponse, error)
+	VoidTransaction(ctx context.Context, in *VoidTransactionRequest, opts ...grpc.CallOption) (*VoidTransactionResponse, error)
+	SendTransfer(ctx context.Context, in *SendTransferRequest, opts ...grpc.CallOption) (*Transfer, error)
+	ReceiveTransfer(ctx context.Context, in *ReceiveTransferRequest, opts ...grpc.CallOption) (*Transfer, error)
+	SubscribeChanges(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (SheketService_SubscribeChangesClient, error)
+	GetReorderReport(ctx context.Context, in *ReorderReportRequest, opts ...grpc.CallOption) (*ReorderReportResponse, error)
+	ReconcileStock(ctx context.Context, in *ReconcileStockRequest, opts ...grpc.CallOption) (*ReconcileStockResponse, error)
+	GetStockAsOf(ctx context.Context, in *StockAsOfRequest, opts ...grpc.CallOption) (*StockAsOfResponse, error)
+	GetItemMovements(ctx context.Context, in *ItemMovementsRequest, opts ...grpc.CallOption) (*ItemMovementsResponse, error)
+	// only managers can see the valuation
+	GetInventoryValuation(ctx context.Context, in *InventoryValuationRequest, opts ...grpc.CallOption) (*InventoryValuationResponse, error)
+	SubmitStockCount(ctx context.Context, in *SubmitStockCountRequest, opts ...grpc.CallOption) (*StockTake, error)
+	// only managers can approve
+	ApproveStockTake(ctx context.Context, in *ApproveStockTakeRequest, opts ...grpc.CallOption) (*ApproveStockTakeResponse, error)
+	// fails with NOT_FOUND if no item in the company has the bar code
+	LookupItemByBarcode(ctx context.Context, in *BarcodeLookupRequest, opts ...grpc.CallOption) (*Item, error)
+	SearchItems(ctx context.Context, in *SearchItemsRequest, opts ...grpc.CallOption) (*SearchItemsResponse, error)
+	GetItemPriceHistory(ctx context.Context, in *ItemPriceHistoryRequest, opts ...grpc.CallOption) (*ItemPriceHistoryResponse, error)
+	IssuePayment(ctx context.Context, in *IssuePaymentRequest, opts ...grpc.CallOption) (*IssuePaymentResponse, error)
+	VerifyPayment(ctx context.Context, in *VerifyPaymentRequest, opts ...grpc.CallOption) (*VerifyPaymentResponse, error)
+}
+
+type sheketServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSheketServiceClient(cc *grpc.ClientConn) SheketServiceClient {
+	return &sheketServiceClient{cc}
+}
+
+func (c *sheketServiceClient) UserSignup(ctx context.Context, in *SingupRequest, opts ...grpc.CallOption) (*SignupResponse, error) {
+	out := new(SignupResponse)
+	err := grpc.Invoke(ctx, "/sheketproto.SheketService/UserSignup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sheketServiceClient) SyncCompanies(ctx context.Context, in *SyncCompanyRequest, opts ...grpc.CallOption) (*CompanyList, error) {
+	out := new(CompanyList)
+	err := grpc.Invoke(ctx, "/sheketproto.SheketService/SyncCompanies", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sheketServiceClient) EditUserName(ctx context.Context, in *EditUserNameRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := grpc.Invoke(ctx, "/sheketproto.SheketService/EditUserName", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sheketServiceClient) CreateCompany(ctx context.Context, in *NewCompanyRequest, opts ...grpc.CallOption) (*Company, error) {
+	out := new(Company)
+	err := grpc.Invoke(ctx, "/sheketproto.SheketService/CreateCompany", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sheketServiceClient) EditCompany(ctx context.Context, in *EditCompanyRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
 	out := new(EmptyResponse)
 	err := grpc.Invoke(ctx, "/sheketproto.SheketService/EditCompany", in, out, c.cc, opts...)
 	if err != nil {
@@ -1083,27 +2432,194 @@ func (c *sheketServiceClient) EditCompany(ctx context.Context, in *EditCompanyRe
 	return out, nil
 }
 
-func (c *sheketServiceClient) AddEmployee(ctx context.Context, in *AddEmployeeRequest, opts ...grpc.CallOption) (*AddEmployeeResponse, error) {
-	out := new(AddEmployeeResponse)
-	err := grpc.Invoke(ctx, "/sheketproto.SheketService/AddEmployee", in, out, c.cc, opts...)
+func (c *sheketServiceClient) AddEmployee(ctx context.Context, in *AddEmployeeRequest, opts ...grpc.CallOption) (*AddEmployeeResponse, error) {
+	out := new(AddEmployeeResponse)
+	err := grpc.Invoke(ctx, "/sheketproto.SheketService/AddEmployee", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sheketServiceClient) SyncEntity(ctx context.Context, in *EntityRequest, opts ...grpc.CallOption) (*EntityResponse, error) {
+	out := new(EntityResponse)
+	err := grpc.Invoke(ctx, "/sheketproto.SheketService/SyncEntity", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sheketServiceClient) SyncTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*TransactionResponse, error) {
+	out := new(TransactionResponse)
+	err := grpc.Invoke(ctx, "/sheketproto.SheketService/SyncTransaction", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sheketServiceClient) GetTransactionHistory(ctx context.Context, in *TransactionHistoryRequest, opts ...grpc.CallOption) (*TransactionHistoryResponse, error) {
+	out := new(TransactionHistoryResponse)
+	err := grpc.Invoke(ctx, "/sheketproto.SheketService/GetTransactionHistory", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sheketServiceClient) GetSalesReport(ctx context.Context, in *SalesReportRequest, opts ...grpc.CallOption) (*SalesReportResponse, error) {
+	out := new(SalesReportResponse)
+	err := grpc.Invoke(ctx, "/sheketproto.SheketService/GetSalesReport", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sheketServiceClient) VoidTransaction(ctx context.Context, in *VoidTransactionRequest, opts ...grpc.CallOption) (*VoidTransactionResponse, error) {
+	out := new(VoidTransactionResponse)
+	err := grpc.Invoke(ctx, "/sheketproto.SheketService/VoidTransaction", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sheketServiceClient) SendTransfer(ctx context.Context, in *SendTransferRequest, opts ...grpc.CallOption) (*Transfer, error) {
+	out := new(Transfer)
+	err := grpc.Invoke(ctx, "/sheketproto.SheketService/SendTransfer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sheketServiceClient) ReceiveTransfer(ctx context.Context, in *ReceiveTransferRequest, opts ...grpc.CallOption) (*Transfer, error) {
+	out := new(Transfer)
+	err := grpc.Invoke(ctx, "/sheketproto.SheketService/ReceiveTransfer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sheketServiceClient) SubscribeChanges(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (SheketService_SubscribeChangesClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_SheketService_serviceDesc.Streams[0], c.cc, "/sheketproto.SheketService/SubscribeChanges", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sheketServiceSubscribeChangesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SheketService_SubscribeChangesClient interface {
+	Recv() (*ChangeNotification, error)
+	grpc.ClientStream
+}
+
+type sheketServiceSubscribeChangesClient struct {
+	grpc.ClientStream
+}
+
+func (x *sheketServiceSubscribeChangesClient) Recv() (*ChangeNotification, error) {
+	m := new(ChangeNotification)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *sheketServiceClient) GetReorderReport(ctx context.Context, in *ReorderReportRequest, opts ...grpc.CallOption) (*ReorderReportResponse, error) {
+	out := new(ReorderReportResponse)
+	err := grpc.Invoke(ctx, "/sheketproto.SheketService/GetReorderReport", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sheketServiceClient) ReconcileStock(ctx context.Context, in *ReconcileStockRequest, opts ...grpc.CallOption) (*ReconcileStockResponse, error) {
+	out := new(ReconcileStockResponse)
+	err := grpc.Invoke(ctx, "/sheketproto.SheketService/ReconcileStock", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sheketServiceClient) GetStockAsOf(ctx context.Context, in *StockAsOfRequest, opts ...grpc.CallOption) (*StockAsOfResponse, error) {
+	out := new(StockAsOfResponse)
+	err := grpc.Invoke(ctx, "/sheketproto.SheketService/GetStockAsOf", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sheketServiceClient) GetItemMovements(ctx context.Context, in *ItemMovementsRequest, opts ...grpc.CallOption) (*ItemMovementsResponse, error) {
+	out := new(ItemMovementsResponse)
+	err := grpc.Invoke(ctx, "/sheketproto.SheketService/GetItemMovements", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sheketServiceClient) GetInventoryValuation(ctx context.Context, in *InventoryValuationRequest, opts ...grpc.CallOption) (*InventoryValuationResponse, error) {
+	out := new(InventoryValuationResponse)
+	err := grpc.Invoke(ctx, "/sheketproto.SheketService/GetInventoryValuation", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sheketServiceClient) SubmitStockCount(ctx context.Context, in *SubmitStockCountRequest, opts ...grpc.CallOption) (*StockTake, error) {
+	out := new(StockTake)
+	err := grpc.Invoke(ctx, "/sheketproto.SheketService/SubmitStockCount", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sheketServiceClient) ApproveStockTake(ctx context.Context, in *ApproveStockTakeRequest, opts ...grpc.CallOption) (*ApproveStockTakeResponse, error) {
+	out := new(ApproveStockTakeResponse)
+	err := grpc.Invoke(ctx, "/sheketproto.SheketService/ApproveStockTake", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *sheketServiceClient) SyncEntity(ctx context.Context, in *EntityRequest, opts ...grpc.CallOption) (*EntityResponse, error) {
-	out := new(EntityResponse)
-	err := grpc.Invoke(ctx, "/sheketproto.SheketService/SyncEntity", in, out, c.cc, opts...)
+func (c *sheketServiceClient) LookupItemByBarcode(ctx context.Context, in *BarcodeLookupRequest, opts ...grpc.CallOption) (*Item, error) {
+	out := new(Item)
+	err := grpc.Invoke(ctx, "/sheketproto.SheketService/LookupItemByBarcode", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *sheketServiceClient) SyncTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*TransactionResponse, error) {
-	out := new(TransactionResponse)
-	err := grpc.Invoke(ctx, "/sheketproto.SheketService/SyncTransaction", in, out, c.cc, opts...)
+func (c *sheketServiceClient) SearchItems(ctx context.Context, in *SearchItemsRequest, opts ...grpc.CallOption) (*SearchItemsResponse, error) {
+	out := new(SearchItemsResponse)
+	err := grpc.Invoke(ctx, "/sheketproto.SheketService/SearchItems", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sheketServiceClient) GetItemPriceHistory(ctx context.Context, in *ItemPriceHistoryRequest, opts ...grpc.CallOption) (*ItemPriceHistoryResponse, error) {
+	out := new(ItemPriceHistoryResponse)
+	err := grpc.Invoke(ctx, "/sheketproto.SheketService/GetItemPriceHistory", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1139,6 +2655,26 @@ type SheketServiceServer interface {
 	AddEmployee(context.Context, *AddEmployeeRequest) (*AddEmployeeResponse, error)
 	SyncEntity(context.Context, *EntityRequest) (*EntityResponse, error)
 	SyncTransaction(context.Context, *TransactionRequest) (*TransactionResponse, error)
+	GetTransactionHistory(context.Context, *TransactionHistoryRequest) (*TransactionHistoryResponse, error)
+	// only managers can see the sales
+	GetSalesReport(context.Context, *SalesReportRequest) (*SalesReportResponse, error)
+	VoidTransaction(context.Context, *VoidTransactionRequest) (*VoidTransactionResponse, error)
+	SendTransfer(context.Context, *SendTransferRequest) (*Transfer, error)
+	ReceiveTransfer(context.Context, *ReceiveTransferRequest) (*Transfer, error)
+	SubscribeChanges(*SubscribeRequest, SheketService_SubscribeChangesServer) error
+	GetReorderReport(context.Context, *ReorderReportRequest) (*ReorderReportResponse, error)
+	ReconcileStock(context.Context, *ReconcileStockRequest) (*ReconcileStockResponse, error)
+	GetStockAsOf(context.Context, *StockAsOfRequest) (*StockAsOfResponse, error)
+	GetItemMovements(context.Context, *ItemMovementsRequest) (*ItemMovementsResponse, error)
+	// only managers can see the valuation
+	GetInventoryValuation(context.Context, *InventoryValuationRequest) (*InventoryValuationResponse, error)
+	SubmitStockCount(context.Context, *SubmitStockCountRequest) (*StockTake, error)
+	// only managers can approve
+	ApproveStockTake(context.Context, *ApproveStockTakeRequest) (*ApproveStockTakeResponse, error)
+	// fails with NOT_FOUND if no item in the company has the bar code
+	LookupItemByBarcode(context.Context, *BarcodeLookupRequest) (*Item, error)
+	SearchItems(context.Context, *SearchItemsRequest) (*SearchItemsResponse, error)
+	GetItemPriceHistory(context.Context, *ItemPriceHistoryRequest) (*ItemPriceHistoryResponse, error)
 	IssuePayment(context.Context, *IssuePaymentRequest) (*IssuePaymentResponse, error)
 	VerifyPayment(context.Context, *VerifyPaymentRequest) (*VerifyPaymentResponse, error)
 }
@@ -1291,6 +2827,297 @@ func _SheketService_SyncTransaction_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SheketService_GetTransactionHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SheketServiceServer).GetTransactionHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheketproto.SheketService/GetTransactionHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SheketServiceServer).GetTransactionHistory(ctx, req.(*TransactionHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SheketService_GetSalesReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SalesReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SheketServiceServer).GetSalesReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheketproto.SheketService/GetSalesReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SheketServiceServer).GetSalesReport(ctx, req.(*SalesReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SheketService_VoidTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoidTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SheketServiceServer).VoidTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheketproto.SheketService/VoidTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SheketServiceServer).VoidTransaction(ctx, req.(*VoidTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SheketService_SendTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SheketServiceServer).SendTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheketproto.SheketService/SendTransfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SheketServiceServer).SendTransfer(ctx, req.(*SendTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SheketService_ReceiveTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceiveTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SheketServiceServer).ReceiveTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheketproto.SheketService/ReceiveTransfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SheketServiceServer).ReceiveTransfer(ctx, req.(*ReceiveTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SheketService_SubscribeChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SheketServiceServer).SubscribeChanges(m, &sheketServiceSubscribeChangesServer{stream})
+}
+
+type SheketService_SubscribeChangesServer interface {
+	Send(*ChangeNotification) error
+	grpc.ServerStream
+}
+
+type sheketServiceSubscribeChangesServer struct {
+	grpc.ServerStream
+}
+
+func (x *sheketServiceSubscribeChangesServer) Send(m *ChangeNotification) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SheketService_GetReorderReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SheketServiceServer).GetReorderReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheketproto.SheketService/GetReorderReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SheketServiceServer).GetReorderReport(ctx, req.(*ReorderReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SheketService_ReconcileStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SheketServiceServer).ReconcileStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheketproto.SheketService/ReconcileStock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SheketServiceServer).ReconcileStock(ctx, req.(*ReconcileStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SheketService_GetStockAsOf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StockAsOfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SheketServiceServer).GetStockAsOf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheketproto.SheketService/GetStockAsOf",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SheketServiceServer).GetStockAsOf(ctx, req.(*StockAsOfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SheketService_GetItemMovements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ItemMovementsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SheketServiceServer).GetItemMovements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheketproto.SheketService/GetItemMovements",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SheketServiceServer).GetItemMovements(ctx, req.(*ItemMovementsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SheketService_GetInventoryValuation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InventoryValuationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SheketServiceServer).GetInventoryValuation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheketproto.SheketService/GetInventoryValuation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SheketServiceServer).GetInventoryValuation(ctx, req.(*InventoryValuationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SheketService_SubmitStockCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitStockCountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SheketServiceServer).SubmitStockCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheketproto.SheketService/SubmitStockCount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SheketServiceServer).SubmitStockCount(ctx, req.(*SubmitStockCountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SheketService_ApproveStockTake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveStockTakeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SheketServiceServer).ApproveStockTake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheketproto.SheketService/ApproveStockTake",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SheketServiceServer).ApproveStockTake(ctx, req.(*ApproveStockTakeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SheketService_LookupItemByBarcode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BarcodeLookupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SheketServiceServer).LookupItemByBarcode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheketproto.SheketService/LookupItemByBarcode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SheketServiceServer).LookupItemByBarcode(ctx, req.(*BarcodeLookupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SheketService_SearchItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SheketServiceServer).SearchItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheketproto.SheketService/SearchItems",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SheketServiceServer).SearchItems(ctx, req.(*SearchItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SheketService_GetItemPriceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ItemPriceHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SheketServiceServer).GetItemPriceHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sheketproto.SheketService/GetItemPriceHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SheketServiceServer).GetItemPriceHistory(ctx, req.(*ItemPriceHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _SheketService_IssuePayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(IssuePaymentRequest)
 	if err := dec(in); err != nil {
@@ -1363,6 +3190,66 @@ var _SheketService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SyncTransaction",
 			Handler:    _SheketService_SyncTransaction_Handler,
 		},
+		{
+			MethodName: "GetTransactionHistory",
+			Handler:    _SheketService_GetTransactionHistory_Handler,
+		},
+		{
+			MethodName: "GetSalesReport",
+			Handler:    _SheketService_GetSalesReport_Handler,
+		},
+		{
+			MethodName: "VoidTransaction",
+			Handler:    _SheketService_VoidTransaction_Handler,
+		},
+		{
+			MethodName: "SendTransfer",
+			Handler:    _SheketService_SendTransfer_Handler,
+		},
+		{
+			MethodName: "ReceiveTransfer",
+			Handler:    _SheketService_ReceiveTransfer_Handler,
+		},
+		{
+			MethodName: "GetReorderReport",
+			Handler:    _SheketService_GetReorderReport_Handler,
+		},
+		{
+			MethodName: "ReconcileStock",
+			Handler:    _SheketService_ReconcileStock_Handler,
+		},
+		{
+			MethodName: "GetStockAsOf",
+			Handler:    _SheketService_GetStockAsOf_Handler,
+		},
+		{
+			MethodName: "GetItemMovements",
+			Handler:    _SheketService_GetItemMovements_Handler,
+		},
+		{
+			MethodName: "GetInventoryValuation",
+			Handler:    _SheketService_GetInventoryValuation_Handler,
+		},
+		{
+			MethodName: "SubmitStockCount",
+			Handler:    _SheketService_SubmitStockCount_Handler,
+		},
+		{
+			MethodName: "ApproveStockTake",
+			Handler:    _SheketService_ApproveStockTake_Handler,
+		},
+		{
+			MethodName: "LookupItemByBarcode",
+			Handler:    _SheketService_LookupItemByBarcode_Handler,
+		},
+		{
+			MethodName: "SearchItems",
+			Handler:    _SheketService_SearchItems_Handler,
+		},
+		{
+			MethodName: "GetItemPriceHistory",
+			Handler:    _SheketService_GetItemPriceHistory_Handler,
+		},
 		{
 			MethodName: "IssuePayment",
 			Handler:    _SheketService_IssuePayment_Handler,
@@ -1372,157 +3259,346 @@ var _SheketService_serviceDesc = grpc.ServiceDesc{
 			Handler:    _SheketService_VerifyPayment_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeChanges",
+			Handler:       _SheketService_SubscribeChanges_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: fileDescriptor0,
 }
 
 func init() { proto.RegisterFile("sheket_service.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 2312 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xac, 0x59, 0x4b, 0x6f, 0x23, 0x59,
-	0x15, 0xa6, 0xec, 0xd8, 0xb1, 0x8f, 0x1f, 0x49, 0x2a, 0xce, 0x24, 0xe3, 0xd6, 0x4c, 0xa7, 0x6b,
-	0xa6, 0x5b, 0xad, 0x66, 0x70, 0x0f, 0x8d, 0x46, 0x30, 0x80, 0x46, 0xca, 0xc3, 0xad, 0xf1, 0x28,
-	0x9d, 0x0e, 0x95, 0xa4, 0x41, 0x3c, 0x64, 0xb9, 0xed, 0x4a, 0x52, 0x8a, 0x5d, 0xe5, 0x71, 0x95,
-	0xd3, 0xf2, 0x02, 0x58, 0x01, 0x1b, 0x36, 0x6c, 0x60, 0x09, 0x48, 0x2c, 0x90, 0x58, 0xf1, 0x13,
-	0xf8, 0x05, 0xfc, 0x01, 0x36, 0xfc, 0x93, 0x39, 0xf7, 0xdc, 0x87, 0x6f, 0x3d, 0xec, 0x38, 0xed,
-	0x5e, 0xb9, 0xea, 0xf8, 0xbb, 0xe7, 0x7e, 0xe7, 0xdc, 0xf3, 0xb8, 0xb7, 0x2e, 0xd4, 0x82, 0x2b,
-	0xe7, 0xda, 0x09, 0xdb, 0x81, 0x33, 0xba, 0x71, 0xbb, 0x4e, 0x63, 0x38, 0xf2, 0x43, 0xdf, 0x2c,
-	0x71, 0x29, 0xbd, 0x58, 0x55, 0x28, 0x37, 0x07, 0xc3, 0x70, 0x62, 0x3b, 0x5f, 0x8f, 0x9d, 0x20,
-	0xb4, 0xd6, 0xa0, 0x22, 0xde, 0x83, 0xa1, 0xef, 0x05, 0x8e, 0xf5, 0x14, 0xe0, 0x94, 0xf0, 0x7b,
-	0xe3, 0xf0, 0xca, 0x7c, 0x00, 0xe5, 0xbe, 0x7f, 0xe9, 0x7a, 0xed, 0xae, 0xef, 0x5f, 0xbb, 0xce,
-	0x8e, 0xb1, 0x6b, 0x3c, 0x2e, 0xda, 0x25, 0x92, 0x1d, 0x90, 0xc8, 0x7a, 0x02, 0xc5, 0x03, 0x7f,
-	0x30, 0xec, 0x78, 0x93, 0xd6, 0xa1, 0xf9, 0x01, 0x40, 0x97, 0xbf, 0xb4, 0xdd, 0x1e, 0xa1, 0x73,
-	0x76, 0x51, 0x48, 0x5a, 0x3d, 0xeb, 0xd7, 0x50, 0x12, 0x58, 0xd2, 0xfe, 0x7d, 0x80, 0x40, 0xcd,
-	0x45, 0xe8, 0xd2, 0xb3, 0xed, 0x86, 0x46, 0xb7, 0x31, 0xa5, 0x62, 0x6b, 0x50, 0xf3, 0xb3, 0xc8,
-	0x34, 0x19, 0x1a, 0xf8, 0x5e, 0x64, 0xa0, 0xa2, 0xa4, 0x4f, 0xff, 0x10, 0x2a, 0xa7, 0xae, 0x77,
-	0x39, 0x1e, 0x0a, 0xeb, 0xcd, 0x1a, 0xe4, 0x42, 0xff, 0xda, 0xf1, 0x84, 0x5d, 0xfc, 0xc5, 0xba,
-	0x82, 0xea, 0xa9, 0x7b, 0xe9, 0x31, 0x18, 0x77, 0x8a, 0x59, 0x87, 0xc2, 0x18, 0x9d, 0xea, 0x75,
-	0x06, 0xd2, 0x05, 0xea, 0xdd, 0xdc, 0x86, 0x55, 0xf6, 0x2c, 0x89, 0xe4, 0xec, 0x3c, 0x7b, 0x6d,
-	0xf5, 0x12, 0xbe, 0xcb, 0x26, 0x7d, 0xf7, 0x77, 0x03, 0xcc, 0xd3, 0x89, 0xd7, 0x15, 0x6c, 0x25,
-	0xad, 0x6f, 0xc3, 0x4a, 0x67, 0x01, 0x8f, 0x10, 0xc8, 0x7c, 0x9f, 0x73, 0x6b, 0x8f, 0x9c, 0x1b,
-	0x41, 0x80, 0xf8, 0xd8, 0xce, 0x8d, 0x79, 0x0f, 0x8a, 0x3d, 0x87, 0x45, 0x02, 0x23, 0xc7, 0xa7,
-	0x2f, 0x70, 0x01, 0xd2, 0x7b, 0x04, 0x6b, 0x7d, 0xbf, 0xdb, 0xe9, 0xb7, 0x69, 0x74, 0xe8, 0xa2,
-	0x69, 0x2b, 0x04, 0xa9, 0x90, 0xf8, 0x1c, 0xa5, 0x67, 0x28, 0xb4, 0xfe, 0x69, 0xc0, 0xc6, 0xb1,
-	0xf3, 0x66, 0x19, 0x8a, 0xe8, 0x09, 0xb9, 0x5c, 0xe4, 0xc2, 0x0c, 0xf7, 0x84, 0x90, 0x1d, 0x33,
-	0x2f, 0xbe, 0x13, 0xaa, 0xff, 0x36, 0x60, 0x55, 0xf0, 0xbc, 0x25, 0x12, 0x17, 0xa1, 0xf4, 0x21,
-	0xc0, 0xd0, 0x19, 0x0d, 0xdc, 0x20, 0x70, 0x7d, 0x4f, 0x70, 0xd2, 0x24, 0xe6, 0x43, 0xa8, 0x06,
-	0x18, 0x26, 0x4e, 0xaf, 0xdd, 0x47, 0x9a, 0x18, 0x26, 0x92, 0x14, 0x97, 0x1e, 0x71, 0x21, 0x23,
-	0x32, 0xec, 0x4c, 0x06, 0x8e, 0x17, 0x32, 0x22, 0x39, 0x82, 0x14, 0x85, 0x04, 0x63, 0x72, 0x4f,
-	0xa5, 0xc4, 0x91, 0x8b, 0x7e, 0x7d, 0x06, 0x82, 0xa4, 0xeb, 0x04, 0xc8, 0x3a, 0x8b, 0xce, 0xad,
-	0xa5, 0x05, 0xb6, 0x3d, 0x85, 0x59, 0xbf, 0x82, 0xcd, 0x66, 0xcf, 0x0d, 0x99, 0x1b, 0x18, 0xf1,
-	0xb7, 0x8d, 0x22, 0xcf, 0x79, 0xa3, 0xfb, 0x62, 0x15, 0xdf, 0x99, 0x3a, 0xeb, 0x4f, 0x18, 0xa4,
-	0x7b, 0xbd, 0x1e, 0x96, 0x89, 0xbe, 0x3f, 0x71, 0x94, 0xfa, 0x1f, 0x82, 0xf4, 0x96, 0x96, 0xbd,
-	0x3b, 0x69, 0x5c, 0x69, 0x1a, 0x1d, 0x6c, 0xde, 0x87, 0x92, 0x23, 0xd4, 0x4d, 0xf3, 0x06, 0xa4,
-	0x08, 0x97, 0xe7, 0x16, 0xdf, 0x5b, 0xbf, 0x80, 0xcd, 0x08, 0x25, 0x91, 0xa7, 0x31, 0xbd, 0x46,
-	0x42, 0xef, 0x47, 0x50, 0x51, 0x00, 0xcd, 0xd6, 0xb2, 0x14, 0x92, 0xc1, 0x7f, 0xcb, 0xc0, 0x66,
-	0x2b, 0x08, 0xc6, 0xce, 0x09, 0x5f, 0x25, 0x69, 0xf1, 0x5b, 0x97, 0xab, 0x0f, 0x12, 0xe5, 0x2a,
-	0x12, 0x8b, 0x48, 0xaa, 0xeb, 0x7b, 0xe1, 0xa8, 0xd3, 0x0d, 0xdb, 0xe1, 0x64, 0xc8, 0x2b, 0x45,
-	0xce, 0x2e, 0x4b, 0xe1, 0x19, 0xca, 0x18, 0xa8, 0x37, 0x1e, 0x75, 0x42, 0xb4, 0xbe, 0xdd, 0xeb,
-	0x4c, 0x02, 0x0a, 0x36, 0x04, 0x49, 0xe1, 0x21, 0xca, 0x58, 0x48, 0x2a, 0xf3, 0xfa, 0xee, 0xc0,
-	0x0d, 0x29, 0xde, 0x36, 0x6c, 0x65, 0xf4, 0x11, 0x13, 0xb2, 0xe0, 0x7f, 0x3d, 0xea, 0x78, 0xdd,
-	0x2b, 0x01, 0xca, 0x13, 0xa8, 0xc4, 0x65, 0x1c, 0x82, 0x94, 0xdd, 0xd0, 0x19, 0x08, 0xc0, 0x2a,
-	0x01, 0x8a, 0x4c, 0x42, 0x7f, 0x5b, 0x01, 0xd4, 0xa2, 0x1e, 0x12, 0x0b, 0xf0, 0x04, 0x36, 0x5c,
-	0x26, 0xef, 0xb5, 0x13, 0xc9, 0xb7, 0xc6, 0xff, 0x38, 0x50, 0x66, 0x3f, 0x85, 0x4d, 0x99, 0x18,
-	0x3d, 0x27, 0xe8, 0x8e, 0xdc, 0x21, 0xb3, 0x43, 0xac, 0x88, 0x29, 0xfe, 0x3a, 0x9c, 0xfe, 0x63,
-	0xfd, 0xc5, 0x80, 0xda, 0x2b, 0x67, 0xe4, 0x5e, 0x4c, 0x62, 0x0b, 0xb3, 0x4c, 0x28, 0x46, 0x0a,
-	0x4f, 0xe6, 0xf6, 0xc2, 0x93, 0x4d, 0x2b, 0x3c, 0x5f, 0xc0, 0x56, 0x8c, 0x98, 0xf0, 0x47, 0xb2,
-	0x46, 0x18, 0x29, 0x35, 0xc2, 0xba, 0x06, 0x93, 0x65, 0x70, 0xac, 0xc6, 0x2e, 0x63, 0xd6, 0x9c,
-	0x7c, 0xfe, 0x4f, 0x06, 0x56, 0x5a, 0xb8, 0x92, 0xac, 0x73, 0xd1, 0x1a, 0x8b, 0x25, 0xda, 0xb0,
-	0xf3, 0xec, 0x15, 0xcd, 0x36, 0x61, 0x45, 0x1b, 0x48, 0xcf, 0x4c, 0xd6, 0xf5, 0x7b, 0xd2, 0x7e,
-	0x7a, 0x66, 0xb2, 0xf3, 0xf3, 0xd6, 0xa1, 0xa8, 0x7b, 0x2b, 0x63, 0x7c, 0x66, 0x29, 0x18, 0x84,
-	0x9d, 0x70, 0x1c, 0xb4, 0x2f, 0xfa, 0x9d, 0x4b, 0x8a, 0x3f, 0x4c, 0x41, 0x2e, 0x7a, 0x8e, 0x12,
-	0x06, 0xe8, 0x76, 0x42, 0xe7, 0xd2, 0x1f, 0x51, 0x70, 0xf0, 0xd8, 0x03, 0x29, 0xc2, 0xd9, 0x1b,
-	0xb0, 0x39, 0xf6, 0xdc, 0xb0, 0xed, 0x5f, 0xb4, 0x07, 0x4e, 0x27, 0x18, 0x8f, 0x1c, 0xe6, 0x52,
-	0x8a, 0xc1, 0x9c, 0xbd, 0xc1, 0xfe, 0x7a, 0x79, 0xf1, 0x62, 0xfa, 0x87, 0xf9, 0x18, 0xd6, 0xaf,
-	0x3a, 0x01, 0xc6, 0xd0, 0xc8, 0xbd, 0x41, 0x47, 0x33, 0xc0, 0x4e, 0x01, 0xc1, 0x05, 0xbb, 0x8a,
-	0xf2, 0x43, 0x2e, 0x3e, 0xf7, 0x78, 0xdc, 0x4b, 0x14, 0xd9, 0x57, 0xe4, 0x45, 0x5f, 0xc8, 0xa8,
-	0xe8, 0xe3, 0x82, 0x49, 0xc8, 0x05, 0xa6, 0x9e, 0x3f, 0xda, 0x01, 0x04, 0x19, 0x76, 0x45, 0x48,
-	0x9f, 0x93, 0x90, 0xd5, 0xc4, 0xc2, 0x81, 0xa0, 0x1c, 0xb7, 0xc8, 0x48, 0x58, 0x94, 0xe6, 0x4f,
-	0x8c, 0xbb, 0x61, 0x67, 0x24, 0xba, 0x42, 0x96, 0x86, 0x14, 0xb8, 0x80, 0x0f, 0xb8, 0xb3, 0x63,
-	0x2d, 0x0f, 0xf2, 0xfb, 0x94, 0xc1, 0x4c, 0xb7, 0xc8, 0x6f, 0x45, 0xa7, 0xc0, 0x05, 0xb3, 0x17,
-	0x97, 0xe6, 0xcb, 0xce, 0x9e, 0x6f, 0x25, 0x31, 0x5f, 0x1b, 0x0a, 0xb2, 0x00, 0xdf, 0x5e, 0x78,
-	0xd3, 0x66, 0xbd, 0xad, 0xc8, 0xff, 0xce, 0x00, 0xe0, 0x16, 0x51, 0xb8, 0xce, 0xb5, 0x4a, 0x8b,
-	0xe5, 0x4c, 0x24, 0x96, 0x71, 0xeb, 0xf6, 0xf5, 0xb8, 0xe3, 0x85, 0x6e, 0x38, 0xa1, 0x29, 0x0c,
-	0x5b, 0xbd, 0x53, 0x76, 0x5e, 0x39, 0xfd, 0x8b, 0x36, 0xcb, 0x66, 0x2a, 0x3e, 0xb2, 0x83, 0x33,
-	0xe9, 0x91, 0x10, 0x5a, 0xc7, 0x50, 0xe5, 0x34, 0xd4, 0x8a, 0xcf, 0xa5, 0x12, 0x0b, 0x87, 0x4c,
-	0x3c, 0x1c, 0xac, 0xff, 0x96, 0x71, 0xd3, 0x4d, 0x0c, 0x64, 0xa6, 0xff, 0x18, 0x72, 0x8c, 0xae,
-	0xec, 0xf8, 0x8f, 0x22, 0x39, 0x1e, 0x81, 0x36, 0xc4, 0x2f, 0xf3, 0x88, 0xcd, 0x07, 0x99, 0x5f,
-	0x81, 0xd4, 0xce, 0x36, 0x0d, 0x19, 0x52, 0xf1, 0xe4, 0x76, 0x15, 0xd2, 0x1a, 0x5b, 0x1b, 0x6d,
-	0x1e, 0x82, 0x30, 0x04, 0x35, 0x65, 0x49, 0xd3, 0xe3, 0xdb, 0x35, 0x71, 0xef, 0xd8, 0x6a, 0xa4,
-	0xf9, 0x25, 0x14, 0xe5, 0xda, 0xb3, 0x46, 0xb5, 0x20, 0x21, 0xd5, 0xce, 0xa7, 0x83, 0xcd, 0x63,
-	0x10, 0x6d, 0xa9, 0x45, 0xfe, 0xc9, 0x91, 0xae, 0x4f, 0x16, 0xa5, 0x44, 0x5e, 0xd2, 0x15, 0x98,
-	0xbf, 0x84, 0xf5, 0xd7, 0xfa, 0x5a, 0x32, 0x8f, 0xe5, 0x49, 0xe9, 0xa7, 0x8b, 0x2a, 0x55, 0x7e,
-	0x4b, 0x68, 0x8a, 0x57, 0xec, 0xda, 0x5d, 0x2a, 0x36, 0x96, 0x31, 0xbf, 0x8f, 0x7d, 0x53, 0x86,
-	0x0e, 0xdb, 0xcf, 0x6f, 0x51, 0x1e, 0x55, 0x51, 0xae, 0x26, 0xc5, 0x6d, 0xfd, 0x2e, 0x94, 0x19,
-	0x92, 0xe2, 0x9d, 0xa1, 0xde, 0xe3, 0xd9, 0x86, 0x32, 0xb2, 0x17, 0x11, 0x1f, 0x03, 0x1b, 0xd3,
-	0x16, 0x31, 0xca, 0x30, 0xdb, 0x7c, 0xb7, 0x80, 0x52, 0xb1, 0x58, 0x88, 0xfa, 0x0e, 0x6c, 0x6a,
-	0x28, 0xa5, 0x6e, 0x87, 0xa0, 0xeb, 0x0a, 0x1a, 0x53, 0x3a, 0x70, 0x06, 0xaf, 0xc5, 0x71, 0xe3,
-	0x7d, 0xa5, 0xf4, 0x05, 0x09, 0x19, 0xea, 0x33, 0xd8, 0xd6, 0x94, 0x46, 0xac, 0xa9, 0x13, 0xbc,
-	0xa6, 0x14, 0x6b, 0x36, 0xd5, 0x7d, 0x28, 0x69, 0x91, 0x8d, 0x99, 0xb9, 0xc2, 0xf8, 0x88, 0x9e,
-	0xb7, 0x11, 0xf1, 0x20, 0xf1, 0xa1, 0xbf, 0xcd, 0xcf, 0x21, 0x8f, 0xf5, 0x58, 0xee, 0x1a, 0xaa,
-	0xcf, 0x1e, 0xcc, 0x59, 0xc3, 0x3d, 0x02, 0xda, 0x62, 0x40, 0xfd, 0xb7, 0xb0, 0x16, 0xcb, 0x03,
-	0xf3, 0xbb, 0x50, 0x90, 0x7c, 0xc5, 0xc4, 0x5b, 0xd1, 0xa5, 0x93, 0x7c, 0x15, 0x6c, 0x19, 0x02,
-	0x6f, 0xa0, 0x12, 0x09, 0x2b, 0xdc, 0xaf, 0xe7, 0xb9, 0xd7, 0xc4, 0xe4, 0x9b, 0x11, 0x5d, 0x62,
-	0xd9, 0x04, 0xe4, 0xdd, 0x58, 0xae, 0xca, 0xf7, 0x54, 0x9b, 0x71, 0x47, 0x6d, 0xcc, 0x69, 0x32,
-	0x5b, 0xc5, 0x41, 0x3c, 0xea, 0x34, 0x95, 0xd4, 0x0a, 0x56, 0xff, 0x03, 0x9e, 0x28, 0x13, 0x69,
-	0xba, 0x0c, 0x07, 0xdc, 0x98, 0x4f, 0x73, 0x5c, 0xb0, 0xd8, 0x4e, 0xf1, 0x1e, 0x45, 0x8e, 0x06,
-	0xad, 0xe3, 0x8e, 0x72, 0x2b, 0x35, 0xb7, 0x97, 0x61, 0x73, 0x00, 0xd5, 0x48, 0x61, 0x98, 0x08,
-	0x46, 0xf7, 0x52, 0x18, 0xa9, 0x90, 0x8a, 0x0d, 0xb1, 0x3e, 0x81, 0x3c, 0x57, 0x6b, 0x02, 0xe4,
-	0x0f, 0xec, 0xe6, 0xde, 0x59, 0x73, 0xfd, 0x5b, 0xec, 0xf9, 0xfc, 0xe4, 0x90, 0x3d, 0x1b, 0xec,
-	0xf9, 0xb0, 0x79, 0xd4, 0xc4, 0xe7, 0x8c, 0xf5, 0xbf, 0x32, 0x54, 0x25, 0x27, 0xb1, 0xf3, 0x7c,
-	0x09, 0xeb, 0xe3, 0x61, 0x0f, 0xd5, 0x89, 0x42, 0xe1, 0xf6, 0x64, 0x77, 0x79, 0x98, 0x6a, 0x0a,
-	0x1f, 0xd6, 0x38, 0xe7, 0x63, 0x5a, 0x3d, 0xbb, 0x2a, 0x86, 0xb7, 0xa8, 0x8f, 0x06, 0xe6, 0x29,
-	0x98, 0x52, 0xa1, 0xea, 0x7d, 0xb2, 0xdb, 0x2c, 0xa8, 0x52, 0x32, 0x12, 0xab, 0x81, 0x4a, 0x7f,
-	0x0a, 0x35, 0xa9, 0x54, 0xeb, 0x99, 0xb2, 0xf5, 0x2c, 0xa8, 0x56, 0xf2, 0x3a, 0x50, 0x2d, 0x96,
-	0x55, 0x62, 0xd1, 0x51, 0x79, 0xf7, 0xf9, 0x78, 0x9e, 0x26, 0xf6, 0x05, 0x46, 0xef, 0xa7, 0x5f,
-	0x46, 0xfa, 0x69, 0x6e, 0x4e, 0x17, 0xd4, 0x14, 0xa4, 0x76, 0xd3, 0x7d, 0xad, 0x9b, 0xe6, 0xe7,
-	0xb4, 0x76, 0x4d, 0x4f, 0xa2, 0x97, 0x3e, 0xd7, 0x7b, 0xe9, 0xea, 0x62, 0x64, 0xd2, 0x3a, 0xe9,
-	0xcf, 0x53, 0x3a, 0x5f, 0x81, 0xd4, 0x35, 0x16, 0xe3, 0x34, 0xa7, 0xef, 0x61, 0xef, 0x62, 0xa7,
-	0x8d, 0x48, 0xb5, 0xaf, 0xf1, 0xde, 0x85, 0xf2, 0x58, 0xef, 0x62, 0x48, 0xd5, 0x6c, 0x78, 0x87,
-	0x03, 0x94, 0x69, 0x6d, 0x86, 0x21, 0xb4, 0xde, 0xc5, 0xfb, 0x1b, 0x1b, 0x17, 0xe9, 0x5d, 0x1a,
-	0x4a, 0xa9, 0xe3, 0x6d, 0x6e, 0x5d, 0x41, 0x63, 0x4a, 0xb5, 0xde, 0xb5, 0xa3, 0x94, 0x46, 0x7a,
-	0x97, 0xa6, 0x34, 0x62, 0x0d, 0x6f, 0x75, 0x35, 0xa5, 0x58, 0xef, 0x5d, 0x1e, 0x14, 0x64, 0x08,
-	0x2d, 0xda, 0xb8, 0x7e, 0x04, 0x39, 0xb6, 0x93, 0x76, 0x44, 0xf5, 0x7e, 0x78, 0xdb, 0x0a, 0x9c,
-	0x32, 0xb0, 0xcd, 0xc7, 0xd4, 0x7f, 0x03, 0x65, 0x3d, 0xe2, 0xde, 0xa6, 0x6f, 0x2d, 0x35, 0xff,
-	0x0d, 0xc0, 0x34, 0x2a, 0xee, 0xd6, 0xb6, 0xde, 0x85, 0xdd, 0xaa, 0x6b, 0xe9, 0xad, 0xc7, 0x58,
-	0xa8, 0xf5, 0x2c, 0x37, 0xff, 0x9f, 0xc5, 0xd7, 0xda, 0x58, 0xab, 0x48, 0xd6, 0x7b, 0xe3, 0xce,
-	0xf5, 0x7e, 0x39, 0x62, 0x9f, 0x43, 0x51, 0x55, 0x43, 0x73, 0x0b, 0xf2, 0xb4, 0x3b, 0x94, 0x07,
-	0x93, 0x1c, 0xdb, 0x17, 0x92, 0x98, 0x12, 0x4f, 0x7e, 0x7f, 0xca, 0xb1, 0x94, 0xeb, 0x59, 0x1f,
-	0x41, 0x51, 0xa9, 0x33, 0x8b, 0x90, 0x6b, 0xfe, 0xac, 0x75, 0x7a, 0x86, 0x9d, 0xa6, 0x04, 0xab,
-	0x76, 0xf3, 0xc5, 0xcb, 0x57, 0xcd, 0xc3, 0x75, 0xc3, 0xfa, 0x63, 0x16, 0x4a, 0x67, 0xc8, 0x37,
-	0x10, 0x1d, 0xee, 0x18, 0xd6, 0xc3, 0xe9, 0x6b, 0x4b, 0x3b, 0xb9, 0x58, 0x11, 0xde, 0xda, 0x18,
-	0xfe, 0x4c, 0x19, 0x90, 0x18, 0xcb, 0x3e, 0x56, 0x90, 0x4c, 0xb2, 0x33, 0xed, 0x55, 0x7a, 0x47,
-	0xda, 0x91, 0x93, 0x56, 0x36, 0x76, 0xd2, 0x62, 0xdf, 0x6e, 0x90, 0xf7, 0xf4, 0x8b, 0x70, 0xd6,
-	0x2e, 0x30, 0x01, 0xfb, 0x26, 0xc3, 0xbe, 0x60, 0x71, 0xa5, 0x9e, 0x8f, 0x6e, 0x15, 0xdf, 0x5d,
-	0x49, 0x72, 0x8c, 0x02, 0x75, 0xe4, 0xcd, 0x4f, 0x8f, 0xbc, 0xf5, 0x7f, 0x18, 0x50, 0x54, 0x3c,
-	0xe3, 0x47, 0xca, 0x9c, 0x3a, 0x52, 0x2a, 0xcd, 0xf4, 0xb1, 0x4e, 0x7c, 0x5a, 0x26, 0x09, 0x7d,
-	0xa9, 0x9b, 0x77, 0xe2, 0x7c, 0x04, 0x6b, 0x7e, 0x78, 0x85, 0x25, 0x68, 0x6a, 0x14, 0x3f, 0x58,
-	0x57, 0x48, 0xbc, 0xaf, 0x59, 0x46, 0x73, 0x6b, 0xdc, 0x0b, 0x4c, 0xc0, 0xa8, 0x5b, 0xff, 0xc7,
-	0x38, 0xd4, 0x5c, 0x3b, 0x3d, 0x44, 0x96, 0x35, 0xcf, 0xca, 0x15, 0xd9, 0x99, 0xb5, 0x22, 0x76,
-	0x04, 0x1d, 0x3f, 0xba, 0x64, 0xee, 0x72, 0x74, 0x99, 0x71, 0x90, 0xc8, 0xd2, 0x8a, 0x24, 0x0f,
-	0x12, 0x16, 0x54, 0x18, 0x9c, 0xfb, 0x90, 0x01, 0xf9, 0xd2, 0x95, 0x50, 0x48, 0xfc, 0x10, 0x63,
-	0xfd, 0x2b, 0x07, 0x9b, 0x11, 0x1b, 0xc5, 0xb6, 0xe6, 0x2c, 0xd5, 0xc8, 0x4f, 0x67, 0x1a, 0xa9,
-	0xe7, 0xcc, 0x6c, 0xe3, 0x7f, 0x12, 0x3d, 0x65, 0xf2, 0x4d, 0xcd, 0xd3, 0x85, 0x94, 0xce, 0x3a,
-	0x68, 0x5e, 0xc2, 0xb6, 0xdc, 0xd9, 0x68, 0x53, 0x69, 0x9b, 0x9b, 0xdb, 0xd5, 0x8b, 0x9c, 0xe6,
-	0x11, 0xd9, 0xb3, 0xb7, 0xc6, 0xda, 0xbb, 0x48, 0x1f, 0xdc, 0xe9, 0xcc, 0xe8, 0x84, 0xdc, 0xa7,
-	0xc9, 0x4e, 0x88, 0xce, 0x67, 0xf0, 0xa9, 0xf3, 0x73, 0xdc, 0xf9, 0x28, 0x94, 0xce, 0xaf, 0x5f,
-	0xc0, 0x5a, 0xcc, 0x5f, 0x2c, 0x3c, 0x34, 0x33, 0x52, 0xbf, 0x45, 0xea, 0x26, 0xe8, 0xe0, 0x99,
-	0x37, 0x64, 0xf5, 0xdf, 0x1b, 0x50, 0x8d, 0xfa, 0x30, 0xb6, 0x95, 0x37, 0x16, 0xde, 0xca, 0x2f,
-	0x57, 0x40, 0xbf, 0x80, 0x6a, 0xd4, 0xd9, 0xb1, 0x2a, 0x6a, 0xa6, 0x57, 0xd1, 0xac, 0xa8, 0xa2,
-	0xcf, 0xfe, 0x9a, 0x87, 0x0a, 0xff, 0xf6, 0x7f, 0xca, 0xaf, 0x5e, 0xcd, 0x26, 0x00, 0xfb, 0x3a,
-	0xcc, 0xef, 0x11, 0xcd, 0x7a, 0xf4, 0x96, 0x40, 0xbf, 0x83, 0xac, 0xdf, 0x8b, 0xfd, 0x17, 0xb9,
-	0x78, 0xfc, 0x0a, 0xf5, 0xaa, 0xfb, 0x41, 0xb6, 0xd3, 0xba, 0x1f, 0x45, 0x27, 0xee, 0x0e, 0xeb,
-	0xa9, 0x29, 0x4b, 0x57, 0x4b, 0x47, 0x50, 0xd6, 0xaf, 0x89, 0xcc, 0xdd, 0xa8, 0x8b, 0x92, 0x37,
-	0x48, 0xf5, 0x7a, 0xbc, 0x9d, 0x6a, 0xe7, 0x8b, 0x26, 0x54, 0x0e, 0x46, 0x0e, 0xba, 0x4c, 0x5e,
-	0xb8, 0x7d, 0x18, 0x01, 0x27, 0x6e, 0x0c, 0xeb, 0xa9, 0xd7, 0x58, 0x68, 0x60, 0x49, 0xfb, 0xf2,
-	0x1d, 0x33, 0x2f, 0xf9, 0x4d, 0x7c, 0x2e, 0xa5, 0x13, 0x28, 0x69, 0x97, 0x42, 0x31, 0x5d, 0xc9,
-	0x1b, 0xac, 0xfa, 0xee, 0x6c, 0x80, 0x32, 0x92, 0x76, 0x3a, 0x3c, 0x7c, 0x62, 0xab, 0x18, 0x39,
-	0x03, 0xc6, 0x56, 0x31, 0x76, 0x16, 0x3b, 0x4b, 0xe6, 0xd3, 0xfd, 0xd9, 0xd9, 0x9f, 0x46, 0x2e,
-	0xad, 0x14, 0x9e, 0x42, 0x59, 0xbf, 0x83, 0x89, 0xad, 0x67, 0xca, 0x05, 0x56, 0xfd, 0xc1, 0x1c,
-	0x84, 0x50, 0xfa, 0x0a, 0x2a, 0x91, 0x9b, 0x0c, 0x33, 0x3a, 0x26, 0xed, 0xfa, 0xa5, 0x6e, 0xcd,
-	0x83, 0x70, 0xbd, 0xfb, 0x3f, 0x80, 0x5d, 0xec, 0x18, 0x8d, 0xc1, 0xf8, 0xda, 0x19, 0x75, 0x04,
-	0xbe, 0xd1, 0xed, 0xbb, 0x88, 0x69, 0x78, 0x4e, 0xf8, 0xc6, 0x1f, 0x5d, 0xef, 0x9b, 0x91, 0x14,
-	0x3a, 0x61, 0xda, 0x4e, 0x8c, 0xd7, 0x79, 0x52, 0xfb, 0xbd, 0x6f, 0x02, 0x00, 0x00, 0xff, 0xff,
-	0xce, 0x94, 0xf5, 0xcd, 0xdd, 0x20, 0x00, 0x00,
+	// 5248 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x3c, 0x5d, 0x6f, 0x23, 0x59,
+	0x56, 0x53, 0x76, 0xec, 0xd8, 0xc7, 0x1f, 0x71, 0x2a, 0xe9, 0x8e, 0xa7, 0x9a, 0xde, 0xce, 0x54,
+	0x4f, 0xcf, 0xf4, 0xce, 0x47, 0x66, 0xa6, 0x97, 0xd1, 0x32, 0x80, 0x66, 0x49, 0x1c, 0x77, 0xb7,
+	0x67, 0xf2, 0x35, 0x15, 0xa7, 0x87, 0x66, 0x76, 0x55, 0xaa, 0xb8, 0x6e, 0x92, 0x22, 0x76, 0x95,
+	0xa7, 0xaa, 0x9c, 0x5e, 0xaf, 0xb4, 0xb0, 0x42, 0x62, 0x61, 0x90, 0x16, 0x09, 0x10, 0xbb, 0x0f,
+	0xbc, 0x80, 0xb4, 0x0b, 0x08, 0x24, 0x5e, 0x96, 0x77, 0x24, 0xe0, 0x0d, 0x78, 0x5f, 0xc4, 0x7f,
+	0xe0, 0x91, 0x47, 0x84, 0xce, 0xfd, 0x28, 0xdf, 0xfa, 0xb0, 0xe3, 0xee, 0xa4, 0x79, 0xb2, 0xef,
+	0xb9, 0xe7, 0x9e, 0x3a, 0xf7, 0x7c, 0xdd, 0x73, 0xcf, 0xbd, 0x55, 0xb0, 0x1a, 0x9c, 0x91, 0x73,
+	0x12, 0x9a, 0x01, 0xf1, 0x2f, 0x9c, 0x1e, 0xd9, 0x18, 0xfa, 0x5e, 0xe8, 0xa9, 0x15, 0x06, 0xa5,
+	0x0d, 0xbd, 0x0e, 0xd5, 0xf6, 0x60, 0x18, 0x8e, 0x0d, 0xf2, 0xe5, 0x88, 0x04, 0xa1, 0xbe, 0x04,
+	0x35, 0xde, 0x0e, 0x86, 0x9e, 0x1b, 0x10, 0xfd, 0x3d, 0x80, 0x43, 0x8a, 0xbf, 0x39, 0x0a, 0xcf,
+	0xd4, 0xd7, 0xa0, 0xda, 0xf7, 0x4e, 0x1d, 0xd7, 0xec, 0x79, 0xde, 0xb9, 0x43, 0x9a, 0xca, 0xba,
+	0x72, 0xbf, 0x6c, 0x54, 0x28, 0xac, 0x45, 0x41, 0xfa, 0x5b, 0x50, 0x6e, 0x79, 0x83, 0xa1, 0xe5,
+	0x8e, 0x3b, 0xdb, 0xea, 0x6d, 0x80, 0x1e, 0x6b, 0x98, 0x8e, 0x4d, 0xb1, 0x0b, 0x46, 0x99, 0x43,
+	0x3a, 0xb6, 0xfe, 0x7d, 0xa8, 0x70, 0x5c, 0x4a, 0xfd, 0x9b, 0x00, 0x41, 0xf4, 0x2c, 0x8a, 0x5d,
+	0x79, 0xb0, 0xb6, 0x21, 0xb1, 0xbb, 0x31, 0x61, 0xc5, 0x90, 0x50, 0xd5, 0x0f, 0x63, 0x8f, 0xc9,
+	0xd1, 0x81, 0x37, 0x63, 0x03, 0x23, 0x96, 0xe4, 0xc7, 0xdf, 0x83, 0xda, 0xa1, 0xe3, 0x9e, 0x8e,
+	0x86, 0x7c, 0xf6, 0xea, 0x2a, 0x14, 0x42, 0xef, 0x9c, 0xb8, 0x7c, 0x5e, 0xac, 0xa1, 0x9f, 0x41,
+	0xfd, 0xd0, 0x39, 0x75, 0x47, 0x43, 0x21, 0x14, 0x55, 0x83, 0xd2, 0x28, 0x20, 0xbe, 0x6b, 0x0d,
+	0x84, 0x08, 0xa2, 0xb6, 0xba, 0x06, 0x8b, 0xf8, 0x5f, 0x30, 0x52, 0x30, 0x8a, 0xd8, 0xec, 0xd8,
+	0x29, 0xd9, 0xe5, 0xd3, 0xb2, 0xfb, 0x2b, 0x05, 0xd4, 0xc3, 0xb1, 0xdb, 0xe3, 0xdc, 0x0a, 0xb6,
+	0xde, 0x86, 0x05, 0x6b, 0x0e, 0x89, 0x50, 0x24, 0xf5, 0x55, 0xc6, 0x9b, 0xe9, 0x93, 0x0b, 0xce,
+	0x00, 0xe5, 0xc7, 0x20, 0x17, 0xea, 0x2d, 0x28, 0xdb, 0x04, 0x2d, 0x01, 0x99, 0x63, 0x8f, 0x2f,
+	0x31, 0x40, 0xc7, 0x56, 0xdf, 0x80, 0xa5, 0xbe, 0xd7, 0xb3, 0xfa, 0x26, 0x1d, 0x1d, 0x3a, 0x03,
+	0xd2, 0x5c, 0xa0, 0x28, 0x35, 0x0a, 0x3e, 0x0a, 0x88, 0xdf, 0x75, 0x06, 0x44, 0xff, 0x5b, 0x05,
+	0x96, 0xf7, 0xc8, 0xb3, 0xab, 0xb0, 0xf8, 0x1a, 0x54, 0x85, 0xba, 0xa8, 0x08, 0x73, 0x4c, 0x12,
+	0x1c, 0xb6, 0x87, 0x52, 0xbc, 0x16, 0x56, 0x7f, 0xa1, 0xc0, 0x22, 0xe7, 0xf3, 0x12, 0x4b, 0x9c,
+	0x87, 0xa5, 0xaf, 0x01, 0x0c, 0x89, 0x3f, 0x70, 0x82, 0xc0, 0xf1, 0x5c, 0xce, 0x93, 0x04, 0x51,
+	0xef, 0x41, 0x3d, 0x70, 0x4e, 0x5d, 0x62, 0x9b, 0x7d, 0xa7, 0x47, 0xdc, 0x20, 0x62, 0x8a, 0x41,
+	0x77, 0x18, 0x10, 0x19, 0x19, 0x5a, 0xe3, 0x01, 0x71, 0x43, 0x64, 0xa4, 0x40, 0x51, 0xca, 0x1c,
+	0xd2, 0xb1, 0x91, 0x4a, 0xcf, 0x0b, 0x42, 0xc7, 0x3d, 0x35, 0x07, 0x24, 0x3c, 0xf3, 0xec, 0x66,
+	0x91, 0xf2, 0x5a, 0xe3, 0xd0, 0x5d, 0x0a, 0xd4, 0x37, 0x23, 0xcf, 0xd9, 0x71, 0x82, 0x50, 0x7d,
+	0x00, 0x7c, 0x2e, 0x0e, 0x09, 0x9a, 0xca, 0x7a, 0xfe, 0x7e, 0xe5, 0xc1, 0x6a, 0x96, 0xfd, 0x1b,
+	0x13, 0x34, 0xfd, 0x3b, 0xb0, 0xd2, 0xb6, 0x9d, 0x10, 0xa5, 0x85, 0xf3, 0x7b, 0x51, 0x63, 0x73,
+	0xc9, 0x33, 0x59, 0x64, 0x8b, 0x2e, 0x79, 0x86, 0xe4, 0xf4, 0x3f, 0x51, 0x40, 0xdd, 0xb4, 0xed,
+	0xf6, 0x60, 0xd8, 0xf7, 0xc6, 0x24, 0x22, 0xff, 0xab, 0x20, 0x84, 0x2a, 0x39, 0x79, 0x33, 0x8b,
+	0x57, 0xfa, 0x18, 0x19, 0x59, 0xbd, 0x03, 0x15, 0xc2, 0xc9, 0x4d, 0xdc, 0x0b, 0x04, 0xa8, 0x63,
+	0x5f, 0xa6, 0x22, 0xfd, 0x0b, 0x58, 0x89, 0xb1, 0xc4, 0xdd, 0x39, 0x41, 0x57, 0x49, 0xd1, 0xbd,
+	0x0b, 0xb5, 0x08, 0x41, 0x9a, 0x6b, 0x55, 0x00, 0xe9, 0x84, 0xff, 0x32, 0x07, 0x2b, 0x9d, 0x20,
+	0x18, 0x91, 0x03, 0xa6, 0x4c, 0x31, 0xe3, 0x17, 0x8e, 0x6a, 0xb7, 0x53, 0x51, 0x2d, 0x66, 0xb2,
+	0x77, 0xa1, 0xd6, 0xf3, 0xdc, 0xd0, 0xb7, 0x7a, 0xa1, 0x19, 0x8e, 0x87, 0x2c, 0xa0, 0x14, 0x8c,
+	0xaa, 0x00, 0x76, 0xc7, 0x43, 0x82, 0x48, 0xf6, 0xc8, 0xb7, 0x42, 0xc7, 0x73, 0x4d, 0xdb, 0x1a,
+	0x07, 0xd4, 0x26, 0x0b, 0x46, 0x55, 0x00, 0xb7, 0xad, 0x71, 0x80, 0x36, 0x17, 0x4d, 0xaf, 0xef,
+	0x0c, 0x9c, 0x90, 0x9a, 0xe5, 0xb2, 0x11, 0x4d, 0x7a, 0x07, 0x81, 0xe8, 0x23, 0xc7, 0xbe, 0xe5,
+	0xf6, 0xce, 0x38, 0x52, 0x91, 0x22, 0x55, 0x18, 0x8c, 0xa1, 0xdc, 0x06, 0x70, 0x42, 0x32, 0xe0,
+	0x08, 0x8b, 0x14, 0xa1, 0x8c, 0x10, 0xda, 0xad, 0x07, 0xb0, 0x1a, 0x97, 0x10, 0x57, 0xc0, 0x5b,
+	0xb0, 0xec, 0x20, 0xdc, 0x36, 0x53, 0x3e, 0xba, 0xc4, 0x3a, 0x5a, 0xd1, 0xb4, 0xdf, 0x83, 0x15,
+	0xe1, 0x3f, 0x36, 0x09, 0x7a, 0xbe, 0x33, 0xc4, 0x79, 0x70, 0x8d, 0xa8, 0xbc, 0x6b, 0x7b, 0xd2,
+	0xa3, 0xff, 0x44, 0x81, 0xd5, 0x27, 0xc4, 0x77, 0x4e, 0xc6, 0x09, 0xc5, 0x5c, 0xc5, 0x14, 0x63,
+	0xf1, 0x29, 0x77, 0x79, 0x7c, 0xca, 0x67, 0xc5, 0xa7, 0x8f, 0xe1, 0x46, 0x82, 0x31, 0x2e, 0x8f,
+	0x74, 0x28, 0x51, 0x32, 0x42, 0x89, 0xfe, 0xa7, 0x0a, 0xa8, 0xe8, 0xc2, 0x89, 0x58, 0x7c, 0x95,
+	0x79, 0x4d, 0x77, 0xe8, 0x8c, 0xc8, 0x94, 0xcf, 0x8a, 0x4c, 0xff, 0x59, 0x80, 0x85, 0x4e, 0x48,
+	0x06, 0xb8, 0x10, 0x52, 0x5b, 0xe0, 0xaa, 0x5c, 0x36, 0x8a, 0xd8, 0xec, 0xd8, 0xaa, 0x0a, 0x0b,
+	0x12, 0x7d, 0xfa, 0x1f, 0x61, 0x3d, 0xcf, 0x16, 0x72, 0xa2, 0xff, 0x11, 0x76, 0x74, 0xd4, 0xd9,
+	0xe6, 0x61, 0x74, 0x61, 0x74, 0xd4, 0xd9, 0x46, 0x57, 0x0d, 0x42, 0x2b, 0x1c, 0x05, 0xe6, 0x49,
+	0xdf, 0x3a, 0xa5, 0x76, 0x5a, 0x30, 0x80, 0x81, 0x1e, 0xf6, 0xad, 0x53, 0x44, 0xe8, 0x59, 0x21,
+	0x39, 0xf5, 0x7c, 0x6a, 0x44, 0xcc, 0x46, 0x41, 0x80, 0x3a, 0xb6, 0xba, 0x01, 0x2b, 0x23, 0xd7,
+	0x09, 0x4d, 0xef, 0xc4, 0x1c, 0x10, 0x2b, 0x18, 0xf9, 0x04, 0x45, 0x4f, 0x6d, 0xb5, 0x60, 0x2c,
+	0x63, 0xd7, 0xfe, 0xc9, 0xee, 0xa4, 0x43, 0xbd, 0x0f, 0x8d, 0x33, 0x2b, 0x30, 0x6d, 0xe2, 0x3b,
+	0x17, 0xc4, 0x36, 0x11, 0xa1, 0x59, 0x5a, 0x57, 0xee, 0x97, 0x8c, 0xfa, 0x99, 0x15, 0x6c, 0x33,
+	0xf0, 0x91, 0xcb, 0xfc, 0x43, 0x60, 0xd1, 0xf9, 0x95, 0xd9, 0x1a, 0xc2, 0x61, 0x42, 0x86, 0x02,
+	0xe5, 0xc4, 0xea, 0x85, 0x9e, 0xdf, 0x84, 0x75, 0xe5, 0xbe, 0x62, 0xd4, 0x38, 0xf4, 0x21, 0x05,
+	0xaa, 0x4d, 0x58, 0xbc, 0x20, 0x3e, 0x0d, 0x62, 0x15, 0xb6, 0x84, 0xf3, 0x26, 0xfa, 0xb3, 0x4f,
+	0x3c, 0xdf, 0x26, 0xbe, 0xd9, 0x27, 0x17, 0xa4, 0xdf, 0xac, 0xd2, 0xf1, 0x55, 0x0e, 0xdc, 0x41,
+	0x18, 0x7a, 0xe1, 0xc0, 0xb3, 0x49, 0xdf, 0x1c, 0x13, 0xcb, 0x6f, 0xd6, 0xd8, 0x12, 0x43, 0x21,
+	0x4f, 0x89, 0xe5, 0xa3, 0x88, 0x86, 0x96, 0x1f, 0x9a, 0xee, 0x68, 0x70, 0x4c, 0xfc, 0x66, 0x9d,
+	0x87, 0x49, 0xcb, 0x0f, 0xf7, 0x28, 0x04, 0x8d, 0xe0, 0xd8, 0xf2, 0x4d, 0xaa, 0x90, 0x25, 0x66,
+	0x04, 0xc7, 0x96, 0xdf, 0x42, 0x9d, 0xac, 0x43, 0x15, 0xa5, 0x11, 0x75, 0x37, 0xa8, 0x24, 0xe0,
+	0xcc, 0x0a, 0xb6, 0x38, 0x06, 0x8d, 0x5a, 0x41, 0x68, 0x0e, 0x7d, 0xa7, 0x47, 0x9a, 0xcb, 0x94,
+	0xbd, 0x32, 0x42, 0x0e, 0x10, 0x80, 0x13, 0x08, 0x48, 0xbf, 0x8f, 0x56, 0xc4, 0x30, 0x54, 0x36,
+	0x01, 0x0e, 0x64, 0x48, 0xef, 0x40, 0x01, 0xe5, 0x1c, 0x34, 0x57, 0xd6, 0xf3, 0xa9, 0x54, 0x0e,
+	0x8d, 0x6b, 0x03, 0x05, 0x6e, 0x30, 0x24, 0xf4, 0x45, 0xe4, 0x89, 0x8d, 0x58, 0xa5, 0x0c, 0x95,
+	0xce, 0xac, 0x00, 0x51, 0x02, 0xed, 0x53, 0x58, 0xc0, 0x3f, 0x34, 0x2d, 0x43, 0xb5, 0x47, 0x81,
+	0xa5, 0x88, 0xcd, 0x29, 0xd6, 0x78, 0x13, 0x8a, 0x5c, 0x3d, 0x79, 0xca, 0x1d, 0x6f, 0xe9, 0x7f,
+	0xaf, 0x40, 0xa9, 0xc5, 0x4d, 0x29, 0x69, 0x69, 0x4a, 0xca, 0xd2, 0xb2, 0x28, 0xdf, 0x82, 0xf2,
+	0xd0, 0xf2, 0xf9, 0xe2, 0x9f, 0xa7, 0x43, 0x4a, 0x0c, 0xd0, 0xb1, 0x5f, 0xcc, 0xe0, 0x25, 0x5b,
+	0x29, 0xc6, 0x6c, 0x45, 0xff, 0x43, 0x05, 0x8a, 0x5b, 0x34, 0x38, 0xe3, 0x63, 0x79, 0xe8, 0x8e,
+	0x38, 0x2d, 0x31, 0xc0, 0x74, 0x7f, 0xa4, 0xac, 0xe4, 0xa7, 0xb3, 0xb2, 0x30, 0x8b, 0x95, 0x42,
+	0x9c, 0x15, 0x13, 0x4a, 0x62, 0xd5, 0xbd, 0x7c, 0xb5, 0xcd, 0xe2, 0xe7, 0xb2, 0x95, 0xfd, 0x6f,
+	0x72, 0x00, 0x6c, 0xae, 0x34, 0xf6, 0xcc, 0x9c, 0xaf, 0x14, 0x98, 0x72, 0xb1, 0xc0, 0xa4, 0x41,
+	0xe9, 0xcb, 0x91, 0xe5, 0x86, 0x4e, 0x38, 0xe6, 0x8a, 0x8f, 0xda, 0x34, 0x24, 0x9f, 0x91, 0xfe,
+	0x89, 0x89, 0x21, 0x9c, 0xae, 0x38, 0x22, 0xbb, 0x43, 0xe8, 0x0e, 0x07, 0xa6, 0xfd, 0xb3, 0x90,
+	0xe1, 0x9f, 0x6f, 0xc1, 0x32, 0x1a, 0x6c, 0x1c, 0xb1, 0x48, 0x0d, 0x77, 0xe9, 0xcc, 0x0a, 0x0c,
+	0x19, 0x37, 0xe5, 0x2f, 0x8b, 0x19, 0xfe, 0xc2, 0x09, 0xc6, 0x11, 0x4b, 0x11, 0xc1, 0x43, 0x09,
+	0x57, 0xdf, 0x83, 0x3a, 0x13, 0x54, 0x64, 0xc8, 0x33, 0x85, 0x95, 0xb0, 0xf2, 0x5c, 0xd2, 0xca,
+	0xf5, 0x7f, 0xab, 0x43, 0xad, 0x4d, 0x65, 0x24, 0xd6, 0x9f, 0x5f, 0x87, 0x02, 0x0a, 0x54, 0x24,
+	0xa2, 0x6f, 0xc4, 0xbc, 0x37, 0x86, 0xba, 0xc1, 0x7f, 0x51, 0x67, 0x06, 0x1b, 0xa4, 0x7e, 0x02,
+	0x82, 0x3a, 0xe6, 0xb2, 0x39, 0x4a, 0xe2, 0xad, 0xcb, 0x49, 0x88, 0xd9, 0x18, 0xd2, 0x68, 0x75,
+	0x1b, 0xf8, 0x44, 0x48, 0xd0, 0xcc, 0x53, 0x4a, 0xf7, 0x2f, 0xa7, 0xc4, 0xa4, 0x63, 0x44, 0x23,
+	0xd5, 0xc7, 0x50, 0x16, 0xd6, 0x89, 0xf9, 0xd3, 0x9c, 0x0c, 0x45, 0x59, 0xe6, 0x64, 0xb0, 0xba,
+	0x07, 0x3c, 0x5b, 0xea, 0x50, 0xf9, 0x14, 0x28, 0xad, 0x77, 0xe6, 0x65, 0x89, 0x4a, 0x49, 0x26,
+	0xa0, 0x7e, 0x1b, 0x1a, 0xc7, 0xb2, 0x2e, 0x51, 0x62, 0x45, 0x4a, 0xf4, 0xfd, 0x79, 0x89, 0x46,
+	0x72, 0x4b, 0x51, 0x4a, 0xe6, 0x11, 0xab, 0xcf, 0x93, 0x47, 0xdc, 0x87, 0x86, 0xd7, 0xb7, 0xcd,
+	0xc8, 0x74, 0x70, 0x37, 0x7a, 0x83, 0x7a, 0x7a, 0xdd, 0xeb, 0xdb, 0xd1, 0x43, 0xc9, 0x05, 0xae,
+	0x28, 0x88, 0x49, 0x3d, 0x12, 0xb1, 0x6e, 0x52, 0x2c, 0xf0, 0xfa, 0x36, 0x9d, 0x2f, 0xb9, 0x50,
+	0x5f, 0x07, 0x1c, 0x63, 0x72, 0x1b, 0x45, 0x9c, 0x35, 0x96, 0xc4, 0x7a, 0x7d, 0x9b, 0x2b, 0x8b,
+	0x5c, 0xa8, 0xef, 0xc2, 0x8a, 0x84, 0x15, 0x91, 0x6b, 0x52, 0xd4, 0x46, 0x84, 0x9a, 0x20, 0x3a,
+	0x20, 0xb8, 0xe2, 0x51, 0xcc, 0x57, 0x23, 0xa2, 0xbb, 0x14, 0x88, 0x58, 0x1f, 0xc2, 0x9a, 0x44,
+	0x34, 0x36, 0x1b, 0x8d, 0xa2, 0xaf, 0x46, 0x84, 0xe5, 0x39, 0xd1, 0x28, 0x7f, 0x4a, 0xcc, 0xc0,
+	0xf9, 0x1e, 0x69, 0xde, 0xa2, 0x88, 0x25, 0x04, 0x1c, 0x3a, 0xdf, 0x23, 0xda, 0x1f, 0x2b, 0x50,
+	0x91, 0xec, 0x5e, 0xbd, 0x07, 0x0b, 0xc8, 0x2d, 0xcf, 0xd3, 0x96, 0x53, 0x6b, 0x9d, 0x41, 0xbb,
+	0xd5, 0x8f, 0xa0, 0x68, 0xf5, 0xa2, 0x54, 0xb7, 0xfe, 0xe0, 0xb5, 0x19, 0x1a, 0xde, 0xa4, 0x88,
+	0x06, 0x1f, 0x80, 0x4b, 0xf2, 0x89, 0x43, 0x70, 0xb6, 0x56, 0x70, 0x4e, 0x1d, 0xa1, 0x6c, 0x94,
+	0x29, 0x64, 0xd7, 0x0a, 0xce, 0xb5, 0xff, 0x50, 0x60, 0x29, 0xe1, 0x45, 0xea, 0x07, 0x50, 0x12,
+	0xb3, 0xe5, 0x8c, 0xdd, 0x88, 0x2b, 0x5e, 0xcc, 0x36, 0x42, 0xbb, 0x0a, 0x83, 0x3b, 0x50, 0xb3,
+	0x49, 0x9f, 0x84, 0xc4, 0x1c, 0x7a, 0x7d, 0xa7, 0xc7, 0xa2, 0x6f, 0xfd, 0xc1, 0x9b, 0x33, 0x28,
+	0x6c, 0x53, 0xfc, 0x03, 0x8a, 0x6e, 0x54, 0x6d, 0xa9, 0xa5, 0x3d, 0x83, 0x5a, 0xcc, 0xc4, 0xd5,
+	0xb7, 0xa1, 0xc8, 0x34, 0xc8, 0xa7, 0xb2, 0x12, 0xa3, 0xcb, 0x4d, 0x88, 0xa3, 0x5c, 0x61, 0x1a,
+	0xda, 0xef, 0xc2, 0x52, 0xc2, 0xf9, 0x25, 0x6a, 0xca, 0xf3, 0x0a, 0xe5, 0x03, 0x28, 0x89, 0xc8,
+	0xd1, 0xcc, 0x65, 0xa8, 0x20, 0x0a, 0x30, 0x11, 0x9a, 0xf6, 0xd7, 0x0a, 0x2c, 0xa7, 0x42, 0xc6,
+	0x55, 0x78, 0xf8, 0x26, 0xc0, 0x24, 0xde, 0x34, 0x73, 0x19, 0x7b, 0x57, 0xc9, 0xab, 0x24, 0xd4,
+	0xcb, 0x4c, 0xee, 0x27, 0x0a, 0xdc, 0xc8, 0x0c, 0x43, 0x57, 0x61, 0xb6, 0x05, 0xf5, 0x58, 0x0c,
+	0x1b, 0x73, 0x86, 0x6f, 0x65, 0x30, 0x1c, 0xd9, 0x6f, 0x62, 0x88, 0xfe, 0x0e, 0x14, 0x19, 0x59,
+	0x15, 0xa0, 0xd8, 0x32, 0xda, 0x9b, 0xdd, 0x76, 0xe3, 0x15, 0xfc, 0x7f, 0x74, 0xb0, 0x8d, 0xff,
+	0x15, 0xfc, 0xbf, 0xdd, 0xde, 0x69, 0x77, 0xdb, 0x8d, 0x9c, 0xbe, 0x0f, 0x55, 0xd9, 0x10, 0x55,
+	0x15, 0xea, 0xbb, 0xfb, 0x4f, 0xda, 0x66, 0x77, 0xdf, 0x3c, 0xd8, 0x34, 0xda, 0x7b, 0xdd, 0xc6,
+	0x2b, 0x08, 0x63, 0xf8, 0xe6, 0xe1, 0xd1, 0x56, 0xd7, 0x68, 0x23, 0x8d, 0x35, 0x58, 0x31, 0xda,
+	0x0f, 0x8f, 0x0e, 0xdb, 0x66, 0xe7, 0xa1, 0xb9, 0xb7, 0xdf, 0x35, 0xdb, 0xbb, 0x07, 0xdd, 0xa7,
+	0x8d, 0x9c, 0xfe, 0xd5, 0x1a, 0xd4, 0xc5, 0x24, 0xf9, 0x66, 0x70, 0x1f, 0x1a, 0xa3, 0xa1, 0x6d,
+	0x85, 0x84, 0x07, 0x49, 0xc7, 0x16, 0x2b, 0xeb, 0xbd, 0x4c, 0xd9, 0xb0, 0x61, 0x1b, 0x47, 0x6c,
+	0x4c, 0xc7, 0x36, 0xea, 0x7c, 0x78, 0x87, 0x66, 0x39, 0x81, 0x7a, 0x08, 0xaa, 0x20, 0x18, 0xad,
+	0xfb, 0x62, 0xa5, 0x9d, 0x93, 0xa4, 0xe0, 0x88, 0x6b, 0xdf, 0x0e, 0xd4, 0xcf, 0x61, 0x55, 0x10,
+	0x95, 0xf2, 0x05, 0xb1, 0xec, 0xce, 0x49, 0x56, 0xf0, 0xd5, 0x8a, 0xd2, 0x0b, 0x5c, 0x85, 0x78,
+	0x36, 0xc1, 0x56, 0xde, 0xd7, 0x67, 0x51, 0xc2, 0xda, 0xa9, 0x9c, 0x4b, 0x3c, 0x8e, 0xe5, 0x12,
+	0x85, 0x19, 0x19, 0x80, 0x44, 0x20, 0x33, 0x93, 0xd8, 0x92, 0x32, 0x89, 0xe2, 0x8c, 0xb4, 0x46,
+	0xa2, 0x93, 0xca, 0x23, 0x1e, 0xca, 0x79, 0xc4, 0xe2, 0x7c, 0xcc, 0x64, 0x65, 0x11, 0xbf, 0x95,
+	0xb1, 0xea, 0x97, 0x28, 0xb9, 0x8d, 0xf9, 0x78, 0x9a, 0xb1, 0xe6, 0xef, 0x43, 0x9d, 0x1a, 0x59,
+	0xcf, 0x73, 0x4f, 0xfa, 0x4e, 0x2f, 0x0c, 0x9a, 0xe5, 0xcb, 0x19, 0x45, 0x91, 0xb7, 0xf8, 0x00,
+	0xa3, 0xe6, 0x48, 0xad, 0x40, 0xfd, 0x02, 0xd4, 0xc8, 0x1e, 0x26, 0x44, 0x61, 0x46, 0xe6, 0xc3,
+	0x89, 0x0a, 0x26, 0x23, 0xc2, 0xcb, 0xbd, 0x04, 0x24, 0x50, 0x8f, 0x84, 0x24, 0x24, 0xd2, 0x95,
+	0x19, 0x09, 0x1a, 0x27, 0xcd, 0xa5, 0x20, 0x08, 0x2f, 0x1d, 0xc7, 0xda, 0x54, 0x08, 0x3e, 0xf9,
+	0x6d, 0xd2, 0x13, 0x2e, 0x17, 0x34, 0xab, 0x97, 0x0b, 0xc1, 0xe0, 0x23, 0xa8, 0xfd, 0xd5, 0x7c,
+	0xa9, 0x15, 0xa8, 0xdf, 0x81, 0x95, 0x88, 0xa0, 0x64, 0x90, 0xb5, 0xcb, 0xa5, 0x20, 0xa8, 0x46,
+	0x2a, 0x53, 0xfd, 0x38, 0x04, 0x95, 0x76, 0x1f, 0x1a, 0x58, 0xb4, 0x89, 0xa5, 0x27, 0xab, 0x2c,
+	0xd9, 0x72, 0xc9, 0xb3, 0x44, 0xb2, 0x85, 0x98, 0x51, 0x76, 0xc4, 0x52, 0x32, 0x70, 0xc9, 0x33,
+	0x29, 0x2f, 0x42, 0x0c, 0x29, 0xd9, 0x62, 0x09, 0x19, 0x8e, 0x8b, 0x25, 0x5b, 0x12, 0x56, 0x44,
+	0x8e, 0xe5, 0x65, 0x8d, 0x08, 0x35, 0x41, 0x54, 0x4a, 0xb6, 0x9a, 0x11, 0xd1, 0x58, 0xb2, 0x25,
+	0x11, 0x8d, 0xcd, 0x86, 0xe5, 0x66, 0xab, 0x11, 0x61, 0x79, 0x4e, 0xaf, 0x02, 0xee, 0xf6, 0xcd,
+	0x81, 0xe7, 0x13, 0x9a, 0x94, 0x95, 0x8c, 0xc5, 0x33, 0x2b, 0xd8, 0xf5, 0x7c, 0xa2, 0xb9, 0x50,
+	0x12, 0x21, 0x61, 0xde, 0x34, 0xeb, 0xd7, 0xa0, 0x80, 0x3b, 0x5a, 0xc2, 0x57, 0xff, 0x7b, 0x97,
+	0x79, 0xd4, 0x21, 0x22, 0x1b, 0x6c, 0x8c, 0xf6, 0x3b, 0x50, 0x95, 0x23, 0xc8, 0x8b, 0x64, 0x51,
+	0x57, 0x7a, 0xfe, 0x05, 0xc0, 0xc4, 0xcb, 0x9f, 0x2f, 0xed, 0xb9, 0x8e, 0x79, 0x47, 0x59, 0x8f,
+	0x9c, 0xba, 0x28, 0x73, 0xa5, 0x2e, 0x57, 0x7b, 0xfe, 0x8f, 0xf9, 0xb9, 0x59, 0x22, 0x97, 0x48,
+	0x27, 0x04, 0xca, 0x73, 0x27, 0x04, 0x57, 0x63, 0xec, 0xf7, 0x15, 0xa8, 0xca, 0xd1, 0x71, 0x5e,
+	0x2b, 0xdc, 0x03, 0xf0, 0x49, 0xe0, 0xf5, 0x47, 0x52, 0x22, 0x3a, 0x33, 0xb8, 0x47, 0xc1, 0x2c,
+	0x1a, 0x65, 0x48, 0x14, 0xb4, 0x3f, 0x57, 0xa0, 0x91, 0x0c, 0xa8, 0x2f, 0x62, 0x9d, 0xd7, 0xcd,
+	0xd7, 0x8f, 0x94, 0xa8, 0x1a, 0x21, 0xb8, 0x7a, 0x2e, 0xab, 0xbd, 0x6e, 0x7e, 0xbe, 0x0f, 0x55,
+	0x39, 0x8e, 0xcf, 0xac, 0x61, 0xd3, 0xfa, 0x58, 0x4e, 0xaa, 0x8f, 0xfd, 0x06, 0x14, 0x7d, 0x62,
+	0x05, 0xbc, 0x3e, 0x55, 0x9f, 0x67, 0xb9, 0x30, 0x28, 0xbe, 0xc1, 0xc7, 0x69, 0x5f, 0x29, 0xd0,
+	0x48, 0x46, 0xfc, 0xb9, 0xea, 0x8c, 0x2f, 0x81, 0x97, 0x8f, 0xa0, 0x1c, 0x25, 0x66, 0xea, 0x0d,
+	0x28, 0xd2, 0x4d, 0xba, 0x78, 0x7c, 0xc1, 0xeb, 0x73, 0x30, 0x5d, 0x4e, 0xc4, 0xe9, 0x54, 0x01,
+	0x17, 0x12, 0x5b, 0xbf, 0x0b, 0xe5, 0xc8, 0x13, 0xd4, 0x32, 0x14, 0xda, 0xbf, 0xd9, 0x39, 0xc4,
+	0x4c, 0xb8, 0x02, 0x8b, 0x46, 0x1b, 0xf3, 0xe3, 0xed, 0x86, 0xa2, 0x7f, 0x03, 0xd4, 0xb4, 0x32,
+	0xd4, 0x2a, 0x94, 0x8c, 0xf6, 0x27, 0xed, 0x56, 0xb7, 0xbd, 0xdd, 0x78, 0x45, 0x5d, 0x82, 0xca,
+	0xfe, 0x93, 0xb6, 0xf1, 0xb9, 0xd1, 0xe9, 0x76, 0xdb, 0x7b, 0x0d, 0x45, 0x3f, 0x87, 0xaa, 0xcc,
+	0xac, 0x7a, 0x13, 0xd4, 0xed, 0xa3, 0x83, 0x9d, 0x4e, 0x6b, 0xb3, 0xdb, 0x36, 0xb7, 0x36, 0x0d,
+	0xb3, 0xb5, 0xbf, 0x8d, 0xf9, 0xfa, 0x1a, 0xac, 0x4c, 0xe0, 0x9d, 0x6e, 0x7b, 0x97, 0x75, 0x28,
+	0x98, 0x8c, 0x23, 0xec, 0xd1, 0xbe, 0xf1, 0xd4, 0x6c, 0x3d, 0x6d, 0xed, 0xb4, 0x1b, 0x39, 0x24,
+	0x12, 0xc1, 0x26, 0xb9, 0x78, 0x5e, 0xff, 0xf7, 0x02, 0x54, 0xba, 0xbe, 0xe5, 0x06, 0x7c, 0x7f,
+	0xb1, 0x07, 0x8d, 0x70, 0xd2, 0xec, 0x48, 0x25, 0x2e, 0x3d, 0x26, 0x5d, 0x69, 0x0c, 0xfb, 0x4f,
+	0x1d, 0x3b, 0x35, 0x16, 0x17, 0x2e, 0x0a, 0x13, 0xf2, 0x53, 0x8d, 0x45, 0xda, 0xee, 0xd8, 0xf1,
+	0x92, 0x5c, 0x3e, 0x51, 0x92, 0xc3, 0xb3, 0x27, 0x2b, 0x24, 0x93, 0x83, 0xef, 0xbc, 0x51, 0x42,
+	0x00, 0x9e, 0x29, 0xe1, 0xc6, 0x8b, 0x11, 0x75, 0xbd, 0x90, 0x88, 0xe3, 0x65, 0x0a, 0xd9, 0xf3,
+	0xc2, 0x49, 0x5d, 0xb7, 0x28, 0xd9, 0x0a, 0x1e, 0x57, 0x39, 0xee, 0x39, 0xb1, 0xcd, 0x88, 0x9d,
+	0x45, 0x4a, 0xb5, 0xc6, 0xc0, 0x5d, 0xce, 0x14, 0x3a, 0x43, 0x60, 0x5e, 0x78, 0x8e, 0xcd, 0x6b,
+	0x8b, 0x45, 0x27, 0x78, 0xe2, 0x39, 0xb6, 0xfa, 0x36, 0xa8, 0x08, 0xc5, 0xfd, 0xc4, 0x78, 0x42,
+	0xa3, 0x4c, 0x69, 0x2c, 0xb1, 0x9e, 0xad, 0x31, 0xa7, 0xa2, 0xfd, 0x4f, 0x0e, 0xca, 0x91, 0x54,
+	0x92, 0xb5, 0xd8, 0x42, 0xe4, 0x60, 0xd1, 0x3c, 0xe8, 0xd1, 0x26, 0x3f, 0xaf, 0xa7, 0x10, 0x7a,
+	0xae, 0x39, 0xab, 0x54, 0xfb, 0x06, 0x2c, 0x79, 0xe1, 0x19, 0xf1, 0x27, 0xbb, 0x1b, 0x5e, 0xab,
+	0xae, 0x51, 0xf0, 0x96, 0x24, 0x47, 0xfa, 0x6c, 0x49, 0x52, 0x25, 0x04, 0x50, 0x41, 0xf1, 0x43,
+	0x05, 0x56, 0x4a, 0x2d, 0x46, 0x87, 0x0a, 0xac, 0xde, 0x7a, 0x1b, 0x80, 0x1e, 0x26, 0xc8, 0x15,
+	0xd9, 0x32, 0x42, 0x58, 0xb7, 0x06, 0x25, 0xdb, 0x09, 0x7a, 0xde, 0xc8, 0x65, 0x47, 0x45, 0x8a,
+	0x11, 0xb5, 0x71, 0x68, 0xdf, 0x71, 0x89, 0x19, 0x7a, 0xa1, 0xd5, 0xa7, 0x32, 0x52, 0x8c, 0x32,
+	0x42, 0xba, 0x08, 0x90, 0x8f, 0x29, 0x80, 0x05, 0x1c, 0x7e, 0x4c, 0x71, 0x17, 0x6a, 0xb4, 0x23,
+	0x9a, 0x75, 0x85, 0xd5, 0x81, 0x11, 0xf8, 0x99, 0x98, 0xf9, 0x2d, 0xa0, 0x5c, 0xb0, 0xe3, 0xa7,
+	0x2a, 0x9b, 0x11, 0x02, 0xe8, 0xf9, 0xf4, 0xff, 0x2a, 0xa0, 0x4a, 0xa6, 0x39, 0xa9, 0xd6, 0x56,
+	0x25, 0xcb, 0x14, 0x16, 0xdd, 0x9c, 0x66, 0xd1, 0x46, 0x0c, 0x3b, 0x59, 0x23, 0xcc, 0x3d, 0x4f,
+	0x8d, 0x70, 0x4a, 0xc5, 0x2e, 0x4f, 0xed, 0x26, 0x5d, 0xb1, 0xd3, 0xa1, 0x86, 0xe8, 0xcc, 0x2a,
+	0x10, 0x91, 0x99, 0x7e, 0xc5, 0xeb, 0x33, 0x0b, 0x4d, 0x15, 0xde, 0x0a, 0xf1, 0xc2, 0x9b, 0xfe,
+	0x47, 0x45, 0x58, 0x89, 0x09, 0x80, 0x6f, 0xb0, 0xbb, 0x99, 0x12, 0x78, 0x7f, 0xaa, 0x04, 0xe4,
+	0xd5, 0x7e, 0xba, 0x64, 0x3e, 0x8b, 0xd7, 0x7a, 0xd9, 0xf6, 0xfa, 0xbd, 0xb9, 0x88, 0x4e, 0x2b,
+	0xf7, 0x9e, 0xc2, 0x9a, 0xd8, 0x63, 0x4b, 0x8f, 0x92, 0xb6, 0xd9, 0x97, 0x93, 0xe7, 0x21, 0x9d,
+	0x3b, 0xa3, 0x71, 0x63, 0x24, 0xb5, 0x79, 0x6c, 0xb2, 0x83, 0x69, 0xe9, 0x3d, 0x13, 0x78, 0x3a,
+	0xbd, 0xd7, 0xa1, 0x86, 0xe8, 0x13, 0xcd, 0x14, 0x98, 0x66, 0x5c, 0xf2, 0x2c, 0xd2, 0x8c, 0x9c,
+	0xa5, 0x17, 0x63, 0x59, 0xba, 0xba, 0x05, 0xcb, 0x43, 0xe2, 0xda, 0x78, 0x72, 0x41, 0x49, 0x9c,
+	0x10, 0x5f, 0xec, 0x8f, 0x6f, 0xa4, 0x27, 0x74, 0x42, 0x7c, 0xa3, 0xc1, 0xf1, 0x05, 0x20, 0xd0,
+	0x4e, 0x60, 0x29, 0xa1, 0x0e, 0x34, 0x4d, 0x49, 0x4a, 0x99, 0xc7, 0xe0, 0xb2, 0x84, 0x64, 0xe4,
+	0xa9, 0x97, 0xb8, 0xb4, 0x1f, 0x2a, 0x50, 0x8f, 0xab, 0x28, 0x51, 0x23, 0x53, 0xe6, 0xaf, 0x91,
+	0x5d, 0x29, 0xb3, 0xfc, 0x18, 0xea, 0x71, 0x5d, 0x26, 0xd6, 0x68, 0x35, 0x7b, 0x8d, 0xce, 0x8b,
+	0x35, 0xfa, 0x2f, 0x72, 0xf0, 0xaa, 0x34, 0xfd, 0xc7, 0x4e, 0x10, 0xd2, 0xfd, 0xd4, 0xb5, 0x5c,
+	0x8d, 0x98, 0x04, 0x5e, 0x26, 0xbd, 0xd8, 0xd9, 0x9b, 0x10, 0x6c, 0x5e, 0x16, 0x6c, 0x22, 0xde,
+	0x2f, 0x24, 0xe3, 0xfd, 0x6d, 0xc0, 0x83, 0x46, 0x3f, 0x34, 0x71, 0xca, 0xdc, 0xbe, 0xca, 0x14,
+	0xb2, 0x6d, 0x85, 0x04, 0xad, 0x8b, 0xb8, 0x36, 0xeb, 0x2c, 0xd2, 0xce, 0x45, 0xe2, 0xda, 0xb4,
+	0xeb, 0x26, 0x14, 0x7b, 0x23, 0x3f, 0xf0, 0x7c, 0xbe, 0xa8, 0xf1, 0x56, 0x3c, 0x54, 0x94, 0x12,
+	0xa1, 0xe2, 0x1f, 0x14, 0xd0, 0xb2, 0xa4, 0xf3, 0x52, 0x23, 0xc6, 0x1d, 0xa8, 0xb8, 0xe4, 0xbb,
+	0xa1, 0xc9, 0xd9, 0x65, 0xea, 0x02, 0x04, 0xb5, 0x18, 0xcb, 0xb2, 0x0f, 0xe5, 0x63, 0x3e, 0xa4,
+	0xff, 0x77, 0x0e, 0xd4, 0x43, 0xab, 0x4f, 0x02, 0x83, 0x0c, 0x3d, 0xff, 0x5a, 0xae, 0xb8, 0x6c,
+	0x41, 0xe9, 0xd4, 0xf7, 0x46, 0x43, 0xf3, 0x78, 0xdc, 0xcc, 0x65, 0xd4, 0xe3, 0xd3, 0x8f, 0xdb,
+	0x78, 0x84, 0xf8, 0x5b, 0x63, 0x63, 0xf1, 0x94, 0xfd, 0x49, 0xe7, 0x31, 0xb2, 0x2d, 0xc4, 0x75,
+	0xba, 0x30, 0x4b, 0xa7, 0x85, 0xb8, 0x4e, 0x71, 0xfd, 0x0d, 0x7b, 0xa6, 0x77, 0x72, 0x12, 0x90,
+	0x90, 0x2b, 0xbc, 0x3c, 0x0a, 0x7b, 0xfb, 0x14, 0xa0, 0x1f, 0xc3, 0x22, 0xe7, 0x04, 0x53, 0xce,
+	0xa3, 0xbd, 0x4f, 0xf7, 0xf6, 0x3f, 0xdf, 0x6b, 0xbc, 0xa2, 0x96, 0x60, 0x01, 0x73, 0xc1, 0x86,
+	0x82, 0x69, 0xa6, 0x48, 0xf9, 0x1a, 0x39, 0xac, 0xe8, 0x6e, 0x19, 0x9b, 0x7b, 0xad, 0xc7, 0x8d,
+	0x3c, 0xe2, 0x1c, 0x1d, 0xb6, 0x8d, 0xc6, 0x82, 0xba, 0x08, 0xf9, 0xed, 0xcd, 0xa7, 0x8d, 0x02,
+	0x82, 0x3e, 0x6f, 0xb7, 0x3f, 0x6d, 0x14, 0x31, 0x97, 0xdd, 0xdd, 0xdf, 0xeb, 0x3e, 0x6e, 0x2c,
+	0xea, 0x3f, 0x55, 0x60, 0x25, 0x26, 0x01, 0x6e, 0x1a, 0x1f, 0x43, 0x91, 0x2e, 0xed, 0xd9, 0xa7,
+	0x9f, 0x19, 0x23, 0x36, 0xe8, 0xc2, 0x6f, 0xf0, 0x51, 0xda, 0x2e, 0x14, 0x28, 0x40, 0x6d, 0x40,
+	0xfe, 0x9c, 0xb0, 0x8d, 0x59, 0xde, 0xc0, 0xbf, 0xb1, 0x9c, 0x27, 0x97, 0xc8, 0x79, 0x6e, 0x42,
+	0xd1, 0x1a, 0xd0, 0x84, 0x83, 0xdf, 0x58, 0x60, 0x2d, 0xfd, 0x0f, 0x14, 0xb8, 0x89, 0x39, 0x5a,
+	0xc6, 0xc2, 0x7f, 0xc5, 0x6b, 0x42, 0xb1, 0xd4, 0x35, 0x3f, 0x49, 0x5d, 0x6f, 0xc6, 0x76, 0x1e,
+	0x65, 0xb1, 0x9f, 0xd0, 0x2f, 0x60, 0x2d, 0xc5, 0x08, 0x97, 0xd9, 0x17, 0xd0, 0xc0, 0x2c, 0xd1,
+	0x4c, 0x87, 0xeb, 0xe7, 0x77, 0x29, 0x9a, 0x6f, 0x4a, 0x00, 0xfd, 0xbf, 0x16, 0xa0, 0x24, 0xd6,
+	0x09, 0x74, 0x31, 0xb1, 0xc4, 0x48, 0x77, 0x0f, 0x04, 0xa8, 0x63, 0x63, 0xa5, 0xea, 0xc4, 0xf7,
+	0x06, 0x66, 0x32, 0x82, 0x55, 0x11, 0x1a, 0x65, 0x8e, 0xeb, 0x50, 0x0d, 0x3d, 0x33, 0x69, 0xd9,
+	0x10, 0x7a, 0x11, 0xc6, 0x2f, 0x43, 0x91, 0x5d, 0x8c, 0xa0, 0x76, 0x5d, 0x7f, 0xf0, 0x4b, 0x99,
+	0x0b, 0xd9, 0xc6, 0x21, 0xc5, 0x31, 0x38, 0x2e, 0xbd, 0xf9, 0x30, 0x49, 0x46, 0xe9, 0x7f, 0x8c,
+	0x98, 0x01, 0x71, 0x43, 0xf4, 0x42, 0x76, 0xbf, 0xa3, 0x88, 0x4d, 0xe6, 0x5b, 0xb4, 0x83, 0x3a,
+	0x08, 0x8b, 0x6d, 0x25, 0x04, 0x50, 0x0f, 0xd1, 0xf1, 0xda, 0x80, 0x2b, 0x65, 0xf4, 0x25, 0xb6,
+	0x24, 0x23, 0x50, 0x2c, 0x18, 0x77, 0xa0, 0xe2, 0x93, 0x1e, 0xa1, 0xb7, 0x91, 0x8e, 0xc7, 0x34,
+	0x17, 0x2d, 0x18, 0x20, 0x40, 0x5b, 0x63, 0x76, 0x99, 0x81, 0x23, 0xd0, 0xa7, 0x00, 0x25, 0x52,
+	0x15, 0x40, 0xfa, 0xa4, 0xfb, 0xd0, 0xe0, 0xed, 0xc9, 0xc3, 0x2a, 0x14, 0xaf, 0xce, 0xe1, 0xe2,
+	0x79, 0xef, 0x43, 0x41, 0xae, 0xa6, 0x6a, 0xd9, 0x22, 0x91, 0xea, 0xf7, 0xda, 0x97, 0xd9, 0x57,
+	0xc9, 0x26, 0xbb, 0x04, 0x7a, 0x3b, 0xc2, 0x95, 0xb2, 0xe2, 0x9c, 0xb8, 0x1d, 0xe1, 0x4e, 0xb2,
+	0xe2, 0xb7, 0x61, 0x39, 0x9a, 0x46, 0x62, 0xd3, 0x20, 0x58, 0xb7, 0x05, 0xb2, 0xfe, 0x2e, 0x14,
+	0x99, 0x52, 0x52, 0xa1, 0xe3, 0x10, 0x8f, 0x73, 0x14, 0xb6, 0x43, 0x6d, 0xb5, 0x3b, 0xb8, 0x8b,
+	0xcd, 0xe9, 0xff, 0x92, 0x83, 0x95, 0x43, 0x21, 0x53, 0x4c, 0x4d, 0xae, 0xc1, 0xb9, 0xb2, 0xf6,
+	0xf3, 0x69, 0xbb, 0xcc, 0xcf, 0x61, 0x97, 0x0b, 0x29, 0xbb, 0xcc, 0xb2, 0xb0, 0x6f, 0x09, 0xbd,
+	0xb0, 0x83, 0x8d, 0xaf, 0xc7, 0x23, 0x56, 0x7a, 0x72, 0x08, 0x93, 0xaf, 0x6c, 0x68, 0xdf, 0x82,
+	0x92, 0x00, 0x4d, 0x57, 0xd5, 0x8c, 0xe8, 0xa5, 0xff, 0x5e, 0x0e, 0x6e, 0x1a, 0x92, 0xb1, 0x5c,
+	0x93, 0x20, 0x13, 0xde, 0x9e, 0x4b, 0x79, 0xfb, 0x43, 0x31, 0xf3, 0x7c, 0xc6, 0x02, 0x9e, 0xcd,
+	0x90, 0x00, 0xdb, 0xb2, 0x00, 0x5a, 0x50, 0x95, 0xc1, 0x2f, 0x26, 0x84, 0x3d, 0x68, 0x1c, 0x8e,
+	0x8e, 0xf1, 0xde, 0xea, 0xf1, 0x75, 0xdc, 0x96, 0xd6, 0x7d, 0x50, 0x5b, 0x67, 0x96, 0x7b, 0x4a,
+	0xf6, 0xbc, 0xd0, 0x39, 0x71, 0xf8, 0x05, 0xa5, 0xaf, 0x43, 0x83, 0xe0, 0xf3, 0x1c, 0x12, 0x98,
+	0x3d, 0xda, 0xcd, 0x78, 0x2c, 0x19, 0x4b, 0x02, 0xce, 0x46, 0xd9, 0xea, 0x07, 0xb0, 0x2a, 0xe7,
+	0x27, 0x11, 0x7a, 0x8e, 0xa2, 0xaf, 0xc8, 0x7d, 0x7c, 0x08, 0xde, 0x48, 0x5d, 0xe5, 0xd7, 0x97,
+	0xae, 0x2f, 0x11, 0x99, 0x99, 0x50, 0x4a, 0x17, 0xf7, 0x9f, 0x39, 0xb6, 0xc8, 0x8b, 0xc4, 0xf8,
+	0xcf, 0x1d, 0x9b, 0xe8, 0x7f, 0x96, 0x83, 0x1b, 0x09, 0xa6, 0xf8, 0xc2, 0xb3, 0x1d, 0xbf, 0xa9,
+	0xb4, 0x91, 0xd0, 0x7f, 0xc6, 0x10, 0x01, 0x95, 0xb5, 0xff, 0x73, 0x7a, 0xa1, 0x23, 0x02, 0xa7,
+	0xef, 0x53, 0x15, 0xa6, 0x5f, 0x3e, 0x2b, 0xcc, 0x75, 0xf9, 0x2c, 0x75, 0xab, 0x6c, 0x21, 0xe3,
+	0x56, 0xd9, 0xbb, 0xa0, 0x06, 0xa3, 0xd3, 0x53, 0x12, 0x84, 0x72, 0x9c, 0x63, 0xf7, 0xcf, 0x96,
+	0xa3, 0x9e, 0x28, 0xd0, 0x9d, 0xa3, 0x50, 0x7a, 0x9e, 0xdb, 0x73, 0xfa, 0xe4, 0x30, 0xf4, 0x7a,
+	0xe7, 0xd7, 0xa1, 0x2a, 0xba, 0xf8, 0x0f, 0x2d, 0xc7, 0xe7, 0x46, 0xc2, 0x5b, 0xfa, 0xcf, 0x99,
+	0x83, 0xc7, 0x9e, 0xc6, 0x75, 0xf0, 0x10, 0x8a, 0xb6, 0xef, 0x9c, 0x84, 0xd3, 0x94, 0x90, 0x35,
+	0x68, 0x83, 0xb6, 0xb6, 0x71, 0x98, 0xc1, 0x47, 0xa3, 0xfc, 0xd8, 0xc3, 0x22, 0x0b, 0x8d, 0xda,
+	0xda, 0x3f, 0x2a, 0x00, 0x93, 0x21, 0x2f, 0x41, 0x41, 0x6f, 0xc2, 0x52, 0x9f, 0xd8, 0xa7, 0xc4,
+	0x9f, 0x08, 0x9e, 0xa9, 0xa8, 0xce, 0xc0, 0xf2, 0x5a, 0x44, 0xbe, 0x3b, 0x64, 0x27, 0x81, 0x09,
+	0x1d, 0x35, 0x44, 0x47, 0xa4, 0xa2, 0x1f, 0x28, 0xd0, 0xa0, 0x6c, 0x6f, 0x06, 0xfb, 0x27, 0x2f,
+	0xdd, 0x93, 0x56, 0xa0, 0x60, 0x05, 0xa6, 0x77, 0xc2, 0x0b, 0x30, 0x0b, 0x56, 0xb0, 0x7f, 0xa2,
+	0xff, 0xab, 0x02, 0xcb, 0x12, 0x0b, 0x5c, 0x67, 0x3b, 0x00, 0x9c, 0xf9, 0xc9, 0xfb, 0x26, 0xf1,
+	0x63, 0xcc, 0xd4, 0x18, 0x06, 0x11, 0x53, 0x33, 0xa4, 0xf1, 0x9a, 0x05, 0xb5, 0x58, 0xe7, 0xf5,
+	0xeb, 0x47, 0xff, 0x67, 0x05, 0x56, 0xd1, 0x37, 0x77, 0xbd, 0x0b, 0x7a, 0xab, 0x3b, 0xf8, 0xff,
+	0xd8, 0xe8, 0x0a, 0x36, 0xf3, 0xc9, 0xc2, 0xe6, 0x8b, 0xed, 0x7a, 0xf4, 0xaf, 0xf2, 0x70, 0x23,
+	0x31, 0x09, 0xae, 0x8f, 0x0e, 0x94, 0x07, 0x02, 0xc8, 0xd5, 0xf1, 0x76, 0xea, 0x68, 0x29, 0x35,
+	0x6c, 0x43, 0x40, 0x8c, 0xc9, 0x68, 0xed, 0xa7, 0x39, 0x28, 0x09, 0x78, 0x2c, 0xcd, 0x57, 0xe2,
+	0x69, 0xbe, 0x0a, 0x0b, 0xb6, 0xa8, 0x5d, 0xe4, 0x0d, 0xfa, 0x3f, 0xb1, 0x87, 0xcf, 0x27, 0xf7,
+	0xf0, 0xf3, 0xd6, 0x65, 0xa5, 0x1a, 0x41, 0x21, 0xbb, 0x46, 0x40, 0x53, 0x98, 0x62, 0xb2, 0xb6,
+	0x1d, 0xab, 0xe7, 0x2e, 0x26, 0xea, 0xb9, 0x53, 0x8b, 0xd7, 0xb2, 0xd9, 0x94, 0x13, 0x6e, 0xdd,
+	0x84, 0xc5, 0x63, 0xab, 0x6f, 0xb9, 0x3d, 0xc2, 0xef, 0xe9, 0x8b, 0x26, 0xfa, 0xc5, 0xab, 0x1d,
+	0xf7, 0x82, 0xb8, 0x58, 0x17, 0x78, 0x62, 0xf5, 0x47, 0xd6, 0x75, 0x6d, 0xad, 0x66, 0x5a, 0xd5,
+	0x7d, 0x68, 0x04, 0xb8, 0x85, 0x34, 0x25, 0x13, 0x62, 0xee, 0x5a, 0xa7, 0xf0, 0xc3, 0xc8, 0x8e,
+	0x5e, 0x07, 0x06, 0x31, 0x23, 0x6b, 0x62, 0xa6, 0x56, 0xa5, 0xd0, 0x36, 0x37, 0xa9, 0x5f, 0xe4,
+	0x41, 0xcb, 0x9a, 0xc6, 0xe4, 0x3d, 0x94, 0xc4, 0x2b, 0x1f, 0x4a, 0xc6, 0x2b, 0x1f, 0xea, 0x23,
+	0xb1, 0x8c, 0xb2, 0x22, 0xe7, 0x07, 0x71, 0xd3, 0x9b, 0x4a, 0x9e, 0x5a, 0x25, 0x42, 0x89, 0xb8,
+	0xaf, 0x83, 0x09, 0x1b, 0x6e, 0x7e, 0xcd, 0x0b, 0x84, 0x72, 0x2f, 0x06, 0x0a, 0xa2, 0x78, 0x38,
+	0x7f, 0xc7, 0x65, 0xfb, 0x0c, 0x27, 0xe4, 0x58, 0x3c, 0xd0, 0x3a, 0x6e, 0x97, 0x81, 0x19, 0xe6,
+	0x3e, 0x00, 0xce, 0xd4, 0x44, 0x4e, 0xc5, 0xd5, 0x9f, 0xf7, 0xe7, 0x65, 0x0c, 0xb7, 0xe9, 0x2d,
+	0x2f, 0x08, 0x8d, 0x72, 0xc0, 0xff, 0x05, 0x5a, 0x00, 0xe5, 0x88, 0xdf, 0x97, 0xb0, 0x82, 0xac,
+	0x42, 0x41, 0x9e, 0x0e, 0x6b, 0x68, 0x1f, 0x41, 0x49, 0xf0, 0x72, 0x89, 0x33, 0xe2, 0x3c, 0x79,
+	0x4a, 0x49, 0xff, 0xeb, 0x3f, 0x5c, 0x80, 0x32, 0x0d, 0xab, 0x5d, 0xeb, 0x9c, 0xed, 0x07, 0xb1,
+	0x61, 0x86, 0xd6, 0xb9, 0x74, 0xed, 0xbe, 0x12, 0x08, 0x8c, 0xe4, 0xa1, 0x53, 0xd2, 0xf2, 0x3e,
+	0x8c, 0x36, 0xb4, 0xec, 0x40, 0xf1, 0x76, 0x3a, 0xdc, 0x23, 0x99, 0xe4, 0x8e, 0x16, 0xdf, 0x06,
+	0xf1, 0x09, 0xbb, 0x6b, 0x36, 0x16, 0x65, 0x3d, 0x0e, 0xd9, 0x1a, 0xd3, 0xec, 0x8d, 0x77, 0x4b,
+	0x11, 0xaf, 0xc2, 0x61, 0xd4, 0x90, 0xef, 0x40, 0xc5, 0x1a, 0x0e, 0x7d, 0x8f, 0xef, 0x52, 0xd9,
+	0x1e, 0x18, 0x04, 0x88, 0xed, 0x52, 0x23, 0x04, 0x69, 0x2f, 0x5c, 0x15, 0x40, 0x4a, 0xe5, 0x03,
+	0x61, 0xa2, 0xec, 0xa2, 0xd4, 0xad, 0x29, 0xdc, 0xcb, 0x69, 0xdd, 0x8f, 0x95, 0xcb, 0x76, 0x9f,
+	0x5f, 0x87, 0x06, 0x3d, 0xd4, 0x91, 0xd7, 0x72, 0xa6, 0x82, 0x25, 0x0e, 0x4f, 0xec, 0x41, 0x31,
+	0x5b, 0xcb, 0xdc, 0x83, 0xd2, 0x8e, 0x08, 0x19, 0x85, 0xc6, 0xe9, 0x4a, 0x42, 0x63, 0x90, 0xad,
+	0x59, 0x5b, 0xd4, 0xfd, 0x83, 0xf6, 0x1e, 0xdb, 0xa2, 0x6e, 0x1e, 0x1c, 0x18, 0xfb, 0x6c, 0x8b,
+	0xfa, 0xa3, 0x1c, 0xac, 0x1d, 0x8e, 0x8e, 0x07, 0x4e, 0x48, 0xe7, 0xd9, 0x42, 0x3a, 0x2f, 0x3d,
+	0x50, 0xcd, 0xdc, 0x59, 0x4d, 0xe1, 0x66, 0xa3, 0xc5, 0xa6, 0x28, 0x2b, 0xe1, 0x33, 0xa8, 0x48,
+	0xd0, 0xeb, 0x50, 0x85, 0x3e, 0x86, 0xb5, 0x4d, 0x66, 0x1a, 0x91, 0xde, 0xaf, 0x43, 0x1c, 0x29,
+	0x0f, 0xcb, 0xa5, 0x3c, 0x4c, 0xff, 0x99, 0x02, 0xcd, 0xf4, 0xb3, 0x79, 0xb0, 0xfd, 0x10, 0x60,
+	0x42, 0x80, 0x3f, 0xfb, 0x66, 0xb6, 0x9d, 0x62, 0xc2, 0xc0, 0xff, 0xaa, 0x06, 0xf2, 0xec, 0xfb,
+	0x84, 0x97, 0xa2, 0x73, 0x2f, 0x58, 0x37, 0x93, 0x89, 0xe8, 0x03, 0x58, 0xdd, 0xb2, 0xfc, 0x9e,
+	0x67, 0x93, 0x1d, 0xcf, 0x3b, 0x1f, 0x0d, 0xaf, 0x43, 0x3e, 0xf2, 0x4b, 0x65, 0xb9, 0xd8, 0x4b,
+	0x65, 0xba, 0x0b, 0x6b, 0xa8, 0x5d, 0x7a, 0x78, 0x7a, 0x8d, 0x07, 0x11, 0xd3, 0xe2, 0xb0, 0xfe,
+	0xb3, 0x1c, 0x34, 0xd3, 0x0f, 0xe4, 0x6a, 0x78, 0x04, 0x45, 0x7a, 0xac, 0x2b, 0x12, 0xa9, 0xf7,
+	0x52, 0x89, 0x54, 0xd6, 0xb0, 0x49, 0x87, 0xc1, 0x87, 0x6b, 0xff, 0xa4, 0x40, 0x39, 0x82, 0xce,
+	0x5e, 0x31, 0xe2, 0xef, 0xcc, 0xe5, 0x2e, 0x7d, 0x67, 0x2e, 0x3f, 0xef, 0x3b, 0x40, 0x0b, 0x99,
+	0xef, 0x00, 0xe1, 0xba, 0x4e, 0x4e, 0x4e, 0x50, 0xdb, 0x17, 0x44, 0x0e, 0xbc, 0xb5, 0x08, 0x4a,
+	0xb3, 0x83, 0x1f, 0xe0, 0xa1, 0x02, 0xb1, 0x7c, 0x7e, 0xfe, 0x78, 0x1d, 0x3a, 0x59, 0x85, 0xc2,
+	0x97, 0x23, 0xc2, 0xaf, 0x66, 0x97, 0x0d, 0xd6, 0x48, 0x5e, 0x71, 0xc9, 0xa7, 0xae, 0xb8, 0xc4,
+	0xa4, 0xb7, 0x90, 0x90, 0xde, 0x3d, 0xa8, 0x3b, 0x6e, 0xaf, 0x3f, 0xb2, 0x89, 0x79, 0xe6, 0xd8,
+	0x36, 0x61, 0x6f, 0x9f, 0x95, 0x8c, 0x1a, 0x87, 0x3e, 0xa6, 0x40, 0xdc, 0x9b, 0x4a, 0x07, 0x06,
+	0x05, 0x83, 0xb7, 0xe2, 0x07, 0x41, 0x8b, 0x89, 0x83, 0xa0, 0xa7, 0xb0, 0x12, 0x93, 0x00, 0x37,
+	0x92, 0x37, 0xe3, 0x85, 0x83, 0x8c, 0x7b, 0x5c, 0xac, 0x3f, 0x76, 0x64, 0x93, 0x8b, 0x1d, 0xd9,
+	0x3c, 0xf8, 0xbb, 0x65, 0xa8, 0xb1, 0x37, 0xbf, 0x0f, 0xd9, 0xf7, 0x39, 0xd4, 0x36, 0x00, 0xbe,
+	0x1b, 0xcc, 0x3e, 0x36, 0xa1, 0xc6, 0xeb, 0xa3, 0xb1, 0x0f, 0x55, 0x68, 0x89, 0xf5, 0x2b, 0xfe,
+	0x75, 0x8a, 0x4f, 0xa0, 0x36, 0xf9, 0x88, 0x84, 0x43, 0x02, 0xf5, 0x4e, 0x1c, 0x3b, 0xf5, 0x81,
+	0x09, 0x2d, 0x53, 0x79, 0xf4, 0xc3, 0x02, 0x3b, 0x50, 0x95, 0x3f, 0x12, 0xa0, 0xae, 0xc7, 0x30,
+	0x33, 0xbe, 0x1f, 0xa0, 0x69, 0xc9, 0x9b, 0x7e, 0xd2, 0x55, 0xf6, 0x36, 0xd4, 0x5a, 0x74, 0x69,
+	0xe7, 0x8f, 0x50, 0xbf, 0x16, 0x43, 0x4e, 0x7d, 0x56, 0x42, 0xcb, 0xfc, 0x88, 0x81, 0xfa, 0x09,
+	0x54, 0xa4, 0xd7, 0x9e, 0x13, 0xd3, 0x4b, 0xbf, 0x10, 0x3d, 0x93, 0xa5, 0x03, 0xa8, 0x48, 0x9f,
+	0x04, 0x48, 0xd0, 0x4a, 0x7f, 0xbf, 0x40, 0x5b, 0x9f, 0x8e, 0x10, 0x4d, 0x92, 0x5e, 0xc2, 0x64,
+	0x07, 0xb8, 0x09, 0x2d, 0xc6, 0xde, 0x5f, 0xd0, 0x6e, 0x65, 0xf6, 0x45, 0x67, 0x8c, 0xa9, 0x13,
+	0xed, 0x3b, 0xd3, 0xa3, 0x7a, 0x16, 0x73, 0x59, 0x47, 0x2d, 0x67, 0x70, 0xe3, 0x11, 0x09, 0xd3,
+	0x47, 0x9b, 0xea, 0x1b, 0xd3, 0x86, 0xc6, 0x03, 0xb2, 0xf6, 0xe6, 0xa5, 0x78, 0xfc, 0x49, 0x87,
+	0x50, 0x7f, 0x44, 0x42, 0xe9, 0xc0, 0x2b, 0x69, 0x86, 0xa9, 0xe3, 0x43, 0x6d, 0x7d, 0x3a, 0x02,
+	0x27, 0xfa, 0x6d, 0x58, 0x4a, 0x1c, 0x22, 0xa9, 0x77, 0x63, 0x83, 0xb2, 0xcf, 0xba, 0xb4, 0xd7,
+	0x67, 0x23, 0x45, 0xa1, 0xbf, 0x2a, 0x97, 0xbb, 0x13, 0xc6, 0x9e, 0x51, 0x09, 0xd7, 0xb2, 0xef,
+	0x27, 0xa8, 0xbb, 0xb0, 0xc4, 0xeb, 0xc1, 0x11, 0xe8, 0xee, 0x1c, 0xb5, 0xe5, 0x69, 0xe4, 0xba,
+	0x52, 0x65, 0x98, 0x55, 0x5a, 0x03, 0xf5, 0x76, 0x32, 0xa3, 0x8a, 0x15, 0x8e, 0xb5, 0xb8, 0xac,
+	0xd3, 0x75, 0xe0, 0xf7, 0x15, 0xf5, 0x29, 0x34, 0x1e, 0x91, 0x30, 0x56, 0xe5, 0x54, 0x5f, 0x9b,
+	0x55, 0x01, 0x65, 0x94, 0xf5, 0xcb, 0x8b, 0xa4, 0xea, 0x53, 0xa8, 0xc7, 0x0b, 0x77, 0xaa, 0x3e,
+	0xb3, 0xaa, 0xc7, 0x28, 0xdf, 0x9d, 0xa3, 0xf2, 0xa7, 0xee, 0x42, 0x15, 0xcd, 0x4a, 0x94, 0x97,
+	0xd4, 0xdb, 0xd3, 0xca, 0x4e, 0x8c, 0xe6, 0xd7, 0x66, 0x57, 0xa5, 0xb8, 0x10, 0x62, 0xe5, 0x91,
+	0x84, 0x10, 0xb2, 0xca, 0x46, 0x9a, 0x3e, 0x0b, 0x25, 0xe6, 0x6a, 0xe9, 0x5d, 0x66, 0xc2, 0xd5,
+	0xa6, 0x56, 0x11, 0xb4, 0x37, 0x2f, 0xc5, 0x8b, 0x62, 0x58, 0x23, 0x99, 0x52, 0xab, 0xaf, 0xcf,
+	0x93, 0x71, 0x6b, 0x53, 0xf2, 0x4b, 0xd5, 0x84, 0x46, 0x32, 0x4f, 0x4d, 0x50, 0x9c, 0x92, 0x42,
+	0x6b, 0xf7, 0x2e, 0xc1, 0xe2, 0x2c, 0x7f, 0x0a, 0x2b, 0x2c, 0xb5, 0x44, 0xd9, 0x6d, 0x8d, 0x79,
+	0xb6, 0x99, 0x10, 0x7d, 0x56, 0x0e, 0xaa, 0xa5, 0xd7, 0x5a, 0x8c, 0xe1, 0xd2, 0x22, 0x9d, 0x8c,
+	0x33, 0xa9, 0x04, 0x46, 0x5b, 0x9f, 0x8e, 0xc0, 0xd9, 0x3b, 0x86, 0x15, 0x6e, 0x16, 0x72, 0xb2,
+	0x97, 0x10, 0xc1, 0x94, 0x9c, 0x55, 0xbb, 0x77, 0x09, 0x56, 0x14, 0x20, 0xab, 0xf2, 0xc7, 0x50,
+	0x12, 0xd1, 0x26, 0xe3, 0x4b, 0x32, 0xda, 0x6b, 0x33, 0x30, 0x38, 0xd1, 0x27, 0x50, 0x8b, 0x7d,
+	0x52, 0x24, 0x21, 0xd1, 0xac, 0xef, 0xa0, 0x68, 0xfa, 0x2c, 0x14, 0x46, 0x77, 0xeb, 0x57, 0x60,
+	0xbd, 0xe7, 0x0d, 0x36, 0x06, 0xa3, 0x73, 0xe2, 0x5b, 0x1c, 0x7f, 0xa3, 0xd7, 0x77, 0x88, 0x1b,
+	0x6e, 0xb8, 0x24, 0x7c, 0xe6, 0xf9, 0xe7, 0x5b, 0x6a, 0x2c, 0x9b, 0x39, 0x40, 0x6a, 0x07, 0xca,
+	0x71, 0x91, 0x92, 0xfd, 0xc6, 0xff, 0x0d, 0x00, 0xf4, 0x33, 0xd0, 0x36, 0x8d, 0x4c, 0x00, 0x00,
 }